@@ -85,9 +85,27 @@ var (
 	PingIdentityFederationGroupVersionKind = SchemeGroupVersion.WithKind(PingIdentityFederationKind)
 )
 
+// GitHubActionsFederation type metadata.
+var (
+	GitHubActionsFederationKind             = reflect.TypeOf(GitHubActionsFederation{}).Name()
+	GitHubActionsFederationGroupKind        = schema.GroupKind{Group: Group, Kind: GitHubActionsFederationKind}.String()
+	GitHubActionsFederationKindAPIVersion   = GitHubActionsFederationKind + "." + SchemeGroupVersion.String()
+	GitHubActionsFederationGroupVersionKind = SchemeGroupVersion.WithKind(GitHubActionsFederationKind)
+)
+
+// AWSIAMFederation type metadata.
+var (
+	AWSIAMFederationKind             = reflect.TypeOf(AWSIAMFederation{}).Name()
+	AWSIAMFederationGroupKind        = schema.GroupKind{Group: Group, Kind: AWSIAMFederationKind}.String()
+	AWSIAMFederationKindAPIVersion   = AWSIAMFederationKind + "." + SchemeGroupVersion.String()
+	AWSIAMFederationGroupVersionKind = SchemeGroupVersion.WithKind(AWSIAMFederationKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&KubernetesFederation{}, &KubernetesFederationList{})
 	SchemeBuilder.Register(&SpiffeFederation{}, &SpiffeFederationList{})
 	SchemeBuilder.Register(&OktaFederation{}, &OktaFederationList{})
 	SchemeBuilder.Register(&PingIdentityFederation{}, &PingIdentityFederationList{})
+	SchemeBuilder.Register(&GitHubActionsFederation{}, &GitHubActionsFederationList{})
+	SchemeBuilder.Register(&AWSIAMFederation{}, &AWSIAMFederationList{})
 }
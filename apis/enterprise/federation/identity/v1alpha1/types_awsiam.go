@@ -0,0 +1,76 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AWSIAMFederationSpec defines the specification for AWS IAM federation.
+// Callers authenticate with a signed sts:GetCallerIdentity request, which the federation server
+// sends to STS. Authorizations reference the STS endpoint as issuer, and match the ARN of the
+// caller, with assumed roles mapped to their role ARN, e.g. "arn:aws:iam::123456789012:role/ci".
+type AWSIAMFederationSpec struct {
+	// STSEndpoint is the STS endpoint signed requests are sent to.
+	// +optional
+	// +kubebuilder:default="https://sts.amazonaws.com"
+	STSEndpoint string `json:"stsEndpoint,omitempty"`
+
+	// ServerID, when set, must be sent by callers in the signed X-External-Secrets-Server-Id header,
+	// so requests signed for the federation server cannot be replayed against other services.
+	// +optional
+	ServerID string `json:"serverId,omitempty"`
+
+	// AccountIDs restricts callers to these AWS accounts.
+	// +optional
+	AccountIDs []string `json:"accountIds,omitempty"`
+}
+
+// AWSIAMFederation represents an AWS IAM federation configuration.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Cluster,categories={external-secrets, external-secrets-federation}
+type AWSIAMFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              AWSIAMFederationSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// AWSIAMFederationList contains a list of AWSIAMFederation resources.
+type AWSIAMFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSIAMFederation `json:"items"`
+}
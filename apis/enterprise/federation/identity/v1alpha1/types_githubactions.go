@@ -0,0 +1,85 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GitHubActionsFederationSpec defines the specification for GitHub Actions OIDC federation.
+// Authorizations reference the issuer of this federation, and match the subject of the
+// workflow token, e.g. "repo:octo-org/octo-repo:ref:refs/heads/main".
+type GitHubActionsFederationSpec struct {
+	// Issuer is the issuer of GitHub Actions OIDC tokens.
+	// Use "https://HOSTNAME/_services/token" for GitHub Enterprise Server.
+	// +optional
+	// +kubebuilder:default="https://token.actions.githubusercontent.com"
+	Issuer string `json:"issuer,omitempty"`
+
+	// Audience the workflow requests its token for, e.g. the URL of the federation server.
+	// Tokens issued for other audiences are rejected.
+	// +kubebuilder:validation:MinLength=1
+	Audience string `json:"audience"`
+
+	// Repositories restricts the repositories, as owner/name globs, workflows can authenticate from.
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+
+	// Refs restricts the git refs, as globs, workflows can authenticate from, e.g. refs/heads/main.
+	// +optional
+	Refs []string `json:"refs,omitempty"`
+
+	// Environments restricts the deployment environments, as globs, of the jobs that can authenticate.
+	// Jobs without an environment are rejected when set.
+	// +optional
+	Environments []string `json:"environments,omitempty"`
+}
+
+// GitHubActionsFederation represents a GitHub Actions OIDC federation configuration.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Cluster,categories={external-secrets, external-secrets-federation}
+type GitHubActionsFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GitHubActionsFederationSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// GitHubActionsFederationList contains a list of GitHubActionsFederation resources.
+type GitHubActionsFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GitHubActionsFederation `json:"items"`
+}
@@ -40,18 +40,155 @@ import (
 
 // AuthorizationSpec defines the specification for authorization.
 // +kubebuilder:validation:XValidation:rule="(has(self.subject.spiffe) && !has(self.subject.oidc)) || (!has(self.subject.spiffe) && has(self.subject.oidc))",message="spiffe or subject must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.subjectMatcher) || !has(self.subject.oidc) || (has(self.subject.oidc.subject) && size(self.subject.oidc.subject) > 0)",message="subject.oidc.subject must be set when subjectMatcher is not set"
 type AuthorizationSpec struct {
 	FederationRef FederationRef `json:"federationRef"`
 
+	// Subject is the issuer, or SPIFFE trust domain, and the exact subject this authorization applies to.
+	// When SubjectMatcher is set, the subject may be omitted and a SPIFFE ID may only hold the trust domain.
 	// +kubebuilder:validation:Required
 	Subject *FederationSubject `json:"subject,omitempty"`
 
-	// Which ClusterSecretStores can this subject request
+	// SubjectMatcher matches subjects by patterns and token claims instead of the exact subject,
+	// so a single Authorization can apply to many workloads.
+	// +kubebuilder:validation:Optional
+	SubjectMatcher *SubjectMatcher `json:"subjectMatcher,omitempty"`
+
+	// Which ClusterSecretStores can this subject request.
+	// Names may be templated from the caller, e.g. ns-{{ .namespace }}.
 	AllowedClusterSecretStores []string `json:"allowedClusterSecretStores"`
-	// Which Generators namespaces can this subject request
+	// AllowedSecrets restricts the secrets that can be read through the allowed ClusterSecretStores.
+	// When empty, any secret of an allowed ClusterSecretStore can be read.
+	// +kubebuilder:validation:Optional
+	AllowedSecrets []AllowedSecret `json:"allowedSecrets,omitempty"`
+	// AllowedPushTargets defines which secrets can be pushed into which ClusterSecretStores.
+	// Secrets cannot be pushed when empty.
+	// +kubebuilder:validation:Optional
+	AllowedPushTargets []AllowedPushTarget `json:"allowedPushTargets,omitempty"`
+	// Which Generators namespaces can this subject request.
+	// Names and namespaces may be templated from the caller.
 	AllowedGenerators []AllowedGenerator `json:"allowedGenerators"`
-	// Which GeneratorState namespaces can this subject delete
+	// Which GeneratorState namespaces can this subject delete.
+	// Namespaces may be templated from the caller.
 	AllowedGeneratorStates []AllowedGeneratorState `json:"allowedGeneratorStates"`
+
+	// RateLimits limits the requests callers can make through this authorization.
+	// +kubebuilder:validation:Optional
+	RateLimits *RateLimits `json:"rateLimits,omitempty"`
+
+	// Quotas limits the resources callers can hold through this authorization.
+	// +kubebuilder:validation:Optional
+	Quotas *Quotas `json:"quotas,omitempty"`
+
+	// Lease configures the leases of credentials issued by generators through this authorization.
+	// When not set, leases expire after the idle timeout of the cleanup policy of the generator, if any.
+	// +kubebuilder:validation:Optional
+	Lease *LeasePolicy `json:"lease,omitempty"`
+}
+
+// LeasePolicy defines how long credentials issued by generators are valid for.
+// Expired credentials are revoked by deleting their GeneratorState.
+type LeasePolicy struct {
+	// TTL is the duration a lease is valid for once issued or renewed.
+	// +kubebuilder:validation:Format=duration
+	TTL metav1.Duration `json:"ttl"`
+
+	// MaxTTL is the maximum duration since issuance a lease can be renewed up to.
+	// When not set, leases can be renewed indefinitely.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Format=duration
+	MaxTTL *metav1.Duration `json:"maxTTL,omitempty"`
+}
+
+// RateLimits defines token bucket limits on the requests made through an authorization.
+// Requests over a limit are rejected with 429 Too Many Requests.
+type RateLimits struct {
+	// PerSubject limits the requests of every subject matching the authorization.
+	// +kubebuilder:validation:Optional
+	PerSubject *TokenBucket `json:"perSubject,omitempty"`
+
+	// PerAuthorization limits the requests of all subjects matching the authorization together.
+	// +kubebuilder:validation:Optional
+	PerAuthorization *TokenBucket `json:"perAuthorization,omitempty"`
+}
+
+// TokenBucket defines a token bucket rate limit.
+type TokenBucket struct {
+	// RequestsPerMinute is the rate the bucket is refilled at.
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerMinute int32 `json:"requestsPerMinute"`
+
+	// Burst is the number of requests that can be made at once. Defaults to RequestsPerMinute.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// Quotas defines limits on the resources held through an authorization.
+type Quotas struct {
+	// MaxGeneratorStates is the maximum number of live GeneratorStates bound to the
+	// AuthorizedIdentity of a caller. Generator requests over the quota are rejected
+	// with 429 Too Many Requests until credentials are revoked.
+	// The GeneratorStates are counted across all Authorizations of the caller, as the
+	// AuthorizedIdentity is shared by them.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxGeneratorStates *int32 `json:"maxGeneratorStates,omitempty"`
+}
+
+// MatchOperator defines how the conditions of a SubjectMatcher are combined.
+// +kubebuilder:validation:Enum=And;Or
+type MatchOperator string
+
+const (
+	// MatchOperatorAnd requires all conditions to match.
+	MatchOperatorAnd MatchOperator = "And"
+	// MatchOperatorOr requires any condition to match.
+	MatchOperatorOr MatchOperator = "Or"
+)
+
+// SubjectMatcher matches the authenticated caller by patterns and token claims.
+// Its conditions are combined with the operator.
+// +kubebuilder:validation:XValidation:rule="has(self.subject) || has(self.spiffePathPrefix) || (has(self.claims) && size(self.claims) > 0)",message="at least one of subject, spiffePathPrefix or claims must be set"
+type SubjectMatcher struct {
+	// Operator combines the conditions of this matcher. Defaults to And.
+	// +kubebuilder:default=And
+	// +kubebuilder:validation:Optional
+	Operator MatchOperator `json:"operator,omitempty"`
+
+	// Subject matches the subject of the caller.
+	// +kubebuilder:validation:Optional
+	Subject *StringMatcher `json:"subject,omitempty"`
+
+	// SpiffePathPrefix matches the path of the SPIFFE ID of the caller by whole path segments,
+	// e.g. /ns/prod matches /ns/prod/sa/api but not /ns/production.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^/`
+	SpiffePathPrefix string `json:"spiffePathPrefix,omitempty"`
+
+	// Claims match claims of the token of the caller. Every claim is a condition combined by the operator.
+	// +kubebuilder:validation:Optional
+	Claims []ClaimMatcher `json:"claims,omitempty"`
+}
+
+// StringMatcher matches a string by a glob or a regular expression.
+// Globs follow path.Match, so * does not match /.
+// +kubebuilder:validation:XValidation:rule="has(self.glob) != has(self.regex)",message="exactly one of glob or regex must be set"
+type StringMatcher struct {
+	// +kubebuilder:validation:Optional
+	Glob string `json:"glob,omitempty"`
+	// Regex is anchored at both ends.
+	// +kubebuilder:validation:Optional
+	Regex string `json:"regex,omitempty"`
+}
+
+// ClaimMatcher matches a claim of the token of the caller.
+// Claims holding a list, e.g. groups, match if any element matches.
+type ClaimMatcher struct {
+	// Claim is the dot separated path of the claim, e.g. groups, kubernetes.io.namespace or repository.
+	Claim string `json:"claim"`
+
+	StringMatcher `json:",inline"`
 }
 
 // RequiresTLS returns whether TLS is required for this authorization.
@@ -94,6 +231,44 @@ func (a *AuthorizationSpec) Authority() (string, error) {
 	}
 }
 
+// AllowedSecret defines which secrets can be read through a ClusterSecretStore.
+type AllowedSecret struct {
+	// ClusterSecretStore this rule applies to. Applies to all allowed ClusterSecretStores when empty.
+	// May be templated from the caller.
+	// +kubebuilder:validation:Optional
+	ClusterSecretStore string `json:"clusterSecretStore,omitempty"`
+
+	// Key matches the remote key of the secret. The glob or regex may be templated from the caller.
+	Key StringMatcher `json:"key"`
+
+	// Properties restricts reads to these properties of the secret, e.g. password.
+	// When empty, the whole secret and any of its properties can be read.
+	// +kubebuilder:validation:Optional
+	Properties []string `json:"properties,omitempty"`
+
+	// Versions restricts reads to these versions of the secret.
+	// Reads without a version request the latest version, which is allowed by listing latest.
+	// When empty, any version can be read.
+	// +kubebuilder:validation:Optional
+	Versions []string `json:"versions,omitempty"`
+}
+
+// AllowedPushTarget defines which secrets can be pushed into a ClusterSecretStore.
+type AllowedPushTarget struct {
+	// ClusterSecretStore secrets are pushed into. May be templated from the caller.
+	ClusterSecretStore string `json:"clusterSecretStore"`
+
+	// Key matches the remote key of pushed secrets. The glob or regex may be templated from the caller.
+	// When not set, any remote key can be pushed.
+	// +kubebuilder:validation:Optional
+	Key *StringMatcher `json:"key,omitempty"`
+
+	// AllowDelete allows callers to delete the secrets they can push,
+	// e.g. when a PushSecret with the Delete deletion policy is removed.
+	// +kubebuilder:validation:Optional
+	AllowDelete bool `json:"allowDelete,omitempty"`
+}
+
 // AllowedGeneratorState defines which generator states are allowed.
 type AllowedGeneratorState struct {
 	Namespace string `json:"namespace"`
@@ -126,8 +301,9 @@ type FederationSubject struct {
 
 // FederationOIDC defines OIDC-based federation.
 type FederationOIDC struct {
-	Issuer  string `json:"issuer"`
-	Subject string `json:"subject"`
+	Issuer string `json:"issuer"`
+	// +kubebuilder:validation:Optional
+	Subject string `json:"subject,omitempty"`
 }
 
 // FederationSpiffe defines SPIFFE-based federation.
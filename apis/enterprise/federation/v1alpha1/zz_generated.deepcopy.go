@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -54,6 +55,52 @@ func (in *AllowedGeneratorState) DeepCopy() *AllowedGeneratorState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedPushTarget) DeepCopyInto(out *AllowedPushTarget) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(StringMatcher)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedPushTarget.
+func (in *AllowedPushTarget) DeepCopy() *AllowedPushTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedPushTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedSecret) DeepCopyInto(out *AllowedSecret) {
+	*out = *in
+	out.Key = in.Key
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedSecret.
+func (in *AllowedSecret) DeepCopy() *AllowedSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Authorization) DeepCopyInto(out *Authorization) {
 	*out = *in
@@ -121,11 +168,30 @@ func (in *AuthorizationSpec) DeepCopyInto(out *AuthorizationSpec) {
 		*out = new(FederationSubject)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SubjectMatcher != nil {
+		in, out := &in.SubjectMatcher, &out.SubjectMatcher
+		*out = new(SubjectMatcher)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AllowedClusterSecretStores != nil {
 		in, out := &in.AllowedClusterSecretStores, &out.AllowedClusterSecretStores
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedSecrets != nil {
+		in, out := &in.AllowedSecrets, &out.AllowedSecrets
+		*out = make([]AllowedSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedPushTargets != nil {
+		in, out := &in.AllowedPushTargets, &out.AllowedPushTargets
+		*out = make([]AllowedPushTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AllowedGenerators != nil {
 		in, out := &in.AllowedGenerators, &out.AllowedGenerators
 		*out = make([]AllowedGenerator, len(*in))
@@ -136,6 +202,21 @@ func (in *AuthorizationSpec) DeepCopyInto(out *AuthorizationSpec) {
 		*out = make([]AllowedGeneratorState, len(*in))
 		copy(*out, *in)
 	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(RateLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(Quotas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lease != nil {
+		in, out := &in.Lease, &out.Lease
+		*out = new(LeasePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationSpec.
@@ -229,6 +310,22 @@ func (in *AuthorizedIdentitySpec) DeepCopy() *AuthorizedIdentitySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimMatcher) DeepCopyInto(out *ClaimMatcher) {
+	*out = *in
+	out.StringMatcher = in.StringMatcher
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimMatcher.
+func (in *ClaimMatcher) DeepCopy() *ClaimMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FederationOIDC) DeepCopyInto(out *FederationOIDC) {
 	*out = *in
@@ -352,6 +449,72 @@ func (in *IssuedCredential) DeepCopy() *IssuedCredential {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeasePolicy) DeepCopyInto(out *LeasePolicy) {
+	*out = *in
+	out.TTL = in.TTL
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeasePolicy.
+func (in *LeasePolicy) DeepCopy() *LeasePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(LeasePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Quotas) DeepCopyInto(out *Quotas) {
+	*out = *in
+	if in.MaxGeneratorStates != nil {
+		in, out := &in.MaxGeneratorStates, &out.MaxGeneratorStates
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Quotas.
+func (in *Quotas) DeepCopy() *Quotas {
+	if in == nil {
+		return nil
+	}
+	out := new(Quotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimits) DeepCopyInto(out *RateLimits) {
+	*out = *in
+	if in.PerSubject != nil {
+		in, out := &in.PerSubject, &out.PerSubject
+		*out = new(TokenBucket)
+		**out = **in
+	}
+	if in.PerAuthorization != nil {
+		in, out := &in.PerAuthorization, &out.PerAuthorization
+		*out = new(TokenBucket)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimits.
+func (in *RateLimits) DeepCopy() *RateLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteRef) DeepCopyInto(out *RemoteRef) {
 	*out = *in
@@ -407,6 +570,61 @@ func (in *StateRef) DeepCopy() *StateRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StringMatcher) DeepCopyInto(out *StringMatcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StringMatcher.
+func (in *StringMatcher) DeepCopy() *StringMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(StringMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectMatcher) DeepCopyInto(out *SubjectMatcher) {
+	*out = *in
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(StringMatcher)
+		**out = **in
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]ClaimMatcher, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectMatcher.
+func (in *SubjectMatcher) DeepCopy() *SubjectMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenBucket) DeepCopyInto(out *TokenBucket) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenBucket.
+func (in *TokenBucket) DeepCopy() *TokenBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadBinding) DeepCopyInto(out *WorkloadBinding) {
 	*out = *in
@@ -73,6 +73,18 @@ var (
 	MongoDBKind = reflect.TypeOf(MongoDB{}).Name()
 	// PostgreSQLKind is the type name of the PostgreSQL generator.
 	PostgreSQLKind = reflect.TypeOf(PostgreSQL{}).Name()
+	// MySQLKind is the type name of the MySQL generator.
+	MySQLKind = reflect.TypeOf(MySQL{}).Name()
+	// RedisKind is the type name of the Redis generator.
+	RedisKind = reflect.TypeOf(Redis{}).Name()
+	// KafkaKind is the type name of the Kafka generator.
+	KafkaKind = reflect.TypeOf(Kafka{}).Name()
+	// CertificateKind is the type name of the Certificate generator.
+	CertificateKind = reflect.TypeOf(Certificate{}).Name()
+	// JWTKind is the type name of the JWT generator.
+	JWTKind = reflect.TypeOf(JWT{}).Name()
+	// LDAPKind is the type name of the LDAP generator.
+	LDAPKind = reflect.TypeOf(LDAP{}).Name()
 	// OpenAIKind is the type name of the OpenAI generator.
 	OpenAIKind = reflect.TypeOf(OpenAI{}).Name()
 )
@@ -101,6 +113,12 @@ func init() {
 	genv1alpha1.SchemeBuilder.Register(&SSH{}, &SSHList{})
 	genv1alpha1.SchemeBuilder.Register(&Neo4j{}, &Neo4jList{})
 	genv1alpha1.SchemeBuilder.Register(&PostgreSQL{}, &PostgreSQLList{})
+	genv1alpha1.SchemeBuilder.Register(&MySQL{}, &MySQLList{})
+	genv1alpha1.SchemeBuilder.Register(&Redis{}, &RedisList{})
+	genv1alpha1.SchemeBuilder.Register(&Kafka{}, &KafkaList{})
+	genv1alpha1.SchemeBuilder.Register(&Certificate{}, &CertificateList{})
+	genv1alpha1.SchemeBuilder.Register(&JWT{}, &JWTList{})
+	genv1alpha1.SchemeBuilder.Register(&LDAP{}, &LDAPList{})
 	genv1alpha1.SchemeBuilder.Register(&OpenAI{}, &OpenAIList{})
 	genv1alpha1.SchemeBuilder.Register(&Federation{}, &FederationList{})
 
@@ -113,6 +131,12 @@ func init() {
 	SchemeBuilder.Register(&SSH{}, &SSHList{})
 	SchemeBuilder.Register(&Neo4j{}, &Neo4jList{})
 	SchemeBuilder.Register(&PostgreSQL{}, &PostgreSQLList{})
+	SchemeBuilder.Register(&MySQL{}, &MySQLList{})
+	SchemeBuilder.Register(&Redis{}, &RedisList{})
+	SchemeBuilder.Register(&Kafka{}, &KafkaList{})
+	SchemeBuilder.Register(&Certificate{}, &CertificateList{})
+	SchemeBuilder.Register(&JWT{}, &JWTList{})
+	SchemeBuilder.Register(&LDAP{}, &LDAPList{})
 	SchemeBuilder.Register(&OpenAI{}, &OpenAIList{})
 	SchemeBuilder.Register(&Federation{}, &FederationList{})
 }
@@ -0,0 +1,207 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertificateSpec controls the behavior of the Certificate generator.
+// The generator issues X.509 leaf certificates signed by a CA stored in a Secret.
+type CertificateSpec struct {
+	// CA is the certificate authority that signs the certificates.
+	CA CertificateCA `json:"ca"`
+	// CommonName is the common name of the subject of the certificate.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+	// Organizations are the organizations of the subject of the certificate.
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+	// DNSNames are the DNS subject alternative names of the certificate.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// IPAddresses are the IP address subject alternative names of the certificate.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// URIs are the URI subject alternative names of the certificate, e.g. SPIFFE IDs.
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+	// PrivateKey configures the private key generated for the certificate.
+	// +optional
+	PrivateKey CertificatePrivateKey `json:"privateKey,omitempty"`
+	// Usages are the key usages and extended key usages of the certificate.
+	// If not specified, the certificate can be used for server and client authentication.
+	// +optional
+	Usages []CertificateKeyUsage `json:"usages,omitempty"`
+	// TTL is the lifetime of the certificate.
+	// The certificate never outlives the CA certificate.
+	// +kubebuilder:default="2160h"
+	TTL metav1.Duration `json:"ttl,omitempty"`
+	// PKCS12 adds a PKCS#12 keystore with the certificate, its key and the CA to the output.
+	// +optional
+	PKCS12 *CertificatePKCS12 `json:"pkcs12,omitempty"`
+	// CRL configures the certificate revocation list maintained for the CA.
+	// Certificates are revoked when they are cleaned up before they expire.
+	// If not specified, certificates are not revoked.
+	// +optional
+	CRL *CertificateCRL `json:"crl,omitempty"`
+
+	// CleanupPolicy controls the behavior of the cleanup process
+	// +optional
+	CleanupPolicy *genv1alpha1.CleanupPolicy `json:"cleanupPolicy,omitempty"`
+}
+
+// CertificateCA references the Secret with the certificate and the key of the CA.
+type CertificateCA struct {
+	// SecretName is the name of the Secret in the namespace of the generator.
+	SecretName string `json:"secretName"`
+	// CertificateKey is the key of the PEM encoded CA certificate in the Secret.
+	// Certificates following the CA certificate are its chain: the intermediates are added to
+	// the tls.crt of the issued certificates, and the last one is their ca.crt.
+	// +kubebuilder:default="tls.crt"
+	CertificateKey string `json:"certificateKey,omitempty"`
+	// PrivateKeyKey is the key of the PEM encoded CA private key in the Secret.
+	// +kubebuilder:default="tls.key"
+	PrivateKeyKey string `json:"privateKeyKey,omitempty"`
+}
+
+// CertificateKeyAlgorithm defines the algorithm of the generated private key.
+type CertificateKeyAlgorithm string
+
+const (
+	// CertificateKeyAlgorithmRSA generates an RSA key.
+	CertificateKeyAlgorithmRSA CertificateKeyAlgorithm = "RSA"
+	// CertificateKeyAlgorithmECDSA generates an ECDSA key.
+	CertificateKeyAlgorithmECDSA CertificateKeyAlgorithm = "ECDSA"
+	// CertificateKeyAlgorithmEd25519 generates an Ed25519 key.
+	CertificateKeyAlgorithmEd25519 CertificateKeyAlgorithm = "Ed25519"
+)
+
+// CertificatePrivateKey configures the generated private key.
+type CertificatePrivateKey struct {
+	// Algorithm is the algorithm of the key.
+	// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+	// +kubebuilder:default="ECDSA"
+	Algorithm CertificateKeyAlgorithm `json:"algorithm,omitempty"`
+	// Size is the size of the key: 2048, 3072 or 4096 bits for RSA keys,
+	// and 256, 384 or 521 for the curve of ECDSA keys. It is ignored for Ed25519 keys.
+	// If not specified, 2048 is used for RSA keys and 256 for ECDSA keys.
+	// +optional
+	Size int `json:"size,omitempty"`
+}
+
+// CertificateKeyUsage is a key usage or an extended key usage of a certificate.
+// +kubebuilder:validation:Enum=DigitalSignature;KeyEncipherment;KeyAgreement;DataEncipherment;ContentCommitment;ServerAuth;ClientAuth;CodeSigning;EmailProtection;OCSPSigning
+type CertificateKeyUsage string
+
+const (
+	// CertificateKeyUsageDigitalSignature allows the key to verify digital signatures.
+	CertificateKeyUsageDigitalSignature CertificateKeyUsage = "DigitalSignature"
+	// CertificateKeyUsageKeyEncipherment allows the key to encipher keys, as in RSA key exchange.
+	CertificateKeyUsageKeyEncipherment CertificateKeyUsage = "KeyEncipherment"
+	// CertificateKeyUsageKeyAgreement allows the key to be used for key agreement.
+	CertificateKeyUsageKeyAgreement CertificateKeyUsage = "KeyAgreement"
+	// CertificateKeyUsageDataEncipherment allows the key to encipher data.
+	CertificateKeyUsageDataEncipherment CertificateKeyUsage = "DataEncipherment"
+	// CertificateKeyUsageContentCommitment allows the key to verify signatures for non-repudiation.
+	CertificateKeyUsageContentCommitment CertificateKeyUsage = "ContentCommitment"
+	// CertificateKeyUsageServerAuth allows the certificate to authenticate TLS servers.
+	CertificateKeyUsageServerAuth CertificateKeyUsage = "ServerAuth"
+	// CertificateKeyUsageClientAuth allows the certificate to authenticate TLS clients.
+	CertificateKeyUsageClientAuth CertificateKeyUsage = "ClientAuth"
+	// CertificateKeyUsageCodeSigning allows the certificate to sign code.
+	CertificateKeyUsageCodeSigning CertificateKeyUsage = "CodeSigning"
+	// CertificateKeyUsageEmailProtection allows the certificate to protect emails.
+	CertificateKeyUsageEmailProtection CertificateKeyUsage = "EmailProtection"
+	// CertificateKeyUsageOCSPSigning allows the certificate to sign OCSP responses.
+	CertificateKeyUsageOCSPSigning CertificateKeyUsage = "OCSPSigning"
+)
+
+// CertificatePKCS12 configures the PKCS#12 keystore.
+type CertificatePKCS12 struct {
+	// Password is a reference to the password of the keystore.
+	// If not specified, the keystore is not encrypted.
+	// +optional
+	Password *esmeta.SecretKeySelector `json:"password,omitempty"`
+}
+
+// CertificateCRL configures the certificate revocation list of the CA.
+type CertificateCRL struct {
+	// SecretName is the name of the Secret the CRL is stored in, in the namespace of the generator.
+	// It is created if it does not exist. It can be the Secret of the CA.
+	SecretName string `json:"secretName"`
+	// Key is the key of the PEM encoded CRL in the Secret.
+	// The expiration times of the revoked certificates are stored in the key with the ".expiry" suffix,
+	// so that certificates are dropped from the CRL once they have expired.
+	// +kubebuilder:default="ca.crl"
+	Key string `json:"key,omitempty"`
+	// Validity is the time until the next update of the CRL.
+	// The CRL is signed again when a certificate is revoked, and refreshed periodically
+	// once half of its validity has passed.
+	// +kubebuilder:default="168h"
+	Validity metav1.Duration `json:"validity,omitempty"`
+	// DistributionPoints are the URLs the CRL is published at, added to the issued certificates.
+	// +optional
+	DistributionPoints []string `json:"distributionPoints,omitempty"`
+}
+
+// CertificateState represents the state of an issued certificate.
+type CertificateState struct {
+	// SerialNumber is the hex encoded serial number of the certificate.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// NotAfter is the expiration time of the certificate.
+	NotAfter metav1.Time `json:"notAfter,omitempty"`
+}
+
+// Certificate issues X.509 certificates signed by a CA based on the configuration parameters in spec.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateSpec             `json:"spec,omitempty"`
+	Status genv1alpha1.GeneratorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CertificateList contains a list of Certificate resources.
+type CertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Certificate `json:"items"`
+}
@@ -0,0 +1,114 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+// JWTSpec controls the behavior of the JWT generator.
+// The generator issues JSON Web Tokens signed by a key stored in a Secret.
+type JWTSpec struct {
+	// SigningKey is the key that signs the tokens.
+	SigningKey JWTSigningKey `json:"signingKey"`
+	// Issuer is the iss claim of the token.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// Subject is the sub claim of the token.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+	// Audience is the aud claim of the token.
+	// +optional
+	Audience []string `json:"audience,omitempty"`
+	// Claims are additional string claims of the token.
+	// They cannot override the registered claims set by the generator.
+	// +optional
+	Claims map[string]string `json:"claims,omitempty"`
+	// TTL is the lifetime of the token, used for its exp claim.
+	// The refresh interval of the ExternalSecret should be shorter to renew the token before it expires.
+	// +kubebuilder:default="1h"
+	TTL metav1.Duration `json:"ttl,omitempty"`
+}
+
+// JWTAlgorithm defines the algorithm used to sign the token.
+type JWTAlgorithm string
+
+const (
+	// JWTAlgorithmRS256 signs the token with an RSA key using RSASSA-PKCS1-v1_5 and SHA-256.
+	JWTAlgorithmRS256 JWTAlgorithm = "RS256"
+	// JWTAlgorithmES256 signs the token with a P-256 ECDSA key and SHA-256.
+	JWTAlgorithmES256 JWTAlgorithm = "ES256"
+	// JWTAlgorithmEdDSA signs the token with an Ed25519 key.
+	JWTAlgorithmEdDSA JWTAlgorithm = "EdDSA"
+	// JWTAlgorithmHS256 signs the token with a shared secret using HMAC SHA-256.
+	JWTAlgorithmHS256 JWTAlgorithm = "HS256"
+)
+
+// JWTSigningKey references the key that signs the tokens.
+type JWTSigningKey struct {
+	// SecretRef references the key in a Secret in the namespace of the generator.
+	// It is a PEM encoded private key for asymmetric algorithms, and the raw shared secret for HS256.
+	SecretRef esmeta.SecretKeySelector `json:"secretRef"`
+	// Algorithm is the algorithm used to sign the tokens.
+	// +kubebuilder:validation:Enum=RS256;ES256;EdDSA;HS256
+	// +kubebuilder:default="RS256"
+	Algorithm JWTAlgorithm `json:"algorithm,omitempty"`
+	// KeyID is the kid header of the tokens.
+	// If not specified, the RFC 7638 thumbprint of the public key is used for asymmetric algorithms,
+	// and no kid header is set for HS256.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// JWT issues signed JSON Web Tokens based on the configuration parameters in spec.
+// The issuer, subject, audience and claims are templates, executed with the namespace of the generator.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type JWT struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JWTSpec                     `json:"spec,omitempty"`
+	Status genv1alpha1.GeneratorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// JWTList contains a list of JWT resources.
+type JWTList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JWT `json:"items"`
+}
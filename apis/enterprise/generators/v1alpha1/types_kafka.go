@@ -0,0 +1,232 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaSpec controls the behavior of the Kafka generator.
+// The generator creates SCRAM credentials and ACLs through the Kafka admin protocol,
+// and requires Kafka 2.7+, or 3.5+ in KRaft mode.
+type KafkaSpec struct {
+	// BootstrapServers are the addresses of the brokers, e.g. "kafka:9092".
+	// +kubebuilder:validation:MinItems=1
+	BootstrapServers []string `json:"bootstrapServers"`
+	// Auth contains the SASL credentials used to authenticate against the brokers.
+	// The principal must be allowed to alter the cluster and its ACLs.
+	// If not specified, SASL is disabled.
+	// +optional
+	Auth *KafkaAuth `json:"auth,omitempty"`
+	// TLS configures the TLS connection to the brokers.
+	// If not specified, TLS is disabled.
+	// +optional
+	TLS *KafkaTLS `json:"tls,omitempty"`
+	// User is the data of the user to be created.
+	User KafkaUser `json:"user"`
+
+	// CleanupPolicy controls the behavior of the cleanup process.
+	// Only retainLatest is supported, as Kafka does not expose the activity of a user.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.type != 'idle'",message="the idle cleanup policy is not supported by the Kafka generator"
+	CleanupPolicy *genv1alpha1.CleanupPolicy `json:"cleanupPolicy,omitempty"`
+}
+
+// KafkaSASLMechanism defines a SASL mechanism used to authenticate against the brokers.
+type KafkaSASLMechanism string
+
+const (
+	// KafkaSASLMechanismPlain is the SASL/PLAIN mechanism.
+	KafkaSASLMechanismPlain KafkaSASLMechanism = "PLAIN"
+	// KafkaSASLMechanismScramSHA256 is the SASL/SCRAM-SHA-256 mechanism.
+	KafkaSASLMechanismScramSHA256 KafkaSASLMechanism = "SCRAM-SHA-256"
+	// KafkaSASLMechanismScramSHA512 is the SASL/SCRAM-SHA-512 mechanism.
+	KafkaSASLMechanismScramSHA512 KafkaSASLMechanism = "SCRAM-SHA-512"
+)
+
+// KafkaAuth defines the SASL authentication configuration.
+type KafkaAuth struct {
+	// Mechanism is the SASL mechanism used to authenticate.
+	// +kubebuilder:validation:Enum=PLAIN;SCRAM-SHA-256;SCRAM-SHA-512
+	// +kubebuilder:default="SCRAM-SHA-512"
+	Mechanism KafkaSASLMechanism `json:"mechanism,omitempty"`
+	// Username is the user used to authenticate against the brokers.
+	Username string `json:"username"`
+	// Password is the password used to authenticate against the brokers.
+	Password esmeta.SecretKeySelector `json:"password"`
+}
+
+// KafkaTLS configures TLS for the connections to the brokers.
+type KafkaTLS struct {
+	// PEM encoded CA bundle used to validate the broker certificates.
+	// If neither CABundle nor CAProvider are set the system root certificates are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// The provider for the CA bundle used to validate the broker certificates.
+	// +optional
+	CAProvider *esv1.CAProvider `json:"caProvider,omitempty"`
+	// ClientCertificate is a reference to the PEM encoded client certificate.
+	// +optional
+	ClientCertificate *esmeta.SecretKeySelector `json:"clientCertificate,omitempty"`
+	// ClientKey is a reference to the PEM encoded private key of the client certificate.
+	// +optional
+	ClientKey *esmeta.SecretKeySelector `json:"clientKey,omitempty"`
+	// InsecureSkipVerify disables the verification of the broker certificates.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// KafkaScramMechanism defines the mechanism of the generated SCRAM credentials.
+type KafkaScramMechanism string
+
+const (
+	// KafkaScramMechanismSHA256 is the SCRAM-SHA-256 mechanism.
+	KafkaScramMechanismSHA256 KafkaScramMechanism = "SCRAM-SHA-256"
+	// KafkaScramMechanismSHA512 is the SCRAM-SHA-512 mechanism.
+	KafkaScramMechanismSHA512 KafkaScramMechanism = "SCRAM-SHA-512"
+)
+
+// KafkaUser defines a Kafka SCRAM user and its ACLs.
+type KafkaUser struct {
+	// The username of the user to be created.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+$`
+	Username string `json:"username"`
+	// SuffixSize define the size of the random suffix added after the defined username.
+	// If not specified, a random suffix of size 8 will be used.
+	// If set to 0, no suffix will be added.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=8
+	SuffixSize *int `json:"suffixSize,omitempty"`
+	// Mechanism is the SCRAM mechanism of the generated credentials.
+	// +kubebuilder:validation:Enum=SCRAM-SHA-256;SCRAM-SHA-512
+	// +kubebuilder:default="SCRAM-SHA-512"
+	Mechanism KafkaScramMechanism `json:"mechanism,omitempty"`
+	// Iterations is the number of SCRAM iterations of the generated credentials.
+	// +kubebuilder:validation:Minimum=4096
+	// +kubebuilder:validation:Maximum=16384
+	// +kubebuilder:default=8192
+	Iterations int32 `json:"iterations,omitempty"`
+	// ACLs are the ACLs bound to the principal of the user.
+	// +optional
+	ACLs []KafkaACL `json:"acls,omitempty"`
+}
+
+// KafkaResourceType defines the type of resource an ACL applies to.
+type KafkaResourceType string
+
+const (
+	// KafkaResourceTypeTopic is a topic.
+	KafkaResourceTypeTopic KafkaResourceType = "Topic"
+	// KafkaResourceTypeGroup is a consumer group.
+	KafkaResourceTypeGroup KafkaResourceType = "Group"
+	// KafkaResourceTypeTransactionalID is a transactional id.
+	KafkaResourceTypeTransactionalID KafkaResourceType = "TransactionalId"
+	// KafkaResourceTypeCluster is the cluster.
+	KafkaResourceTypeCluster KafkaResourceType = "Cluster"
+)
+
+// KafkaPatternType defines how the resource name of an ACL is matched.
+type KafkaPatternType string
+
+const (
+	// KafkaPatternTypeLiteral matches the exact resource name, or every resource if the name is "*".
+	KafkaPatternTypeLiteral KafkaPatternType = "Literal"
+	// KafkaPatternTypePrefixed matches every resource name starting with the name.
+	KafkaPatternTypePrefixed KafkaPatternType = "Prefixed"
+)
+
+// KafkaACLPermission defines whether an ACL allows or denies the operations.
+type KafkaACLPermission string
+
+const (
+	// KafkaACLPermissionAllow allows the operations.
+	KafkaACLPermissionAllow KafkaACLPermission = "Allow"
+	// KafkaACLPermissionDeny denies the operations.
+	KafkaACLPermissionDeny KafkaACLPermission = "Deny"
+)
+
+// KafkaACL defines the operations the user can run on a resource.
+type KafkaACL struct {
+	// ResourceType is the type of the resource.
+	// +kubebuilder:validation:Enum=Topic;Group;TransactionalId;Cluster
+	ResourceType KafkaResourceType `json:"resourceType"`
+	// Name is the name of the resource. It is ignored for the Cluster resource type.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// PatternType defines how the name is matched.
+	// +kubebuilder:validation:Enum=Literal;Prefixed
+	// +kubebuilder:default="Literal"
+	PatternType KafkaPatternType `json:"patternType,omitempty"`
+	// Operations are the operations of the ACL.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=All;Read;Write;Create;Delete;Alter;Describe;ClusterAction;DescribeConfigs;AlterConfigs;IdempotentWrite
+	Operations []string `json:"operations"`
+	// Permission defines whether the operations are allowed or denied.
+	// +kubebuilder:validation:Enum=Allow;Deny
+	// +kubebuilder:default="Allow"
+	Permission KafkaACLPermission `json:"permission,omitempty"`
+	// Host is the host the ACL applies to.
+	// If not specified, it applies to all hosts.
+	// +optional
+	Host string `json:"host,omitempty"`
+}
+
+// KafkaUserState represents the state of a Kafka user.
+type KafkaUserState struct {
+	Username  string              `json:"username,omitempty"`
+	Mechanism KafkaScramMechanism `json:"mechanism,omitempty"`
+}
+
+// Kafka generates Kafka SCRAM credentials and ACLs based on the configuration parameters in spec.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type Kafka struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaSpec                   `json:"spec,omitempty"`
+	Status genv1alpha1.GeneratorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KafkaList contains a list of Kafka resources.
+type KafkaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Kafka `json:"items"`
+}
@@ -0,0 +1,182 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+// LDAPSpec controls the behavior of the LDAP generator.
+// The generator rotates the password of an existing entry, or creates and deletes
+// users in an organizational unit, in Active Directory and OpenLDAP directories.
+type LDAPSpec struct {
+	// URL is the URL of the directory server, e.g. "ldaps://dc1.example.com:636".
+	// Passwords are only sent over TLS, so ldap:// URLs require StartTLS.
+	// +kubebuilder:validation:Pattern=`^ldaps?://`
+	URL string `json:"url"`
+	// Auth contains the credentials of the account that manages the users.
+	Auth LDAPAuth `json:"auth"`
+	// TLS configures the TLS connection to the server.
+	// +optional
+	TLS LDAPTLS `json:"tls,omitempty"`
+	// Dialect is the kind of directory server, which defines how passwords are set.
+	// +kubebuilder:validation:Enum=ActiveDirectory;OpenLDAP
+	// +kubebuilder:default="OpenLDAP"
+	Dialect LDAPDialect `json:"dialect,omitempty"`
+	// PasswordMethod is how passwords are set on OpenLDAP servers.
+	// Active Directory passwords are always set with the unicodePwd attribute.
+	// +kubebuilder:validation:Enum=PasswordModify;UserPassword
+	// +kubebuilder:default="PasswordModify"
+	PasswordMethod LDAPPasswordMethod `json:"passwordMethod,omitempty"`
+	// User is the entry whose password is set.
+	User LDAPUser `json:"user"`
+	// Password controls the generated password.
+	// It must satisfy the password policy of the directory.
+	// +optional
+	Password genv1alpha1.PasswordSpec `json:"password,omitempty"`
+
+	// CleanupPolicy controls the behavior of the cleanup process
+	// +optional
+	CleanupPolicy *genv1alpha1.CleanupPolicy `json:"cleanupPolicy,omitempty"`
+}
+
+// LDAPDialect defines the kind of directory server.
+type LDAPDialect string
+
+const (
+	// LDAPDialectActiveDirectory is a Microsoft Active Directory server.
+	LDAPDialectActiveDirectory LDAPDialect = "ActiveDirectory"
+	// LDAPDialectOpenLDAP is an OpenLDAP server, or another server following the same conventions.
+	LDAPDialectOpenLDAP LDAPDialect = "OpenLDAP"
+)
+
+// LDAPPasswordMethod defines how passwords are set on OpenLDAP servers.
+type LDAPPasswordMethod string
+
+const (
+	// LDAPPasswordMethodPasswordModify uses the password modify extended operation (RFC 3062),
+	// which lets the server hash the password following its policy.
+	LDAPPasswordMethodPasswordModify LDAPPasswordMethod = "PasswordModify"
+	// LDAPPasswordMethodUserPassword replaces the userPassword attribute of the entry.
+	LDAPPasswordMethodUserPassword LDAPPasswordMethod = "UserPassword"
+)
+
+// LDAPAuth defines the credentials of the account that manages the users.
+type LDAPAuth struct {
+	// BindDN is the DN the generator binds as.
+	BindDN string `json:"bindDN"`
+	// Password is the password of the bind DN.
+	Password esmeta.SecretKeySelector `json:"password"`
+}
+
+// LDAPTLS configures TLS for the connections to the server.
+type LDAPTLS struct {
+	// StartTLS upgrades ldap:// connections to TLS with the StartTLS operation.
+	// +optional
+	StartTLS bool `json:"startTLS,omitempty"`
+	// PEM encoded CA bundle used to validate the server certificate.
+	// If neither CABundle nor CAProvider are set the system root certificates are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// The provider for the CA bundle used to validate the server certificate.
+	// +optional
+	CAProvider *esv1.CAProvider `json:"caProvider,omitempty"`
+	// ServerName is the name the server certificate is verified against.
+	// If not specified, the host of the URL is used.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify disables the verification of the server certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// LDAPUser defines the entry whose password is set.
+// Exactly one of DN and OU must be set.
+type LDAPUser struct {
+	// DN is the DN of an existing entry whose password is rotated.
+	// The entry is left in place on cleanup.
+	// +optional
+	DN string `json:"dn,omitempty"`
+	// OU is the DN of the organizational unit users are created in.
+	// The users are deleted on cleanup.
+	// +optional
+	OU string `json:"ou,omitempty"`
+	// Username is the username of the users created in the OU.
+	// It is the cn and sAMAccountName of Active Directory users, and the cn and uid of OpenLDAP users.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// SuffixSize define the size of the random suffix added after the defined username.
+	// If not specified, a random suffix of size 8 will be used.
+	// If set to 0, no suffix will be added.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=8
+	SuffixSize *int `json:"suffixSize,omitempty"`
+	// ObjectClasses are the object classes of the users created in the OU.
+	// If not specified, Active Directory users are user objects and OpenLDAP users are inetOrgPerson objects.
+	// +optional
+	ObjectClasses []string `json:"objectClasses,omitempty"`
+	// Attributes are additional attributes of the users created in the OU, e.g. description.
+	// +optional
+	Attributes map[string][]string `json:"attributes,omitempty"`
+}
+
+// LDAPUserState represents the state of an LDAP user.
+type LDAPUserState struct {
+	// DN is the DN of the entry.
+	DN string `json:"dn,omitempty"`
+	// Created is true if the entry was created by the generator, and must be deleted on cleanup.
+	Created bool `json:"created,omitempty"`
+}
+
+// LDAP sets generated passwords on Active Directory and OpenLDAP entries based on the configuration parameters in spec.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type LDAP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LDAPSpec                    `json:"spec,omitempty"`
+	Status genv1alpha1.GeneratorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LDAPList contains a list of LDAP resources.
+type LDAPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LDAP `json:"items"`
+}
@@ -45,6 +45,10 @@ type MongoDBSpec struct {
 	// User is the configuration for the service account that
 	// is supposed to be generated by the generator.
 	User MongoDBUser `json:"user"`
+	// RotationPolicy controls how the user is rotated.
+	// With the alternating strategy, the name of the user is required and used as the prefix of the two users.
+	// +optional
+	RotationPolicy *genv1alpha1.RotationPolicy `json:"rotationPolicy,omitempty"`
 }
 
 // MongoDBDatabase defines the MongoDB database configuration.
@@ -124,4 +128,7 @@ type MongoDBList struct {
 // MongoDBUserState represents the state of a MongoDB user.
 type MongoDBUserState struct {
 	User string `json:"user"`
+	// Usernames are the users rotated with the alternating strategy.
+	// +optional
+	Usernames []string `json:"usernames,omitempty"`
 }
@@ -0,0 +1,175 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MySQLCleanupPolicy controls the cleanup policy for the MySQL generator.
+type MySQLCleanupPolicy struct {
+	genv1alpha1.CleanupPolicy `json:",inline"`
+
+	// ActivityTrackingInterval is the interval the sessions of the server are observed at.
+	// +optional
+	// +kubebuilder:default="2s"
+	ActivityTrackingInterval metav1.Duration `json:"activityTrackingInterval,omitempty"`
+
+	// ObservationDatabase is the database the observed sessions are recorded in.
+	// It is created if it does not exist.
+	// +optional
+	// +kubebuilder:default="external_secrets"
+	ObservationDatabase string `json:"observationDatabase,omitempty"`
+}
+
+// MySQLSpec controls the behavior of the MySQL generator.
+// The generator supports MySQL and MariaDB servers.
+type MySQLSpec struct {
+	// Host is the server where the database is hosted.
+	Host string `json:"host"`
+	// Port is the port of the database to connect to.
+	// If not specified, the "3306" port will be used.
+	// +kubebuilder:validation:Pattern=`^[0-9]{1,5}$`
+	// +kubebuilder:default="3306"
+	Port string `json:"port"`
+	// Auth contains the credentials or auth configuration
+	Auth MySQLAuth `json:"auth"`
+	// User is the data of the user to be created.
+	User MySQLUser `json:"user"`
+
+	CleanupPolicy *MySQLCleanupPolicy `json:"cleanupPolicy,omitempty"`
+}
+
+// MySQLAuth defines MySQL authentication configuration.
+type MySQLAuth struct {
+	// A basic auth username used to authenticate against the MySQL instance.
+	// If not specified, "root" will be used.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// A basic auth password used to authenticate against the MySQL instance.
+	Password esmeta.SecretKeySelector `json:"password"`
+}
+
+// MySQLUser defines a MySQL user.
+type MySQLUser struct {
+	// The username of the user to be created.
+	// MySQL limits usernames to 32 characters, including the suffix.
+	Username string `json:"username"`
+	// SuffixSize define the size of the random suffix added after the defined username.
+	// If not specified, a random suffix of size 8 will be used.
+	// If set to 0, no suffix will be added.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=8
+	SuffixSize *int `json:"suffixSize,omitempty"`
+	// Hosts are the host patterns the user can connect from, e.g. "%" or "10.0.%".
+	// An account is created for each pattern, with the same password and grants.
+	// If not specified, the user can connect from any host.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+	// Grants are the privileges granted to the user.
+	// +optional
+	Grants []MySQLGrant `json:"grants,omitempty"`
+	// MaxUserConnections limits the number of simultaneous connections of the user.
+	// There is no limit when not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUserConnections *int `json:"maxUserConnections,omitempty"`
+	// TLS sets the TLS requirements of the connections of the user.
+	// +optional
+	TLS *MySQLUserTLS `json:"tls,omitempty"`
+}
+
+// MySQLGrant defines privileges granted globally, on a database or on a table.
+type MySQLGrant struct {
+	// Privileges are the privileges granted, e.g. SELECT, INSERT or ALL PRIVILEGES.
+	// +kubebuilder:validation:MinItems=1
+	Privileges []string `json:"privileges"`
+	// Database is the database the privileges are granted on.
+	// The privileges are granted on all databases when not specified.
+	// +optional
+	Database string `json:"database,omitempty"`
+	// Table is the table of the database the privileges are granted on.
+	// The privileges are granted on all tables of the database when not specified.
+	// +optional
+	Table string `json:"table,omitempty"`
+	// WithGrantOption allows the user to grant the privileges to other users.
+	// +optional
+	WithGrantOption bool `json:"withGrantOption,omitempty"`
+}
+
+// MySQLUserTLS defines the TLS requirements of a MySQL user.
+type MySQLUserTLS struct {
+	// Require is the TLS requirement of the connections of the user.
+	// SSL requires encrypted connections, X509 requires a valid client certificate.
+	// +kubebuilder:validation:Enum=SSL;X509
+	// +optional
+	Require string `json:"require,omitempty"`
+	// Issuer requires a client certificate issued by this issuer.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// Subject requires a client certificate with this subject.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+	// Cipher requires connections to use this cipher.
+	// +optional
+	Cipher string `json:"cipher,omitempty"`
+}
+
+// MySQLUserState represents the state of a MySQL user.
+type MySQLUserState struct {
+	Username string   `json:"username,omitempty"`
+	Hosts    []string `json:"hosts,omitempty"`
+}
+
+// MySQL generates a MySQL or MariaDB user based on the configuration parameters in spec.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type MySQL struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MySQLSpec                   `json:"spec,omitempty"`
+	Status genv1alpha1.GeneratorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MySQLList contains a list of MySQL resources.
+type MySQLList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MySQL `json:"items"`
+}
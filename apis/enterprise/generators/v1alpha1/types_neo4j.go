@@ -49,6 +49,10 @@ type Neo4jSpec struct {
 	// If the neo4j instance is running in enterprise mode.
 	// +kubebuilder:default=false
 	Enterprise bool `json:"enterprise,omitempty"`
+	// RotationPolicy controls how the user is rotated.
+	// With the alternating strategy, the user is used as the prefix of the two users, and no random suffix is added.
+	// +optional
+	RotationPolicy *genv1alpha1.RotationPolicy `json:"rotationPolicy,omitempty"`
 }
 
 // Neo4jAuth defines Neo4j authentication configuration.
@@ -117,6 +121,9 @@ type Neo4jUser struct {
 // Neo4jUserState represents the state of a Neo4j user.
 type Neo4jUserState struct {
 	User string `json:"user"`
+	// Usernames are the users rotated with the alternating strategy.
+	// +optional
+	Usernames []string `json:"usernames,omitempty"`
 }
 
 // Neo4j generates a Neo4j user based on the configuration parameters in spec.
@@ -31,6 +31,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,8 +62,16 @@ type PostgreSQLSpec struct {
 	Port string `json:"port"`
 	// Auth contains the credentials or auth configuration
 	Auth PostgreSQLAuth `json:"auth"`
+	// TLS configures the TLS connection to the database.
+	// If not specified, TLS is disabled.
+	// +optional
+	TLS *PostgreSQLTLS `json:"tls,omitempty"`
 	// User is the data of the user to be created.
 	User *PostgreSQLUser `json:"user,omitempty"`
+	// RotationPolicy controls how the user is rotated.
+	// With the alternating strategy, the username is used as the prefix of the two users, and no random suffix is added.
+	// +optional
+	RotationPolicy *genv1alpha1.RotationPolicy `json:"rotationPolicy,omitempty"`
 
 	CleanupPolicy *PostgreSQLCleanupPolicy `json:"cleanupPolicy,omitempty"`
 }
@@ -72,7 +81,67 @@ type PostgreSQLAuth struct {
 	// A basic auth username used to authenticate against the PostgreSQL instance.
 	Username string `json:"username"`
 	// A basic auth password used to authenticate against the PostgreSQL instance.
-	Password esmeta.SecretKeySelector `json:"password"`
+	// Not required when authenticating with a token generator or a client certificate.
+	// +optional
+	Password esmeta.SecretKeySelector `json:"password,omitempty"`
+	// TokenGeneratorRef is a reference to a generator producing a short-lived token
+	// used as password, e.g. for IAM database authentication of managed instances.
+	// Takes precedence over Password.
+	// +optional
+	TokenGeneratorRef *PostgreSQLTokenGeneratorRef `json:"tokenGeneratorRef,omitempty"`
+}
+
+// PostgreSQLTokenGeneratorRef is a reference to a generator producing the admin token.
+type PostgreSQLTokenGeneratorRef struct {
+	// Specify the apiVersion of the generator resource
+	// +kubebuilder:default="generators.external-secrets.io/v1alpha1"
+	APIVersion string `json:"apiVersion,omitempty"`
+	// Specify the Kind of the generator resource
+	Kind string `json:"kind"`
+	// Specify the name of the generator resource
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	Name string `json:"name"`
+	// Key is the key of the generated data holding the token.
+	// If not specified, the "password" key will be used.
+	// +kubebuilder:default=password
+	Key string `json:"key,omitempty"`
+}
+
+// PostgreSQLSSLMode is the TLS mode of the connection, following the libpq sslmode semantics.
+type PostgreSQLSSLMode string
+
+const (
+	// PostgreSQLSSLModeDisable disables TLS.
+	PostgreSQLSSLModeDisable PostgreSQLSSLMode = "disable"
+	// PostgreSQLSSLModeRequire requires TLS without verifying the server certificate,
+	// unless a CA is configured, in which case it behaves like verify-ca.
+	PostgreSQLSSLModeRequire PostgreSQLSSLMode = "require"
+	// PostgreSQLSSLModeVerifyCA requires TLS and verifies the server certificate is signed by a trusted CA.
+	PostgreSQLSSLModeVerifyCA PostgreSQLSSLMode = "verify-ca"
+	// PostgreSQLSSLModeVerifyFull additionally verifies the server certificate matches the host.
+	PostgreSQLSSLModeVerifyFull PostgreSQLSSLMode = "verify-full"
+)
+
+// PostgreSQLTLS configures TLS for the connections to the database.
+type PostgreSQLTLS struct {
+	// SSLMode is the TLS mode of the connection.
+	// +kubebuilder:validation:Enum=disable;require;verify-ca;verify-full
+	// +kubebuilder:default=verify-full
+	SSLMode PostgreSQLSSLMode `json:"sslMode,omitempty"`
+	// PEM encoded CA bundle used to validate the server certificate.
+	// If neither CABundle nor CAProvider are set the system root certificates are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// The provider for the CA bundle used to validate the server certificate.
+	// +optional
+	CAProvider *esv1.CAProvider `json:"caProvider,omitempty"`
+	// ClientCertificate is a reference to the PEM encoded client certificate.
+	// +optional
+	ClientCertificate *esmeta.SecretKeySelector `json:"clientCertificate,omitempty"`
+	// ClientKey is a reference to the PEM encoded private key of the client certificate.
+	// +optional
+	ClientKey *esmeta.SecretKeySelector `json:"clientKey,omitempty"`
 }
 
 // PostgreSQLUserAttributesEnum represents PostgreSQL user attributes.
@@ -125,6 +194,102 @@ type PostgreSQLUser struct {
 	// If not specified, the role from `spec.auth.username` will be used.
 	// If the role does not exist, it will be created with no attributes or roles..
 	ReassignTo *string `json:"reassignTo,omitempty"`
+	// Privileges are the privileges granted directly to this user.
+	// Privileges on schemas, tables, sequences and default privileges apply to `spec.database`.
+	// Previously granted privileges not listed here are revoked when the user is regenerated.
+	// +optional
+	Privileges *PostgreSQLPrivileges `json:"privileges,omitempty"`
+	// SearchPath is the search_path set for this user.
+	// +optional
+	SearchPath []string `json:"searchPath,omitempty"`
+}
+
+// PostgreSQLPrivileges defines the privileges granted to a PostgreSQL user.
+type PostgreSQLPrivileges struct {
+	// Databases are the privileges granted on databases.
+	// +optional
+	Databases []PostgreSQLDatabasePrivilege `json:"databases,omitempty"`
+	// Schemas are the privileges granted on schemas.
+	// +optional
+	Schemas []PostgreSQLSchemaPrivilege `json:"schemas,omitempty"`
+	// Tables are the privileges granted on tables.
+	// +optional
+	Tables []PostgreSQLTablePrivilege `json:"tables,omitempty"`
+	// Sequences are the privileges granted on sequences.
+	// +optional
+	Sequences []PostgreSQLSequencePrivilege `json:"sequences,omitempty"`
+	// DefaultPrivileges are the privileges granted on objects created in the future.
+	// +optional
+	DefaultPrivileges []PostgreSQLDefaultPrivilege `json:"defaultPrivileges,omitempty"`
+}
+
+// PostgreSQLDatabasePrivilege defines privileges granted on a database.
+type PostgreSQLDatabasePrivilege struct {
+	// Name is the name of the database.
+	Name string `json:"name"`
+	// Privileges are the privileges granted on the database.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=CONNECT;CREATE;TEMPORARY;ALL
+	Privileges []string `json:"privileges"`
+}
+
+// PostgreSQLSchemaPrivilege defines privileges granted on a schema.
+type PostgreSQLSchemaPrivilege struct {
+	// Name is the name of the schema.
+	Name string `json:"name"`
+	// Privileges are the privileges granted on the schema.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=USAGE;CREATE;ALL
+	Privileges []string `json:"privileges"`
+}
+
+// PostgreSQLTablePrivilege defines privileges granted on tables of a schema.
+type PostgreSQLTablePrivilege struct {
+	// Schema is the schema of the tables.
+	// +kubebuilder:default=public
+	Schema string `json:"schema,omitempty"`
+	// Tables are the names of the tables.
+	// If not specified, the privileges are granted on ALL TABLES IN SCHEMA.
+	// +optional
+	Tables []string `json:"tables,omitempty"`
+	// Privileges are the privileges granted on the tables.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=SELECT;INSERT;UPDATE;DELETE;TRUNCATE;REFERENCES;TRIGGER;ALL
+	Privileges []string `json:"privileges"`
+}
+
+// PostgreSQLSequencePrivilege defines privileges granted on sequences of a schema.
+type PostgreSQLSequencePrivilege struct {
+	// Schema is the schema of the sequences.
+	// +kubebuilder:default=public
+	Schema string `json:"schema,omitempty"`
+	// Sequences are the names of the sequences.
+	// If not specified, the privileges are granted on ALL SEQUENCES IN SCHEMA.
+	// +optional
+	Sequences []string `json:"sequences,omitempty"`
+	// Privileges are the privileges granted on the sequences.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=USAGE;SELECT;UPDATE;ALL
+	Privileges []string `json:"privileges"`
+}
+
+// PostgreSQLDefaultPrivilege defines privileges granted on objects created in the future, using ALTER DEFAULT PRIVILEGES.
+type PostgreSQLDefaultPrivilege struct {
+	// Schema restricts the default privileges to objects created in this schema.
+	// If not specified, they apply to objects created in any schema.
+	// +optional
+	Schema string `json:"schema,omitempty"`
+	// ForRole is the role creating the objects.
+	// If not specified, the role from `spec.auth.username` will be used.
+	// +optional
+	ForRole string `json:"forRole,omitempty"`
+	// ObjectType is the type of the objects.
+	// +kubebuilder:validation:Enum=TABLES;SEQUENCES;FUNCTIONS;TYPES
+	ObjectType string `json:"objectType"`
+	// Privileges are the privileges granted on the objects.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=SELECT;INSERT;UPDATE;DELETE;TRUNCATE;REFERENCES;TRIGGER;USAGE;EXECUTE;ALL
+	Privileges []string `json:"privileges"`
 }
 
 // PostgreSQLUserAttribute defines a PostgreSQL user attribute.
@@ -140,6 +305,9 @@ type PostgreSQLUserAttribute struct {
 // PostgreSQLUserState represents the state of a PostgreSQL user.
 type PostgreSQLUserState struct {
 	Username string `json:"username,omitempty"`
+	// Usernames are the users rotated with the alternating strategy.
+	// +optional
+	Usernames []string `json:"usernames,omitempty"`
 }
 
 // PostgreSQL generates a PostgreSQL user based on the configuration parameters in spec.
@@ -0,0 +1,173 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RedisCleanupPolicy controls the cleanup policy for the Redis generator.
+type RedisCleanupPolicy struct {
+	genv1alpha1.CleanupPolicy `json:",inline"`
+
+	// ActivityTrackingInterval is the interval the clients of the server are observed at.
+	// +optional
+	// +kubebuilder:default="2s"
+	ActivityTrackingInterval metav1.Duration `json:"activityTrackingInterval,omitempty"`
+
+	// ObservationKey is the hash the last activity of the users is recorded in.
+	// +optional
+	// +kubebuilder:default="external-secrets:session-observation"
+	ObservationKey string `json:"observationKey,omitempty"`
+}
+
+// RedisSpec controls the behavior of the Redis generator.
+// The generator supports Redis 6+ and Valkey servers with ACLs.
+type RedisSpec struct {
+	// Host is the server where Redis is hosted.
+	// In cluster mode, it is any node of the cluster.
+	Host string `json:"host"`
+	// Port is the port of the server to connect to.
+	// If not specified, the "6379" port will be used.
+	// +kubebuilder:validation:Pattern=`^[0-9]{1,5}$`
+	// +kubebuilder:default="6379"
+	Port string `json:"port"`
+	// Cluster enables Redis Cluster mode. ACL users are not replicated across
+	// a cluster, so the user is set on every node of the cluster.
+	// +optional
+	Cluster bool `json:"cluster,omitempty"`
+	// Auth contains the credentials or auth configuration
+	Auth RedisAuth `json:"auth"`
+	// TLS configures the TLS connection to the server.
+	// If not specified, TLS is disabled.
+	// +optional
+	TLS *RedisTLS `json:"tls,omitempty"`
+	// User is the data of the user to be created.
+	User RedisUser `json:"user"`
+
+	CleanupPolicy *RedisCleanupPolicy `json:"cleanupPolicy,omitempty"`
+}
+
+// RedisAuth defines Redis authentication configuration.
+type RedisAuth struct {
+	// Username is the ACL user used to authenticate against the Redis instance.
+	// If not specified, the "default" user is used.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// Password is the password used to authenticate against the Redis instance.
+	Password esmeta.SecretKeySelector `json:"password"`
+}
+
+// RedisTLS configures TLS for the connections to the server.
+type RedisTLS struct {
+	// PEM encoded CA bundle used to validate the server certificate.
+	// If neither CABundle nor CAProvider are set the system root certificates are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// The provider for the CA bundle used to validate the server certificate.
+	// +optional
+	CAProvider *esv1.CAProvider `json:"caProvider,omitempty"`
+	// ClientCertificate is a reference to the PEM encoded client certificate.
+	// +optional
+	ClientCertificate *esmeta.SecretKeySelector `json:"clientCertificate,omitempty"`
+	// ClientKey is a reference to the PEM encoded private key of the client certificate.
+	// +optional
+	ClientKey *esmeta.SecretKeySelector `json:"clientKey,omitempty"`
+	// ServerName is the name the server certificate is verified against.
+	// If not specified, the host is used.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify disables the verification of the server certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// RedisUser defines a Redis ACL user.
+type RedisUser struct {
+	// The username of the user to be created.
+	Username string `json:"username"`
+	// SuffixSize define the size of the random suffix added after the defined username.
+	// If not specified, a random suffix of size 8 will be used.
+	// If set to 0, no suffix will be added.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=8
+	SuffixSize *int `json:"suffixSize,omitempty"`
+	// Keys are the key patterns the user can access, e.g. "app:*".
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+	// ReadKeys are the key patterns the user can only read. Requires Redis 7.
+	// +optional
+	ReadKeys []string `json:"readKeys,omitempty"`
+	// WriteKeys are the key patterns the user can only write. Requires Redis 7.
+	// +optional
+	WriteKeys []string `json:"writeKeys,omitempty"`
+	// Channels are the Pub/Sub channel patterns the user can access.
+	// +optional
+	Channels []string `json:"channels,omitempty"`
+	// Categories are the command categories the user can run, e.g. "read" or "write".
+	// +optional
+	Categories []string `json:"categories,omitempty"`
+	// Commands are additional command rules applied after the categories,
+	// e.g. "+info" to allow or "-flushall" to deny a command.
+	// +optional
+	Commands []string `json:"commands,omitempty"`
+}
+
+// RedisUserState represents the state of a Redis user.
+type RedisUserState struct {
+	Username string `json:"username,omitempty"`
+}
+
+// Redis generates a Redis ACL user based on the configuration parameters in spec.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={external-secrets, external-secrets-generators}
+type Redis struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedisSpec                   `json:"spec,omitempty"`
+	Status genv1alpha1.GeneratorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RedisList contains a list of Redis resources.
+type RedisList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Redis `json:"items"`
+}
@@ -32,27 +32,40 @@ package v1alpha1
 
 import (
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // SSHKeyType defines the type of SSH key to be generated.
-// +kubebuilder:validation:Enum=RSA
+// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
 type SSHKeyType string
 
 const (
 	// SSHKeyTypeRSA defines the type of SSH key to be generated.
 	SSHKeyTypeRSA SSHKeyType = "RSA"
+	// SSHKeyTypeECDSA generates an ECDSA key.
+	SSHKeyTypeECDSA SSHKeyType = "ECDSA"
+	// SSHKeyTypeEd25519 generates an Ed25519 key.
+	SSHKeyTypeEd25519 SSHKeyType = "Ed25519"
 )
 
 // SSHSpec controls the behavior of the password generator.
 type SSHSpec struct {
 	// KeyType specifies the SSH key type to be generated.
-	// Currently only RSA is supported.
 	// +kubebuilder:default="RSA"
 	KeyType SSHKeyType `json:"keyType,omitempty"`
 
 	// RSAConfig specifies the configuration of the RSA key to be generated.
 	RSAConfig RSASpec `json:"rsaConfig,omitempty"`
+
+	// ECDSAConfig specifies the configuration of the ECDSA key to be generated.
+	// +optional
+	ECDSAConfig ECDSASpec `json:"ecdsaConfig,omitempty"`
+
+	// Certificate signs the public key with an SSH certificate authority,
+	// and adds the OpenSSH certificate to the output.
+	// +optional
+	Certificate *SSHCertificate `json:"certificate,omitempty"`
 }
 
 // RSASpec controls the behavior of the password generator.
@@ -63,6 +76,74 @@ type RSASpec struct {
 	Bits int `json:"bits"`
 }
 
+// ECDSASpec controls the ECDSA key to be generated.
+type ECDSASpec struct {
+	// Bit size of the curve of the ECDSA key to be generated.
+	// Defaults to 256
+	// +kubebuilder:validation:Enum=256;384;521
+	// +kubebuilder:default=256
+	Bits int `json:"bits,omitempty"`
+}
+
+// SSHCertificateType defines whether a certificate authenticates a user or a host.
+type SSHCertificateType string
+
+const (
+	// SSHCertificateTypeUser is a certificate authenticating a user to hosts.
+	SSHCertificateTypeUser SSHCertificateType = "User"
+	// SSHCertificateTypeHost is a certificate authenticating a host to users.
+	SSHCertificateTypeHost SSHCertificateType = "Host"
+)
+
+// SSHCertificate controls the OpenSSH certificate signed by the CA.
+type SSHCertificate struct {
+	// CA is the certificate authority that signs the certificate.
+	CA SSHCertificateCA `json:"ca"`
+
+	// PublicKey is a public key in authorized_keys format to sign, instead of
+	// generating a key pair. The output then only contains the public key and its certificate.
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// Type is the type of the certificate.
+	// +kubebuilder:validation:Enum=User;Host
+	// +kubebuilder:default="User"
+	Type SSHCertificateType `json:"type,omitempty"`
+
+	// KeyID is the identity of the certificate, logged by the server on authentication.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// Principals are the users or host names the certificate is valid for.
+	// A certificate without principals is valid for any user or host, so at least one is required.
+	// +kubebuilder:validation:MinItems=1
+	Principals []string `json:"principals"`
+
+	// TTL is the validity of the certificate.
+	// +kubebuilder:default="1h"
+	TTL metav1.Duration `json:"ttl,omitempty"`
+
+	// CriticalOptions restrict the use of a user certificate, e.g. "force-command" or "source-address".
+	// +optional
+	CriticalOptions map[string]string `json:"criticalOptions,omitempty"`
+
+	// Extensions enable features for a user certificate, e.g. "permit-pty".
+	// If not specified, user certificates get the default extensions of ssh-keygen:
+	// permit-X11-forwarding, permit-agent-forwarding, permit-port-forwarding, permit-pty and permit-user-rc.
+	// +optional
+	Extensions map[string]string `json:"extensions,omitempty"`
+}
+
+// SSHCertificateCA references the Secret with the private key of the SSH certificate authority.
+type SSHCertificateCA struct {
+	// PrivateKey is a reference to the private key of the CA, in OpenSSH or PEM format.
+	PrivateKey esmeta.SecretKeySelector `json:"privateKey"`
+
+	// Passphrase is a reference to the passphrase of the private key of the CA, if it is encrypted.
+	// +optional
+	Passphrase *esmeta.SecretKeySelector `json:"passphrase,omitempty"`
+}
+
 // SSH generates a random ssh based on the
 // configuration parameters in spec.
 // You can specify the length, characterset and other attributes.
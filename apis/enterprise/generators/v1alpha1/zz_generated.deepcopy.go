@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	externalsecretsv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	generatorsv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	"github.com/external-secrets/external-secrets/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -161,6 +162,225 @@ func (in *BasicAuthSpec) DeepCopy() *BasicAuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Certificate) DeepCopyInto(out *Certificate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certificate.
+func (in *Certificate) DeepCopy() *Certificate {
+	if in == nil {
+		return nil
+	}
+	out := new(Certificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Certificate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateCA) DeepCopyInto(out *CertificateCA) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateCA.
+func (in *CertificateCA) DeepCopy() *CertificateCA {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateCRL) DeepCopyInto(out *CertificateCRL) {
+	*out = *in
+	out.Validity = in.Validity
+	if in.DistributionPoints != nil {
+		in, out := &in.DistributionPoints, &out.DistributionPoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateCRL.
+func (in *CertificateCRL) DeepCopy() *CertificateCRL {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateCRL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateList) DeepCopyInto(out *CertificateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Certificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateList.
+func (in *CertificateList) DeepCopy() *CertificateList {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatePKCS12) DeepCopyInto(out *CertificatePKCS12) {
+	*out = *in
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePKCS12.
+func (in *CertificatePKCS12) DeepCopy() *CertificatePKCS12 {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatePKCS12)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatePrivateKey) DeepCopyInto(out *CertificatePrivateKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePrivateKey.
+func (in *CertificatePrivateKey) DeepCopy() *CertificatePrivateKey {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatePrivateKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
+	*out = *in
+	out.CA = in.CA
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URIs != nil {
+		in, out := &in.URIs, &out.URIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.PrivateKey = in.PrivateKey
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]CertificateKeyUsage, len(*in))
+		copy(*out, *in)
+	}
+	out.TTL = in.TTL
+	if in.PKCS12 != nil {
+		in, out := &in.PKCS12, &out.PKCS12
+		*out = new(CertificatePKCS12)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CRL != nil {
+		in, out := &in.CRL, &out.CRL
+		*out = new(CertificateCRL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CleanupPolicy != nil {
+		in, out := &in.CleanupPolicy, &out.CleanupPolicy
+		*out = new(generatorsv1alpha1.CleanupPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSpec.
+func (in *CertificateSpec) DeepCopy() *CertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateState) DeepCopyInto(out *CertificateState) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateState.
+func (in *CertificateState) DeepCopy() *CertificateState {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ECDSASpec) DeepCopyInto(out *ECDSASpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ECDSASpec.
+func (in *ECDSASpec) DeepCopy() *ECDSASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ECDSASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Federation) DeepCopyInto(out *Federation) {
 	*out = *in
@@ -326,7 +546,7 @@ func (in *IAMRef) DeepCopy() *IAMRef {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDB) DeepCopyInto(out *MongoDB) {
+func (in *JWT) DeepCopyInto(out *JWT) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -334,18 +554,18 @@ func (in *MongoDB) DeepCopyInto(out *MongoDB) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDB.
-func (in *MongoDB) DeepCopy() *MongoDB {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWT.
+func (in *JWT) DeepCopy() *JWT {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDB)
+	out := new(JWT)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MongoDB) DeepCopyObject() runtime.Object {
+func (in *JWT) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -353,87 +573,794 @@ func (in *MongoDB) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBAuth) DeepCopyInto(out *MongoDBAuth) {
+func (in *JWTList) DeepCopyInto(out *JWTList) {
 	*out = *in
-	if in.SCRAM != nil {
-		in, out := &in.SCRAM, &out.SCRAM
-		*out = new(MongoDBSCRAMAuth)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JWT, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBAuth.
-func (in *MongoDBAuth) DeepCopy() *MongoDBAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTList.
+func (in *JWTList) DeepCopy() *JWTList {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBAuth)
+	out := new(JWTList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JWTList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBAuthSecretRef) DeepCopyInto(out *MongoDBAuthSecretRef) {
+func (in *JWTSigningKey) DeepCopyInto(out *JWTSigningKey) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTSigningKey.
+func (in *JWTSigningKey) DeepCopy() *JWTSigningKey {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTSigningKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTSpec) DeepCopyInto(out *JWTSpec) {
+	*out = *in
+	in.SigningKey.DeepCopyInto(&out.SigningKey)
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.TTL = in.TTL
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTSpec.
+func (in *JWTSpec) DeepCopy() *JWTSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Kafka) DeepCopyInto(out *Kafka) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kafka.
+func (in *Kafka) DeepCopy() *Kafka {
+	if in == nil {
+		return nil
+	}
+	out := new(Kafka)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Kafka) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaACL) DeepCopyInto(out *KafkaACL) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaACL.
+func (in *KafkaACL) DeepCopy() *KafkaACL {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaACL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaAuth) DeepCopyInto(out *KafkaAuth) {
 	*out = *in
 	in.Password.DeepCopyInto(&out.Password)
-	if in.Username != nil {
-		in, out := &in.Username, &out.Username
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaAuth.
+func (in *KafkaAuth) DeepCopy() *KafkaAuth {
+	if in == nil {
+		return nil
 	}
+	out := new(KafkaAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaList) DeepCopyInto(out *KafkaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Kafka, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaList.
+func (in *KafkaList) DeepCopy() *KafkaList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSpec) DeepCopyInto(out *KafkaSpec) {
+	*out = *in
+	if in.BootstrapServers != nil {
+		in, out := &in.BootstrapServers, &out.BootstrapServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(KafkaAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KafkaTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	in.User.DeepCopyInto(&out.User)
+	if in.CleanupPolicy != nil {
+		in, out := &in.CleanupPolicy, &out.CleanupPolicy
+		*out = new(generatorsv1alpha1.CleanupPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSpec.
+func (in *KafkaSpec) DeepCopy() *KafkaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTLS) DeepCopyInto(out *KafkaTLS) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CAProvider != nil {
+		in, out := &in.CAProvider, &out.CAProvider
+		*out = new(externalsecretsv1.CAProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientKey != nil {
+		in, out := &in.ClientKey, &out.ClientKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTLS.
+func (in *KafkaTLS) DeepCopy() *KafkaTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUser) DeepCopyInto(out *KafkaUser) {
+	*out = *in
+	if in.SuffixSize != nil {
+		in, out := &in.SuffixSize, &out.SuffixSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.ACLs != nil {
+		in, out := &in.ACLs, &out.ACLs
+		*out = make([]KafkaACL, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUser.
+func (in *KafkaUser) DeepCopy() *KafkaUser {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaUserState) DeepCopyInto(out *KafkaUserState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserState.
+func (in *KafkaUserState) DeepCopy() *KafkaUserState {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaUserState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAP) DeepCopyInto(out *LDAP) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAP.
+func (in *LDAP) DeepCopy() *LDAP {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LDAP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPAuth) DeepCopyInto(out *LDAPAuth) {
+	*out = *in
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPAuth.
+func (in *LDAPAuth) DeepCopy() *LDAPAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPList) DeepCopyInto(out *LDAPList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LDAP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPList.
+func (in *LDAPList) DeepCopy() *LDAPList {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LDAPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPSpec) DeepCopyInto(out *LDAPSpec) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+	in.TLS.DeepCopyInto(&out.TLS)
+	in.User.DeepCopyInto(&out.User)
+	in.Password.DeepCopyInto(&out.Password)
+	if in.CleanupPolicy != nil {
+		in, out := &in.CleanupPolicy, &out.CleanupPolicy
+		*out = new(generatorsv1alpha1.CleanupPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPSpec.
+func (in *LDAPSpec) DeepCopy() *LDAPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPTLS) DeepCopyInto(out *LDAPTLS) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CAProvider != nil {
+		in, out := &in.CAProvider, &out.CAProvider
+		*out = new(externalsecretsv1.CAProvider)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPTLS.
+func (in *LDAPTLS) DeepCopy() *LDAPTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPUser) DeepCopyInto(out *LDAPUser) {
+	*out = *in
+	if in.SuffixSize != nil {
+		in, out := &in.SuffixSize, &out.SuffixSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.ObjectClasses != nil {
+		in, out := &in.ObjectClasses, &out.ObjectClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPUser.
+func (in *LDAPUser) DeepCopy() *LDAPUser {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPUserState) DeepCopyInto(out *LDAPUserState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPUserState.
+func (in *LDAPUserState) DeepCopy() *LDAPUserState {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPUserState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDB) DeepCopyInto(out *MongoDB) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDB.
+func (in *MongoDB) DeepCopy() *MongoDB {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDB) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBAuth) DeepCopyInto(out *MongoDBAuth) {
+	*out = *in
+	if in.SCRAM != nil {
+		in, out := &in.SCRAM, &out.SCRAM
+		*out = new(MongoDBSCRAMAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBAuth.
+func (in *MongoDBAuth) DeepCopy() *MongoDBAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBAuthSecretRef) DeepCopyInto(out *MongoDBAuthSecretRef) {
+	*out = *in
+	in.Password.DeepCopyInto(&out.Password)
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBAuthSecretRef.
+func (in *MongoDBAuthSecretRef) DeepCopy() *MongoDBAuthSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBAuthSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBDatabase) DeepCopyInto(out *MongoDBDatabase) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBDatabase.
+func (in *MongoDBDatabase) DeepCopy() *MongoDBDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBList) DeepCopyInto(out *MongoDBList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MongoDB, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBList.
+func (in *MongoDBList) DeepCopy() *MongoDBList {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDBList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBRole) DeepCopyInto(out *MongoDBRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBRole.
+func (in *MongoDBRole) DeepCopy() *MongoDBRole {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSCRAMAuth) DeepCopyInto(out *MongoDBSCRAMAuth) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(MongoDBAuthSecretRef)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSCRAMAuth.
+func (in *MongoDBSCRAMAuth) DeepCopy() *MongoDBSCRAMAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSCRAMAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSpec) DeepCopyInto(out *MongoDBSpec) {
+	*out = *in
+	out.Database = in.Database
+	in.Auth.DeepCopyInto(&out.Auth)
+	in.User.DeepCopyInto(&out.User)
+	if in.RotationPolicy != nil {
+		in, out := &in.RotationPolicy, &out.RotationPolicy
+		*out = new(generatorsv1alpha1.RotationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSpec.
+func (in *MongoDBSpec) DeepCopy() *MongoDBSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBUser) DeepCopyInto(out *MongoDBUser) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]MongoDBRole, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBUser.
+func (in *MongoDBUser) DeepCopy() *MongoDBUser {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBUserState) DeepCopyInto(out *MongoDBUserState) {
+	*out = *in
+	if in.Usernames != nil {
+		in, out := &in.Usernames, &out.Usernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBUserState.
+func (in *MongoDBUserState) DeepCopy() *MongoDBUserState {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBUserState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQL) DeepCopyInto(out *MySQL) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQL.
+func (in *MySQL) DeepCopy() *MySQL {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MySQL) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLAuth) DeepCopyInto(out *MySQLAuth) {
+	*out = *in
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLAuth.
+func (in *MySQLAuth) DeepCopy() *MySQLAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLCleanupPolicy) DeepCopyInto(out *MySQLCleanupPolicy) {
+	*out = *in
+	out.CleanupPolicy = in.CleanupPolicy
+	out.ActivityTrackingInterval = in.ActivityTrackingInterval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBAuthSecretRef.
-func (in *MongoDBAuthSecretRef) DeepCopy() *MongoDBAuthSecretRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLCleanupPolicy.
+func (in *MySQLCleanupPolicy) DeepCopy() *MySQLCleanupPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBAuthSecretRef)
+	out := new(MySQLCleanupPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBDatabase) DeepCopyInto(out *MongoDBDatabase) {
+func (in *MySQLGrant) DeepCopyInto(out *MySQLGrant) {
 	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBDatabase.
-func (in *MongoDBDatabase) DeepCopy() *MongoDBDatabase {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLGrant.
+func (in *MySQLGrant) DeepCopy() *MySQLGrant {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBDatabase)
+	out := new(MySQLGrant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBList) DeepCopyInto(out *MongoDBList) {
+func (in *MySQLList) DeepCopyInto(out *MySQLList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]MongoDB, len(*in))
+		*out = make([]MySQL, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBList.
-func (in *MongoDBList) DeepCopy() *MongoDBList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLList.
+func (in *MySQLList) DeepCopy() *MySQLList {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBList)
+	out := new(MySQLList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MongoDBList) DeepCopyObject() runtime.Object {
+func (in *MySQLList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -441,94 +1368,100 @@ func (in *MongoDBList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBRole) DeepCopyInto(out *MongoDBRole) {
+func (in *MySQLSpec) DeepCopyInto(out *MySQLSpec) {
 	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+	in.User.DeepCopyInto(&out.User)
+	if in.CleanupPolicy != nil {
+		in, out := &in.CleanupPolicy, &out.CleanupPolicy
+		*out = new(MySQLCleanupPolicy)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBRole.
-func (in *MongoDBRole) DeepCopy() *MongoDBRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLSpec.
+func (in *MySQLSpec) DeepCopy() *MySQLSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBRole)
+	out := new(MySQLSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBSCRAMAuth) DeepCopyInto(out *MongoDBSCRAMAuth) {
+func (in *MySQLUser) DeepCopyInto(out *MySQLUser) {
 	*out = *in
-	if in.Username != nil {
-		in, out := &in.Username, &out.Username
-		*out = new(string)
+	if in.SuffixSize != nil {
+		in, out := &in.SuffixSize, &out.SuffixSize
+		*out = new(int)
 		**out = **in
 	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(MongoDBAuthSecretRef)
-		(*in).DeepCopyInto(*out)
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSCRAMAuth.
-func (in *MongoDBSCRAMAuth) DeepCopy() *MongoDBSCRAMAuth {
-	if in == nil {
-		return nil
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]MySQLGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxUserConnections != nil {
+		in, out := &in.MaxUserConnections, &out.MaxUserConnections
+		*out = new(int)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MySQLUserTLS)
+		**out = **in
 	}
-	out := new(MongoDBSCRAMAuth)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBSpec) DeepCopyInto(out *MongoDBSpec) {
-	*out = *in
-	out.Database = in.Database
-	in.Auth.DeepCopyInto(&out.Auth)
-	in.User.DeepCopyInto(&out.User)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSpec.
-func (in *MongoDBSpec) DeepCopy() *MongoDBSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLUser.
+func (in *MySQLUser) DeepCopy() *MySQLUser {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBSpec)
+	out := new(MySQLUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBUser) DeepCopyInto(out *MongoDBUser) {
+func (in *MySQLUserState) DeepCopyInto(out *MySQLUserState) {
 	*out = *in
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]MongoDBRole, len(*in))
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBUser.
-func (in *MongoDBUser) DeepCopy() *MongoDBUser {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLUserState.
+func (in *MySQLUserState) DeepCopy() *MySQLUserState {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBUser)
+	out := new(MySQLUserState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MongoDBUserState) DeepCopyInto(out *MongoDBUserState) {
+func (in *MySQLUserTLS) DeepCopyInto(out *MySQLUserTLS) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBUserState.
-func (in *MongoDBUserState) DeepCopy() *MongoDBUserState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLUserTLS.
+func (in *MySQLUserTLS) DeepCopy() *MySQLUserTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(MongoDBUserState)
+	out := new(MySQLUserTLS)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -658,6 +1591,11 @@ func (in *Neo4jSpec) DeepCopyInto(out *Neo4jSpec) {
 		*out = new(Neo4jUser)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RotationPolicy != nil {
+		in, out := &in.RotationPolicy, &out.RotationPolicy
+		*out = new(generatorsv1alpha1.RotationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Neo4jSpec.
@@ -703,6 +1641,11 @@ func (in *Neo4jUser) DeepCopy() *Neo4jUser {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Neo4jUserState) DeepCopyInto(out *Neo4jUserState) {
 	*out = *in
+	if in.Usernames != nil {
+		in, out := &in.Usernames, &out.Usernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Neo4jUserState.
@@ -882,6 +1825,11 @@ func (in *PostgreSQL) DeepCopyObject() runtime.Object {
 func (in *PostgreSQLAuth) DeepCopyInto(out *PostgreSQLAuth) {
 	*out = *in
 	in.Password.DeepCopyInto(&out.Password)
+	if in.TokenGeneratorRef != nil {
+		in, out := &in.TokenGeneratorRef, &out.TokenGeneratorRef
+		*out = new(PostgreSQLTokenGeneratorRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLAuth.
@@ -897,74 +1845,294 @@ func (in *PostgreSQLAuth) DeepCopy() *PostgreSQLAuth {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgreSQLCleanupPolicy) DeepCopyInto(out *PostgreSQLCleanupPolicy) {
 	*out = *in
-	out.CleanupPolicy = in.CleanupPolicy
-	out.ActivityTrackingInterval = in.ActivityTrackingInterval
+	out.CleanupPolicy = in.CleanupPolicy
+	out.ActivityTrackingInterval = in.ActivityTrackingInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLCleanupPolicy.
+func (in *PostgreSQLCleanupPolicy) DeepCopy() *PostgreSQLCleanupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLCleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLDatabasePrivilege) DeepCopyInto(out *PostgreSQLDatabasePrivilege) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLDatabasePrivilege.
+func (in *PostgreSQLDatabasePrivilege) DeepCopy() *PostgreSQLDatabasePrivilege {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLDatabasePrivilege)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLDefaultPrivilege) DeepCopyInto(out *PostgreSQLDefaultPrivilege) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLDefaultPrivilege.
+func (in *PostgreSQLDefaultPrivilege) DeepCopy() *PostgreSQLDefaultPrivilege {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLDefaultPrivilege)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLList) DeepCopyInto(out *PostgreSQLList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PostgreSQL, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLList.
+func (in *PostgreSQLList) DeepCopy() *PostgreSQLList {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgreSQLList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLPrivileges) DeepCopyInto(out *PostgreSQLPrivileges) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]PostgreSQLDatabasePrivilege, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Schemas != nil {
+		in, out := &in.Schemas, &out.Schemas
+		*out = make([]PostgreSQLSchemaPrivilege, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]PostgreSQLTablePrivilege, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sequences != nil {
+		in, out := &in.Sequences, &out.Sequences
+		*out = make([]PostgreSQLSequencePrivilege, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultPrivileges != nil {
+		in, out := &in.DefaultPrivileges, &out.DefaultPrivileges
+		*out = make([]PostgreSQLDefaultPrivilege, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLPrivileges.
+func (in *PostgreSQLPrivileges) DeepCopy() *PostgreSQLPrivileges {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLPrivileges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLSchemaPrivilege) DeepCopyInto(out *PostgreSQLSchemaPrivilege) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLSchemaPrivilege.
+func (in *PostgreSQLSchemaPrivilege) DeepCopy() *PostgreSQLSchemaPrivilege {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLSchemaPrivilege)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLSequencePrivilege) DeepCopyInto(out *PostgreSQLSequencePrivilege) {
+	*out = *in
+	if in.Sequences != nil {
+		in, out := &in.Sequences, &out.Sequences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLSequencePrivilege.
+func (in *PostgreSQLSequencePrivilege) DeepCopy() *PostgreSQLSequencePrivilege {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLSequencePrivilege)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLSpec) DeepCopyInto(out *PostgreSQLSpec) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(PostgreSQLTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(PostgreSQLUser)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RotationPolicy != nil {
+		in, out := &in.RotationPolicy, &out.RotationPolicy
+		*out = new(generatorsv1alpha1.RotationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CleanupPolicy != nil {
+		in, out := &in.CleanupPolicy, &out.CleanupPolicy
+		*out = new(PostgreSQLCleanupPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLSpec.
+func (in *PostgreSQLSpec) DeepCopy() *PostgreSQLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgreSQLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgreSQLTLS) DeepCopyInto(out *PostgreSQLTLS) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CAProvider != nil {
+		in, out := &in.CAProvider, &out.CAProvider
+		*out = new(externalsecretsv1.CAProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientKey != nil {
+		in, out := &in.ClientKey, &out.ClientKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLCleanupPolicy.
-func (in *PostgreSQLCleanupPolicy) DeepCopy() *PostgreSQLCleanupPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLTLS.
+func (in *PostgreSQLTLS) DeepCopy() *PostgreSQLTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgreSQLCleanupPolicy)
+	out := new(PostgreSQLTLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgreSQLList) DeepCopyInto(out *PostgreSQLList) {
+func (in *PostgreSQLTablePrivilege) DeepCopyInto(out *PostgreSQLTablePrivilege) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PostgreSQL, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLList.
-func (in *PostgreSQLList) DeepCopy() *PostgreSQLList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLTablePrivilege.
+func (in *PostgreSQLTablePrivilege) DeepCopy() *PostgreSQLTablePrivilege {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgreSQLList)
+	out := new(PostgreSQLTablePrivilege)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PostgreSQLList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PostgreSQLSpec) DeepCopyInto(out *PostgreSQLSpec) {
+func (in *PostgreSQLTokenGeneratorRef) DeepCopyInto(out *PostgreSQLTokenGeneratorRef) {
 	*out = *in
-	in.Auth.DeepCopyInto(&out.Auth)
-	if in.User != nil {
-		in, out := &in.User, &out.User
-		*out = new(PostgreSQLUser)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CleanupPolicy != nil {
-		in, out := &in.CleanupPolicy, &out.CleanupPolicy
-		*out = new(PostgreSQLCleanupPolicy)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLSpec.
-func (in *PostgreSQLSpec) DeepCopy() *PostgreSQLSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLTokenGeneratorRef.
+func (in *PostgreSQLTokenGeneratorRef) DeepCopy() *PostgreSQLTokenGeneratorRef {
 	if in == nil {
 		return nil
 	}
-	out := new(PostgreSQLSpec)
+	out := new(PostgreSQLTokenGeneratorRef)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -994,6 +2162,16 @@ func (in *PostgreSQLUser) DeepCopyInto(out *PostgreSQLUser) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = new(PostgreSQLPrivileges)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SearchPath != nil {
+		in, out := &in.SearchPath, &out.SearchPath
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLUser.
@@ -1029,6 +2207,11 @@ func (in *PostgreSQLUserAttribute) DeepCopy() *PostgreSQLUserAttribute {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgreSQLUserState) DeepCopyInto(out *PostgreSQLUserState) {
 	*out = *in
+	if in.Usernames != nil {
+		in, out := &in.Usernames, &out.Usernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLUserState.
@@ -1240,12 +2423,231 @@ func (in *RabbitMQSpec) DeepCopy() *RabbitMQSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Redis) DeepCopyInto(out *Redis) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redis.
+func (in *Redis) DeepCopy() *Redis {
+	if in == nil {
+		return nil
+	}
+	out := new(Redis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Redis) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisAuth) DeepCopyInto(out *RedisAuth) {
+	*out = *in
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisAuth.
+func (in *RedisAuth) DeepCopy() *RedisAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisCleanupPolicy) DeepCopyInto(out *RedisCleanupPolicy) {
+	*out = *in
+	out.CleanupPolicy = in.CleanupPolicy
+	out.ActivityTrackingInterval = in.ActivityTrackingInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCleanupPolicy.
+func (in *RedisCleanupPolicy) DeepCopy() *RedisCleanupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisCleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisList) DeepCopyInto(out *RedisList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Redis, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisList.
+func (in *RedisList) DeepCopy() *RedisList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedisList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisSpec) DeepCopyInto(out *RedisSpec) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RedisTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	in.User.DeepCopyInto(&out.User)
+	if in.CleanupPolicy != nil {
+		in, out := &in.CleanupPolicy, &out.CleanupPolicy
+		*out = new(RedisCleanupPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisSpec.
+func (in *RedisSpec) DeepCopy() *RedisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisTLS) DeepCopyInto(out *RedisTLS) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CAProvider != nil {
+		in, out := &in.CAProvider, &out.CAProvider
+		*out = new(externalsecretsv1.CAProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientKey != nil {
+		in, out := &in.ClientKey, &out.ClientKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisTLS.
+func (in *RedisTLS) DeepCopy() *RedisTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisUser) DeepCopyInto(out *RedisUser) {
+	*out = *in
+	if in.SuffixSize != nil {
+		in, out := &in.SuffixSize, &out.SuffixSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadKeys != nil {
+		in, out := &in.ReadKeys, &out.ReadKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WriteKeys != nil {
+		in, out := &in.WriteKeys, &out.WriteKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisUser.
+func (in *RedisUser) DeepCopy() *RedisUser {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisUserState) DeepCopyInto(out *RedisUserState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisUserState.
+func (in *RedisUserState) DeepCopy() *RedisUserState {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisUserState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSH) DeepCopyInto(out *SSH) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1267,6 +2669,63 @@ func (in *SSH) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHCertificate) DeepCopyInto(out *SSHCertificate) {
+	*out = *in
+	in.CA.DeepCopyInto(&out.CA)
+	if in.Principals != nil {
+		in, out := &in.Principals, &out.Principals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.TTL = in.TTL
+	if in.CriticalOptions != nil {
+		in, out := &in.CriticalOptions, &out.CriticalOptions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHCertificate.
+func (in *SSHCertificate) DeepCopy() *SSHCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHCertificateCA) DeepCopyInto(out *SSHCertificateCA) {
+	*out = *in
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+	if in.Passphrase != nil {
+		in, out := &in.Passphrase, &out.Passphrase
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHCertificateCA.
+func (in *SSHCertificateCA) DeepCopy() *SSHCertificateCA {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHCertificateCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHList) DeepCopyInto(out *SSHList) {
 	*out = *in
@@ -1303,6 +2762,12 @@ func (in *SSHList) DeepCopyObject() runtime.Object {
 func (in *SSHSpec) DeepCopyInto(out *SSHSpec) {
 	*out = *in
 	out.RSAConfig = in.RSAConfig
+	out.ECDSAConfig = in.ECDSAConfig
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(SSHCertificate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHSpec.
@@ -88,6 +88,36 @@ func (g *BasicAuth) Copy() genv1alpha1.GenericGenerator {
 	return g.DeepCopy()
 }
 
+var _ genv1alpha1.GenericGenerator = &Certificate{}
+
+func (g *Certificate) GetObjectMeta() *metav1.ObjectMeta {
+	return &g.ObjectMeta
+}
+
+func (g *Certificate) GetTypeMeta() *metav1.TypeMeta {
+	return &g.TypeMeta
+}
+
+func (g *Certificate) GetKind() string {
+	return reflect.TypeOf(Certificate{}).Name()
+}
+
+func (g *Certificate) SetOutputs(expectedOutput map[string]string) error {
+	bytes, err := json.Marshal(expectedOutput)
+	if err != nil {
+		return err
+	}
+
+	g.Status.Output = &apiextensions.JSON{
+		Raw: bytes,
+	}
+	return nil
+}
+
+func (g *Certificate) Copy() genv1alpha1.GenericGenerator {
+	return g.DeepCopy()
+}
+
 var _ genv1alpha1.GenericGenerator = &Federation{}
 
 func (g *Federation) GetObjectMeta() *metav1.ObjectMeta {
@@ -118,6 +148,96 @@ func (g *Federation) Copy() genv1alpha1.GenericGenerator {
 	return g.DeepCopy()
 }
 
+var _ genv1alpha1.GenericGenerator = &JWT{}
+
+func (g *JWT) GetObjectMeta() *metav1.ObjectMeta {
+	return &g.ObjectMeta
+}
+
+func (g *JWT) GetTypeMeta() *metav1.TypeMeta {
+	return &g.TypeMeta
+}
+
+func (g *JWT) GetKind() string {
+	return reflect.TypeOf(JWT{}).Name()
+}
+
+func (g *JWT) SetOutputs(expectedOutput map[string]string) error {
+	bytes, err := json.Marshal(expectedOutput)
+	if err != nil {
+		return err
+	}
+
+	g.Status.Output = &apiextensions.JSON{
+		Raw: bytes,
+	}
+	return nil
+}
+
+func (g *JWT) Copy() genv1alpha1.GenericGenerator {
+	return g.DeepCopy()
+}
+
+var _ genv1alpha1.GenericGenerator = &Kafka{}
+
+func (g *Kafka) GetObjectMeta() *metav1.ObjectMeta {
+	return &g.ObjectMeta
+}
+
+func (g *Kafka) GetTypeMeta() *metav1.TypeMeta {
+	return &g.TypeMeta
+}
+
+func (g *Kafka) GetKind() string {
+	return reflect.TypeOf(Kafka{}).Name()
+}
+
+func (g *Kafka) SetOutputs(expectedOutput map[string]string) error {
+	bytes, err := json.Marshal(expectedOutput)
+	if err != nil {
+		return err
+	}
+
+	g.Status.Output = &apiextensions.JSON{
+		Raw: bytes,
+	}
+	return nil
+}
+
+func (g *Kafka) Copy() genv1alpha1.GenericGenerator {
+	return g.DeepCopy()
+}
+
+var _ genv1alpha1.GenericGenerator = &LDAP{}
+
+func (g *LDAP) GetObjectMeta() *metav1.ObjectMeta {
+	return &g.ObjectMeta
+}
+
+func (g *LDAP) GetTypeMeta() *metav1.TypeMeta {
+	return &g.TypeMeta
+}
+
+func (g *LDAP) GetKind() string {
+	return reflect.TypeOf(LDAP{}).Name()
+}
+
+func (g *LDAP) SetOutputs(expectedOutput map[string]string) error {
+	bytes, err := json.Marshal(expectedOutput)
+	if err != nil {
+		return err
+	}
+
+	g.Status.Output = &apiextensions.JSON{
+		Raw: bytes,
+	}
+	return nil
+}
+
+func (g *LDAP) Copy() genv1alpha1.GenericGenerator {
+	return g.DeepCopy()
+}
+
 var _ genv1alpha1.GenericGenerator = &MongoDB{}
 
 func (g *MongoDB) GetObjectMeta() *metav1.ObjectMeta {
@@ -148,6 +268,36 @@ func (g *MongoDB) Copy() genv1alpha1.GenericGenerator {
 	return g.DeepCopy()
 }
 
+var _ genv1alpha1.GenericGenerator = &MySQL{}
+
+func (g *MySQL) GetObjectMeta() *metav1.ObjectMeta {
+	return &g.ObjectMeta
+}
+
+func (g *MySQL) GetTypeMeta() *metav1.TypeMeta {
+	return &g.TypeMeta
+}
+
+func (g *MySQL) GetKind() string {
+	return reflect.TypeOf(MySQL{}).Name()
+}
+
+func (g *MySQL) SetOutputs(expectedOutput map[string]string) error {
+	bytes, err := json.Marshal(expectedOutput)
+	if err != nil {
+		return err
+	}
+
+	g.Status.Output = &apiextensions.JSON{
+		Raw: bytes,
+	}
+	return nil
+}
+
+func (g *MySQL) Copy() genv1alpha1.GenericGenerator {
+	return g.DeepCopy()
+}
+
 var _ genv1alpha1.GenericGenerator = &Neo4j{}
 
 func (g *Neo4j) GetObjectMeta() *metav1.ObjectMeta {
@@ -268,6 +418,36 @@ func (g *RabbitMQ) Copy() genv1alpha1.GenericGenerator {
 	return g.DeepCopy()
 }
 
+var _ genv1alpha1.GenericGenerator = &Redis{}
+
+func (g *Redis) GetObjectMeta() *metav1.ObjectMeta {
+	return &g.ObjectMeta
+}
+
+func (g *Redis) GetTypeMeta() *metav1.TypeMeta {
+	return &g.TypeMeta
+}
+
+func (g *Redis) GetKind() string {
+	return reflect.TypeOf(Redis{}).Name()
+}
+
+func (g *Redis) SetOutputs(expectedOutput map[string]string) error {
+	bytes, err := json.Marshal(expectedOutput)
+	if err != nil {
+		return err
+	}
+
+	g.Status.Output = &apiextensions.JSON{
+		Raw: bytes,
+	}
+	return nil
+}
+
+func (g *Redis) Copy() genv1alpha1.GenericGenerator {
+	return g.DeepCopy()
+}
+
 var _ genv1alpha1.GenericGenerator = &SSH{}
 
 func (g *SSH) GetObjectMeta() *metav1.ObjectMeta {
@@ -65,6 +65,25 @@ type WorkflowSpec struct {
 	Variables apiextensionsv1.JSON `json:"variables,omitempty"`
 	// +kubebuilder:validation:Required
 	Jobs map[string]Job `json:"jobs"`
+
+	// OnCancel contains the steps to execute when the workflow is cancelled.
+	// They run once, after the running job was interrupted, and can be used to
+	// revoke credentials that were issued before the cancellation.
+	// +optional
+	OnCancel []CancelStep `json:"onCancel,omitempty"`
+
+	// Suspend pauses the workflow between steps. It is propagated from the owning WorkflowRun.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Cancel requests the cancellation of the workflow. It is propagated from the owning WorkflowRun.
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
+
+	// RetryFrom references the WorkflowRun whose succeeded jobs and steps are reused
+	// when the workflow is initialized. It is propagated from the owning WorkflowRun.
+	// +optional
+	RetryFrom *RetryFromRef `json:"retryFrom,omitempty"`
 }
 
 // Phase types for workflow state machine.
@@ -75,10 +94,16 @@ const (
 	PhasePending Phase = "Pending"
 	// PhaseRunning indicates the workflow is running.
 	PhaseRunning Phase = "Running"
+	// PhaseSuspended indicates the workflow is suspended.
+	PhaseSuspended Phase = "Suspended"
 	// PhaseSucceeded indicates the workflow has succeeded.
 	PhaseSucceeded Phase = "Succeeded"
 	// PhaseFailed indicates the workflow has failed.
 	PhaseFailed Phase = "Failed"
+	// PhaseCancelling indicates the workflow was cancelled and its onCancel steps are being executed.
+	PhaseCancelling Phase = "Cancelling"
+	// PhaseCancelled indicates the workflow has been cancelled.
+	PhaseCancelled Phase = "Cancelled"
 )
 
 // JobPhase types for job state machine.
@@ -93,6 +118,8 @@ const (
 	JobPhaseSucceeded JobPhase = "Succeeded"
 	// JobPhaseFailed indicates the job has failed.
 	JobPhaseFailed JobPhase = "Failed"
+	// JobPhaseCancelled indicates the job was cancelled before it completed.
+	JobPhaseCancelled JobPhase = "Cancelled"
 )
 
 // StepPhase types for step state machine.
@@ -114,12 +141,16 @@ type WorkflowStatus struct {
 	// +kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 
-	// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+	// +kubebuilder:validation:Enum=Pending;Running;Suspended;Succeeded;Failed;Cancelling;Cancelled
 	Phase Phase `json:"phase,omitempty"`
 
 	JobStatuses    map[string]JobStatus `json:"jobStatuses"`
 	ExecutionOrder []string             `json:"executionOrder,omitempty"`
 
+	// OnCancelStatus is the status of the onCancel steps, set once the workflow is cancelled.
+	// +optional
+	OnCancelStatus *JobStatus `json:"onCancelStatus,omitempty"`
+
 	StartTime          *metav1.Time `json:"startTime,omitempty"`
 	CompletionTime     *metav1.Time `json:"completionTime,omitempty"`
 	ExecutionTimeNanos *int64       `json:"executionTimeNanos,omitempty"`
@@ -248,17 +279,78 @@ type Step struct {
 	// +kubebuilder:validation:Optional
 	JavaScript *JavaScriptStep `json:"javascript,omitempty"`
 	// +kubebuilder:validation:Optional
+	Revoke *RevokeStep `json:"revoke,omitempty"`
+	// +kubebuilder:validation:Optional
 	// Outputs defines the expected outputs from this step
 	// Only values explicitly defined here will be saved in the step outputs
 	Outputs []OutputDefinition `json:"outputs,omitempty"`
 	// +kubebuilder:validation:Optional
 }
 
+// CancelStep defines a step executed when a workflow is cancelled.
+// Only the step types that are needed to clean up after a cancellation are supported,
+// so that the schema of the steps is not repeated in full.
+type CancelStep struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Debug *DebugStep `json:"debug,omitempty"`
+	// +kubebuilder:validation:Optional
+	Transform *TransformStep `json:"transform,omitempty"`
+	// +kubebuilder:validation:Optional
+	JavaScript *JavaScriptStep `json:"javascript,omitempty"`
+	// +kubebuilder:validation:Optional
+	Revoke *RevokeStep `json:"revoke,omitempty"`
+}
+
 // JavaScriptStep defines a step that executes JavaScript code with access to step input data.
+// Exactly one of script or scriptFrom must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.script) != has(self.scriptFrom)",message="exactly one of script or scriptFrom must be set"
 type JavaScriptStep struct {
 	// Script contains the JavaScript code to execute
+	// +kubebuilder:validation:Optional
+	Script string `json:"script,omitempty"`
+
+	// ScriptFrom loads the JavaScript code from a ConfigMap in the namespace of the workflow,
+	// so that scripts can be shared across templates.
+	// +kubebuilder:validation:Optional
+	ScriptFrom *ScriptSource `json:"scriptFrom,omitempty"`
+
+	// Timeout is the maximum execution time of the script. Defaults to 10s.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxInstructions is the instruction budget of the script, counted as loop iterations
+	// and function calls. Defaults to 1000000.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxInstructions *int64 `json:"maxInstructions,omitempty"`
+}
+
+// ScriptSource references a script stored in a ConfigMap.
+type ScriptSource struct {
+	// ConfigMapKeyRef selects the key of the ConfigMap holding the script.
+	// +kubebuilder:validation:Required
+	ConfigMapKeyRef ConfigMapKeySelector `json:"configMapKeyRef"`
+}
+
+// ConfigMapKeySelector selects a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Key of the ConfigMap entry holding the script.
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// RevokeStep defines a step that revokes the credentials issued by the generator
+// steps of a job during the current run. The generator states of the job are
+// deleted, which makes the generator clean up the issued credentials.
+type RevokeStep struct {
+	// Job is the name of the job whose generated credentials should be revoked.
 	// +kubebuilder:validation:Required
-	Script string `json:"script"`
+	Job string `json:"job"`
 }
 
 // GeneratorStep defines a step that generates secrets using a configured generator.
@@ -337,7 +429,7 @@ type DebugStep struct {
 
 // JobStatus defines the observed state of a Job.
 type JobStatus struct {
-	// +kubebuilder:validation:Enum=Pending;Waiting;Running;Succeeded;Failed
+	// +kubebuilder:validation:Enum=Pending;Waiting;Running;Succeeded;Failed;Cancelled
 	Phase JobPhase `json:"phase,omitempty"`
 
 	StepStatuses       map[string]StepStatus `json:"stepStatuses"`
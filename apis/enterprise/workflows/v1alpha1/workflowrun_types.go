@@ -46,6 +46,30 @@ type WorkflowRunSpec struct {
 	// Each argument corresponds to a parameter defined in the template
 	// +optional
 	Arguments apiextensionsv1.JSON `json:"arguments,omitempty"`
+
+	// Suspend pauses the execution of the run. The step that is currently
+	// executing completes, but no further steps are started until suspend is unset.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Cancel requests the cancellation of the run. No further steps are started,
+	// the onCancel steps of the template are executed and the run ends in the
+	// Cancelled phase. A cancelled run cannot be resumed.
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
+
+	// RetryFrom references a failed or cancelled WorkflowRun in the same namespace.
+	// The jobs and steps that succeeded in that run are not executed again: their
+	// outputs are copied into this run and execution restarts at the first failed step.
+	// +optional
+	RetryFrom *RetryFromRef `json:"retryFrom,omitempty"`
+}
+
+// RetryFromRef is a reference to the WorkflowRun a run is retried from.
+type RetryFromRef struct {
+	// Name of the WorkflowRun
+	// +required
+	Name string `json:"name"`
 }
 
 // TemplateRef is a reference to a WorkflowTemplate.
@@ -75,7 +99,7 @@ type WorkflowRunStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Phase represents the current phase of the WorkflowRun
-	// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+	// +kubebuilder:validation:Enum=Pending;Running;Suspended;Succeeded;Failed;Cancelling;Cancelled
 	// +optional
 	Phase Phase `json:"phase,omitempty"`
 
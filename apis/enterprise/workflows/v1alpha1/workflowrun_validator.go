@@ -34,6 +34,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -54,6 +55,13 @@ func SetValidationClient(c client.Client) {
 // validateWorkflowRunParameters validates the arguments in a WorkflowRun against the parameters
 // defined in the referenced WorkflowTemplate.
 func validateWorkflowRunParameters(wr *WorkflowRun) error {
+	return validateWorkflowRunArguments(wr, nil)
+}
+
+// validateWorkflowRunArguments validates the arguments in a WorkflowRun against the parameters
+// defined in the referenced WorkflowTemplate. Bound parameters are filled in when the run is
+// created, they must exist in the template but are not required to have an argument.
+func validateWorkflowRunArguments(wr *WorkflowRun, boundParameters []string) error {
 	if k8sClient == nil {
 		return fmt.Errorf("validation client not initialized")
 	}
@@ -110,10 +118,16 @@ func validateWorkflowRunParameters(wr *WorkflowRun) error {
 		}
 	}
 
+	for _, name := range boundParameters {
+		if _, exists := paramMap[name]; !exists {
+			return fmt.Errorf("bound parameter %q is not defined in the template", name)
+		}
+	}
+
 	// Check if all required parameters have arguments
 	for _, group := range template.Spec.ParameterGroups {
 		for _, param := range group.Parameters {
-			if param.Required {
+			if param.Required && !slices.Contains(boundParameters, param.Name) {
 				if _, exists := parsedArguments[param.Name]; !exists {
 					// If a default value is provided, it's okay
 					if param.Default == "" {
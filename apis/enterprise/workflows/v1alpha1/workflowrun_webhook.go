@@ -32,6 +32,7 @@ package v1alpha1
 
 import (
 	"context"
+	"errors"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -49,15 +50,21 @@ func (v *WorkflowRunValidator) ValidateCreate(_ context.Context, obj runtime.Obj
 	if !ok {
 		return nil, nil
 	}
+	if workflowRun.Spec.RetryFrom != nil && workflowRun.Spec.RetryFrom.Name == workflowRun.Name {
+		return nil, errors.New("spec.retryFrom cannot reference the WorkflowRun itself")
+	}
 	return nil, validateWorkflowRunParameters(workflowRun)
 }
 
 // ValidateUpdate implements admission.CustomValidator so a webhook will be registered for the type.
-func (v *WorkflowRunValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+func (v *WorkflowRunValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	workflowRun, ok := newObj.(*WorkflowRun)
 	if !ok {
 		return nil, nil
 	}
+	if oldRun, ok := oldObj.(*WorkflowRun); ok && oldRun.Spec.Cancel && !workflowRun.Spec.Cancel {
+		return nil, errors.New("spec.cancel cannot be unset once a WorkflowRun was cancelled")
+	}
 	return nil, validateWorkflowRunParameters(workflowRun)
 }
 
@@ -40,11 +40,54 @@ type WorkflowRunTemplateSpec struct {
 	RunSpec WorkflowRunSpec `json:"runSpec"`
 	// +kubebuilder:default={once:{}}
 	RunPolicy RunPolicy `json:"runPolicy"`
+	// RevisionHistoryLimit is the number of runs to keep, including the new run.
+	// It is ignored if successfulRunsHistoryLimit or failedRunsHistoryLimit is set.
 	//+kubebuilder:default=3
 	//+kubebuilder:validation:Minimum=1
 	RevisionHistoryLimit int `json:"revisionHistoryLimit,omitempty"`
+
+	// ConcurrencyPolicy specifies how to treat a new run while runs created earlier are still active.
+	// Allow lets the runs overlap, Forbid skips the new run and Replace cancels the active runs
+	// before creating the new one.
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	// +kubebuilder:default=Allow
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// StartingDeadlineSeconds is the deadline for starting a scheduled run that missed its scheduled time,
+	// for example because the controller was down or a previous run was still active.
+	// Scheduled runs that cannot be started within the deadline are skipped.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// SuccessfulRunsHistoryLimit is the number of succeeded runs to keep.
+	// If it or failedRunsHistoryLimit is set, the runs are limited by their phase instead of
+	// revisionHistoryLimit, and the runs of a phase without a limit are all kept.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+
+	// FailedRunsHistoryLimit is the number of failed or cancelled runs to keep.
+	// If it or successfulRunsHistoryLimit is set, the runs are limited by their phase instead of
+	// revisionHistoryLimit, and the runs of a phase without a limit are all kept.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedRunsHistoryLimit *int32 `json:"failedRunsHistoryLimit,omitempty"`
 }
 
+// ConcurrencyPolicy describes how concurrent runs of a WorkflowRunTemplate are handled.
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows runs to overlap.
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent skips a new run while a previous run is still active.
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent cancels the active runs and creates the new run.
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
 // RunPolicy defines the policy for running workflow runs.
 // +kubebuilder:validation:MinProperties=1
 // +kubebuilder:validation:MaxProperties=1
@@ -52,6 +95,7 @@ type RunPolicy struct {
 	Once      *RunPolicyOnce      `json:"once,omitempty"`
 	Scheduled *RunPolicyScheduled `json:"scheduled,omitempty"`
 	OnChange  *RunPolicyOnChange  `json:"onChange,omitempty"`
+	OnEvent   *RunPolicyOnEvent   `json:"onEvent,omitempty"`
 }
 
 // RunPolicyOnce specifies that the workflow should run only once.
@@ -62,12 +106,69 @@ type RunPolicyOnce struct {
 type RunPolicyOnChange struct {
 }
 
+// RunPolicyOnEvent specifies that a workflow run is created for every cluster event matching one of the triggers.
+// Only events that happen after the WorkflowRunTemplate was created trigger a run.
+type RunPolicyOnEvent struct {
+	// +kubebuilder:validation:MinItems=1
+	Triggers []EventTrigger `json:"triggers"`
+}
+
+// EventTrigger defines a cluster event that creates a workflow run.
+// Exactly one event source must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.finding), has(self.externalSecret), has(self.pushSecret), has(self.generatorState)].filter(x, x).size() == 1",message="exactly one of finding, externalSecret, pushSecret or generatorState must be set"
+type EventTrigger struct {
+	// Parameter is the name of the template parameter the triggering object is bound to.
+	// Findings are bound as a `finding` value, all other objects are bound by their name.
+	// +optional
+	Parameter string `json:"parameter,omitempty"`
+
+	// Finding triggers a run when a new Finding is created.
+	// +optional
+	Finding *ResourceEventTrigger `json:"finding,omitempty"`
+
+	// ExternalSecret triggers a run when an ExternalSecret enters the SecretSyncedError state.
+	// +optional
+	ExternalSecret *ResourceEventTrigger `json:"externalSecret,omitempty"`
+
+	// PushSecret triggers a run when a PushSecret fails to push its secrets.
+	// +optional
+	PushSecret *ResourceEventTrigger `json:"pushSecret,omitempty"`
+
+	// GeneratorState triggers a run when a GeneratorState is about to be garbage collected.
+	// +optional
+	GeneratorState *GeneratorStateEventTrigger `json:"generatorState,omitempty"`
+}
+
+// ResourceEventTrigger selects the objects that trigger a run.
+type ResourceEventTrigger struct {
+	// Selector selects the objects by their labels. All objects in the namespace of the
+	// WorkflowRunTemplate are selected if it is not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// GeneratorStateEventTrigger selects the generator states that trigger a run before they expire.
+type GeneratorStateEventTrigger struct {
+	// Selector selects the generator states by their labels. All generator states in the namespace of the
+	// WorkflowRunTemplate are selected if it is not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// ExpiresWithin triggers a run once the garbage collection deadline of the generator state
+	// is closer than this duration.
+	ExpiresWithin metav1.Duration `json:"expiresWithin"`
+}
+
 // RunPolicyScheduled defines a scheduled policy for running workflow runs.
-// +kubebuilder:validation:MinProperties=1
-// +kubebuilder:validation:MaxProperties=1
+// +kubebuilder:validation:XValidation:rule="has(self.every) != has(self.cron)",message="exactly one of every or cron must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.timeZone) || has(self.cron)",message="timeZone can only be set with cron"
 type RunPolicyScheduled struct {
 	Every *metav1.Duration `json:"every,omitempty"`
 	Cron  *string          `json:"cron,omitempty"`
+	// TimeZone is the name of the time zone the cron expression is evaluated in, e.g. "Europe/Berlin".
+	// Defaults to the time zone of the controller.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // WorkflowRunTemplateStatus defines the observed state of WorkflowRunTemplate.
@@ -81,6 +182,26 @@ type WorkflowRunTemplateStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// +optional
 	SyncedResourceVersion string `json:"syncedResourceVersion,omitempty"`
+	// TriggeredEvents are the most recent events that triggered a run of an onEvent run policy.
+	// +optional
+	TriggeredEvents []TriggeredEvent `json:"triggeredEvents,omitempty"`
+	// TriggeredEventsSince is the time of the most recent event evicted from triggeredEvents.
+	// Events that happened at or before it do not trigger a run.
+	// +optional
+	TriggeredEventsSince *metav1.Time `json:"triggeredEventsSince,omitempty"`
+}
+
+// TriggeredEvent records a cluster event that triggered a workflow run.
+type TriggeredEvent struct {
+	// Key identifies the event.
+	Key string `json:"key"`
+	// RunName is the name of the triggered WorkflowRun.
+	RunName string `json:"runName"`
+	// Time is the time the run was triggered.
+	Time metav1.Time `json:"time"`
+	// EventTime is the time the event happened.
+	// +optional
+	EventTime metav1.Time `json:"eventTime,omitempty"`
 }
 
 // NamedWorkflowRunStatus represents a named workflow run status.
@@ -30,6 +30,11 @@
 
 package v1alpha1
 
+import (
+	"fmt"
+	"time"
+)
+
 // validateWorkflowRunParameters validates the arguments in a WorkflowRun against the parameters
 // defined in the referenced WorkflowTemplate.
 func validateWorkflowRunTemplateParameters(wr *WorkflowRunTemplate) error {
@@ -37,5 +42,25 @@ func validateWorkflowRunTemplateParameters(wr *WorkflowRunTemplate) error {
 		ObjectMeta: wr.ObjectMeta,
 		Spec:       wr.Spec.RunSpec,
 	}
-	return validateWorkflowRunParameters(workflowrun)
+	var boundParameters []string
+	if onEvent := wr.Spec.RunPolicy.OnEvent; onEvent != nil {
+		for _, trigger := range onEvent.Triggers {
+			if trigger.Parameter != "" {
+				boundParameters = append(boundParameters, trigger.Parameter)
+			}
+		}
+	}
+	return validateWorkflowRunArguments(workflowrun, boundParameters)
+}
+
+// validateWorkflowRunTemplateSchedule validates the time zone of a scheduled WorkflowRunTemplate.
+func validateWorkflowRunTemplateSchedule(wr *WorkflowRunTemplate) error {
+	scheduled := wr.Spec.RunPolicy.Scheduled
+	if scheduled == nil || scheduled.TimeZone == nil {
+		return nil
+	}
+	if _, err := time.LoadLocation(*scheduled.TimeZone); err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", *scheduled.TimeZone, err)
+	}
+	return nil
 }
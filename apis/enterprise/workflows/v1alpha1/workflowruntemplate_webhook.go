@@ -49,6 +49,9 @@ func (v *WorkflowRunTemplateValidator) ValidateCreate(_ context.Context, obj run
 	if !ok {
 		return nil, nil
 	}
+	if err := validateWorkflowRunTemplateSchedule(workflowRunTemplate); err != nil {
+		return nil, err
+	}
 	return nil, validateWorkflowRunTemplateParameters(workflowRunTemplate)
 }
 
@@ -58,6 +61,9 @@ func (v *WorkflowRunTemplateValidator) ValidateUpdate(_ context.Context, _, newO
 	if !ok {
 		return nil, nil
 	}
+	if err := validateWorkflowRunTemplateSchedule(workflowRunTemplate); err != nil {
+		return nil, err
+	}
 	return nil, validateWorkflowRunTemplateParameters(workflowRunTemplate)
 }
 
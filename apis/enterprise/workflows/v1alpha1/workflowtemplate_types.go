@@ -53,6 +53,10 @@ type WorkflowTemplateSpec struct {
 	// Jobs is a map of job names to job definitions
 	// +required
 	Jobs map[string]Job `json:"jobs"`
+
+	// OnCancel contains the steps to execute when a run of this template is cancelled
+	// +optional
+	OnCancel []CancelStep `json:"onCancel,omitempty"`
 }
 
 // ParameterGroup defines a group of parameters with a name and description.
@@ -28,6 +28,56 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CancelStep) DeepCopyInto(out *CancelStep) {
+	*out = *in
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(DebugStep)
+		**out = **in
+	}
+	if in.Transform != nil {
+		in, out := &in.Transform, &out.Transform
+		*out = new(TransformStep)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JavaScript != nil {
+		in, out := &in.JavaScript, &out.JavaScript
+		*out = new(JavaScriptStep)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Revoke != nil {
+		in, out := &in.Revoke, &out.Revoke
+		*out = new(RevokeStep)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CancelStep.
+func (in *CancelStep) DeepCopy() *CancelStep {
+	if in == nil {
+		return nil
+	}
+	out := new(CancelStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DebugStep) DeepCopyInto(out *DebugStep) {
 	*out = *in
@@ -59,6 +109,41 @@ func (in *DestinationRef) DeepCopy() *DestinationRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventTrigger) DeepCopyInto(out *EventTrigger) {
+	*out = *in
+	if in.Finding != nil {
+		in, out := &in.Finding, &out.Finding
+		*out = new(ResourceEventTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalSecret != nil {
+		in, out := &in.ExternalSecret, &out.ExternalSecret
+		*out = new(ResourceEventTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PushSecret != nil {
+		in, out := &in.PushSecret, &out.PushSecret
+		*out = new(ResourceEventTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GeneratorState != nil {
+		in, out := &in.GeneratorState, &out.GeneratorState
+		*out = new(GeneratorStateEventTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventTrigger.
+func (in *EventTrigger) DeepCopy() *EventTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(EventTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FindingParameterType) DeepCopyInto(out *FindingParameterType) {
 	*out = *in
@@ -99,6 +184,27 @@ func (in *GeneratorParameterType) DeepCopy() *GeneratorParameterType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratorStateEventTrigger) DeepCopyInto(out *GeneratorStateEventTrigger) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ExpiresWithin = in.ExpiresWithin
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratorStateEventTrigger.
+func (in *GeneratorStateEventTrigger) DeepCopy() *GeneratorStateEventTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratorStateEventTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GeneratorStep) DeepCopyInto(out *GeneratorStep) {
 	*out = *in
@@ -134,6 +240,21 @@ func (in *GeneratorStep) DeepCopy() *GeneratorStep {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JavaScriptStep) DeepCopyInto(out *JavaScriptStep) {
 	*out = *in
+	if in.ScriptFrom != nil {
+		in, out := &in.ScriptFrom, &out.ScriptFrom
+		*out = new(ScriptSource)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInstructions != nil {
+		in, out := &in.MaxInstructions, &out.MaxInstructions
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JavaScriptStep.
@@ -423,6 +544,56 @@ func (in *ResourceConstraints) DeepCopy() *ResourceConstraints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceEventTrigger) DeepCopyInto(out *ResourceEventTrigger) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceEventTrigger.
+func (in *ResourceEventTrigger) DeepCopy() *ResourceEventTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceEventTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryFromRef) DeepCopyInto(out *RetryFromRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryFromRef.
+func (in *RetryFromRef) DeepCopy() *RetryFromRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryFromRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevokeStep) DeepCopyInto(out *RevokeStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevokeStep.
+func (in *RevokeStep) DeepCopy() *RevokeStep {
+	if in == nil {
+		return nil
+	}
+	out := new(RevokeStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunPolicy) DeepCopyInto(out *RunPolicy) {
 	*out = *in
@@ -441,6 +612,11 @@ func (in *RunPolicy) DeepCopyInto(out *RunPolicy) {
 		*out = new(RunPolicyOnChange)
 		**out = **in
 	}
+	if in.OnEvent != nil {
+		in, out := &in.OnEvent, &out.OnEvent
+		*out = new(RunPolicyOnEvent)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunPolicy.
@@ -468,6 +644,28 @@ func (in *RunPolicyOnChange) DeepCopy() *RunPolicyOnChange {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunPolicyOnEvent) DeepCopyInto(out *RunPolicyOnEvent) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]EventTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunPolicyOnEvent.
+func (in *RunPolicyOnEvent) DeepCopy() *RunPolicyOnEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(RunPolicyOnEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunPolicyOnce) DeepCopyInto(out *RunPolicyOnce) {
 	*out = *in
@@ -496,6 +694,11 @@ func (in *RunPolicyScheduled) DeepCopyInto(out *RunPolicyScheduled) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunPolicyScheduled.
@@ -508,6 +711,22 @@ func (in *RunPolicyScheduled) DeepCopy() *RunPolicyScheduled {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptSource) DeepCopyInto(out *ScriptSource) {
+	*out = *in
+	out.ConfigMapKeyRef = in.ConfigMapKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptSource.
+func (in *ScriptSource) DeepCopy() *ScriptSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretStoreParameterType) DeepCopyInto(out *SecretStoreParameterType) {
 	*out = *in
@@ -576,6 +795,11 @@ func (in *Step) DeepCopyInto(out *Step) {
 	if in.JavaScript != nil {
 		in, out := &in.JavaScript, &out.JavaScript
 		*out = new(JavaScriptStep)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Revoke != nil {
+		in, out := &in.Revoke, &out.Revoke
+		*out = new(RevokeStep)
 		**out = **in
 	}
 	if in.Outputs != nil {
@@ -711,6 +935,23 @@ func (in *TransformStep) DeepCopy() *TransformStep {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggeredEvent) DeepCopyInto(out *TriggeredEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	in.EventTime.DeepCopyInto(&out.EventTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggeredEvent.
+func (in *TriggeredEvent) DeepCopy() *TriggeredEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggeredEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Workflow) DeepCopyInto(out *Workflow) {
 	*out = *in
@@ -849,6 +1090,11 @@ func (in *WorkflowRunSpec) DeepCopyInto(out *WorkflowRunSpec) {
 	*out = *in
 	out.TemplateRef = in.TemplateRef
 	in.Arguments.DeepCopyInto(&out.Arguments)
+	if in.RetryFrom != nil {
+		in, out := &in.RetryFrom, &out.RetryFrom
+		*out = new(RetryFromRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRunSpec.
@@ -970,6 +1216,21 @@ func (in *WorkflowRunTemplateSpec) DeepCopyInto(out *WorkflowRunTemplateSpec) {
 	*out = *in
 	in.RunSpec.DeepCopyInto(&out.RunSpec)
 	in.RunPolicy.DeepCopyInto(&out.RunPolicy)
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessfulRunsHistoryLimit != nil {
+		in, out := &in.SuccessfulRunsHistoryLimit, &out.SuccessfulRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedRunsHistoryLimit != nil {
+		in, out := &in.FailedRunsHistoryLimit, &out.FailedRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRunTemplateSpec.
@@ -1003,6 +1264,17 @@ func (in *WorkflowRunTemplateStatus) DeepCopyInto(out *WorkflowRunTemplateStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TriggeredEvents != nil {
+		in, out := &in.TriggeredEvents, &out.TriggeredEvents
+		*out = make([]TriggeredEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TriggeredEventsSince != nil {
+		in, out := &in.TriggeredEventsSince, &out.TriggeredEventsSince
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRunTemplateStatus.
@@ -1056,6 +1328,18 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.OnCancel != nil {
+		in, out := &in.OnCancel, &out.OnCancel
+		*out = make([]CancelStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RetryFrom != nil {
+		in, out := &in.RetryFrom, &out.RetryFrom
+		*out = new(RetryFromRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowSpec.
@@ -1090,6 +1374,11 @@ func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.OnCancelStatus != nil {
+		in, out := &in.OnCancelStatus, &out.OnCancelStatus
+		*out = new(JobStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StartTime != nil {
 		in, out := &in.StartTime, &out.StartTime
 		*out = (*in).DeepCopy()
@@ -1190,6 +1479,13 @@ func (in *WorkflowTemplateSpec) DeepCopyInto(out *WorkflowTemplateSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.OnCancel != nil {
+		in, out := &in.OnCancel, &out.OnCancel
+		*out = make([]CancelStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowTemplateSpec.
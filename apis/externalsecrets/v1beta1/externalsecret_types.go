@@ -480,7 +480,7 @@ type GeneratorRef struct {
 
 	// Specify the Kind of the generator resource
 	//nolint:lll
-	// +kubebuilder:validation:Enum=ACRAccessToken;ClusterGenerator;ECRAuthorizationToken;Fake;GCRAccessToken;GithubAccessToken;QuayAccessToken;Password;SSHKey;STSSessionToken;UUID;VaultDynamicSecret;Webhook;Grafana;AWSIAMKey;SendgridAuthorizationToken;RabbitMQ;MongoDB;Federation;BasicAuth;SSH;Neo4j;PostgreSql;OpenAI
+	// +kubebuilder:validation:Enum=ACRAccessToken;ClusterGenerator;ECRAuthorizationToken;Fake;GCRAccessToken;GithubAccessToken;QuayAccessToken;Password;SSHKey;STSSessionToken;UUID;VaultDynamicSecret;Webhook;Grafana;AWSIAMKey;SendgridAuthorizationToken;RabbitMQ;MongoDB;Federation;BasicAuth;SSH;Neo4j;PostgreSql;MySQL;Redis;Kafka;Certificate;JWT;LDAP;OpenAI
 	Kind string `json:"kind"`
 
 	// Specify the name of the generator resource
@@ -66,5 +66,29 @@ type Generator interface {
 	GetKeys() map[string]string
 }
 
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+
+// AlternatingGenerator is implemented by generators that can rotate two stable users
+// instead of creating a new user on each rotation.
+type AlternatingGenerator interface {
+	Generator
+
+	// GetRotationPolicy returns the rotation policy of the generator.
+	GetRotationPolicy(obj *apiextensions.JSON) (*RotationPolicy, error)
+
+	// GenerateAlternating creates the user of the given slot or resets its password.
+	// The returned state covers both users, so that Cleanup removes them together.
+	GenerateAlternating(
+		ctx context.Context,
+		obj *apiextensions.JSON,
+		kube client.Client,
+		namespace string,
+		slot string,
+	) (map[string][]byte, GeneratorProviderState, error)
+}
+
 // GeneratorProviderState represents the state of a generator provider that can be stored and retrieved.
 type GeneratorProviderState *apiextensions.JSON
@@ -38,6 +38,22 @@ const (
 	// It is used in the garbage collection process to identify all states
 	// that belong to a specific resource.
 	GeneratorStateLabelOwnerKey = "generators.external-secrets.io/owner-key"
+
+	// GeneratorStateAnnotationHardDeadline marks generator states that are deleted once their
+	// garbage collection deadline is reached, regardless of the cleanup policy of the generator.
+	// It is set on states backing credentials with a fixed lifetime, such as leases.
+	GeneratorStateAnnotationHardDeadline = "generators.external-secrets.io/hard-deadline"
+
+	// GeneratorStateAnnotationIssuedSlot marks the generator states of users rotated with the
+	// alternating strategy. It is the slot of the user whose credentials were last issued.
+	GeneratorStateAnnotationIssuedSlot = "generators.external-secrets.io/issued-slot"
+
+	// GeneratorStateAnnotationIssuedAt is the time the credentials of the issued slot were issued at.
+	GeneratorStateAnnotationIssuedAt = "generators.external-secrets.io/issued-at"
+
+	// GeneratorStateAnnotationActiveSlot is the slot of the user the consumer of the credentials
+	// has rolled to. It is not set until the consumer has rolled for the first time.
+	GeneratorStateAnnotationActiveSlot = "generators.external-secrets.io/active-slot"
 )
 
 // GeneratorStateSpec defines the desired state of a generator state resource.
@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	reloaderv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/reloader/v1alpha1"
 )
 
 // ControllerClassResource defines a resource that can be assigned to a specific controller class.
@@ -56,3 +58,67 @@ type CleanupPolicy struct {
 	// +kubebuilder:default="2m"
 	GracePeriod metav1.Duration `json:"gracePeriod,omitempty"`
 }
+
+const (
+	// RecreateRotationStrategy indicates that a new user is created on each rotation.
+	RecreateRotationStrategy = "recreate"
+	// AlternatingRotationStrategy indicates that two stable users are rotated in turns.
+	AlternatingRotationStrategy = "alternating"
+)
+
+const (
+	// AlternatingSlotA is the first of the two users rotated by the alternating strategy.
+	AlternatingSlotA = "a"
+	// AlternatingSlotB is the second of the two users rotated by the alternating strategy.
+	AlternatingSlotB = "b"
+)
+
+// RotationPolicy defines how the users of a generator are rotated.
+type RotationPolicy struct {
+	// Strategy of the rotation. Supported values: "recreate", "alternating".
+	// recreate: create a new user on each rotation and clean up the previous one
+	// alternating: maintain two stable users, e.g. app_a and app_b, and reset the password
+	// of the inactive one on each rotation. The users are only flipped once the consumer
+	// of the credentials has rolled to the last issued user, so that the user it
+	// still uses is never changed. Until then, the last issued credentials are returned
+	// again; they are stored in a Secret owned by the generator state.
+	// The alternating strategy requires the generator state to be enabled.
+	// +kubebuilder:validation:Enum=recreate;alternating
+	// +kubebuilder:default=recreate
+	Strategy string `json:"strategy"`
+
+	// Consumer is the name of the scan Consumer of the credentials, in the namespace of the generator.
+	// It has rolled when it uses the latest version of its locations and all of them
+	// were observed after the last rotation.
+	// +optional
+	Consumer string `json:"consumer,omitempty"`
+
+	// WaitFor waits for the consumer of the credentials to roll.
+	// If neither consumer nor waitFor are specified, the users are flipped on each rotation.
+	// +optional
+	WaitFor *RotationWaitFor `json:"waitFor,omitempty"`
+}
+
+// RotationWaitFor defines how to wait for the consumer of the credentials to roll.
+// With a time, the consumer has rolled once the interval has passed since the last rotation.
+// With a condition, it has rolled once the condition of the target is met.
+// If both are specified, both must be satisfied.
+type RotationWaitFor struct {
+	reloaderv1alpha1.WaitStrategy `json:",inline"`
+
+	// Target is the object the condition is observed on, e.g. the Deployment restarted by the Reloader.
+	// The condition must have been updated after the last rotation.
+	// The retry settings of the condition are not used: it is checked again on the next rotation.
+	// +optional
+	Target *RotationTarget `json:"target,omitempty"`
+}
+
+// RotationTarget references an object in the namespace of the generator.
+type RotationTarget struct {
+	// APIVersion of the object, e.g. "apps/v1".
+	APIVersion string `json:"apiVersion"`
+	// Kind of the object, e.g. "Deployment".
+	Kind string `json:"kind"`
+	// Name of the object.
+	Name string `json:"name"`
+}
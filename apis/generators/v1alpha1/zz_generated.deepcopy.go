@@ -1592,6 +1592,62 @@ func (in *RequestParameters) DeepCopy() *RequestParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RotationPolicy) DeepCopyInto(out *RotationPolicy) {
+	*out = *in
+	if in.WaitFor != nil {
+		in, out := &in.WaitFor, &out.WaitFor
+		*out = new(RotationWaitFor)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RotationPolicy.
+func (in *RotationPolicy) DeepCopy() *RotationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RotationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RotationTarget) DeepCopyInto(out *RotationTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RotationTarget.
+func (in *RotationTarget) DeepCopy() *RotationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RotationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RotationWaitFor) DeepCopyInto(out *RotationWaitFor) {
+	*out = *in
+	in.WaitStrategy.DeepCopyInto(&out.WaitStrategy)
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(RotationTarget)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RotationWaitFor.
+func (in *RotationWaitFor) DeepCopy() *RotationWaitFor {
+	if in == nil {
+		return nil
+	}
+	out := new(RotationWaitFor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHKey) DeepCopyInto(out *SSHKey) {
 	*out = *in
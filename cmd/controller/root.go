@@ -68,8 +68,12 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/enterprise/controllers/workflow"
 	workflowapi "github.com/external-secrets/external-secrets/pkg/enterprise/controllers/workflow/api"
 	workflowcommon "github.com/external-secrets/external-secrets/pkg/enterprise/controllers/workflow/common"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
 	federationserver "github.com/external-secrets/external-secrets/pkg/enterprise/federation/server"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/fmetrics"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/generator/mysql"
 	"github.com/external-secrets/external-secrets/pkg/enterprise/generator/postgresql"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/generator/redis"
 	"github.com/external-secrets/external-secrets/pkg/enterprise/scheduler"
 	"github.com/external-secrets/external-secrets/runtime/feature"
 
@@ -125,6 +129,10 @@ var (
 	tlsMinVersion                         string
 	sensitivePatterns                     []string
 	spireAgentSocketPath                  string
+	workloadTokenAudiences                []string
+	federationAuthenticatorPriority       []string
+	federationTrustedProxies              []string
+	federationAudit                       audit.Config
 	enableHTTP2                           bool
 	allowGenericTargets                   bool
 )
@@ -407,6 +415,23 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, errCreateController, "controller", "WorkflowRunTemplate")
 			os.Exit(1)
 		}
+		for _, source := range []workflow.TriggerSource{
+			workflow.TriggerSourceFinding,
+			workflow.TriggerSourceExternalSecret,
+			workflow.TriggerSourcePushSecret,
+			workflow.TriggerSourceGeneratorState,
+		} {
+			if err = (&workflow.EventTriggerReconciler{
+				Client:   mgr.GetClient(),
+				Log:      ctrl.Log.WithName("controllers").WithName("WorkflowRunTemplateTrigger").WithName(string(source)),
+				Scheme:   mgr.GetScheme(),
+				Recorder: mgr.GetEventRecorderFor("workflowruntemplate-controller"),
+				Source:   source,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, errCreateController, "controller", "WorkflowRunTemplateTrigger", "source", source)
+				os.Exit(1)
+			}
+		}
 		if enableClusterExternalSecretReconciler {
 			cesmetrics.SetUpMetrics()
 
@@ -457,6 +482,22 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, errCreateController, "controller", "PingIdentityFederation")
 			os.Exit(1)
 		}
+		if err = (&federation.GitHubActionsFederationController{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("controllers").WithName("GitHubActionsFederation"),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr, controller.Options{}); err != nil {
+			setupLog.Error(err, errCreateController, "controller", "GitHubActionsFederation")
+			os.Exit(1)
+		}
+		if err = (&federation.AWSIAMFederationController{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("controllers").WithName("AWSIAMFederation"),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr, controller.Options{}); err != nil {
+			setupLog.Error(err, errCreateController, "controller", "AWSIAMFederation")
+			os.Exit(1)
+		}
 		if err = (&federation.SpiffeFederationController{
 			Client: mgr.GetClient(),
 			Log:    ctrl.Log.WithName("controllers").WithName("SpiffeFederation"),
@@ -473,7 +514,25 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, errCreateController, "controller", "AuthorizedIdentity")
 			os.Exit(1)
 		}
-		handler := federationserver.NewHandler(externalSecretReconciler, serverPort, serverTLSPort, spireAgentSocketPath, enableFederationTLS)
+		fmetrics.SetUpMetrics()
+		handler := federationserver.NewHandler(externalSecretReconciler, serverPort, serverTLSPort, spireAgentSocketPath, enableFederationTLS, workloadTokenAudiences)
+		handler.SetAPIReader(mgr.GetAPIReader())
+		if err := handler.SetAuthenticatorPriority(federationAuthenticatorPriority); err != nil {
+			setupLog.Error(err, "invalid federation authenticator priority")
+			os.Exit(1)
+		}
+		if err := handler.SetTrustedProxies(federationTrustedProxies); err != nil {
+			setupLog.Error(err, "invalid federation trusted proxies")
+			os.Exit(1)
+		}
+		auditSinks, err := audit.NewSinks(federationAudit, ctrl.Log.WithName("federationaudit"))
+		if err != nil {
+			setupLog.Error(err, "unable to set up federation audit log")
+			os.Exit(1)
+		}
+		if len(auditSinks) > 0 {
+			handler.SetAuditLogger(audit.NewLogger(ctrl.Log.WithName("federationaudit"), auditSinks...))
+		}
 		go handler.SetupEcho(cmd.Context())
 
 		sched := scheduler.New(mgr.GetClient(), ctrl.Log.WithName("scheduler"))
@@ -488,6 +547,16 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, "unable to add postgresql bootstrap")
 			os.Exit(1)
 		}
+		mysqlBootstrap := mysql.NewBootstrap(mgr.GetClient(), mgr)
+		if err := mgr.Add(mysqlBootstrap); err != nil {
+			setupLog.Error(err, "unable to add mysql bootstrap")
+			os.Exit(1)
+		}
+		redisBootstrap := redis.NewBootstrap(mgr.GetClient(), mgr)
+		if err := mgr.Add(redisBootstrap); err != nil {
+			setupLog.Error(err, "unable to add redis bootstrap")
+			os.Exit(1)
+		}
 		// Start the workflow API server if enabled
 		if enableWorkflowAPI {
 			apiServer := workflowapi.NewServer(mgr.GetClient(), ctrl.Log.WithName("api").WithName("Workflow"))
@@ -583,6 +652,15 @@ func init() {
 	rootCmd.Flags().StringSliceVar(&sensitivePatterns, "workflow-sensitive-patterns", []string{}, "Comma-separated list of regular expressions to match sensitive data in workflow outputs")
 	rootCmd.Flags().StringVar(&spireAgentSocketPath, "spire-agent-socket-path", "unix:///tmp/spire-agent/public/api.sock", "Path to the Spiffe agent socket")
 	rootCmd.Flags().BoolVar(&enableFederationTLS, "enable-federation-tls", false, "Enable federation server TLS")
+	rootCmd.Flags().StringSliceVar(&workloadTokenAudiences, "federation-workload-token-audiences", nil, "Comma-separated list of audiences accepted for x-workload-token on the federation server, e.g. external-secrets-federation. The audience is not checked if unset")
+	rootCmd.Flags().StringSliceVar(&federationAuthenticatorPriority, "federation-authenticator-priority", nil, "Comma-separated order federation authenticators are tried in when the credentials of a request match several, e.g. spiffe,aws-iam,oidc,github-actions,okta,pingidentity")
+	rootCmd.Flags().StringSliceVar(&federationTrustedProxies, "federation-trusted-proxies", nil, "Comma-separated CIDR ranges of proxies the source IP of federation audit records is read from the X-Forwarded-For header of, e.g. 10.0.0.0/8. X-Forwarded-For is ignored if unset")
+	rootCmd.Flags().StringVar(&federationAudit.File, "federation-audit-file", "", "Path of the file federation audit records are appended to as JSON lines, or - for stdout")
+	rootCmd.Flags().StringVar(&federationAudit.WebhookURL, "federation-audit-webhook-url", "", "URL batches of federation audit records are posted to")
+	rootCmd.Flags().IntVar(&federationAudit.WebhookBatchSize, "federation-audit-webhook-batch-size", 100, "Maximum number of federation audit records posted at once")
+	rootCmd.Flags().DurationVar(&federationAudit.WebhookFlushInterval, "federation-audit-webhook-flush-interval", 5*time.Second, "Maximum time a federation audit record waits before it is posted")
+	rootCmd.Flags().IntVar(&federationAudit.WebhookMaxRetries, "federation-audit-webhook-max-retries", 5, "Number of times a failed batch of federation audit records is posted again")
+	rootCmd.Flags().StringVar(&federationAudit.SyslogAddress, "federation-audit-syslog-address", "", "Address of the syslog server federation audit records are sent to, e.g. udp://syslog:514 or unix:///dev/log")
 
 	rootCmd.Flags().BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics server")
@@ -0,0 +1,107 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	workflowAPIURL    string
+	workflowNamespace string
+)
+
+// workflowRunResponse mirrors the response body of the workflow API server.
+type workflowRunResponse struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+func init() {
+	rootCmd.AddCommand(workflowCmd)
+	workflowCmd.PersistentFlags().StringVar(&workflowAPIURL, "api-url", "http://localhost:8080", "URL of the workflow API server")
+	workflowCmd.PersistentFlags().StringVarP(&workflowNamespace, "namespace", "n", "default", "Namespace of the WorkflowRun")
+	workflowCmd.AddCommand(workflowRetryCmd)
+}
+
+var workflowCmd = &cobra.Command{
+	Use:   "workflow",
+	Short: "Operate on workflow runs",
+	Long:  `Operate on workflow runs through the workflow API server.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Usage()
+	},
+}
+
+var workflowRetryCmd = &cobra.Command{
+	Use:   "retry RUN_NAME",
+	Short: "Retry a failed WorkflowRun from its first failed step",
+	Long: `Creates a new WorkflowRun from a failed or cancelled WorkflowRun.
+The outputs of the jobs and steps that succeeded are reused and execution restarts at the first failed step.`,
+	Args: cobra.ExactArgs(1),
+	RunE: workflowRetryRun,
+}
+
+func workflowRetryRun(cmd *cobra.Command, args []string) error {
+	resp, err := postWorkflowRunAction(workflowAPIURL, workflowNamespace, args[0], "retry")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "workflowrun %s/%s %s\n", resp.Namespace, resp.Name, resp.Status)
+	return nil
+}
+
+// postWorkflowRunAction calls an action endpoint of the workflow API server for the given WorkflowRun.
+func postWorkflowRunAction(apiURL, namespace, name, action string) (*workflowRunResponse, error) {
+	endpoint, err := url.JoinPath(apiURL, "api", "v1", "namespaces", namespace, "workflowruns", name, action)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api url %q: %w", apiURL, err)
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	res, err := httpClient.Post(endpoint, "application/json", http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call workflow API server: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%s %s failed with status %d: %s", action, name, res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	resp := &workflowRunResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp, nil
+}
@@ -169,14 +169,32 @@ func (r *Reconciler) handleGenerateSecrets(ctx context.Context, namespace string
 	if err != nil {
 		return nil, fmt.Errorf("error resolving cleanup policy for spec.dataFrom[%d], err: %w", i, err)
 	}
-	secretMap, newState, err := impl.Generate(ctx, generatorResource, r.Client, namespace)
+	alternating, rotationPolicy, err := statemanager.AlternatingRotation(impl, generatorResource)
 	if err != nil {
-		return nil, fmt.Errorf(errGenerate, err)
+		return nil, fmt.Errorf("error resolving rotation policy for spec.dataFrom[%d], err: %w", i, err)
 	}
 
-	if generatorState != nil {
+	var secretMap map[string][]byte
+	if alternating != nil {
+		if generatorState == nil {
+			return nil, errors.New("the alternating rotation strategy requires the generator state to be enabled")
+		}
 		generatorState.SetCleanupPolicy(cleanupPolicy)
-		generatorState.EnqueueCreateState(generatorStateKey(i), namespace, generatorResource, impl, newState)
+		secretMap, err = generatorState.GenerateAlternating(generatorStateKey(i), namespace, generatorResource, alternating, rotationPolicy)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerate, err)
+		}
+	} else {
+		var newState genv1alpha1.GeneratorProviderState
+		secretMap, newState, err = impl.Generate(ctx, generatorResource, r.Client, namespace)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerate, err)
+		}
+
+		if generatorState != nil {
+			generatorState.SetCleanupPolicy(cleanupPolicy)
+			generatorState.EnqueueCreateState(generatorStateKey(i), namespace, generatorResource, impl, newState)
+		}
 	}
 
 	// rewrite the keys if needed
@@ -91,7 +91,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		}
 
 		gcDeadlineReached := generatorState.Spec.GarbageCollectionDeadline.Time.Before(time.Now())
-		if cleanupPolicy != nil && cleanupPolicy.Type == genv1alpha1.IdleCleanupPolicy && gcDeadlineReached {
+		_, hardDeadline := generatorState.Annotations[genv1alpha1.GeneratorStateAnnotationHardDeadline]
+		if cleanupPolicy != nil && cleanupPolicy.Type == genv1alpha1.IdleCleanupPolicy && gcDeadlineReached && !hardDeadline {
 			if generatorState.DeletionTimestamp != nil {
 				return ctrl.Result{}, nil
 			}
@@ -40,6 +40,7 @@ import (
 
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 
 	tgtv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/targets/v1alpha1"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
@@ -518,13 +519,26 @@ func (r *Reconciler) resolveSecretFromGenerator(ctx context.Context, namespace s
 		return nil, fmt.Errorf("unable to get cleanup policy: %w", err)
 	}
 
-	secretMap, newState, err := gen.Generate(ctx, genResource, r.Client, namespace)
+	alternating, rotationPolicy, err := statemanager.AlternatingRotation(gen, genResource)
 	if err != nil {
-		return nil, fmt.Errorf("unable to generate: %w", err)
+		return nil, fmt.Errorf("unable to get rotation policy: %w", err)
 	}
 
 	generatorState.SetCleanupPolicy(cleanupPolicy)
-	generatorState.EnqueueCreateState(defaultGeneratorStateKey, namespace, genResource, gen, newState)
+	var secretMap map[string][]byte
+	if alternating != nil {
+		secretMap, err = generatorState.GenerateAlternating(defaultGeneratorStateKey, namespace, genResource, alternating, rotationPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate: %w", err)
+		}
+	} else {
+		var newState genv1alpha1.GeneratorProviderState
+		secretMap, newState, err = gen.Generate(ctx, genResource, r.Client, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate: %w", err)
+		}
+		generatorState.EnqueueCreateState(defaultGeneratorStateKey, namespace, genResource, gen, newState)
+	}
 
 	return &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -85,7 +85,11 @@ func (c *AuthorizationController) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	if authorization.Spec.RequiresTLS() {
-		server.AddTLSAllowedID(principal)
+		if authorization.Spec.SubjectMatcher != nil {
+			server.AddTLSAllowedTrustDomain(authorization.Name, authority, &authorization.Spec)
+		} else {
+			server.AddTLSAllowedID(principal)
+		}
 	}
 
 	// Get the Spec and add it to the federation store
@@ -96,7 +100,11 @@ func (c *AuthorizationController) Reconcile(ctx context.Context, req ctrl.Reques
 
 func (c *AuthorizationController) cleanup(ctx context.Context, authorization *v1alpha1.Authorization, principal string) (result ctrl.Result, err error) {
 	if authorization.Spec.RequiresTLS() {
-		server.RemoveTLSAllowedID(principal)
+		if authorization.Spec.SubjectMatcher != nil {
+			server.RemoveTLSAllowedTrustDomain(authorization.Name)
+		} else {
+			server.RemoveTLSAllowedID(principal)
+		}
 	}
 
 	for i, f := range authorization.GetFinalizers() {
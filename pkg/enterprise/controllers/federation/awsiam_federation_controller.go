@@ -0,0 +1,84 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// /*
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package federation implements federation controllers.
+package federation
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	idfedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/identity/v1alpha1"
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/provider"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+// AWSIAMFederationController reconciles AWSIAMFederation resources.
+type AWSIAMFederationController struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// Reconcile reconciles an AWSIAMFederation resource.
+func (c *AWSIAMFederationController) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	federation := &idfedv1alpha1.AWSIAMFederation{}
+	if err := c.Get(ctx, req.NamespacedName, federation); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	ref := fedv1alpha1.FederationRef{
+		Name: federation.Name,
+		Kind: idfedv1alpha1.AWSIAMFederationKind,
+	}
+	spec := federation.Spec
+	prov := provider.NewAWSIAMProvider(spec.STSEndpoint, spec.ServerID, spec.AccountIDs)
+	store.AddStore(ref, prov)
+
+	c.Log.Info("Registered AWS IAM federation provider",
+		"name", federation.Name,
+		"stsEndpoint", prov.STSEndpoint)
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager returns a new controller builder that will be started by the provided Manager.
+func (c *AWSIAMFederationController) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(opts).
+		For(&idfedv1alpha1.AWSIAMFederation{}).
+		Complete(c)
+}
@@ -26,7 +26,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -84,15 +83,9 @@ func NewServer(c client.Client, log logr.Logger) *Server {
 
 // Start starts the API server.
 func (s *Server) Start(addr string) error {
-	mux := http.NewServeMux()
-
-	// Register API endpoints
-	mux.HandleFunc("/api/v1/namespaces/", s.handleNamespacedRequests)
-	mux.HandleFunc("/healthz", s.handleHealthz)
-
 	s.server = &http.Server{
 		Addr:              addr,
-		Handler:           mux,
+		Handler:           s.Handler(),
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
@@ -100,6 +93,20 @@ func (s *Server) Start(addr string) error {
 	return s.server.ListenAndServe()
 }
 
+// Handler returns the HTTP handler serving the API endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	// Register API endpoints
+	mux.HandleFunc("GET /api/v1/namespaces/{namespace}/workflowruns", s.listWorkflowRuns)
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/workflowruns", s.createWorkflowRun)
+	mux.HandleFunc("GET /api/v1/namespaces/{namespace}/workflowruns/{name}", s.getWorkflowRun)
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/workflowruns/{name}/{action}", s.handleWorkflowRunAction)
+	mux.HandleFunc("/healthz", s.handleHealthz)
+
+	return mux
+}
+
 // Stop stops the API server.
 func (s *Server) Stop(ctx context.Context) error {
 	s.log.Info("Stopping API server")
@@ -115,45 +122,22 @@ func (s *Server) handleHealthz(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
-// handleNamespacedRequests handles requests to namespaced resources.
-func (s *Server) handleNamespacedRequests(w http.ResponseWriter, r *http.Request) {
-	// Extract namespace and resource type from the URL
-	parts := strings.Split(r.URL.Path, "/")
-	if len(parts) < 5 {
-		http.Error(w, "Invalid URL path", http.StatusBadRequest)
+// handleWorkflowRunAction handles the actions of a WorkflowRun.
+func (s *Server) handleWorkflowRunAction(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	name := r.PathValue("name")
+	action := r.PathValue("action")
+	if action == "retry" {
+		s.retryWorkflowRun(w, r, namespace, name)
 		return
 	}
-
-	namespace := parts[3]
-	resourceType := parts[4]
-
-	switch resourceType {
-	case "workflowruns":
-		s.handleWorkflowRuns(w, r, namespace)
-	default:
-		http.Error(w, fmt.Sprintf("Unsupported resource type: %s", resourceType), http.StatusBadRequest)
-	}
-}
-
-// handleWorkflowRuns handles requests to the workflowruns endpoint.
-func (s *Server) handleWorkflowRuns(w http.ResponseWriter, r *http.Request, namespace string) {
-	switch r.Method {
-	case http.MethodPost:
-		s.createWorkflowRun(w, r, namespace)
-	case http.MethodGet:
-		parts := strings.Split(r.URL.Path, "/")
-		if len(parts) > 5 {
-			s.getWorkflowRun(w, r, namespace, parts[5])
-		} else {
-			s.listWorkflowRuns(w, r, namespace)
-		}
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
+	s.controlWorkflowRun(w, r, namespace, name, action)
 }
 
 // createWorkflowRun creates a new WorkflowRun.
-func (s *Server) createWorkflowRun(w http.ResponseWriter, r *http.Request, namespace string) {
+func (s *Server) createWorkflowRun(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+
 	// Parse request body
 	var req WorkflowRunRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -234,8 +218,8 @@ func (s *Server) createWorkflowRun(w http.ResponseWriter, r *http.Request, names
 	}
 }
 
-// getWorkflowRun gets a WorkflowRun by name.
-func (s *Server) getWorkflowRun(w http.ResponseWriter, r *http.Request, namespace, name string) {
+// controlWorkflowRun suspends, resumes or cancels a WorkflowRun.
+func (s *Server) controlWorkflowRun(w http.ResponseWriter, r *http.Request, namespace, name, action string) {
 	run := &workflows.WorkflowRun{}
 	if err := s.client.Get(r.Context(), types.NamespacedName{
 		Name:      name,
@@ -245,6 +229,135 @@ func (s *Server) getWorkflowRun(w http.ResponseWriter, r *http.Request, namespac
 		return
 	}
 
+	if isFinished(run) {
+		http.Error(w, fmt.Sprintf("WorkflowRun is already %s", run.Status.Phase), http.StatusConflict)
+		return
+	}
+
+	patch := client.MergeFrom(run.DeepCopy())
+	var status string
+	switch action {
+	case "cancel":
+		run.Spec.Cancel = true
+		status = "cancelled"
+	case "suspend":
+		run.Spec.Suspend = true
+		status = "suspended"
+	case "resume":
+		if run.Spec.Cancel {
+			http.Error(w, "A cancelled WorkflowRun cannot be resumed", http.StatusConflict)
+			return
+		}
+		run.Spec.Suspend = false
+		status = "resumed"
+	default:
+		http.Error(w, fmt.Sprintf("Unsupported action: %s", action), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.client.Patch(r.Context(), run, patch); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update WorkflowRun: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	resp := WorkflowRunResponse{
+		Name:      run.Name,
+		Namespace: run.Namespace,
+		Status:    status,
+		Message:   fmt.Sprintf("WorkflowRun %s requested", action),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log.Error(err, "Failed to encode workflow run response")
+	}
+}
+
+// retryWorkflowRun creates a new WorkflowRun that retries a failed or cancelled run
+// from its first failed step.
+func (s *Server) retryWorkflowRun(w http.ResponseWriter, r *http.Request, namespace, name string) {
+	original := &workflows.WorkflowRun{}
+	if err := s.client.Get(r.Context(), types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}, original); err != nil {
+		http.Error(w, fmt.Sprintf("WorkflowRun not found: %v", err), http.StatusNotFound)
+		return
+	}
+
+	if original.Status.Phase != workflows.PhaseFailed && original.Status.Phase != workflows.PhaseCancelled {
+		http.Error(w, fmt.Sprintf("Only failed or cancelled WorkflowRuns can be retried, WorkflowRun is %s", original.Status.Phase), http.StatusConflict)
+		return
+	}
+
+	labels := map[string]string{
+		"workflows.external-secrets.io/retry-of": original.Name,
+	}
+	for _, key := range []string{"workflows.external-secrets.io/template", "workflows.external-secrets.io/api"} {
+		if value, ok := original.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+
+	run := &workflows.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-retry-", original.Name),
+			Namespace:    namespace,
+			Labels:       labels,
+			Annotations: map[string]string{
+				"workflows.external-secrets.io/created-at": time.Now().Format(time.RFC3339),
+			},
+		},
+		Spec: workflows.WorkflowRunSpec{
+			TemplateRef: original.Spec.TemplateRef,
+			Arguments:   *original.Spec.Arguments.DeepCopy(),
+			RetryFrom: &workflows.RetryFromRef{
+				Name: original.Name,
+			},
+		},
+	}
+
+	if err := s.client.Create(r.Context(), run); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create WorkflowRun: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	resp := WorkflowRunResponse{
+		Name:      run.Name,
+		Namespace: run.Namespace,
+		Status:    "created",
+		Message:   fmt.Sprintf("WorkflowRun %s retried", original.Name),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log.Error(err, "Failed to encode workflow run response")
+	}
+}
+
+// isFinished returns true if the WorkflowRun reached a terminal phase.
+func isFinished(run *workflows.WorkflowRun) bool {
+	switch run.Status.Phase {
+	case workflows.PhaseSucceeded, workflows.PhaseFailed, workflows.PhaseCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// getWorkflowRun gets a WorkflowRun by name.
+func (s *Server) getWorkflowRun(w http.ResponseWriter, r *http.Request) {
+	run := &workflows.WorkflowRun{}
+	if err := s.client.Get(r.Context(), types.NamespacedName{
+		Name:      r.PathValue("name"),
+		Namespace: r.PathValue("namespace"),
+	}, run); err != nil {
+		http.Error(w, fmt.Sprintf("WorkflowRun not found: %v", err), http.StatusNotFound)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(run); err != nil {
 		s.log.Error(err, "Failed to encode workflow run")
@@ -252,9 +365,9 @@ func (s *Server) getWorkflowRun(w http.ResponseWriter, r *http.Request, namespac
 }
 
 // listWorkflowRuns lists WorkflowRuns in a namespace.
-func (s *Server) listWorkflowRuns(w http.ResponseWriter, r *http.Request, namespace string) {
+func (s *Server) listWorkflowRuns(w http.ResponseWriter, r *http.Request) {
 	runList := &workflows.WorkflowRunList{}
-	if err := s.client.List(r.Context(), runList, client.InNamespace(namespace)); err != nil {
+	if err := s.client.List(r.Context(), runList, client.InNamespace(r.PathValue("namespace"))); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to list WorkflowRuns: %v", err), http.StatusInternalServerError)
 		return
 	}
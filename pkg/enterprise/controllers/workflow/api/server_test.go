@@ -0,0 +1,227 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workflows "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
+)
+
+func newTestServer(t *testing.T, objs ...client.Object) (*httptest.Server, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, workflows.AddToScheme(scheme))
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&workflows.WorkflowRun{}).
+		Build()
+
+	srv := httptest.NewServer(NewServer(c, logr.Discard()).Handler())
+	t.Cleanup(srv.Close)
+	return srv, c
+}
+
+func newTestRun(name string, phase workflows.Phase) *workflows.WorkflowRun {
+	return &workflows.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: workflows.WorkflowRunSpec{
+			TemplateRef: workflows.TemplateRef{Name: "template"},
+		},
+		Status: workflows.WorkflowRunStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func post(t *testing.T, srv *httptest.Server, path string) (*http.Response, WorkflowRunResponse) {
+	t.Helper()
+	res, err := http.Post(srv.URL+path, "application/json", http.NoBody)
+	require.NoError(t, err)
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	var resp WorkflowRunResponse
+	if res.StatusCode < http.StatusBadRequest {
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	}
+	return res, resp
+}
+
+func getRun(t *testing.T, c client.Client, name string) *workflows.WorkflowRun {
+	t.Helper()
+	run := &workflows.WorkflowRun{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, run))
+	return run
+}
+
+func TestControlWorkflowRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        *workflows.WorkflowRun
+		action     string
+		wantCode   int
+		wantStatus string
+		check      func(t *testing.T, run *workflows.WorkflowRun)
+	}{
+		{
+			name:       "suspend a running run",
+			run:        newTestRun("run", workflows.PhaseRunning),
+			action:     "suspend",
+			wantCode:   http.StatusAccepted,
+			wantStatus: "suspended",
+			check: func(t *testing.T, run *workflows.WorkflowRun) {
+				assert.True(t, run.Spec.Suspend)
+			},
+		},
+		{
+			name: "resume a suspended run",
+			run: func() *workflows.WorkflowRun {
+				run := newTestRun("run", workflows.PhaseRunning)
+				run.Spec.Suspend = true
+				return run
+			}(),
+			action:     "resume",
+			wantCode:   http.StatusAccepted,
+			wantStatus: "resumed",
+			check: func(t *testing.T, run *workflows.WorkflowRun) {
+				assert.False(t, run.Spec.Suspend)
+			},
+		},
+		{
+			name:       "cancel a running run",
+			run:        newTestRun("run", workflows.PhaseRunning),
+			action:     "cancel",
+			wantCode:   http.StatusAccepted,
+			wantStatus: "cancelled",
+			check: func(t *testing.T, run *workflows.WorkflowRun) {
+				assert.True(t, run.Spec.Cancel)
+			},
+		},
+		{
+			name: "resume a cancelled run",
+			run: func() *workflows.WorkflowRun {
+				run := newTestRun("run", workflows.PhaseRunning)
+				run.Spec.Cancel = true
+				return run
+			}(),
+			action:   "resume",
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "cancel a finished run",
+			run:      newTestRun("run", workflows.PhaseSucceeded),
+			action:   "cancel",
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "unsupported action",
+			run:      newTestRun("run", workflows.PhaseRunning),
+			action:   "restart",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "run not found",
+			run:      newTestRun("other", workflows.PhaseRunning),
+			action:   "suspend",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, c := newTestServer(t, tt.run)
+
+			res, resp := post(t, srv, "/api/v1/namespaces/default/workflowruns/run/"+tt.action)
+			require.Equal(t, tt.wantCode, res.StatusCode)
+			if tt.check == nil {
+				return
+			}
+			assert.Equal(t, "run", resp.Name)
+			assert.Equal(t, "default", resp.Namespace)
+			assert.Equal(t, tt.wantStatus, resp.Status)
+			tt.check(t, getRun(t, c, "run"))
+		})
+	}
+}
+
+func TestGetWorkflowRun(t *testing.T) {
+	srv, _ := newTestServer(t, newTestRun("run", workflows.PhaseRunning))
+
+	res, err := http.Get(srv.URL + "/api/v1/namespaces/default/workflowruns/run")
+	require.NoError(t, err)
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	run := &workflows.WorkflowRun{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(run))
+	assert.Equal(t, "run", run.Name)
+	assert.Equal(t, workflows.PhaseRunning, run.Status.Phase)
+}
+
+func TestRetryWorkflowRun(t *testing.T) {
+	t.Run("retry a failed run", func(t *testing.T) {
+		failed := newTestRun("run", workflows.PhaseFailed)
+		failed.Labels = map[string]string{"workflows.external-secrets.io/template": "template"}
+		srv, c := newTestServer(t, failed)
+
+		res, resp := post(t, srv, "/api/v1/namespaces/default/workflowruns/run/retry")
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+		assert.Equal(t, "created", resp.Status)
+		assert.NotEqual(t, "run", resp.Name)
+
+		retry := getRun(t, c, resp.Name)
+		require.NotNil(t, retry.Spec.RetryFrom)
+		assert.Equal(t, "run", retry.Spec.RetryFrom.Name)
+		assert.Equal(t, failed.Spec.TemplateRef, retry.Spec.TemplateRef)
+		assert.Equal(t, "run", retry.Labels["workflows.external-secrets.io/retry-of"])
+		assert.Equal(t, "template", retry.Labels["workflows.external-secrets.io/template"])
+	})
+
+	t.Run("retry a running run", func(t *testing.T) {
+		srv, _ := newTestServer(t, newTestRun("run", workflows.PhaseRunning))
+
+		res, _ := post(t, srv, "/api/v1/namespaces/default/workflowruns/run/retry")
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("run not found", func(t *testing.T) {
+		srv, _ := newTestServer(t)
+
+		res, _ := post(t, srv, "/api/v1/namespaces/default/workflowruns/run/retry")
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
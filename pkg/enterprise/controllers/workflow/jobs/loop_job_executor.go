@@ -129,6 +129,12 @@ func executeIteration(
 		// 	continue
 		// }
 
+		// Iterations share their step statuses, so a suspended loop restarts
+		// from its first iteration once the workflow is resumed.
+		if err := CheckInterruption(ctx, baseCtx.Client, baseCtx.Workflow); err != nil {
+			return err
+		}
+
 		// Execute the step.
 		if err := ExecuteStepWithContext(ctx, iterationCtx, step, step.Name); err != nil {
 			return fmt.Errorf("failed to execute step %s for key %s: %w", step.Name, key, err)
@@ -71,6 +71,13 @@ func (e *StandardJobExecutor) Execute(ctx context.Context, client client.Client,
 
 	// Process each step sequentially
 	for _, step := range e.job.Steps {
+		// Steps that succeeded before the workflow was suspended are not executed again.
+		if jobStatus.StepStatuses[step.Name].Phase == workflows.StepPhaseSucceeded {
+			continue
+		}
+		if err := CheckInterruption(ctx, client, wf); err != nil {
+			return err
+		}
 		if err := ExecuteStepWithContext(ctx, jobCtx, step, step.Name); err != nil {
 			return err
 		}
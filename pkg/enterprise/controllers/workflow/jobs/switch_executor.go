@@ -87,6 +87,13 @@ func (e *SwitchJobExecutor) Execute(ctx context.Context, client client.Client, w
 
 			// Process each step sequentially
 			for _, step := range switchCase.Steps {
+				// Steps that succeeded before the workflow was suspended are not executed again.
+				if jobStatus.StepStatuses[step.Name].Phase == workflows.StepPhaseSucceeded {
+					continue
+				}
+				if err := CheckInterruption(ctx, client, wf); err != nil {
+					return err
+				}
 				if err := ExecuteStepWithContext(ctx, jobCtx, step, step.Name); err != nil {
 					return err
 				}
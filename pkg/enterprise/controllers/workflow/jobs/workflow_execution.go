@@ -23,6 +23,7 @@ package jobs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/aws/smithy-go/ptr"
@@ -37,6 +38,16 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/enterprise/controllers/workflow/steps"
 )
 
+// OnCancelJobName is the job name the onCancel steps of a workflow are executed with.
+const OnCancelJobName = "onCancel"
+
+var (
+	// ErrWorkflowCancelled is returned by job executors when the workflow was cancelled while the job was running.
+	ErrWorkflowCancelled = errors.New("workflow was cancelled")
+	// ErrWorkflowSuspended is returned by job executors when the workflow was suspended while the job was running.
+	ErrWorkflowSuspended = errors.New("workflow was suspended")
+)
+
 // StepExecutor abstracts the execution of a workflow step.
 type StepExecutor interface {
 	Execute(ctx context.Context, client client.Client, wf *workflows.Workflow, data map[string]interface{}, jobName string) (map[string]interface{}, error)
@@ -57,6 +68,8 @@ func createExecutor(step workflows.Step, c client.Client, scheme runtime.Scheme,
 		return steps.NewGeneratorStepExecutor(step.Generator, c, &scheme, manager), nil
 	case step.JavaScript != nil:
 		return steps.NewJavaScriptExecutor(step.JavaScript, logger), nil
+	case step.Revoke != nil:
+		return steps.NewRevokeStepExecutor(step.Revoke, c, &scheme), nil
 	default:
 		return nil, fmt.Errorf("unknown step type")
 	}
@@ -272,6 +285,78 @@ func ExecuteStepWithContext(
 	return nil
 }
 
+// CheckInterruption reads the latest version of the workflow and returns
+// ErrWorkflowCancelled or ErrWorkflowSuspended if it was cancelled or suspended
+// since the job started. Job executors call it before starting a step.
+func CheckInterruption(ctx context.Context, c client.Client, wf *workflows.Workflow) error {
+	if c == nil {
+		return nil
+	}
+	latest := &workflows.Workflow{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(wf), latest); err != nil {
+		// A failed read must not fail the job, the request is picked up by the next check.
+		return nil
+	}
+	if latest.Spec.Cancel {
+		return ErrWorkflowCancelled
+	}
+	if latest.Spec.Suspend {
+		return ErrWorkflowSuspended
+	}
+	return nil
+}
+
+// IsCancelled reports whether err was caused by a cancellation of the workflow.
+func IsCancelled(err error) bool {
+	return errors.Is(err, ErrWorkflowCancelled)
+}
+
+// IsSuspended reports whether err was caused by a suspension of the workflow.
+func IsSuspended(err error) bool {
+	return errors.Is(err, ErrWorkflowSuspended)
+}
+
+// ExecuteOnCancelSteps executes the onCancel steps of a cancelled workflow.
+// Unlike regular jobs, it does not check for interruptions between steps.
+// Steps recorded as succeeded are not executed again, and saveStatus is called
+// after each step so a step that succeeded is not repeated.
+func ExecuteOnCancelSteps(
+	ctx context.Context,
+	c client.Client,
+	wf *workflows.Workflow,
+	jobStatus *workflows.JobStatus,
+	scheme *runtime.Scheme,
+	logger logr.Logger,
+	manager secretstore.ManagerInterface,
+	saveStatus func() error,
+) error {
+	jobCtx, err := NewJobExecutionContext(c, wf, OnCancelJobName, jobStatus, scheme, logger, manager)
+	if err != nil {
+		return fmt.Errorf("error creating new job execution context: %w", err)
+	}
+
+	for _, cancelStep := range wf.Spec.OnCancel {
+		step := workflows.Step{
+			Name:       cancelStep.Name,
+			Debug:      cancelStep.Debug,
+			Transform:  cancelStep.Transform,
+			JavaScript: cancelStep.JavaScript,
+			Revoke:     cancelStep.Revoke,
+		}
+		if jobStatus.StepStatuses[step.Name].Phase == workflows.StepPhaseSucceeded {
+			continue
+		}
+		if err := ExecuteStepWithContext(ctx, jobCtx, step, step.Name); err != nil {
+			return err
+		}
+		if err := saveStatus(); err != nil {
+			return fmt.Errorf("failed to save status of step %s: %w", step.Name, err)
+		}
+	}
+
+	return CompleteJob(jobStatus)
+}
+
 // CompleteJob marks a job as completed and returns nil.
 func CompleteJob(jobStatus *workflows.JobStatus) error {
 	MarkJobCompleted(jobStatus)
@@ -129,14 +129,7 @@ func (e *GeneratorStepExecutor) Execute(ctx context.Context, _ client.Client, wf
 
 	// Handle generator state
 	if e.Step.AutoCleanup {
-		var statefulResource genv1alpha1.StatefulResource
-		runTemplate, err := getWorkflowRunTemplateFromWorkflow(ctx, e.Client, wf)
-		if err == nil {
-			statefulResource = runTemplate
-		} else {
-			statefulResource = wf
-			log.Info(fmt.Sprintf("error getting workflow run template: %v", err))
-		}
+		statefulResource := getStatefulResource(ctx, e.Client, wf)
 
 		generatorState := statemanager.New(ctx, e.Client, e.Scheme, namespace, statefulResource)
 		defer func() {
@@ -157,10 +150,8 @@ func (e *GeneratorStepExecutor) Execute(ctx context.Context, _ client.Client, wf
 		}
 		generatorState.SetCleanupPolicy(cleanupPolicy)
 
-		genStateKey := fmt.Sprintf("%s.%s.%s.%s", statefulResource.GetObjectKind().GroupVersionKind().String(), wf.Namespace, statefulResource.GetName(), jobName)
-		hash := sha512.Sum512([]byte(genStateKey))
 		if generatorState != nil {
-			generatorState.EnqueueCreateState(hex.EncodeToString(hash[:]), namespace, obj, gen, newGenState)
+			generatorState.EnqueueCreateState(generatorStateKey(statefulResource, wf, jobName), namespace, obj, gen, newGenState)
 		}
 	}
 
@@ -188,6 +179,26 @@ func (e *GeneratorStepExecutor) Execute(ctx context.Context, _ client.Client, wf
 
 	return output, nil
 }
+
+// getStatefulResource returns the resource owning the generator states of the workflow.
+// Runs created by a WorkflowRunTemplate share their states through the template,
+// so that a new run can clean up the credentials issued by the previous one.
+func getStatefulResource(ctx context.Context, c client.Client, wf *workflows.Workflow) genv1alpha1.StatefulResource {
+	runTemplate, err := getWorkflowRunTemplateFromWorkflow(ctx, c, wf)
+	if err != nil {
+		ctrl.Log.WithName("controllers").WithName("Workflow").Info(fmt.Sprintf("error getting workflow run template: %v", err))
+		return wf
+	}
+	return runTemplate
+}
+
+// generatorStateKey returns the key under which the generator steps of a job store their state.
+func generatorStateKey(statefulResource genv1alpha1.StatefulResource, wf *workflows.Workflow, jobName string) string {
+	genStateKey := fmt.Sprintf("%s.%s.%s.%s", statefulResource.GetObjectKind().GroupVersionKind().String(), wf.Namespace, statefulResource.GetName(), jobName)
+	hash := sha512.Sum512([]byte(genStateKey))
+	return hex.EncodeToString(hash[:])
+}
+
 func getWorkflowRunTemplateFromWorkflow(
 	ctx context.Context,
 	c client.Client,
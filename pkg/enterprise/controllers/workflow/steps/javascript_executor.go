@@ -24,17 +24,25 @@ package steps
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/dop251/goja"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workflows "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
 	"github.com/external-secrets/external-secrets/pkg/enterprise/controllers/workflow/templates"
 )
 
+const (
+	defaultScriptTimeout   = 10 * time.Second
+	defaultMaxInstructions = int64(1000000)
+)
+
 // JavaScriptExecutor executes JavaScript code with provided input data.
 type JavaScriptExecutor struct {
 	step    *workflows.JavaScriptStep
@@ -52,11 +60,18 @@ func NewJavaScriptExecutor(step *workflows.JavaScriptStep, logger logr.Logger) *
 }
 
 // Execute runs the JavaScript code with the provided input data and returns the outputs.
-func (e *JavaScriptExecutor) Execute(_ context.Context, _ client.Client, _ *workflows.Workflow, inputData map[string]interface{}, _ string) (map[string]interface{}, error) {
+// The script is interrupted when it exceeds its timeout or its instruction budget.
+func (e *JavaScriptExecutor) Execute(ctx context.Context, c client.Client, wf *workflows.Workflow, inputData map[string]interface{}, _ string) (map[string]interface{}, error) {
 	// Reset outputs for each new execution.
 	e.outputs = make(map[string]interface{})
 
+	script, err := e.loadScript(ctx, c, wf)
+	if err != nil {
+		return nil, err
+	}
+
 	vm := goja.New()
+	vm.SetMaxCallStackSize(maxCallStackSize)
 
 	// Create and set the "input" object in JavaScript.
 	inputObj := vm.NewObject()
@@ -101,20 +116,77 @@ func (e *JavaScriptExecutor) Execute(_ context.Context, _ client.Client, _ *work
 		return nil, fmt.Errorf("failed to set 'setMap' function: %w", err)
 	}
 
+	if err := registerStdlib(vm, inputData); err != nil {
+		return nil, fmt.Errorf("failed to register standard library: %w", err)
+	}
+	if err := disableDynamicCode(vm); err != nil {
+		return nil, fmt.Errorf("failed to disable dynamic code evaluation: %w", err)
+	}
+
 	// Process templates in the script before execution
-	resolvedScript, err := templates.ResolveTemplate(e.step.Script, inputData)
+	resolvedScript, err := templates.ResolveTemplate(script, inputData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve templates in script: %w", err)
 	}
 
+	// Count loop iterations and function calls against the instruction budget.
+	instrumentedScript, err := instrumentScript(resolvedScript)
+	if err != nil {
+		return nil, err
+	}
+	budget := &scriptBudget{vm: vm, remaining: defaultMaxInstructions}
+	if e.step.MaxInstructions != nil {
+		budget.remaining = *e.step.MaxInstructions
+	}
+	if err := budget.install(); err != nil {
+		return nil, fmt.Errorf("failed to set instruction budget: %w", err)
+	}
+
+	timeout := defaultScriptTimeout
+	if e.step.Timeout != nil {
+		timeout = e.step.Timeout.Duration
+	}
+	timer := time.AfterFunc(timeout, func() {
+		vm.Interrupt(errScriptTimeout)
+	})
+	defer timer.Stop()
+	stop := context.AfterFunc(ctx, func() {
+		vm.Interrupt(ctx.Err())
+	})
+	defer stop()
+
 	// Execute the resolved script.
-	if _, err := vm.RunString(resolvedScript); err != nil {
+	if _, err := vm.RunString(instrumentedScript); err != nil {
+		var interrupted *goja.InterruptedError
+		if errors.As(err, &interrupted) {
+			if cause, ok := interrupted.Value().(error); ok {
+				return nil, fmt.Errorf("failed to execute script: %w", cause)
+			}
+		}
 		return nil, fmt.Errorf("failed to execute script: %w", err)
 	}
 
 	return e.outputs, nil
 }
 
+// loadScript returns the inline script of the step or loads it from the referenced ConfigMap.
+func (e *JavaScriptExecutor) loadScript(ctx context.Context, c client.Client, wf *workflows.Workflow) (string, error) {
+	if e.step.ScriptFrom == nil {
+		return e.step.Script, nil
+	}
+
+	ref := e.step.ScriptFrom.ConfigMapKeyRef
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: wf.Namespace}, cm); err != nil {
+		return "", fmt.Errorf("failed to get script ConfigMap %s: %w", ref.Name, err)
+	}
+	script, ok := cm.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in script ConfigMap %s", ref.Key, ref.Name)
+	}
+	return script, nil
+}
+
 // jsSetString is the JavaScript binding for setString().
 // It expects exactly two arguments: key (string) and value (string).
 func (e *JavaScriptExecutor) jsSetString(call goja.FunctionCall) goja.Value {
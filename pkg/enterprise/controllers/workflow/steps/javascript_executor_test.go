@@ -26,6 +26,10 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	esapi "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
 )
@@ -101,6 +105,49 @@ func TestJavaScriptExecutor(t *testing.T) {
 			expected: map[string]interface{}{"result": map[string]interface{}{"key": "value"}},
 			wantErr:  false,
 		},
+		{
+			name:     "encode and hash values",
+			input:    map[string]interface{}{},
+			script:   "setString('b64', base64.encode('hello')); setString('hex', hex.decode('68656c6c6f')); setString('sha', crypto.sha256('hello')); setString('mac', crypto.hmac('sha256', 'key', 'hello'));",
+			expected: map[string]interface{}{"b64": "aGVsbG8=", "hex": "hello", "sha": "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", "mac": "9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b"},
+			wantErr:  false,
+		},
+		{
+			name:     "parse yaml and compute dates",
+			input:    map[string]interface{}{},
+			script:   "const doc = yaml.parse('user: admin'); setString('user', doc.user); setString('expires', date.add('2025-01-01T00:00:00Z', '48h')); setNumber('age', date.diff('2025-01-02T00:00:00Z', '2025-01-01T00:00:00Z'));",
+			expected: map[string]interface{}{"user": "admin", "expires": "2025-01-03T00:00:00Z", "age": int64(86400)},
+			wantErr:  false,
+		},
+		{
+			name:     "declarations shadow the standard library",
+			input:    map[string]interface{}{},
+			script:   "let date = '2025-01-01'; const hex = 'ff'; var yaml = 'doc'; setString('date', date); setString('hex', hex); setString('yaml', yaml);",
+			expected: map[string]interface{}{"date": "2025-01-01", "hex": "ff", "yaml": "doc"},
+			wantErr:  false,
+		},
+		{
+			name: "read outputs of previous steps",
+			input: map[string]interface{}{
+				"global": map[string]interface{}{
+					"jobs": map[string]map[string]map[string]string{"build": {"generate": {"password": "secret"}}},
+				},
+			},
+			script:   "'use strict'; setString('password', jobs.build.generate.password);",
+			expected: map[string]interface{}{"password": "secret"},
+			wantErr:  false,
+		},
+		{
+			name: "outputs of previous steps are read-only",
+			input: map[string]interface{}{
+				"global": map[string]interface{}{
+					"jobs": map[string]map[string]map[string]string{"build": {"generate": {"password": "secret"}}},
+				},
+			},
+			script:   "'use strict'; jobs.build.generate.password = 'changed';",
+			expected: nil,
+			wantErr:  true,
+		},
 		{
 			name: "invalid script",
 			input: map[string]interface{}{
@@ -126,3 +173,102 @@ func TestJavaScriptExecutor(t *testing.T) {
 		})
 	}
 }
+
+func TestJavaScriptExecutorLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    *esapi.JavaScriptStep
+		wantErr error
+	}{
+		{
+			name: "timeout interrupts endless loops",
+			// The budget is large enough not to be exhausted before the timeout, even on a loaded machine.
+			step:    &esapi.JavaScriptStep{Script: "while (true) {}", Timeout: &metav1.Duration{Duration: 100 * time.Millisecond}, MaxInstructions: ptrInt64(1 << 40)},
+			wantErr: errScriptTimeout,
+		},
+		{
+			name:    "budget limits loop iterations",
+			step:    &esapi.JavaScriptStep{Script: "for (let i = 0; i < 1000; i++) {}", MaxInstructions: ptrInt64(100)},
+			wantErr: errScriptBudgetExhausted,
+		},
+		{
+			name:    "budget limits function calls",
+			step:    &esapi.JavaScriptStep{Script: "const f = (n) => { return n > 0 ? f(n - 1) : 0; }; f(500);", MaxInstructions: ptrInt64(100)},
+			wantErr: errScriptBudgetExhausted,
+		},
+		{
+			name: "script within budget",
+			step: &esapi.JavaScriptStep{Script: "let n = 0; for (const x of [1, 2, 3]) n += x; setNumber('sum', n);", MaxInstructions: ptrInt64(3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := NewJavaScriptExecutor(tt.step, logr.Discard())
+			_, err := executor.Execute(context.Background(), nil, nil, map[string]interface{}{}, "job-test")
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestJavaScriptExecutorRejectsBudgetBypass(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{name: "eval", script: `eval("while (true) {}")`, wantErr: "eval is not defined"},
+		{name: "function constructor", script: `new Function("while (true) {}")()`, wantErr: "Function is not defined"},
+		{name: "constructor of a function", script: `(() => {}).constructor("while (true) {}")()`, wantErr: "dynamic code evaluation is not allowed"},
+		{name: "constructor of a generator function", script: `(function* () {}).constructor("while (true) {}")().next()`, wantErr: "dynamic code evaluation is not allowed"},
+		{name: "constructor of an async function", script: `(async function () {}).constructor("while (true) {}")()`, wantErr: "dynamic code evaluation is not allowed"},
+		{name: "parameter shadowing the budget function", script: `function f(__esoConsumeBudget) { while (true) {} } f(() => {});`, wantErr: "is reserved"},
+		{name: "escaped name shadowing the budget function", script: `let \u005f_esoConsumeBudget = () => {}; while (true) {}`, wantErr: "is reserved"},
+		{name: "with statement", script: `with ({}) { while (true) {} }`, wantErr: "with statements are not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := &esapi.JavaScriptStep{Script: tt.script, Timeout: &metav1.Duration{Duration: 5 * time.Second}, MaxInstructions: ptrInt64(100)}
+			executor := NewJavaScriptExecutor(step, logr.Discard())
+			_, err := executor.Execute(context.Background(), nil, nil, map[string]interface{}{}, "job-test")
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestJavaScriptExecutorScriptFromConfigMap(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "scripts", Namespace: "default"},
+		Data:       map[string]string{"rotate.js": "setString('result', 'shared');"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cm).Build()
+	wf := &esapi.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "wf", Namespace: "default"}}
+
+	executor := NewJavaScriptExecutor(&esapi.JavaScriptStep{
+		ScriptFrom: &esapi.ScriptSource{ConfigMapKeyRef: esapi.ConfigMapKeySelector{Name: "scripts", Key: "rotate.js"}},
+	}, logr.Discard())
+	result, err := executor.Execute(context.Background(), c, wf, map[string]interface{}{}, "job-test")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"result": "shared"}, result)
+
+	executor = NewJavaScriptExecutor(&esapi.JavaScriptStep{
+		ScriptFrom: &esapi.ScriptSource{ConfigMapKeyRef: esapi.ConfigMapKeySelector{Name: "scripts", Key: "missing.js"}},
+	}, logr.Discard())
+	_, err = executor.Execute(context.Background(), c, wf, map[string]interface{}{}, "job-test")
+	require.ErrorContains(t, err, "key missing.js not found")
+}
+
+func TestInstrumentScript(t *testing.T) {
+	instrumented, err := instrumentScript("do x--; while (x); function f() { return 1; }")
+	require.NoError(t, err)
+	require.Equal(t, "do {__esoConsumeBudget();x--;} while (x); function f() {__esoConsumeBudget(); return 1; }", instrumented)
+}
+
+func ptrInt64(v int64) *int64 {
+	return &v
+}
@@ -0,0 +1,215 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// 2025
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+
+package steps
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja/ast"
+	"github.com/dop251/goja/parser"
+)
+
+const (
+	// budgetFunctionName is the name of the function that is called by instrumented scripts
+	// on every loop iteration and function call.
+	budgetFunctionName = "__esoConsumeBudget"
+	// maxCallStackSize bounds the recursion depth of scripts.
+	maxCallStackSize = 1024
+)
+
+var (
+	errScriptTimeout         = errors.New("script execution timed out")
+	errScriptBudgetExhausted = errors.New("script exceeded its instruction budget")
+)
+
+// dynamicCodeGuard removes eval and the function constructors, which evaluate code that
+// is not instrumented. The constructors are also reachable through the constructor property
+// of every function, which is replaced by a function that throws.
+const dynamicCodeGuard = `(function () {
+	"use strict";
+	const blocked = function () {
+		throw new EvalError("dynamic code evaluation is not allowed");
+	};
+	const prototypes = [
+		Function.prototype,
+		Object.getPrototypeOf(function* () {}),
+		Object.getPrototypeOf(async function () {}),
+	];
+	for (const prototype of prototypes) {
+		Object.defineProperty(prototype, "constructor", { value: blocked, writable: false, enumerable: false, configurable: false });
+	}
+	delete globalThis.eval;
+	delete globalThis.Function;
+})();`
+
+// insertion is a piece of code inserted into a script at a byte offset.
+type insertion struct {
+	offset int
+	code   string
+}
+
+// instrumentScript inserts a call to the budget function at the start of every loop body
+// and every function body of the script, so the number of executed loop iterations and
+// function calls can be bounded.
+// Scripts that could shadow the budget function, by using its name or a with statement, are rejected.
+func instrumentScript(script string) (string, error) {
+	program, err := parser.ParseFile(nil, "", script, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse script: %w", err)
+	}
+
+	consume := budgetFunctionName + "();"
+	visited := make(map[ast.Node]bool)
+	var insertions []insertion
+	var invalid error
+	walkAST(reflect.ValueOf(program), func(node ast.Node) {
+		if visited[node] {
+			return
+		}
+		visited[node] = true
+
+		switch n := node.(type) {
+		case *ast.Identifier:
+			if n.Name.String() == budgetFunctionName {
+				invalid = fmt.Errorf("the name %s is reserved", budgetFunctionName)
+			}
+		case *ast.WithStatement:
+			invalid = errors.New("with statements are not allowed")
+		case *ast.ForStatement:
+			insertions = append(insertions, wrapStatement(script, n.Body, consume)...)
+		case *ast.ForInStatement:
+			insertions = append(insertions, wrapStatement(script, n.Body, consume)...)
+		case *ast.ForOfStatement:
+			insertions = append(insertions, wrapStatement(script, n.Body, consume)...)
+		case *ast.WhileStatement:
+			insertions = append(insertions, wrapStatement(script, n.Body, consume)...)
+		case *ast.DoWhileStatement:
+			insertions = append(insertions, wrapStatement(script, n.Body, consume)...)
+		case *ast.FunctionLiteral:
+			if n.Body != nil {
+				insertions = append(insertions, insertion{offset: int(n.Body.LeftBrace), code: consume})
+			}
+		case *ast.ArrowFunctionLiteral:
+			if body, ok := n.Body.(*ast.BlockStatement); ok {
+				insertions = append(insertions, insertion{offset: int(body.LeftBrace), code: consume})
+			}
+		}
+	})
+	if invalid != nil {
+		return "", invalid
+	}
+
+	sort.SliceStable(insertions, func(i, j int) bool {
+		return insertions[i].offset < insertions[j].offset
+	})
+	var b strings.Builder
+	b.Grow(len(script) + len(insertions)*len(consume))
+	start := 0
+	for _, ins := range insertions {
+		b.WriteString(script[start:ins.offset])
+		b.WriteString(ins.code)
+		start = ins.offset
+	}
+	b.WriteString(script[start:])
+	return b.String(), nil
+}
+
+// wrapStatement wraps a loop body into a block that starts with the given code.
+func wrapStatement(script string, stmt ast.Statement, code string) []insertion {
+	if stmt == nil {
+		return nil
+	}
+	// file.Idx is 1-based, Idx0 points to the first and Idx1 past the last character.
+	start, end := int(stmt.Idx0())-1, int(stmt.Idx1())-1
+	// The end of a simple statement does not include its terminating semicolon, which has to
+	// be moved into the block, e.g. for "do x--; while (x)".
+	if _, ok := stmt.(*ast.BlockStatement); !ok {
+		next := end
+		for next < len(script) && (script[next] == ' ' || script[next] == '\t') {
+			next++
+		}
+		if next < len(script) && script[next] == ';' {
+			end = next + 1
+		}
+	}
+	return []insertion{
+		{offset: start, code: "{" + code},
+		{offset: end, code: "}"},
+	}
+}
+
+// walkAST calls visit for every AST node reachable from v.
+func walkAST(v reflect.Value, visit func(ast.Node)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if v.CanInterface() {
+			if node, ok := v.Interface().(ast.Node); ok {
+				visit(node)
+			}
+		}
+		walkAST(v.Elem(), visit)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				walkAST(v.Field(i), visit)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			walkAST(v.Index(i), visit)
+		}
+	default:
+	}
+}
+
+// scriptBudget counts the loop iterations and function calls of an instrumented script.
+type scriptBudget struct {
+	vm        *goja.Runtime
+	remaining int64
+}
+
+// consume is called by instrumented scripts and interrupts the runtime once the budget is exhausted.
+func (b *scriptBudget) consume(goja.FunctionCall) goja.Value {
+	b.remaining--
+	if b.remaining < 0 {
+		b.vm.Interrupt(errScriptBudgetExhausted)
+	}
+	return goja.Undefined()
+}
+
+// install defines the budget function as a non-writable, non-configurable global.
+func (b *scriptBudget) install() error {
+	return b.vm.GlobalObject().DefineDataProperty(budgetFunctionName, b.vm.ToValue(b.consume), goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE)
+}
+
+// disableDynamicCode removes the ways to evaluate code at runtime from the runtime.
+func disableDynamicCode(vm *goja.Runtime) error {
+	_, err := vm.RunString(dynamicCodeGuard)
+	return err
+}
@@ -0,0 +1,269 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// 2025
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+
+package steps
+
+import (
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // md5 is offered for compatibility checksums, not for security.
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // sha1 is offered for compatibility checksums, not for security.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"time"
+
+	"github.com/dop251/goja"
+	"sigs.k8s.io/yaml"
+)
+
+// maxRandomBytes bounds the size of crypto.randomBytes().
+const maxRandomBytes = 1024
+
+// hashFunctions lists the hash algorithms available to scripts.
+var hashFunctions = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// registerStdlib registers the standard library modules available to scripts.
+// All functions are pure, apart from crypto.randomBytes() and date.now(), and have no access
+// to the filesystem, the network or the cluster.
+func registerStdlib(vm *goja.Runtime, inputData map[string]interface{}) error {
+	modules := map[string]map[string]interface{}{
+		"base64": {
+			"encode":    func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+			"decode":    func(s string) (string, error) { b, err := base64.StdEncoding.DecodeString(s); return string(b), err },
+			"encodeURL": func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) },
+			"decodeURL": func(s string) (string, error) { b, err := base64.RawURLEncoding.DecodeString(s); return string(b), err },
+		},
+		"hex": {
+			"encode": func(s string) string { return hex.EncodeToString([]byte(s)) },
+			"decode": func(s string) (string, error) { b, err := hex.DecodeString(s); return string(b), err },
+		},
+		"crypto": {
+			"md5":         func(s string) string { return hashHex(md5.New, s) },
+			"sha1":        func(s string) string { return hashHex(sha1.New, s) },
+			"sha256":      func(s string) string { return hashHex(sha256.New, s) },
+			"sha512":      func(s string) string { return hashHex(sha512.New, s) },
+			"hmac":        jsHMAC,
+			"randomBytes": jsRandomBytes,
+		},
+		"yaml": {
+			"parse":     jsYAMLParse,
+			"stringify": jsYAMLStringify,
+		},
+		"date": {
+			"now":    func() string { return time.Now().UTC().Format(time.RFC3339) },
+			"parse":  jsDateParse,
+			"format": jsDateFormat,
+			"add":    jsDateAdd,
+			"diff":   jsDateDiff,
+		},
+	}
+
+	for name, functions := range modules {
+		module := vm.NewObject()
+		for fnName, fn := range functions {
+			if err := module.Set(fnName, fn); err != nil {
+				return fmt.Errorf("failed to set %s.%s: %w", name, fnName, err)
+			}
+		}
+		if err := defineFrozenGlobal(vm, name, module); err != nil {
+			return err
+		}
+	}
+
+	// Outputs of the previous steps are exposed as jobs.<job>.<step>.<output>.
+	var jobs interface{} = map[string]interface{}{}
+	if global, ok := inputData["global"].(map[string]interface{}); ok && global["jobs"] != nil {
+		jobs = global["jobs"]
+	}
+	jobsValue, err := toJSValue(vm, jobs)
+	if err != nil {
+		return fmt.Errorf("failed to convert outputs of previous steps: %w", err)
+	}
+	return defineFrozenGlobal(vm, "jobs", jobsValue)
+}
+
+// defineFrozenGlobal deep-freezes the value and defines it as a global. The global stays writable
+// and configurable, so scripts declaring a variable of the same name shadow it.
+func defineFrozenGlobal(vm *goja.Runtime, name string, value goja.Value) error {
+	freeze, ok := goja.AssertFunction(vm.Get("Object").ToObject(vm).Get("freeze"))
+	if !ok {
+		return fmt.Errorf("Object.freeze is not a function")
+	}
+	if err := deepFreeze(vm, freeze, value); err != nil {
+		return fmt.Errorf("failed to freeze %s: %w", name, err)
+	}
+	if err := vm.GlobalObject().DefineDataProperty(name, value, goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_TRUE); err != nil {
+		return fmt.Errorf("failed to set %s: %w", name, err)
+	}
+	return nil
+}
+
+func deepFreeze(vm *goja.Runtime, freeze goja.Callable, value goja.Value) error {
+	obj, ok := value.(*goja.Object)
+	if !ok {
+		return nil
+	}
+	for _, key := range obj.Keys() {
+		if err := deepFreeze(vm, freeze, obj.Get(key)); err != nil {
+			return err
+		}
+	}
+	_, err := freeze(goja.Undefined(), obj)
+	return err
+}
+
+// toJSValue converts a Go value into plain JavaScript objects, arrays and primitives.
+func toJSValue(vm *goja.Runtime, v interface{}) (goja.Value, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	parse, ok := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("parse"))
+	if !ok {
+		return nil, fmt.Errorf("JSON.parse is not a function")
+	}
+	return parse(goja.Undefined(), vm.ToValue(string(raw)))
+}
+
+func hashHex(newHash func() hash.Hash, data string) string {
+	h := newHash()
+	h.Write([]byte(data))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// jsHMAC implements crypto.hmac(algorithm, key, data) and returns the hex encoded MAC.
+func jsHMAC(algorithm, key, data string) (string, error) {
+	newHash, ok := hashFunctions[algorithm]
+	if !ok {
+		return "", fmt.Errorf("unsupported hmac algorithm %q", algorithm)
+	}
+	mac := hmac.New(newHash, []byte(key))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// jsRandomBytes implements crypto.randomBytes(n[, encoding]). The encoding is either hex (default) or base64.
+func jsRandomBytes(n int, encoding string) (string, error) {
+	if n < 1 || n > maxRandomBytes {
+		return "", fmt.Errorf("randomBytes() size must be between 1 and %d", maxRandomBytes)
+	}
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	switch encoding {
+	case "", "hex":
+		return hex.EncodeToString(b), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(b), nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q", encoding)
+	}
+}
+
+func jsYAMLParse(data string) (interface{}, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(data), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func jsYAMLStringify(v interface{}) (string, error) {
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// toTime converts a Date object or an RFC3339 string into a time.
+func toTime(v goja.Value) (time.Time, error) {
+	switch t := v.Export().(type) {
+	case time.Time:
+		return t.UTC(), nil
+	case string:
+		parsed, err := time.Parse(time.RFC3339, t)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return parsed.UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("value must be a date or an RFC3339 string")
+	}
+}
+
+// jsDateParse implements date.parse(value[, layout]) and returns an RFC3339 string.
+// The layout uses the Go reference time and defaults to RFC3339.
+func jsDateParse(value, layout string) (string, error) {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// jsDateFormat implements date.format(date, layout) with a Go reference time layout.
+func jsDateFormat(value goja.Value, layout string) (string, error) {
+	t, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+// jsDateAdd implements date.add(date, duration), e.g. date.add(date.now(), "-24h").
+func jsDateAdd(value goja.Value, duration string) (string, error) {
+	t, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return "", err
+	}
+	return t.Add(d).Format(time.RFC3339), nil
+}
+
+// jsDateDiff implements date.diff(a, b) and returns a - b in seconds.
+func jsDateDiff(a, b goja.Value) (float64, error) {
+	ta, err := toTime(a)
+	if err != nil {
+		return 0, err
+	}
+	tb, err := toTime(b)
+	if err != nil {
+		return 0, err
+	}
+	return ta.Sub(tb).Seconds(), nil
+}
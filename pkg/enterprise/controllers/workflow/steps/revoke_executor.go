@@ -0,0 +1,84 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// 2025
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+
+// Package steps provides workflow step executors.
+package steps
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workflows "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
+	"github.com/external-secrets/external-secrets/runtime/statemanager"
+)
+
+// RevokeStepExecutor revokes the credentials issued by the generator steps of a job.
+type RevokeStepExecutor struct {
+	Step   *workflows.RevokeStep
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+// NewRevokeStepExecutor creates a new revoke step executor.
+func NewRevokeStepExecutor(step *workflows.RevokeStep, c client.Client, scheme *runtime.Scheme) *RevokeStepExecutor {
+	return &RevokeStepExecutor{
+		Step:   step,
+		Client: c,
+		Scheme: scheme,
+	}
+}
+
+// Execute deletes the generator states created by the referenced job during the current run.
+// The GeneratorState controller then calls the generator's Cleanup through its finalizer.
+// States that are older than the run or already scheduled for garbage collection are left untouched.
+func (e *RevokeStepExecutor) Execute(ctx context.Context, _ client.Client, wf *workflows.Workflow, _ map[string]interface{}, _ string) (map[string]interface{}, error) {
+	if _, ok := wf.Spec.Jobs[e.Step.Job]; !ok {
+		return nil, fmt.Errorf("job %q does not exist", e.Step.Job)
+	}
+
+	statefulResource := getStatefulResource(ctx, e.Client, wf)
+	manager := statemanager.New(ctx, e.Client, e.Scheme, wf.Namespace, statefulResource)
+	states, err := manager.GetAllStates(generatorStateKey(statefulResource, wf, e.Step.Job))
+	if err != nil {
+		return nil, fmt.Errorf("error listing generator states of job %q: %w", e.Step.Job, err)
+	}
+
+	revoked := 0
+	for i := range states {
+		state := &states[i]
+		if state.Spec.GarbageCollectionDeadline != nil {
+			continue
+		}
+		if wf.Status.StartTime != nil && state.CreationTimestamp.Before(wf.Status.StartTime) {
+			continue
+		}
+		if err := e.Client.Delete(ctx, state); client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("error deleting generator state %s: %w", state.Name, err)
+		}
+		revoked++
+	}
+
+	return map[string]interface{}{
+		"revoked": revoked,
+	}, nil
+}
@@ -0,0 +1,90 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// 2025
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+
+package steps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workflows "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/runtime/statemanager"
+)
+
+func TestRevokeStepExecutor_Execute(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workflows.AddToScheme(scheme))
+	require.NoError(t, genv1alpha1.AddToScheme(scheme))
+
+	wf := &workflows.Workflow{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Workflow",
+			APIVersion: "workflows.external-secrets.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rotate",
+			Namespace: "default",
+			UID:       "1234",
+		},
+		Spec: workflows.WorkflowSpec{
+			Jobs: map[string]workflows.Job{
+				"generate": {Standard: &workflows.StandardJob{}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(wf).Build()
+	ctx := context.Background()
+
+	// One state issued by the run and one already flagged for garbage collection.
+	key := generatorStateKey(wf, wf, "generate")
+	manager := statemanager.New(ctx, c, scheme, wf.Namespace, wf)
+	state := &apiextensions.JSON{Raw: []byte(`{}`)}
+	manager.EnqueueCreateState(key, wf.Namespace, state, nil, state)
+	require.NoError(t, manager.Commit())
+	manager = statemanager.New(ctx, c, scheme, wf.Namespace, wf)
+	manager.EnqueueCreateState(key, wf.Namespace, state, nil, state)
+	require.NoError(t, manager.Commit())
+
+	t.Run("revokes the states issued by the job", func(t *testing.T) {
+		executor := NewRevokeStepExecutor(&workflows.RevokeStep{Job: "generate"}, c, scheme)
+		outputs, err := executor.Execute(ctx, c, wf, nil, "onCancel")
+		require.NoError(t, err)
+		assert.Equal(t, 1, outputs["revoked"])
+
+		var states genv1alpha1.GeneratorStateList
+		require.NoError(t, c.List(ctx, &states))
+		require.Len(t, states.Items, 1)
+		assert.NotNil(t, states.Items[0].Spec.GarbageCollectionDeadline)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		executor := NewRevokeStepExecutor(&workflows.RevokeStep{Job: "missing"}, c, scheme)
+		_, err := executor.Execute(ctx, c, wf, nil, "onCancel")
+		assert.ErrorContains(t, err, `job "missing" does not exist`)
+	})
+}
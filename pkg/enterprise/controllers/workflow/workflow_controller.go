@@ -72,6 +72,7 @@ type Reconciler struct {
 //+kubebuilder:rbac:groups=workflows.external-secrets.io,resources=workflowruns/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 // Reconcile is the main entrypoint for reconciliation.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -98,17 +99,29 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return r.markWorkflowFailed(ctx, wf, "ValidationError", err.Error())
 	}
 
-	// If the workflow is already in a terminal phase (Succeeded or Failed),
+	// If the workflow is already in a terminal phase (Succeeded, Failed or Cancelled),
 	// there is nothing to do.
-	if wf.Status.Phase == workflows.PhaseSucceeded || wf.Status.Phase == workflows.PhaseFailed {
+	if isTerminalPhase(wf.Status.Phase) {
 		return ctrl.Result{}, nil
 	}
 
+	// A cancellation request takes precedence over any other processing.
+	if wf.Spec.Cancel {
+		return r.cancelWorkflow(ctx, wf)
+	}
+
 	// If the workflow has not been initialized yet, initialize it.
 	if wf.Status.Phase == "" {
 		return r.initializeWorkflow(ctx, wf, log)
 	}
 
+	if wf.Spec.Suspend {
+		return r.suspendWorkflow(ctx, wf)
+	}
+	if wf.Status.Phase == workflows.PhaseSuspended {
+		return r.resumeWorkflow(ctx, wf)
+	}
+
 	// If the execution order is not yet calculated, do so now.
 	if wf.Status.ExecutionOrder == nil {
 		order, err := r.calculateExecutionOrder(wf.Spec.Jobs)
@@ -172,6 +185,15 @@ func (r *Reconciler) processJobs(ctx context.Context, wf *workflows.Workflow) (b
 		case workflows.JobPhaseRunning:
 			// Execute the job and handle errors.
 			if err := r.executeJob(ctx, wf, jobName, &jobStatus); err != nil {
+				wf.Status.JobStatuses[jobName] = jobStatus
+				if jobs.IsCancelled(err) {
+					res, cancelErr := r.cancelWorkflow(ctx, wf)
+					return false, res, cancelErr
+				}
+				if jobs.IsSuspended(err) {
+					res, suspendErr := r.suspendWorkflow(ctx, wf)
+					return false, res, suspendErr
+				}
 				res, markErr := r.markJobFailed(ctx, wf, jobName, err)
 				return false, res, markErr
 			}
@@ -205,6 +227,13 @@ func (r *Reconciler) initializeWorkflow(ctx context.Context, wf *workflows.Workf
 		}
 	}
 
+	if wf.Spec.RetryFrom != nil {
+		if err := r.seedFromRetriedRun(ctx, wf); err != nil {
+			log.Error(err, "failed to seed workflow from retried run")
+			return r.markWorkflowFailed(ctx, wf, "RetryFailed", err.Error())
+		}
+	}
+
 	return r.updateStatusWithEvent(ctx, wf,
 		ctrl.Result{}, ctrl.Result{Requeue: true},
 		"Normal", "WorkflowInitialized", fmt.Sprintf("Workflow %s initialized", wf.Name))
@@ -476,21 +505,27 @@ func (r *Reconciler) mergeSensitiveValues(ctx context.Context, wf *workflows.Wor
 		return client.IgnoreNotFound(err)
 	}
 
-	// No secrets to read
-	if len(workflowRun.Status.SensitiveValuesSecrets) == 0 {
-		return nil
+	sensitiveValues, err := r.readSensitiveValues(ctx, workflowRun)
+	if err != nil {
+		return err
 	}
 
-	// Read sensitive values from secrets
+	unmaskSensitiveValues(wf.Status.JobStatuses, sensitiveValues)
+	return nil
+}
+
+// readSensitiveValues reads the sensitive values referenced by a WorkflowRun status,
+// indexed by job name, step name and output key.
+func (r *Reconciler) readSensitiveValues(ctx context.Context, workflowRun *workflows.WorkflowRun) (map[string]map[string]map[string]string, error) {
 	sensitiveValues := make(map[string]map[string]map[string]string)
 
 	for _, secretName := range workflowRun.Status.SensitiveValuesSecrets {
 		secret := &corev1.Secret{}
-		if err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: wf.Namespace}, secret); err != nil {
+		if err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: workflowRun.Namespace}, secret); err != nil {
 			if errors.IsNotFound(err) {
 				continue // Skip if secret not found
 			}
-			return err
+			return nil, err
 		}
 
 		// Process secret data
@@ -516,9 +551,13 @@ func (r *Reconciler) mergeSensitiveValues(ctx context.Context, wf *workflows.Wor
 		}
 	}
 
-	// Merge sensitive values with workflow state
+	return sensitiveValues, nil
+}
+
+// unmaskSensitiveValues replaces masked step outputs with their sensitive values.
+func unmaskSensitiveValues(jobStatuses map[string]workflows.JobStatus, sensitiveValues map[string]map[string]map[string]string) {
 	for jobName, jobValues := range sensitiveValues {
-		jobStatus, exists := wf.Status.JobStatuses[jobName]
+		jobStatus, exists := jobStatuses[jobName]
 		if !exists {
 			continue
 		}
@@ -540,10 +579,8 @@ func (r *Reconciler) mergeSensitiveValues(ctx context.Context, wf *workflows.Wor
 			jobStatus.StepStatuses[stepName] = stepStatus
 		}
 
-		wf.Status.JobStatuses[jobName] = jobStatus
+		jobStatuses[jobName] = jobStatus
 	}
-
-	return nil
 }
 
 // markWorkflowCompleted marks the workflow as succeeded.
@@ -563,6 +600,135 @@ func (r *Reconciler) markWorkflowCompleted(ctx context.Context, wf *workflows.Wo
 		"Normal", "WorkflowCompleted", fmt.Sprintf("Workflow %s completed", wf.Name))
 }
 
+// suspendWorkflow marks the workflow as suspended. The running job is continued
+// from its first step that did not succeed once the workflow is resumed.
+func (r *Reconciler) suspendWorkflow(ctx context.Context, wf *workflows.Workflow) (ctrl.Result, error) {
+	if wf.Status.Phase == workflows.PhaseSuspended {
+		return ctrl.Result{}, nil
+	}
+	wf.Status.Phase = workflows.PhaseSuspended
+	meta.SetStatusCondition(&wf.Status.Conditions, metav1.Condition{
+		Type:    "Suspended",
+		Status:  metav1.ConditionTrue,
+		Reason:  "WorkflowSuspended",
+		Message: "Workflow execution is suspended",
+	})
+	return r.updateStatusWithEvent(ctx, wf,
+		ctrl.Result{}, ctrl.Result{},
+		"Normal", "WorkflowSuspended", fmt.Sprintf("Workflow %s suspended", wf.Name))
+}
+
+// resumeWorkflow continues the execution of a suspended workflow.
+func (r *Reconciler) resumeWorkflow(ctx context.Context, wf *workflows.Workflow) (ctrl.Result, error) {
+	wf.Status.Phase = workflows.PhaseRunning
+	meta.SetStatusCondition(&wf.Status.Conditions, metav1.Condition{
+		Type:    "Suspended",
+		Status:  metav1.ConditionFalse,
+		Reason:  "WorkflowResumed",
+		Message: "Workflow execution was resumed",
+	})
+	return r.updateStatusWithEvent(ctx, wf,
+		ctrl.Result{}, ctrl.Result{Requeue: true},
+		"Normal", "WorkflowResumed", fmt.Sprintf("Workflow %s resumed", wf.Name))
+}
+
+// cancelWorkflow marks all unfinished jobs as cancelled, executes the onCancel
+// steps and marks the workflow as cancelled. The cancelling phase is saved before
+// the onCancel steps run, so they are not executed again when a later update fails.
+// A failure of the onCancel steps is recorded in the status but does not prevent the cancellation.
+func (r *Reconciler) cancelWorkflow(ctx context.Context, wf *workflows.Workflow) (ctrl.Result, error) {
+	if wf.Status.Phase != workflows.PhaseCancelling {
+		now := metav1.Now()
+		for jobName, jobStatus := range wf.Status.JobStatuses {
+			if jobStatus.Phase != workflows.JobPhasePending && jobStatus.Phase != workflows.JobPhaseRunning {
+				continue
+			}
+			jobStatus.Phase = workflows.JobPhaseCancelled
+			jobStatus.CompletionTime = &now
+			if jobStatus.StartTime != nil {
+				jobStatus.ExecutionTimeNanos = ptr.Int64(now.Time.Sub(jobStatus.StartTime.Time).Nanoseconds())
+			}
+			wf.Status.JobStatuses[jobName] = jobStatus
+		}
+		wf.Status.Phase = workflows.PhaseCancelling
+		if len(wf.Spec.OnCancel) > 0 && wf.Status.OnCancelStatus == nil {
+			wf.Status.OnCancelStatus = &workflows.JobStatus{
+				Phase:        workflows.JobPhaseRunning,
+				StepStatuses: make(map[string]workflows.StepStatus),
+				StartTime:    &now,
+			}
+		}
+		if res, err := r.updateStatusWithEvent(ctx, wf,
+			ctrl.Result{}, ctrl.Result{},
+			"Normal", "WorkflowCancelling", fmt.Sprintf("Workflow %s is being cancelled", wf.Name)); err != nil {
+			return res, err
+		}
+	}
+
+	message := "Workflow was cancelled"
+	if len(wf.Spec.OnCancel) > 0 {
+		if err := r.executeOnCancel(ctx, wf); err != nil {
+			message = fmt.Sprintf("Workflow was cancelled, onCancel steps failed: %v", err)
+		}
+	}
+
+	now := metav1.Now()
+	wf.Status.Phase = workflows.PhaseCancelled
+	wf.Status.CompletionTime = &now
+	if wf.Status.StartTime != nil {
+		wf.Status.ExecutionTimeNanos = ptr.Int64(now.Time.Sub(wf.Status.StartTime.Time).Nanoseconds())
+	}
+	meta.SetStatusCondition(&wf.Status.Conditions, metav1.Condition{
+		Type:    "Cancelled",
+		Status:  metav1.ConditionTrue,
+		Reason:  "WorkflowCancelled",
+		Message: message,
+	})
+
+	return r.updateStatusWithEvent(ctx, wf,
+		ctrl.Result{}, ctrl.Result{},
+		"Warning", "WorkflowCancelled", fmt.Sprintf("Workflow %s: %s", wf.Name, message))
+}
+
+// executeOnCancel executes the onCancel steps of the workflow that did not succeed yet,
+// saving their status after each step.
+func (r *Reconciler) executeOnCancel(ctx context.Context, wf *workflows.Workflow) error {
+	onCancelStatus := wf.Status.OnCancelStatus
+	if onCancelStatus == nil {
+		now := metav1.Now()
+		onCancelStatus = &workflows.JobStatus{
+			Phase:     workflows.JobPhaseRunning,
+			StartTime: &now,
+		}
+		wf.Status.OnCancelStatus = onCancelStatus
+	}
+	if onCancelStatus.StepStatuses == nil {
+		onCancelStatus.StepStatuses = make(map[string]workflows.StepStatus)
+	}
+	saveStatus := func() error {
+		_, err := r.updateStatusWithEvent(ctx, wf,
+			ctrl.Result{}, ctrl.Result{},
+			"Normal", "OnCancelStepCompleted", fmt.Sprintf("Workflow %s: onCancel step completed", wf.Name))
+		return err
+	}
+
+	err := jobs.ExecuteOnCancelSteps(ctx, r.Client, wf, onCancelStatus, r.Scheme, r.Log, r.Manager, saveStatus)
+	if err != nil {
+		completed := metav1.Now()
+		onCancelStatus.Phase = workflows.JobPhaseFailed
+		onCancelStatus.CompletionTime = &completed
+		if onCancelStatus.StartTime != nil {
+			onCancelStatus.ExecutionTimeNanos = ptr.Int64(completed.Time.Sub(onCancelStatus.StartTime.Time).Nanoseconds())
+		}
+	}
+	return err
+}
+
+// isTerminalPhase returns true if the workflow will not be processed any further.
+func isTerminalPhase(phase workflows.Phase) bool {
+	return phase == workflows.PhaseSucceeded || phase == workflows.PhaseFailed || phase == workflows.PhaseCancelled
+}
+
 // markJobFailed marks a job as failed and records an event.
 func (r *Reconciler) markJobFailed(ctx context.Context, wf *workflows.Workflow, jobName string, err error) (ctrl.Result, error) {
 	jobStatus := wf.Status.JobStatuses[jobName]
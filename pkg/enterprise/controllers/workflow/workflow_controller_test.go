@@ -26,17 +26,20 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	workflows "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/controllers/workflow/common"
 )
 
 // (For testing purposes, ensure that your v1alpha1 package registers the Workflow type into the scheme.)
@@ -388,3 +391,408 @@ func TestInitializeWorkflow(t *testing.T) {
 		t.Errorf("expected 2 job statuses, got %d", len(wf.Status.JobStatuses))
 	}
 }
+
+//
+// Suspension and cancellation tests
+//
+
+func TestReconcileCancelledWorkflow(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := addToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+
+	now := metav1.Now()
+	wf := &workflows.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cancelwf",
+			Namespace: "default",
+		},
+		Spec: workflows.WorkflowSpec{
+			Version: "v1",
+			Name:    "cancelwf",
+			Cancel:  true,
+			Jobs: map[string]workflows.Job{
+				"job1": {
+					Standard: &workflows.StandardJob{
+						Steps: []workflows.Step{{Name: "step1", Debug: &workflows.DebugStep{Message: "test"}}},
+					},
+				},
+			},
+			OnCancel: []workflows.CancelStep{{Name: "cleanup", Debug: &workflows.DebugStep{Message: "cleaning up"}}},
+		},
+		Status: workflows.WorkflowStatus{
+			Phase:          workflows.PhaseRunning,
+			StartTime:      &now,
+			ExecutionOrder: []string{"job1"},
+			JobStatuses: map[string]workflows.JobStatus{
+				"job1": {Phase: workflows.JobPhaseRunning, StepStatuses: map[string]workflows.StepStatus{}},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(wf).
+		WithStatusSubresource(&workflows.Workflow{}).
+		Build()
+
+	r := &Reconciler{
+		Client:   fakeClient,
+		Log:      logr.Discard(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+		Manager:  secretstore.NewManager(fakeClient, "", false),
+	}
+
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "cancelwf", Namespace: "default"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Requeue || res.RequeueAfter != 0 {
+		t.Errorf("expected no requeue, got: %+v", res)
+	}
+
+	updatedWf := &workflows.Workflow{}
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "cancelwf", Namespace: "default"}, updatedWf); err != nil {
+		t.Fatalf("failed to get updated workflow: %v", err)
+	}
+	if updatedWf.Status.Phase != workflows.PhaseCancelled {
+		t.Errorf("expected phase %q, got %q", workflows.PhaseCancelled, updatedWf.Status.Phase)
+	}
+	if phase := updatedWf.Status.JobStatuses["job1"].Phase; phase != workflows.JobPhaseCancelled {
+		t.Errorf("expected job phase %q, got %q", workflows.JobPhaseCancelled, phase)
+	}
+	if updatedWf.Status.OnCancelStatus == nil || updatedWf.Status.OnCancelStatus.Phase != workflows.JobPhaseSucceeded {
+		t.Errorf("expected onCancel steps to succeed, got: %+v", updatedWf.Status.OnCancelStatus)
+	}
+	if updatedWf.Status.OnCancelStatus != nil && updatedWf.Status.OnCancelStatus.StepStatuses["cleanup"].Phase != workflows.StepPhaseSucceeded {
+		t.Errorf("expected cleanup step to succeed, got: %+v", updatedWf.Status.OnCancelStatus.StepStatuses)
+	}
+	if cond := meta.FindStatusCondition(updatedWf.Status.Conditions, "Cancelled"); cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected Cancelled condition, got: %v", updatedWf.Status.Conditions)
+	}
+}
+
+func TestReconcileCancellingWorkflowSkipsSucceededOnCancelSteps(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := addToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+
+	now := metav1.Now()
+	wf := &workflows.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cancellingwf",
+			Namespace: "default",
+		},
+		Spec: workflows.WorkflowSpec{
+			Version: "v1",
+			Name:    "cancellingwf",
+			Cancel:  true,
+			Jobs: map[string]workflows.Job{
+				"job1": {
+					Standard: &workflows.StandardJob{
+						Steps: []workflows.Step{{Name: "step1", Debug: &workflows.DebugStep{Message: "test"}}},
+					},
+				},
+			},
+			OnCancel: []workflows.CancelStep{
+				{Name: "revoke", JavaScript: &workflows.JavaScriptStep{Script: "throw new Error('executed again')"}},
+				{Name: "notify", Debug: &workflows.DebugStep{Message: "cancelled"}},
+			},
+		},
+		// The cancelling phase and the first onCancel step were saved before the previous reconcile stopped.
+		Status: workflows.WorkflowStatus{
+			Phase:          workflows.PhaseCancelling,
+			StartTime:      &now,
+			ExecutionOrder: []string{"job1"},
+			JobStatuses: map[string]workflows.JobStatus{
+				"job1": {Phase: workflows.JobPhaseCancelled, StepStatuses: map[string]workflows.StepStatus{}},
+			},
+			OnCancelStatus: &workflows.JobStatus{
+				Phase:     workflows.JobPhaseRunning,
+				StartTime: &now,
+				StepStatuses: map[string]workflows.StepStatus{
+					"revoke": {Phase: workflows.StepPhaseSucceeded},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(wf).
+		WithStatusSubresource(&workflows.Workflow{}).
+		Build()
+
+	r := &Reconciler{
+		Client:   fakeClient,
+		Log:      logr.Discard(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+		Manager:  secretstore.NewManager(fakeClient, "", false),
+	}
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "cancellingwf", Namespace: "default"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updatedWf := &workflows.Workflow{}
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "cancellingwf", Namespace: "default"}, updatedWf); err != nil {
+		t.Fatalf("failed to get updated workflow: %v", err)
+	}
+	if updatedWf.Status.Phase != workflows.PhaseCancelled {
+		t.Errorf("expected phase %q, got %q", workflows.PhaseCancelled, updatedWf.Status.Phase)
+	}
+	if cond := meta.FindStatusCondition(updatedWf.Status.Conditions, "Cancelled"); cond == nil || cond.Message != "Workflow was cancelled" {
+		t.Errorf("expected the succeeded onCancel step to be skipped, got: %v", updatedWf.Status.Conditions)
+	}
+	if phase := updatedWf.Status.OnCancelStatus.StepStatuses["notify"].Phase; phase != workflows.StepPhaseSucceeded {
+		t.Errorf("expected notify step to succeed, got %q", phase)
+	}
+}
+
+func TestReconcileSuspendAndResumeWorkflow(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := addToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+
+	now := metav1.Now()
+	wf := &workflows.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "suspendwf",
+			Namespace: "default",
+		},
+		Spec: workflows.WorkflowSpec{
+			Version: "v1",
+			Name:    "suspendwf",
+			Suspend: true,
+			Jobs: map[string]workflows.Job{
+				"job1": {
+					Standard: &workflows.StandardJob{
+						Steps: []workflows.Step{
+							{Name: "step1", Debug: &workflows.DebugStep{Message: "first"}},
+							{Name: "step2", Debug: &workflows.DebugStep{Message: "second"}},
+						},
+					},
+				},
+			},
+		},
+		Status: workflows.WorkflowStatus{
+			Phase:          workflows.PhaseRunning,
+			StartTime:      &now,
+			ExecutionOrder: []string{"job1"},
+			JobStatuses: map[string]workflows.JobStatus{
+				"job1": {
+					Phase: workflows.JobPhaseRunning,
+					StepStatuses: map[string]workflows.StepStatus{
+						"step1": {Phase: workflows.StepPhaseSucceeded, Outputs: map[string]string{"message": "first"}},
+					},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(wf).
+		WithStatusSubresource(&workflows.Workflow{}).
+		Build()
+
+	r := &Reconciler{
+		Client:   fakeClient,
+		Log:      logr.Discard(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+		Manager:  secretstore.NewManager(fakeClient, "", false),
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "suspendwf", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	suspended := &workflows.Workflow{}
+	if err := fakeClient.Get(ctx, key, suspended); err != nil {
+		t.Fatalf("failed to get workflow: %v", err)
+	}
+	if suspended.Status.Phase != workflows.PhaseSuspended {
+		t.Fatalf("expected phase %q, got %q", workflows.PhaseSuspended, suspended.Status.Phase)
+	}
+	if _, ok := suspended.Status.JobStatuses["job1"].StepStatuses["step2"]; ok {
+		t.Errorf("expected step2 not to run while suspended")
+	}
+
+	// Resume the workflow and let it finish.
+	suspended.Spec.Suspend = false
+	if err := fakeClient.Update(ctx, suspended); err != nil {
+		t.Fatalf("failed to resume workflow: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	resumed := &workflows.Workflow{}
+	if err := fakeClient.Get(ctx, key, resumed); err != nil {
+		t.Fatalf("failed to get workflow: %v", err)
+	}
+	if resumed.Status.Phase != workflows.PhaseSucceeded {
+		t.Fatalf("expected phase %q, got %q", workflows.PhaseSucceeded, resumed.Status.Phase)
+	}
+	if phase := resumed.Status.JobStatuses["job1"].StepStatuses["step2"].Phase; phase != workflows.StepPhaseSucceeded {
+		t.Errorf("expected step2 to succeed after resume, got %q", phase)
+	}
+	if cond := meta.FindStatusCondition(resumed.Status.Conditions, "Suspended"); cond == nil || cond.Status != metav1.ConditionFalse {
+		t.Errorf("expected Suspended condition to be false, got: %v", resumed.Status.Conditions)
+	}
+}
+
+func TestReconcileRetriedWorkflow(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := addToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+
+	jobs := map[string]workflows.Job{
+		"job1": {
+			Standard: &workflows.StandardJob{
+				Steps: []workflows.Step{{
+					Name:    "issue",
+					Debug:   &workflows.DebugStep{Message: "issue"},
+					Outputs: []workflows.OutputDefinition{{Name: "token", Type: workflows.OutputTypeString, Sensitive: true}},
+				}},
+			},
+		},
+		"job2": {
+			DependsOn: []string{"job1"},
+			Standard: &workflows.StandardJob{
+				Steps: []workflows.Step{
+					{Name: "prepare", Debug: &workflows.DebugStep{Message: "prepare"}},
+					{Name: "apply", Debug: &workflows.DebugStep{Message: "apply"}},
+				},
+			},
+		},
+	}
+
+	failedRun := &workflows.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "failed-run", Namespace: "default"},
+		Status: workflows.WorkflowRunStatus{
+			Phase:                  workflows.PhaseFailed,
+			WorkflowRef:            &workflows.WorkflowRef{Name: "failed-wf", Namespace: "default"},
+			SensitiveValuesSecrets: []string{"failed-run-sensitive"},
+		},
+	}
+	sensitiveSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "failed-run-sensitive", Namespace: "default"},
+		Data: map[string][]byte{
+			"job1.issue.token": []byte("s3cr3t"),
+		},
+	}
+	failedWf := &workflows.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "failed-wf", Namespace: "default"},
+		Spec:       workflows.WorkflowSpec{Version: "v1", Name: "failed-wf", Jobs: jobs},
+		Status: workflows.WorkflowStatus{
+			Phase: workflows.PhaseFailed,
+			JobStatuses: map[string]workflows.JobStatus{
+				"job1": {
+					Phase: workflows.JobPhaseSucceeded,
+					StepStatuses: map[string]workflows.StepStatus{
+						"issue": {Phase: workflows.StepPhaseSucceeded, Outputs: map[string]string{"token": common.MaskValue}},
+					},
+				},
+				"job2": {
+					Phase: workflows.JobPhaseFailed,
+					StepStatuses: map[string]workflows.StepStatus{
+						"prepare": {Phase: workflows.StepPhaseSucceeded, Outputs: map[string]string{"message": "prepare"}},
+						"apply":   {Phase: workflows.StepPhaseFailed, Message: "boom"},
+					},
+				},
+			},
+		},
+	}
+
+	retryRun := &workflows.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "retry-run", Namespace: "default", UID: "retry-run-uid"},
+		Spec:       workflows.WorkflowRunSpec{RetryFrom: &workflows.RetryFromRef{Name: "failed-run"}},
+	}
+	retryWf := &workflows.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "retry-wf",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: workflows.SchemeGroupVersion.String(),
+				Kind:       "WorkflowRun",
+				Name:       "retry-run",
+				UID:        "retry-run-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: workflows.WorkflowSpec{
+			Version:   "v1",
+			Name:      "retry-wf",
+			Jobs:      jobs,
+			RetryFrom: &workflows.RetryFromRef{Name: "failed-run"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(failedRun, sensitiveSecret, failedWf, retryRun, retryWf).
+		WithStatusSubresource(&workflows.Workflow{}, &workflows.WorkflowRun{}).
+		Build()
+
+	r := &Reconciler{
+		Client:   fakeClient,
+		Log:      logr.Discard(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+		Manager:  secretstore.NewManager(fakeClient, "", false),
+	}
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "retry-wf", Namespace: "default"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updatedWf := &workflows.Workflow{}
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "retry-wf", Namespace: "default"}, updatedWf); err != nil {
+		t.Fatalf("failed to get updated workflow: %v", err)
+	}
+	if updatedWf.Status.Phase != workflows.PhasePending {
+		t.Errorf("expected phase %q, got %q", workflows.PhasePending, updatedWf.Status.Phase)
+	}
+
+	job1 := updatedWf.Status.JobStatuses["job1"]
+	if job1.Phase != workflows.JobPhaseSucceeded {
+		t.Errorf("expected job1 to be copied as succeeded, got %q", job1.Phase)
+	}
+	if token := job1.StepStatuses["issue"].Outputs["token"]; token != common.MaskValue {
+		t.Errorf("expected sensitive output to stay masked in status, got %q", token)
+	}
+
+	job2 := updatedWf.Status.JobStatuses["job2"]
+	if job2.Phase != workflows.JobPhasePending {
+		t.Errorf("expected job2 to restart, got %q", job2.Phase)
+	}
+	if phase := job2.StepStatuses["prepare"].Phase; phase != workflows.StepPhaseSucceeded {
+		t.Errorf("expected succeeded step to be copied, got %q", phase)
+	}
+	if _, ok := job2.StepStatuses["apply"]; ok {
+		t.Errorf("expected failed step not to be copied, got %+v", job2.StepStatuses["apply"])
+	}
+
+	updatedRun := &workflows.WorkflowRun{}
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "retry-run", Namespace: "default"}, updatedRun); err != nil {
+		t.Fatalf("failed to get retry run: %v", err)
+	}
+	values, err := r.readSensitiveValues(context.Background(), updatedRun)
+	if err != nil {
+		t.Fatalf("failed to read sensitive values: %v", err)
+	}
+	if token := values["job1"]["issue"]["token"]; token != "s3cr3t" {
+		t.Errorf("expected sensitive value to be stored for the retry run, got %q", token)
+	}
+}
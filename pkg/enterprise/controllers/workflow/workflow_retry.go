@@ -0,0 +1,92 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// 2025
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	workflows "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
+)
+
+// seedFromRetriedRun copies the succeeded jobs and steps of the run referenced by
+// spec.retryFrom into the workflow status. Masked outputs are restored from the
+// sensitive values secrets of the retried run, so they are available to the remaining
+// steps and stored again for the new run. Jobs that did not succeed are left pending
+// and restart at their first step that did not succeed; loop jobs restart from their
+// first iteration.
+func (r *Reconciler) seedFromRetriedRun(ctx context.Context, wf *workflows.Workflow) error {
+	retriedRun := &workflows.WorkflowRun{}
+	if err := r.Get(ctx, types.NamespacedName{Name: wf.Spec.RetryFrom.Name, Namespace: wf.Namespace}, retriedRun); err != nil {
+		return fmt.Errorf("failed to get retried WorkflowRun %s: %w", wf.Spec.RetryFrom.Name, err)
+	}
+	if retriedRun.Status.WorkflowRef == nil {
+		return fmt.Errorf("retried WorkflowRun %s has no workflow", retriedRun.Name)
+	}
+
+	retriedWorkflow := &workflows.Workflow{}
+	if err := r.Get(ctx, types.NamespacedName{Name: retriedRun.Status.WorkflowRef.Name, Namespace: retriedRun.Status.WorkflowRef.Namespace}, retriedWorkflow); err != nil {
+		return fmt.Errorf("failed to get workflow of retried WorkflowRun %s: %w", retriedRun.Name, err)
+	}
+	if retriedWorkflow.Status.Phase != workflows.PhaseFailed && retriedWorkflow.Status.Phase != workflows.PhaseCancelled {
+		return fmt.Errorf("retried WorkflowRun %s is in phase %q, only failed or cancelled runs can be retried", retriedRun.Name, retriedWorkflow.Status.Phase)
+	}
+
+	sensitiveValues, err := r.readSensitiveValues(ctx, retriedRun)
+	if err != nil {
+		return fmt.Errorf("failed to read sensitive values of retried WorkflowRun %s: %w", retriedRun.Name, err)
+	}
+
+	seeded := make(map[string]workflows.JobStatus)
+	for jobName, job := range wf.Spec.Jobs {
+		previous, ok := retriedWorkflow.Status.JobStatuses[jobName]
+		if !ok {
+			continue
+		}
+
+		if previous.Phase == workflows.JobPhaseSucceeded {
+			seeded[jobName] = *previous.DeepCopy()
+			continue
+		}
+
+		// Loop jobs do not track their iterations, so they always restart.
+		if job.Loop != nil {
+			continue
+		}
+
+		jobStatus := wf.Status.JobStatuses[jobName]
+		for stepName, stepStatus := range previous.StepStatuses {
+			if stepStatus.Phase == workflows.StepPhaseSucceeded {
+				jobStatus.StepStatuses[stepName] = *stepStatus.DeepCopy()
+			}
+		}
+		seeded[jobName] = jobStatus
+	}
+
+	unmaskSensitiveValues(seeded, sensitiveValues)
+	for jobName, jobStatus := range seeded {
+		wf.Status.JobStatuses[jobName] = jobStatus
+	}
+
+	return nil
+}
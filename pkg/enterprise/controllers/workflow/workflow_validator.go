@@ -129,6 +129,21 @@ func validateWorkflowSpec(wf *workflows.Workflow) error {
 		}
 	}
 
+	// 4. Validate the onCancel steps.
+	seenOnCancelSteps := make(map[string]bool)
+	for _, step := range wf.Spec.OnCancel {
+		if seenOnCancelSteps[step.Name] {
+			return fmt.Errorf("onCancel has duplicate step name %q", step.Name)
+		}
+		seenOnCancelSteps[step.Name] = true
+
+		if step.Revoke != nil {
+			if _, exists := wf.Spec.Jobs[step.Revoke.Job]; !exists {
+				return fmt.Errorf("onCancel step %q: revokes job %q which does not exist", step.Name, step.Revoke.Job)
+			}
+		}
+	}
+
 	return nil
 }
 
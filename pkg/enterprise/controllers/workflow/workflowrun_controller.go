@@ -72,6 +72,11 @@ func (r *RunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		return r.checkWorkflowStatus(ctx, run)
 	}
 
+	// A run cancelled before its workflow was created has nothing to interrupt.
+	if run.Spec.Cancel {
+		return r.markCancelledBeforeStart(ctx, run)
+	}
+
 	// Fetch the template
 	template := &workflows.WorkflowTemplate{}
 	templateNamespace := run.Spec.TemplateRef.Namespace
@@ -193,6 +198,12 @@ func (r *RunReconciler) checkWorkflowStatus(ctx context.Context, run *workflows.
 		return ctrl.Result{}, err
 	}
 
+	// Propagate suspension and cancellation requests to the workflow
+	if err := r.syncExecutionControl(ctx, run, workflow); err != nil {
+		log.Error(err, "unable to propagate suspend and cancel to Workflow")
+		return ctrl.Result{}, err
+	}
+
 	// Check if the workflow status has changed
 	statusChanged := false
 
@@ -290,6 +301,25 @@ func (r *RunReconciler) checkWorkflowStatus(ctx context.Context, run *workflows.
 			})
 			statusChanged = true
 		}
+	case workflows.PhaseCancelled:
+		// Check if Cancelled condition already exists
+		cancelledExists := false
+		for _, runCond := range run.Status.Conditions {
+			if runCond.Type == "Cancelled" && runCond.Status == metav1.ConditionTrue {
+				cancelledExists = true
+				break
+			}
+		}
+		if !cancelledExists {
+			run.Status.Conditions = append(run.Status.Conditions, metav1.Condition{
+				Type:               "Cancelled",
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+				Reason:             "WorkflowCancelled",
+				Message:            fmt.Sprintf("Workflow %s was cancelled", workflow.Name),
+			})
+			statusChanged = true
+		}
 	case workflows.PhaseRunning:
 		// Check if Running condition already exists
 		runningExists := false
@@ -322,6 +352,60 @@ func (r *RunReconciler) checkWorkflowStatus(ctx context.Context, run *workflows.
 	return ctrl.Result{RequeueAfter: time.Second * 30}, nil
 }
 
+// syncExecutionControl copies the suspend and cancel fields of the run to its workflow.
+// Workflows that already reached a terminal phase are left untouched.
+func (r *RunReconciler) syncExecutionControl(ctx context.Context, run *workflows.WorkflowRun, workflow *workflows.Workflow) error {
+	if isTerminalPhase(workflow.Status.Phase) {
+		return nil
+	}
+	if workflow.Spec.Suspend == run.Spec.Suspend && workflow.Spec.Cancel == run.Spec.Cancel {
+		return nil
+	}
+
+	workflow.Spec.Suspend = run.Spec.Suspend
+	workflow.Spec.Cancel = run.Spec.Cancel
+	if err := r.Update(ctx, workflow); err != nil {
+		return err
+	}
+
+	switch {
+	case run.Spec.Cancel:
+		r.Recorder.Event(run, corev1.EventTypeNormal, "CancelRequested",
+			fmt.Sprintf("Requested cancellation of workflow %s", workflow.Name))
+	case run.Spec.Suspend:
+		r.Recorder.Event(run, corev1.EventTypeNormal, "SuspendRequested",
+			fmt.Sprintf("Requested suspension of workflow %s", workflow.Name))
+	default:
+		r.Recorder.Event(run, corev1.EventTypeNormal, "ResumeRequested",
+			fmt.Sprintf("Requested resumption of workflow %s", workflow.Name))
+	}
+	return nil
+}
+
+// markCancelledBeforeStart marks a run that was cancelled before its workflow was created.
+func (r *RunReconciler) markCancelledBeforeStart(ctx context.Context, run *workflows.WorkflowRun) (ctrl.Result, error) {
+	if run.Status.Phase == workflows.PhaseCancelled {
+		return ctrl.Result{}, nil
+	}
+
+	now := metav1.Now()
+	run.Status.Phase = workflows.PhaseCancelled
+	run.Status.CompletionTime = &now
+	run.Status.Conditions = append(run.Status.Conditions, metav1.Condition{
+		Type:               "Cancelled",
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: now,
+		Reason:             "CancelledBeforeStart",
+		Message:            "WorkflowRun was cancelled before its workflow was created",
+	})
+	if err := r.Status().Update(ctx, run); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.Recorder.Event(run, corev1.EventTypeNormal, "WorkflowCancelled", "WorkflowRun was cancelled before its workflow was created")
+	return ctrl.Result{}, nil
+}
+
 // resolveWorkflowFromTemplate creates a new Workflow from a WorkflowTemplate and WorkflowRun.
 func (r *RunReconciler) resolveWorkflowFromTemplate(ctx context.Context, template *workflows.WorkflowTemplate, run *workflows.WorkflowRun) (*workflows.Workflow, error) {
 	// Create a new workflow
@@ -339,6 +423,9 @@ func (r *RunReconciler) resolveWorkflowFromTemplate(ctx context.Context, templat
 			Name:      template.Spec.Name,
 			Variables: apiextensionsv1.JSON{},
 			Jobs:      template.Spec.Jobs,
+			OnCancel:  template.Spec.OnCancel,
+			Suspend:   run.Spec.Suspend,
+			RetryFrom: run.Spec.RetryFrom.DeepCopy(),
 		},
 	}
 	runLabels := run.GetLabels()
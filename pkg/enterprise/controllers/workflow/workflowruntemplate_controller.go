@@ -33,6 +33,7 @@ import (
 	cronV3 "github.com/robfig/cron/v3"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -45,6 +46,9 @@ import (
 
 var mu = sync.Mutex{}
 
+// maxMissedSchedules bounds the number of missed cron schedules walked to find the most recent one.
+const maxMissedSchedules = 100
+
 // RunTemplateReconciler reconciles a WorkflowRunTemplate object.
 type RunTemplateReconciler struct {
 	client.Client
@@ -79,7 +83,11 @@ func (r *RunTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				if err != nil {
 					r.Log.Error(err, "Failed to Get Children for WorkflowRunTemplate")
 				}
-				if err := r.updateWorkflowRunTemplate(ctx, run, runs, run.Status.LastRunTime.Time); err != nil {
+				lastRunTime := time.Time{}
+				if run.Status.LastRunTime != nil {
+					lastRunTime = run.Status.LastRunTime.Time
+				}
+				if err := r.updateWorkflowRunTemplate(ctx, run, runs, lastRunTime); err != nil {
 					r.Log.Error(err, "Failed to update WorkflowRunTemplate", "namespace", run.Namespace, "name", run.Name)
 				}
 			}()
@@ -104,6 +112,10 @@ func (r *RunTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 	// Check if this reconcile is a fake one (due to this being a change on the owned WorkflowRun being processed)
 	for _, workflowRun := range workflowRuns {
+		// Finished runs are not reconciled anymore. Runs cancelled before they started never get a WorkflowRef.
+		if isTerminalPhase(workflowRun.Status.Phase) {
+			continue
+		}
 		if workflowRun.Status.WorkflowRef == nil {
 			// False alarm
 			return r.requeueAfter(run)
@@ -114,13 +126,39 @@ func (r *RunTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
-	// Revision History Limit Logic
-	if len(workflowRuns) >= run.Spec.RevisionHistoryLimit {
-		workflowRuns, err = r.cleanup(ctx, workflowRuns, run.Spec.RevisionHistoryLimit)
-		if err != nil {
-			return ctrl.Result{}, err
+	// Skip scheduled runs that could not be started within their starting deadline.
+	now := time.Now()
+	if scheduledTime, ok := r.lastScheduleTime(run, now); ok && run.Spec.StartingDeadlineSeconds != nil {
+		deadline := scheduledTime.Add(time.Duration(*run.Spec.StartingDeadlineSeconds) * time.Second)
+		if now.After(deadline) {
+			r.Recorder.Eventf(run, corev1.EventTypeWarning, "MissedSchedule", "Skipped run scheduled at %s: starting deadline exceeded", scheduledTime.Format(time.RFC3339))
+			if err := r.updateWorkflowRunTemplate(ctx, run, workflowRuns, scheduledTime); err != nil {
+				return ctrl.Result{}, err
+			}
+			return r.requeueAfter(run)
+		}
+	}
+
+	// Concurrency Policy Logic
+	if activeRuns := filterActiveRuns(workflowRuns); len(activeRuns) > 0 {
+		switch run.Spec.ConcurrencyPolicy {
+		case workflows.ForbidConcurrent:
+			// The owned WorkflowRuns trigger a new reconcile once they finish.
+			r.Recorder.Eventf(run, corev1.EventTypeNormal, "RunSkipped", "Not creating a new run: %d runs are still active", len(activeRuns))
+			return ctrl.Result{}, nil
+		case workflows.ReplaceConcurrent:
+			if err := r.cancelRuns(ctx, activeRuns); err != nil {
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(run, corev1.EventTypeNormal, "RunsReplaced", "Cancelled %d active runs", len(activeRuns))
 		}
 	}
+
+	// History Limit Logic
+	workflowRuns, err = r.applyHistoryLimits(ctx, run, workflowRuns)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 	// Create new WorkflowRun
 	newRun, err := r.createWorkflowRun(ctx, run, revision)
 	if err != nil {
@@ -172,6 +210,89 @@ func (r *RunTemplateReconciler) cleanup(ctx context.Context, workflowRuns []work
 	return remain, nil
 }
 
+// applyHistoryLimits deletes the runs exceeding the history limits of the template before a new run is created.
+// The successful and failed runs history limits replace the revision history limit when either is set.
+func (r *RunTemplateReconciler) applyHistoryLimits(ctx context.Context, run *workflows.WorkflowRunTemplate, workflowRuns []workflows.WorkflowRun) ([]workflows.WorkflowRun, error) {
+	if run.Spec.SuccessfulRunsHistoryLimit != nil || run.Spec.FailedRunsHistoryLimit != nil {
+		return r.cleanupFinishedRuns(ctx, run, workflowRuns)
+	}
+	if len(workflowRuns) >= run.Spec.RevisionHistoryLimit {
+		return r.cleanup(ctx, workflowRuns, run.Spec.RevisionHistoryLimit)
+	}
+	return workflowRuns, nil
+}
+
+// cleanupFinishedRuns deletes the oldest succeeded and failed runs exceeding the history limits of the template.
+// Active runs are never deleted.
+func (r *RunTemplateReconciler) cleanupFinishedRuns(ctx context.Context, run *workflows.WorkflowRunTemplate, workflowRuns []workflows.WorkflowRun) ([]workflows.WorkflowRun, error) {
+	var succeeded, failed, remaining []workflows.WorkflowRun
+	for _, workflowRun := range workflowRuns {
+		switch workflowRun.Status.Phase {
+		case workflows.PhaseSucceeded:
+			succeeded = append(succeeded, workflowRun)
+		case workflows.PhaseFailed, workflows.PhaseCancelled:
+			failed = append(failed, workflowRun)
+		default:
+			remaining = append(remaining, workflowRun)
+		}
+	}
+
+	kept, err := r.cleanupHistory(ctx, succeeded, run.Spec.SuccessfulRunsHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	remaining = append(remaining, kept...)
+
+	kept, err = r.cleanupHistory(ctx, failed, run.Spec.FailedRunsHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	return append(remaining, kept...), nil
+}
+
+func (r *RunTemplateReconciler) cleanupHistory(ctx context.Context, workflowRuns []workflows.WorkflowRun, limit *int32) ([]workflows.WorkflowRun, error) {
+	if limit == nil || len(workflowRuns) <= int(*limit) {
+		return workflowRuns, nil
+	}
+	remain, toDelete, err := sortWorkflowsByRevision(workflowRuns, len(workflowRuns)-int(*limit))
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range toDelete {
+		if err := r.Delete(ctx, &d); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+	}
+	return remain, nil
+}
+
+// filterActiveRuns returns the runs that did not reach a terminal phase yet.
+func filterActiveRuns(workflowRuns []workflows.WorkflowRun) []workflows.WorkflowRun {
+	active := []workflows.WorkflowRun{}
+	for _, workflowRun := range workflowRuns {
+		if !isTerminalPhase(workflowRun.Status.Phase) {
+			active = append(active, workflowRun)
+		}
+	}
+	return active
+}
+
+// cancelRuns requests the cancellation of the given runs.
+func (r *RunTemplateReconciler) cancelRuns(ctx context.Context, workflowRuns []workflows.WorkflowRun) error {
+	for i := range workflowRuns {
+		workflowRun := &workflowRuns[i]
+		if workflowRun.Spec.Cancel {
+			continue
+		}
+		patch := client.MergeFrom(workflowRun.DeepCopy())
+		workflowRun.Spec.Cancel = true
+		if err := r.Patch(ctx, workflowRun, patch); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("could not cancel workflow run %s: %w", workflowRun.Name, err)
+		}
+	}
+	return nil
+}
+
 func sortWorkflowsByRevision(workflowRuns []workflows.WorkflowRun, totalToDelete int) ([]workflows.WorkflowRun, []workflows.WorkflowRun, error) {
 	remaining := []workflows.WorkflowRun{}
 	del := []workflows.WorkflowRun{}
@@ -220,7 +341,20 @@ func (r *RunTemplateReconciler) generateRevision(workflowRuns []workflows.Workfl
 }
 
 func (r *RunTemplateReconciler) createWorkflowRun(ctx context.Context, run *workflows.WorkflowRunTemplate, revision string) (*workflows.WorkflowRun, error) {
-	workflowRun := &workflows.WorkflowRun{
+	workflowRun := newTemplateWorkflowRun(run, revision, run.Spec.RunSpec)
+	err := ctrl.SetControllerReference(run, workflowRun, r.Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("could not set controller reference: %w", err)
+	}
+	if err := r.Create(ctx, workflowRun); err != nil {
+		return nil, fmt.Errorf("could not create workflow run: %w", err)
+	}
+	return workflowRun, nil
+}
+
+// newTemplateWorkflowRun returns a new WorkflowRun of the given revision for a WorkflowRunTemplate.
+func newTemplateWorkflowRun(run *workflows.WorkflowRunTemplate, revision string, spec workflows.WorkflowRunSpec) *workflows.WorkflowRun {
+	return &workflows.WorkflowRun{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: run.Name + "-",
 			Namespace:    run.Namespace,
@@ -231,16 +365,8 @@ func (r *RunTemplateReconciler) createWorkflowRun(ctx context.Context, run *work
 				"workflowruntemplate.external-secrets.io/owner": run.Name,
 			},
 		},
-		Spec: run.Spec.RunSpec,
-	}
-	err := ctrl.SetControllerReference(run, workflowRun, r.Scheme)
-	if err != nil {
-		return nil, fmt.Errorf("could not set controller reference: %w", err)
-	}
-	if err := r.Create(ctx, workflowRun); err != nil {
-		return nil, fmt.Errorf("could not create workflow run: %w", err)
+		Spec: spec,
 	}
-	return workflowRun, nil
 }
 
 func (r *RunTemplateReconciler) updateWorkflowRunTemplate(ctx context.Context, run *workflows.WorkflowRunTemplate, workflowRuns []workflows.WorkflowRun, runTime time.Time) error {
@@ -259,7 +385,7 @@ func (r *RunTemplateReconciler) updateWorkflowRunTemplate(ctx context.Context, r
 }
 
 func (r *RunTemplateReconciler) requeueAfter(run *workflows.WorkflowRunTemplate) (ctrl.Result, error) {
-	if run.Spec.RunPolicy.Once != nil || run.Spec.RunPolicy.OnChange != nil {
+	if run.Spec.RunPolicy.Once != nil || run.Spec.RunPolicy.OnChange != nil || run.Spec.RunPolicy.OnEvent != nil {
 		// Never Requeue these
 		return ctrl.Result{}, nil
 	}
@@ -286,7 +412,7 @@ func (r *RunTemplateReconciler) requeueAfter(run *workflows.WorkflowRunTemplate)
 		}
 		if run.Spec.RunPolicy.Scheduled.Cron != nil && *run.Spec.RunPolicy.Scheduled.Cron != "" {
 			cronExpression := *run.Spec.RunPolicy.Scheduled.Cron
-			schedule, err := cronV3.ParseStandard(cronExpression)
+			schedule, err := parseCronSchedule(run.Spec.RunPolicy.Scheduled)
 			if err != nil {
 				r.Log.Error(err, "Failed to parse cron expression, requeuing after 1 minute for WorkflowRunTemplate", "cronExpression", cronExpression, "namespace", run.Namespace, "name", run.Name)
 				return ctrl.Result{}, err
@@ -310,6 +436,10 @@ func (r *RunTemplateReconciler) requeueAfter(run *workflows.WorkflowRunTemplate)
 }
 
 func (r *RunTemplateReconciler) shouldReconcile(run *workflows.WorkflowRunTemplate) bool {
+	if run.Spec.RunPolicy.OnEvent != nil {
+		// Runs are created by the EventTriggerReconciler
+		return false
+	}
 	if run.Spec.RunPolicy.Once != nil {
 		return run.Status.SyncedResourceVersion == ""
 	}
@@ -330,7 +460,7 @@ func (r *RunTemplateReconciler) shouldReconcile(run *workflows.WorkflowRunTempla
 		}
 		if run.Spec.RunPolicy.Scheduled.Cron != nil {
 			cronExpression := *run.Spec.RunPolicy.Scheduled.Cron
-			schedule, err := cronV3.ParseStandard(cronExpression)
+			schedule, err := parseCronSchedule(run.Spec.RunPolicy.Scheduled)
 			if err != nil {
 				r.Log.Error(err, "Failed to parse cron expression, requeuing after 1 minute for WorkflowRunTemplate", "cronExpression", cronExpression, "namespace", run.Namespace, "name", run.Name)
 				return false
@@ -343,6 +473,59 @@ func (r *RunTemplateReconciler) shouldReconcile(run *workflows.WorkflowRunTempla
 	return false
 }
 
+// lastScheduleTime returns the most recent scheduled time that is due, if a scheduled run
+// is due because of its schedule rather than because the template changed.
+func (r *RunTemplateReconciler) lastScheduleTime(run *workflows.WorkflowRunTemplate, now time.Time) (time.Time, bool) {
+	scheduled := run.Spec.RunPolicy.Scheduled
+	if scheduled == nil || run.Status.LastRunTime == nil {
+		return time.Time{}, false
+	}
+	if run.Status.SyncedResourceVersion != ctrlutil.GetResourceVersion(run.ObjectMeta) {
+		return time.Time{}, false
+	}
+	lastRun := run.Status.LastRunTime.Time
+
+	if scheduled.Every != nil {
+		if scheduled.Every.Duration <= 0 {
+			return time.Time{}, false
+		}
+		elapsedPeriods := now.Sub(lastRun) / scheduled.Every.Duration
+		if elapsedPeriods < 1 {
+			return time.Time{}, false
+		}
+		return lastRun.Add(elapsedPeriods * scheduled.Every.Duration), true
+	}
+
+	if scheduled.Cron != nil && *scheduled.Cron != "" {
+		schedule, err := parseCronSchedule(scheduled)
+		if err != nil {
+			return time.Time{}, false
+		}
+		scheduledTime := schedule.Next(lastRun)
+		if scheduledTime.IsZero() || scheduledTime.After(now) {
+			return time.Time{}, false
+		}
+		for range maxMissedSchedules {
+			next := schedule.Next(scheduledTime)
+			if next.IsZero() || next.After(now) {
+				break
+			}
+			scheduledTime = next
+		}
+		return scheduledTime, true
+	}
+	return time.Time{}, false
+}
+
+// parseCronSchedule parses the cron expression of a scheduled run policy in its time zone.
+func parseCronSchedule(scheduled *workflows.RunPolicyScheduled) (cronV3.Schedule, error) {
+	cronExpression := *scheduled.Cron
+	if scheduled.TimeZone != nil && *scheduled.TimeZone != "" {
+		cronExpression = fmt.Sprintf("CRON_TZ=%s %s", *scheduled.TimeZone, cronExpression)
+	}
+	return cronV3.ParseStandard(cronExpression)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *RunTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	workflows "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
+	ctrlutil "github.com/external-secrets/external-secrets/pkg/controllers/util"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -954,3 +956,276 @@ func (s *TestSuite) TestGetChildrenFor() {
 		})
 	}
 }
+
+func (s *TestSuite) TestConcurrencyPolicy() {
+	activeRun := func() *workflows.WorkflowRun {
+		return &workflows.WorkflowRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "scheduled-1",
+				Namespace:   "test-ns",
+				Labels:      map[string]string{"workflowruntemplate.external-secrets.io/owner": "scheduled"},
+				Annotations: map[string]string{"workflowruntemplate.external-secrets.io/revision": "1"},
+			},
+			Status: workflows.WorkflowRunStatus{
+				Phase:       workflows.PhaseRunning,
+				WorkflowRef: &workflows.WorkflowRef{Name: "scheduled-1", Namespace: "test-ns"},
+				Conditions:  []metav1.Condition{{Type: "Running", Status: metav1.ConditionTrue}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		policy          workflows.ConcurrencyPolicy
+		expectRuns      int
+		expectCancelled bool
+	}{
+		{name: "allow creates an overlapping run", policy: workflows.AllowConcurrent, expectRuns: 2},
+		{name: "forbid skips the new run", policy: workflows.ForbidConcurrent, expectRuns: 1},
+		{name: "replace cancels the active run", policy: workflows.ReplaceConcurrent, expectRuns: 2, expectCancelled: true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			lastRun := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+			template := &workflows.WorkflowRunTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "scheduled", Namespace: "test-ns"},
+				Spec: workflows.WorkflowRunTemplateSpec{
+					RunSpec:              workflows.WorkflowRunSpec{TemplateRef: workflows.TemplateRef{Name: "test-template"}},
+					RunPolicy:            workflows.RunPolicy{Scheduled: &workflows.RunPolicyScheduled{Every: &metav1.Duration{Duration: 5 * time.Minute}}},
+					RevisionHistoryLimit: 3,
+					ConcurrencyPolicy:    tc.policy,
+				},
+				Status: workflows.WorkflowRunTemplateStatus{LastRunTime: &lastRun},
+			}
+			template.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(template.ObjectMeta)
+
+			cl := fake.NewClientBuilder().
+				WithScheme(s.scheme).
+				WithObjects(template, activeRun()).
+				WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+				Build()
+			reconciler := &RunTemplateReconciler{
+				Client:   cl,
+				Log:      logr.Discard(),
+				Scheme:   s.scheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "scheduled", Namespace: "test-ns"}})
+			require.NoError(s.T(), err)
+
+			var workflowRuns workflows.WorkflowRunList
+			require.NoError(s.T(), cl.List(context.Background(), &workflowRuns))
+			assert.Len(s.T(), workflowRuns.Items, tc.expectRuns)
+
+			previous := &workflows.WorkflowRun{}
+			require.NoError(s.T(), cl.Get(context.Background(), types.NamespacedName{Name: "scheduled-1", Namespace: "test-ns"}, previous))
+			assert.Equal(s.T(), tc.expectCancelled, previous.Spec.Cancel)
+		})
+	}
+}
+
+func (s *TestSuite) TestRunCancelledBeforeStart() {
+	cancelledRun := &workflows.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "scheduled-1",
+			Namespace:   "test-ns",
+			Labels:      map[string]string{"workflowruntemplate.external-secrets.io/owner": "scheduled"},
+			Annotations: map[string]string{"workflowruntemplate.external-secrets.io/revision": "1"},
+		},
+		Spec: workflows.WorkflowRunSpec{Cancel: true},
+		Status: workflows.WorkflowRunStatus{
+			Phase:      workflows.PhaseCancelled,
+			Conditions: []metav1.Condition{{Type: "Cancelled", Status: metav1.ConditionTrue}},
+		},
+	}
+	lastRun := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	template := &workflows.WorkflowRunTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduled", Namespace: "test-ns"},
+		Spec: workflows.WorkflowRunTemplateSpec{
+			RunSpec:              workflows.WorkflowRunSpec{TemplateRef: workflows.TemplateRef{Name: "test-template"}},
+			RunPolicy:            workflows.RunPolicy{Scheduled: &workflows.RunPolicyScheduled{Every: &metav1.Duration{Duration: 5 * time.Minute}}},
+			RevisionHistoryLimit: 3,
+			ConcurrencyPolicy:    workflows.ForbidConcurrent,
+		},
+		Status: workflows.WorkflowRunTemplateStatus{LastRunTime: &lastRun},
+	}
+	template.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(template.ObjectMeta)
+
+	cl := fake.NewClientBuilder().
+		WithScheme(s.scheme).
+		WithObjects(template, cancelledRun).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+	reconciler := &RunTemplateReconciler{
+		Client:   cl,
+		Log:      logr.Discard(),
+		Scheme:   s.scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "scheduled", Namespace: "test-ns"}})
+	require.NoError(s.T(), err)
+
+	var workflowRuns workflows.WorkflowRunList
+	require.NoError(s.T(), cl.List(context.Background(), &workflowRuns))
+	assert.Len(s.T(), workflowRuns.Items, 2)
+}
+
+func (s *TestSuite) TestStartingDeadline() {
+	lastRun := metav1.NewTime(time.Now().Add(-30 * time.Minute))
+	template := &workflows.WorkflowRunTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "deadline", Namespace: "test-ns"},
+		Spec: workflows.WorkflowRunTemplateSpec{
+			RunSpec:                 workflows.WorkflowRunSpec{TemplateRef: workflows.TemplateRef{Name: "test-template"}},
+			RunPolicy:               workflows.RunPolicy{Scheduled: &workflows.RunPolicyScheduled{Every: &metav1.Duration{Duration: 20 * time.Minute}}},
+			RevisionHistoryLimit:    3,
+			StartingDeadlineSeconds: ptr.To[int64](60),
+		},
+		Status: workflows.WorkflowRunTemplateStatus{LastRunTime: &lastRun},
+	}
+	template.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(template.ObjectMeta)
+
+	cl := fake.NewClientBuilder().
+		WithScheme(s.scheme).
+		WithObjects(template).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+	reconciler := &RunTemplateReconciler{
+		Client:   cl,
+		Log:      logr.Discard(),
+		Scheme:   s.scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "deadline", Namespace: "test-ns"}})
+	require.NoError(s.T(), err)
+
+	var workflowRuns workflows.WorkflowRunList
+	require.NoError(s.T(), cl.List(context.Background(), &workflowRuns))
+	assert.Empty(s.T(), workflowRuns.Items)
+
+	updated := &workflows.WorkflowRunTemplate{}
+	require.NoError(s.T(), cl.Get(context.Background(), types.NamespacedName{Name: "deadline", Namespace: "test-ns"}, updated))
+	assert.WithinDuration(s.T(), lastRun.Add(20*time.Minute), updated.Status.LastRunTime.Time, time.Second)
+}
+
+func (s *TestSuite) TestCleanupFinishedRuns() {
+	newRun := func(name, revision string, phase workflows.Phase) workflows.WorkflowRun {
+		return workflows.WorkflowRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{"workflowruntemplate.external-secrets.io/revision": revision}},
+			Status:     workflows.WorkflowRunStatus{Phase: phase},
+		}
+	}
+	workflowRuns := []workflows.WorkflowRun{
+		newRun("run-1", "1", workflows.PhaseSucceeded),
+		newRun("run-2", "2", workflows.PhaseFailed),
+		newRun("run-3", "3", workflows.PhaseSucceeded),
+		newRun("run-4", "4", workflows.PhaseCancelled),
+		newRun("run-5", "5", workflows.PhaseSucceeded),
+		newRun("run-6", "6", workflows.PhaseRunning),
+	}
+	objs := []client.Object{}
+	for i := range workflowRuns {
+		objs = append(objs, workflowRuns[i].DeepCopy())
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(s.scheme).WithObjects(objs...).Build()
+	reconciler := &RunTemplateReconciler{
+		Client:   cl,
+		Log:      logr.Discard(),
+		Scheme:   s.scheme,
+		Recorder: s.recorder,
+	}
+	template := &workflows.WorkflowRunTemplate{
+		Spec: workflows.WorkflowRunTemplateSpec{
+			SuccessfulRunsHistoryLimit: ptr.To[int32](1),
+			FailedRunsHistoryLimit:     ptr.To[int32](0),
+		},
+	}
+
+	remaining, err := reconciler.cleanupFinishedRuns(context.Background(), template, workflowRuns)
+	require.NoError(s.T(), err)
+
+	names := []string{}
+	for _, run := range remaining {
+		names = append(names, run.Name)
+	}
+	assert.ElementsMatch(s.T(), []string{"run-5", "run-6"}, names)
+
+	for _, deleted := range []string{"run-1", "run-2", "run-3", "run-4"} {
+		err := cl.Get(context.Background(), types.NamespacedName{Name: deleted}, &workflows.WorkflowRun{})
+		assert.True(s.T(), apierrors.IsNotFound(err), "expected %s to be deleted", deleted)
+	}
+}
+
+func (s *TestSuite) TestHistoryLimitsReplaceRevisionHistoryLimit() {
+	objs := []client.Object{}
+	for i := 1; i <= 5; i++ {
+		objs = append(objs, &workflows.WorkflowRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("scheduled-%d", i),
+				Namespace:   "test-ns",
+				Labels:      map[string]string{"workflowruntemplate.external-secrets.io/owner": "scheduled"},
+				Annotations: map[string]string{"workflowruntemplate.external-secrets.io/revision": fmt.Sprint(i)},
+			},
+			Status: workflows.WorkflowRunStatus{
+				Phase:       workflows.PhaseSucceeded,
+				WorkflowRef: &workflows.WorkflowRef{Name: fmt.Sprintf("scheduled-%d", i), Namespace: "test-ns"},
+				Conditions:  []metav1.Condition{{Type: "Succeeded", Status: metav1.ConditionTrue}},
+			},
+		})
+	}
+	lastRun := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	template := &workflows.WorkflowRunTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduled", Namespace: "test-ns"},
+		Spec: workflows.WorkflowRunTemplateSpec{
+			RunSpec:                    workflows.WorkflowRunSpec{TemplateRef: workflows.TemplateRef{Name: "test-template"}},
+			RunPolicy:                  workflows.RunPolicy{Scheduled: &workflows.RunPolicyScheduled{Every: &metav1.Duration{Duration: 5 * time.Minute}}},
+			RevisionHistoryLimit:       3,
+			SuccessfulRunsHistoryLimit: ptr.To[int32](4),
+		},
+		Status: workflows.WorkflowRunTemplateStatus{LastRunTime: &lastRun},
+	}
+	template.Status.SyncedResourceVersion = ctrlutil.GetResourceVersion(template.ObjectMeta)
+
+	cl := fake.NewClientBuilder().
+		WithScheme(s.scheme).
+		WithObjects(append(objs, template)...).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+	reconciler := &RunTemplateReconciler{
+		Client:   cl,
+		Log:      logr.Discard(),
+		Scheme:   s.scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "scheduled", Namespace: "test-ns"}})
+	require.NoError(s.T(), err)
+
+	var workflowRuns workflows.WorkflowRunList
+	require.NoError(s.T(), cl.List(context.Background(), &workflowRuns))
+	// The 4 most recent succeeded runs are kept, in addition to the new run.
+	assert.Len(s.T(), workflowRuns.Items, 5)
+	err = cl.Get(context.Background(), types.NamespacedName{Name: "scheduled-1", Namespace: "test-ns"}, &workflows.WorkflowRun{})
+	assert.True(s.T(), apierrors.IsNotFound(err))
+}
+
+func (s *TestSuite) TestParseCronScheduleTimeZone() {
+	schedule, err := parseCronSchedule(&workflows.RunPolicyScheduled{
+		Cron:     stringPtr("0 9 * * *"),
+		TimeZone: stringPtr("Asia/Tokyo"),
+	})
+	require.NoError(s.T(), err)
+
+	next := schedule.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(s.T(), time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), next.UTC())
+
+	_, err = parseCronSchedule(&workflows.RunPolicyScheduled{
+		Cron:     stringPtr("0 9 * * *"),
+		TimeZone: stringPtr("Not/AZone"),
+	})
+	assert.Error(s.T(), err)
+}
@@ -0,0 +1,410 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// 2025
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+
+package workflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scanv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/scan/v1alpha1"
+	workflows "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+// TriggerSource is the kind of object that triggers workflow runs.
+type TriggerSource string
+
+const (
+	// TriggerSourceFinding triggers runs on new Findings.
+	TriggerSourceFinding TriggerSource = "Finding"
+	// TriggerSourceExternalSecret triggers runs on ExternalSecrets entering SecretSyncedError.
+	TriggerSourceExternalSecret TriggerSource = "ExternalSecret"
+	// TriggerSourcePushSecret triggers runs on failing PushSecrets.
+	TriggerSourcePushSecret TriggerSource = "PushSecret"
+	// TriggerSourceGeneratorState triggers runs on GeneratorStates nearing their garbage collection deadline.
+	TriggerSourceGeneratorState TriggerSource = "GeneratorState"
+)
+
+const (
+	// triggerRequeueInterval is the interval at which a trigger is retried while it is blocked
+	// by the concurrency policy of a WorkflowRunTemplate.
+	triggerRequeueInterval = 30 * time.Second
+	// maxTriggeredEvents bounds the number of events recorded in the WorkflowRunTemplate status.
+	maxTriggeredEvents = 50
+	// triggeredRunNameHashLength is the length of the hash of the event key in the name of a triggered run.
+	triggeredRunNameHashLength = 10
+)
+
+// EventTriggerReconciler creates workflow runs for WorkflowRunTemplates with an onEvent
+// run policy when an object of its source kind matches one of their triggers.
+type EventTriggerReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Source   TriggerSource
+}
+
+// triggerMatch is an event of a triggering object.
+type triggerMatch struct {
+	// key identifies the event, a run is only triggered once per key.
+	key string
+	// time is when the event happened.
+	time time.Time
+	// value is bound to the trigger parameter.
+	value any
+}
+
+//+kubebuilder:rbac:groups=workflows.external-secrets.io,resources=workflowruntemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=workflows.external-secrets.io,resources=workflowruntemplates/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=workflows.external-secrets.io,resources=workflowruns,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile evaluates the triggers of all WorkflowRunTemplates in the namespace of the object.
+func (r *EventTriggerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("source", r.Source, "object", req.NamespacedName)
+
+	obj, err := r.newSourceObject()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	templateList := &workflows.WorkflowRunTemplateList{}
+	if err := r.List(ctx, templateList, client.InNamespace(req.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result := ctrl.Result{}
+	now := time.Now()
+	for i := range templateList.Items {
+		template := &templateList.Items[i]
+		if template.Spec.RunPolicy.OnEvent == nil || !template.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, trigger := range template.Spec.RunPolicy.OnEvent.Triggers {
+			match, requeueAfter, err := r.matchTrigger(trigger, obj, now)
+			if err != nil {
+				log.Error(err, "failed to evaluate trigger", "workflowruntemplate", template.Name)
+				continue
+			}
+			result = earliestRequeue(result, requeueAfter)
+			if match == nil || match.time.Before(template.CreationTimestamp.Time) {
+				continue
+			}
+			// Events evicted from the record already triggered a run.
+			if since := template.Status.TriggeredEventsSince; since != nil && !match.time.After(since.Time) {
+				continue
+			}
+
+			blocked, err := r.triggerRun(ctx, template, trigger, match)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if blocked {
+				result = earliestRequeue(result, triggerRequeueInterval)
+			}
+			// Only one run is created per template and event.
+			break
+		}
+	}
+	return result, nil
+}
+
+// matchTrigger returns the event of the object if it matches the trigger. If the object may
+// match the trigger later on, the duration after which it should be evaluated again is returned.
+func (r *EventTriggerReconciler) matchTrigger(trigger workflows.EventTrigger, obj client.Object, now time.Time) (*triggerMatch, time.Duration, error) {
+	var selector *metav1.LabelSelector
+	switch r.Source {
+	case TriggerSourceFinding:
+		if trigger.Finding == nil {
+			return nil, 0, nil
+		}
+		selector = trigger.Finding.Selector
+	case TriggerSourceExternalSecret:
+		if trigger.ExternalSecret == nil {
+			return nil, 0, nil
+		}
+		selector = trigger.ExternalSecret.Selector
+	case TriggerSourcePushSecret:
+		if trigger.PushSecret == nil {
+			return nil, 0, nil
+		}
+		selector = trigger.PushSecret.Selector
+	case TriggerSourceGeneratorState:
+		if trigger.GeneratorState == nil {
+			return nil, 0, nil
+		}
+		selector = trigger.GeneratorState.Selector
+	}
+
+	if selector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid selector: %w", err)
+		}
+		if !labelSelector.Matches(labels.Set(obj.GetLabels())) {
+			return nil, 0, nil
+		}
+	}
+
+	switch o := obj.(type) {
+	case *scanv1alpha1.Finding:
+		return &triggerMatch{
+			key:   fmt.Sprintf("%s/%s", r.Source, o.UID),
+			time:  o.CreationTimestamp.Time,
+			value: workflows.FindingParameterType{Name: o.Name},
+		}, 0, nil
+	case *esv1.ExternalSecret:
+		for _, condition := range o.Status.Conditions {
+			if condition.Type == esv1.ExternalSecretReady && condition.Status == corev1.ConditionFalse && condition.Reason == esv1.ConditionReasonSecretSyncedError {
+				return conditionMatch(r.Source, o, condition.LastTransitionTime), 0, nil
+			}
+		}
+		return nil, 0, nil
+	case *esv1alpha1.PushSecret:
+		for _, condition := range o.Status.Conditions {
+			if condition.Type == esv1alpha1.PushSecretReady && condition.Status == corev1.ConditionFalse && condition.Reason == esv1alpha1.ReasonErrored {
+				return conditionMatch(r.Source, o, condition.LastTransitionTime), 0, nil
+			}
+		}
+		return nil, 0, nil
+	case *genv1alpha1.GeneratorState:
+		if o.Spec.GarbageCollectionDeadline == nil {
+			return nil, 0, nil
+		}
+		triggerTime := o.Spec.GarbageCollectionDeadline.Add(-trigger.GeneratorState.ExpiresWithin.Duration)
+		if now.Before(triggerTime) {
+			return nil, triggerTime.Sub(now), nil
+		}
+		return &triggerMatch{
+			key:   fmt.Sprintf("%s/%s", r.Source, o.UID),
+			time:  triggerTime,
+			value: o.Name,
+		}, 0, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported trigger source %q", r.Source)
+}
+
+func conditionMatch(source TriggerSource, obj client.Object, transitionTime metav1.Time) *triggerMatch {
+	return &triggerMatch{
+		key:   fmt.Sprintf("%s/%s/%d", source, obj.GetUID(), transitionTime.Unix()),
+		time:  transitionTime.Time,
+		value: obj.GetName(),
+	}
+}
+
+// triggerRun creates a run of the template for the event, unless the event already triggered a run.
+// It returns true if the run is blocked by the concurrency policy of the template.
+func (r *EventTriggerReconciler) triggerRun(ctx context.Context, template *workflows.WorkflowRunTemplate, trigger workflows.EventTrigger, match *triggerMatch) (bool, error) {
+	for _, event := range template.Status.TriggeredEvents {
+		if event.Key == match.key {
+			return false, nil
+		}
+	}
+
+	// Run creation is synchronized with the WorkflowRunTemplate controller
+	// as both compute revisions from the existing runs.
+	mu.Lock()
+	defer mu.Unlock()
+
+	runTemplates := &RunTemplateReconciler{Client: r.Client, Log: r.Log, Scheme: r.Scheme, Recorder: r.Recorder}
+	workflowRuns, err := runTemplates.getChildrenFor(ctx, template)
+	if err != nil {
+		return false, err
+	}
+	for _, workflowRun := range workflowRuns {
+		if workflowRun.Annotations["workflowruntemplate.external-secrets.io/trigger"] == match.key {
+			return false, nil
+		}
+	}
+
+	if activeRuns := filterActiveRuns(workflowRuns); len(activeRuns) > 0 {
+		switch template.Spec.ConcurrencyPolicy {
+		case workflows.ForbidConcurrent:
+			return true, nil
+		case workflows.ReplaceConcurrent:
+			if err := runTemplates.cancelRuns(ctx, activeRuns); err != nil {
+				return false, err
+			}
+			r.Recorder.Eventf(template, corev1.EventTypeNormal, "RunsReplaced", "Cancelled %d active runs", len(activeRuns))
+		}
+	}
+
+	workflowRuns, err = runTemplates.applyHistoryLimits(ctx, template, workflowRuns)
+	if err != nil {
+		return false, err
+	}
+	revision, err := runTemplates.generateRevision(workflowRuns)
+	if err != nil {
+		return false, err
+	}
+
+	runSpec := template.Spec.RunSpec.DeepCopy()
+	if trigger.Parameter != "" {
+		arguments, err := bindArgument(runSpec.Arguments, trigger.Parameter, match.value)
+		if err != nil {
+			return false, err
+		}
+		runSpec.Arguments = arguments
+	}
+
+	workflowRun := newTemplateWorkflowRun(template, revision, *runSpec)
+	workflowRun.Name = triggeredRunName(template, match.key)
+	workflowRun.Annotations["workflowruntemplate.external-secrets.io/trigger"] = match.key
+	if err := ctrl.SetControllerReference(template, workflowRun, r.Scheme); err != nil {
+		return false, fmt.Errorf("could not set controller reference: %w", err)
+	}
+	if err := r.Create(ctx, workflowRun); err != nil {
+		// The cache may not have seen the run of the event yet.
+		if apierrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not create workflow run: %w", err)
+	}
+	r.Recorder.Eventf(template, corev1.EventTypeNormal, "RunTriggered", "Created WorkflowRun %s for %s", workflowRun.Name, match.key)
+
+	return false, r.recordTriggeredEvent(ctx, template, workflowRuns, workflowRun, match)
+}
+
+// triggeredRunName names the run of an event after its key, so an event never creates two runs
+// even when the runs it is checked against are read from a stale cache.
+func triggeredRunName(template *workflows.WorkflowRunTemplate, key string) string {
+	hash := sha256.Sum256([]byte(key))
+	prefix := template.Name
+	if maxPrefix := validation.DNS1123SubdomainMaxLength - triggeredRunNameHashLength - 1; len(prefix) > maxPrefix {
+		prefix = prefix[:maxPrefix]
+	}
+	return prefix + "-" + hex.EncodeToString(hash[:])[:triggeredRunNameHashLength]
+}
+
+// recordTriggeredEvent stores the event and the run it triggered in the template status.
+// Evicted events are covered by the time of the most recent evicted event.
+func (r *EventTriggerReconciler) recordTriggeredEvent(ctx context.Context, template *workflows.WorkflowRunTemplate, workflowRuns []workflows.WorkflowRun, workflowRun *workflows.WorkflowRun, match *triggerMatch) error {
+	now := metav1.Now()
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		latest := &workflows.WorkflowRunTemplate{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(template), latest); err != nil {
+			return err
+		}
+		latest.Status.TriggeredEvents = append(latest.Status.TriggeredEvents, workflows.TriggeredEvent{
+			Key:       match.key,
+			RunName:   workflowRun.Name,
+			Time:      now,
+			EventTime: metav1.NewTime(match.time),
+		})
+		if evict := len(latest.Status.TriggeredEvents) - maxTriggeredEvents; evict > 0 {
+			for _, event := range latest.Status.TriggeredEvents[:evict] {
+				if since := latest.Status.TriggeredEventsSince; since == nil || event.EventTime.After(since.Time) {
+					latest.Status.TriggeredEventsSince = event.EventTime.DeepCopy()
+				}
+			}
+			latest.Status.TriggeredEvents = latest.Status.TriggeredEvents[evict:]
+		}
+		stats := []workflows.NamedWorkflowRunStatus{}
+		for _, run := range append(workflowRuns, *workflowRun) {
+			stats = append(stats, workflows.NamedWorkflowRunStatus{RunName: run.Name, WorkflowRunStatus: run.Status})
+		}
+		latest.Status.RunStatuses = stats
+		latest.Status.LastRunTime = &now
+		if err := r.Status().Update(ctx, latest); err != nil {
+			return err
+		}
+		template.Status = latest.Status
+		return nil
+	})
+}
+
+// bindArgument sets the argument of a parameter in the run arguments.
+func bindArgument(arguments apiextensionsv1.JSON, parameter string, value any) (apiextensionsv1.JSON, error) {
+	parsedArguments := map[string]any{}
+	if len(arguments.Raw) > 0 {
+		if err := json.Unmarshal(arguments.Raw, &parsedArguments); err != nil {
+			return apiextensionsv1.JSON{}, fmt.Errorf("error unmarshalling arguments: %w", err)
+		}
+		if parsedArguments == nil {
+			parsedArguments = map[string]any{}
+		}
+	}
+	parsedArguments[parameter] = value
+	raw, err := json.Marshal(parsedArguments)
+	if err != nil {
+		return apiextensionsv1.JSON{}, fmt.Errorf("error marshaling arguments: %w", err)
+	}
+	return apiextensionsv1.JSON{Raw: raw}, nil
+}
+
+func earliestRequeue(result ctrl.Result, requeueAfter time.Duration) ctrl.Result {
+	if requeueAfter <= 0 {
+		return result
+	}
+	if result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+		result.RequeueAfter = requeueAfter
+	}
+	return result
+}
+
+func (r *EventTriggerReconciler) newSourceObject() (client.Object, error) {
+	switch r.Source {
+	case TriggerSourceFinding:
+		return &scanv1alpha1.Finding{}, nil
+	case TriggerSourceExternalSecret:
+		return &esv1.ExternalSecret{}, nil
+	case TriggerSourcePushSecret:
+		return &esv1alpha1.PushSecret{}, nil
+	case TriggerSourceGeneratorState:
+		return &genv1alpha1.GeneratorState{}, nil
+	}
+	return nil, fmt.Errorf("unsupported trigger source %q", r.Source)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EventTriggerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	obj, err := r.newSourceObject()
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workflowruntemplate-trigger-" + strings.ToLower(string(r.Source))).
+		For(obj).
+		Complete(r)
+}
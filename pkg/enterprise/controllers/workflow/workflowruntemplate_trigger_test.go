@@ -0,0 +1,321 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// 2025
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scanv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/scan/v1alpha1"
+	workflows "github.com/external-secrets/external-secrets/apis/enterprise/workflows/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+func newTriggerScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, workflows.AddToScheme(scheme))
+	require.NoError(t, scanv1alpha1.AddToScheme(scheme))
+	require.NoError(t, esv1.AddToScheme(scheme))
+	require.NoError(t, genv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func newTriggerTemplate(created time.Time, trigger workflows.EventTrigger) *workflows.WorkflowRunTemplate {
+	return &workflows.WorkflowRunTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "remediate",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: workflows.WorkflowRunTemplateSpec{
+			RunSpec: workflows.WorkflowRunSpec{
+				TemplateRef: workflows.TemplateRef{Name: "rotate"},
+			},
+			RunPolicy:            workflows.RunPolicy{OnEvent: &workflows.RunPolicyOnEvent{Triggers: []workflows.EventTrigger{trigger}}},
+			RevisionHistoryLimit: 3,
+		},
+	}
+}
+
+func reconcileTrigger(t *testing.T, cl client.Client, scheme *runtime.Scheme, source TriggerSource, name string) ctrl.Result {
+	t.Helper()
+	r := &EventTriggerReconciler{
+		Client:   cl,
+		Log:      logr.Discard(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+		Source:   source,
+	}
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+	require.NoError(t, err)
+	return res
+}
+
+func listTriggeredRuns(t *testing.T, cl client.Client) []workflows.WorkflowRun {
+	t.Helper()
+	runs := &workflows.WorkflowRunList{}
+	require.NoError(t, cl.List(context.Background(), runs, client.InNamespace("default")))
+	return runs.Items
+}
+
+func TestFindingTriggerBindsParameter(t *testing.T) {
+	scheme := newTriggerScheme(t)
+	template := newTriggerTemplate(time.Now().Add(-time.Hour), workflows.EventTrigger{
+		Parameter: "finding",
+		Finding: &workflows.ResourceEventTrigger{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"severity": "high"}},
+		},
+	})
+	matching := &scanv1alpha1.Finding{
+		ObjectMeta: metav1.ObjectMeta{Name: "leak", Namespace: "default", UID: "leak-uid", Labels: map[string]string{"severity": "high"}, CreationTimestamp: metav1.Now()},
+	}
+	other := &scanv1alpha1.Finding{
+		ObjectMeta: metav1.ObjectMeta{Name: "minor", Namespace: "default", UID: "minor-uid", Labels: map[string]string{"severity": "low"}, CreationTimestamp: metav1.Now()},
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(template, matching, other).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+
+	reconcileTrigger(t, cl, scheme, TriggerSourceFinding, "minor")
+	assert.Empty(t, listTriggeredRuns(t, cl))
+
+	reconcileTrigger(t, cl, scheme, TriggerSourceFinding, "leak")
+	runs := listTriggeredRuns(t, cl)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "remediate", runs[0].Labels["workflowruntemplate.external-secrets.io/owner"])
+	assert.Equal(t, "1", runs[0].Annotations["workflowruntemplate.external-secrets.io/revision"])
+
+	arguments := map[string]any{}
+	require.NoError(t, json.Unmarshal(runs[0].Spec.Arguments.Raw, &arguments))
+	assert.Equal(t, map[string]any{"name": "leak"}, arguments["finding"])
+
+	// The same event does not trigger a second run.
+	reconcileTrigger(t, cl, scheme, TriggerSourceFinding, "leak")
+	assert.Len(t, listTriggeredRuns(t, cl), 1)
+
+	updated := &workflows.WorkflowRunTemplate{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(template), updated))
+	require.Len(t, updated.Status.TriggeredEvents, 1)
+	assert.Equal(t, runs[0].Name, updated.Status.TriggeredEvents[0].RunName)
+}
+
+func TestExternalSecretTrigger(t *testing.T) {
+	scheme := newTriggerScheme(t)
+	template := newTriggerTemplate(time.Now().Add(-time.Hour), workflows.EventTrigger{
+		Parameter:      "externalsecret",
+		ExternalSecret: &workflows.ResourceEventTrigger{},
+	})
+	healthy := &esv1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "healthy", Namespace: "default", UID: "healthy-uid"},
+		Status: esv1.ExternalSecretStatus{Conditions: []esv1.ExternalSecretStatusCondition{
+			{Type: esv1.ExternalSecretReady, Status: corev1.ConditionTrue, Reason: esv1.ConditionReasonSecretSynced, LastTransitionTime: metav1.Now()},
+		}},
+	}
+	failing := &esv1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "failing", Namespace: "default", UID: "failing-uid"},
+		Status: esv1.ExternalSecretStatus{Conditions: []esv1.ExternalSecretStatusCondition{
+			{Type: esv1.ExternalSecretReady, Status: corev1.ConditionFalse, Reason: esv1.ConditionReasonSecretSyncedError, LastTransitionTime: metav1.Now()},
+		}},
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(template, healthy, failing).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+
+	reconcileTrigger(t, cl, scheme, TriggerSourceExternalSecret, "healthy")
+	assert.Empty(t, listTriggeredRuns(t, cl))
+
+	reconcileTrigger(t, cl, scheme, TriggerSourceExternalSecret, "failing")
+	runs := listTriggeredRuns(t, cl)
+	require.Len(t, runs, 1)
+	arguments := map[string]any{}
+	require.NoError(t, json.Unmarshal(runs[0].Spec.Arguments.Raw, &arguments))
+	assert.Equal(t, "failing", arguments["externalsecret"])
+}
+
+func TestTriggerIgnoresEventsBeforeTemplate(t *testing.T) {
+	scheme := newTriggerScheme(t)
+	template := newTriggerTemplate(time.Now(), workflows.EventTrigger{
+		Finding: &workflows.ResourceEventTrigger{},
+	})
+	finding := &scanv1alpha1.Finding{
+		ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "default", UID: "old-uid", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(template, finding).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+
+	reconcileTrigger(t, cl, scheme, TriggerSourceFinding, "old")
+	assert.Empty(t, listTriggeredRuns(t, cl))
+}
+
+func TestGeneratorStateTriggerRequeuesUntilExpiry(t *testing.T) {
+	scheme := newTriggerScheme(t)
+	template := newTriggerTemplate(time.Now().Add(-time.Hour), workflows.EventTrigger{
+		GeneratorState: &workflows.GeneratorStateEventTrigger{ExpiresWithin: metav1.Duration{Duration: time.Hour}},
+	})
+	deadline := metav1.NewTime(time.Now().Add(2 * time.Hour))
+	state := &genv1alpha1.GeneratorState{
+		ObjectMeta: metav1.ObjectMeta{Name: "state", Namespace: "default", UID: "state-uid"},
+		Spec:       genv1alpha1.GeneratorStateSpec{GarbageCollectionDeadline: &deadline},
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(template, state).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+
+	res := reconcileTrigger(t, cl, scheme, TriggerSourceGeneratorState, "state")
+	assert.Empty(t, listTriggeredRuns(t, cl))
+	assert.InDelta(t, time.Hour, res.RequeueAfter, float64(time.Minute))
+
+	state.Spec.GarbageCollectionDeadline = &metav1.Time{Time: time.Now().Add(30 * time.Minute)}
+	require.NoError(t, cl.Update(context.Background(), state))
+	reconcileTrigger(t, cl, scheme, TriggerSourceGeneratorState, "state")
+	assert.Len(t, listTriggeredRuns(t, cl), 1)
+}
+
+func TestTriggerIgnoresEvictedEvents(t *testing.T) {
+	scheme := newTriggerScheme(t)
+	template := newTriggerTemplate(time.Now().Add(-2*time.Hour), workflows.EventTrigger{
+		Finding: &workflows.ResourceEventTrigger{},
+	})
+	evictedTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	for i := range maxTriggeredEvents {
+		template.Status.TriggeredEvents = append(template.Status.TriggeredEvents, workflows.TriggeredEvent{
+			Key:       fmt.Sprintf("%s/uid-%d", TriggerSourceFinding, i),
+			RunName:   fmt.Sprintf("run-%d", i),
+			EventTime: evictedTime,
+		})
+	}
+	evicted := &scanv1alpha1.Finding{
+		ObjectMeta: metav1.ObjectMeta{Name: "evicted", Namespace: "default", UID: "uid-0", CreationTimestamp: evictedTime},
+	}
+	finding := &scanv1alpha1.Finding{
+		ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default", UID: "new-uid", CreationTimestamp: metav1.Now()},
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(template, evicted, finding).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+
+	reconcileTrigger(t, cl, scheme, TriggerSourceFinding, "new")
+	require.Len(t, listTriggeredRuns(t, cl), 1)
+
+	updated := &workflows.WorkflowRunTemplate{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(template), updated))
+	assert.Len(t, updated.Status.TriggeredEvents, maxTriggeredEvents)
+	require.NotNil(t, updated.Status.TriggeredEventsSince)
+	assert.True(t, evictedTime.Equal(updated.Status.TriggeredEventsSince))
+
+	// The evicted event does not trigger a run again, e.g. after a resync.
+	reconcileTrigger(t, cl, scheme, TriggerSourceFinding, "evicted")
+	assert.Len(t, listTriggeredRuns(t, cl), 1)
+}
+
+func TestTriggerIgnoresEventsOfExistingRuns(t *testing.T) {
+	scheme := newTriggerScheme(t)
+	template := newTriggerTemplate(time.Now().Add(-time.Hour), workflows.EventTrigger{
+		Finding: &workflows.ResourceEventTrigger{},
+	})
+	finding := &scanv1alpha1.Finding{
+		ObjectMeta: metav1.ObjectMeta{Name: "leak", Namespace: "default", UID: "leak-uid", CreationTimestamp: metav1.Now()},
+	}
+	run := &workflows.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "remediate-1",
+			Namespace: "default",
+			Labels:    map[string]string{"workflowruntemplate.external-secrets.io/owner": "remediate"},
+			Annotations: map[string]string{
+				"workflowruntemplate.external-secrets.io/revision": "1",
+				"workflowruntemplate.external-secrets.io/trigger":  fmt.Sprintf("%s/leak-uid", TriggerSourceFinding),
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(template, finding, run).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+
+	reconcileTrigger(t, cl, scheme, TriggerSourceFinding, "leak")
+	assert.Len(t, listTriggeredRuns(t, cl), 1)
+}
+
+func TestTriggerCreatesOneRunPerEvent(t *testing.T) {
+	scheme := newTriggerScheme(t)
+	template := newTriggerTemplate(time.Now().Add(-time.Hour), workflows.EventTrigger{
+		Finding: &workflows.ResourceEventTrigger{},
+	})
+	finding := &scanv1alpha1.Finding{
+		ObjectMeta: metav1.ObjectMeta{Name: "leak", Namespace: "default", UID: "leak-uid", CreationTimestamp: metav1.Now()},
+	}
+	// The run of the event exists, but is not listed with the runs of the template yet.
+	run := &workflows.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      triggeredRunName(template, fmt.Sprintf("%s/leak-uid", TriggerSourceFinding)),
+			Namespace: "default",
+		},
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(template, finding, run).
+		WithStatusSubresource(&workflows.WorkflowRunTemplate{}).
+		Build()
+
+	reconcileTrigger(t, cl, scheme, TriggerSourceFinding, "leak")
+	assert.Len(t, listTriggeredRuns(t, cl), 1)
+}
+
+func TestTriggeredRunName(t *testing.T) {
+	template := newTriggerTemplate(time.Now(), workflows.EventTrigger{})
+	name := triggeredRunName(template, "Finding/leak-uid")
+	assert.Equal(t, name, triggeredRunName(template, "Finding/leak-uid"))
+	assert.NotEqual(t, name, triggeredRunName(template, "Finding/other-uid"))
+	assert.Regexp(t, `^remediate-[0-9a-f]{10}$`, name)
+
+	template.Name = strings.Repeat("a", 300)
+	assert.Len(t, triggeredRunName(template, "Finding/leak-uid"), 253)
+}
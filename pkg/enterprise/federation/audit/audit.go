@@ -0,0 +1,132 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package audit implements the audit log of the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Sink writes audit records to an audit stream.
+type Sink interface {
+	// Write appends the record to the stream.
+	Write(ctx context.Context, record *Record) error
+	// Close flushes pending records and releases the stream.
+	Close() error
+}
+
+// Logger writes audit records to all of its sinks.
+type Logger struct {
+	log   logr.Logger
+	sinks []Sink
+}
+
+// NewLogger creates a Logger writing to the sinks. Errors of sinks are logged to log.
+func NewLogger(log logr.Logger, sinks ...Sink) *Logger {
+	return &Logger{log: log, sinks: sinks}
+}
+
+// Record writes the record to all sinks. Records written to a nil Logger are discarded.
+func (l *Logger) Record(ctx context.Context, record Record) {
+	if l == nil {
+		return
+	}
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	for _, sink := range l.sinks {
+		if err := sink.Write(ctx, &record); err != nil {
+			l.log.Error(err, "failed to write audit record", "event", record.Event, "requestId", record.RequestID)
+		}
+	}
+}
+
+// Close closes all sinks.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	var errs []error
+	for _, sink := range l.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Config configures the sinks of the audit log.
+type Config struct {
+	// File is the path of the JSON lines file records are appended to, or - for stdout.
+	File string
+	// WebhookURL is the URL batches of records are posted to.
+	WebhookURL string
+	// WebhookBatchSize is the maximum number of records posted at once.
+	WebhookBatchSize int
+	// WebhookFlushInterval is the maximum time a record waits before it is posted.
+	WebhookFlushInterval time.Duration
+	// WebhookMaxRetries is the number of times a failed batch is posted again.
+	WebhookMaxRetries int
+	// SyslogAddress is the address of the syslog server, e.g. udp://syslog:514 or unix:///dev/log.
+	SyslogAddress string
+}
+
+// NewSinks creates the sinks configured by cfg.
+func NewSinks(cfg Config, log logr.Logger) ([]Sink, error) {
+	var sinks []Sink
+	if cfg.File != "" {
+		sink, err := NewFileSink(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.WebhookURL != "" {
+		sink, err := NewWebhookSink(WebhookConfig{
+			URL:           cfg.WebhookURL,
+			BatchSize:     cfg.WebhookBatchSize,
+			FlushInterval: cfg.WebhookFlushInterval,
+			MaxRetries:    &cfg.WebhookMaxRetries,
+		}, log.WithName("webhook"))
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.SyslogAddress != "" {
+		u, err := url.Parse(cfg.SyslogAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid syslog address %q: %w", cfg.SyslogAddress, err)
+		}
+		address := u.Host
+		if u.Scheme == "unix" || u.Scheme == "unixgram" {
+			address = u.Path
+		}
+		sink, err := NewSyslogSink(u.Scheme, address, "external-secrets-federation")
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
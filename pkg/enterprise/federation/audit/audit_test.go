@@ -0,0 +1,288 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package audit implements the audit log of the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/fmetrics"
+)
+
+func testRecord(subject string) Record {
+	return Record{
+		RequestID:     "request-1",
+		Event:         EventSecretRead,
+		Outcome:       OutcomeSuccess,
+		Authenticator: "oidc",
+		Subject:       subject,
+		Resource:      &Resource{Kind: "ClusterSecretStore", Name: "shared", Key: "db/app1", Property: "password"},
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(path, []byte("{\"existing\":true}\n"), 0o600))
+
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+	logger := NewLogger(logr.Discard(), sink)
+	logger.Record(context.Background(), testRecord("alice"))
+	logger.Record(context.Background(), testRecord("bob"))
+	require.NoError(t, logger.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, `{"existing":true}`, lines[0])
+
+	var record Record
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &record))
+	assert.Equal(t, "bob", record.Subject)
+	assert.Equal(t, EventSecretRead, record.Event)
+	assert.False(t, record.Time.IsZero())
+}
+
+func TestJSONSinkConcurrentWrites(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONSink(&buf)
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			record := testRecord("alice")
+			assert.NoError(t, sink.Write(context.Background(), &record))
+		}()
+	}
+	wg.Wait()
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record Record
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+	}
+}
+
+// webhookReceiver records the batches posted to it, failing the first requests.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	failures int
+	requests int
+	batches  [][]Record
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests++
+	if req.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var batch []Record
+	if err := json.NewDecoder(req.Body).Decode(&batch); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.batches = append(r.batches, batch)
+}
+
+func (r *webhookReceiver) received() ([][]Record, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches, r.requests
+}
+
+func TestWebhookSinkBatchesAndRetries(t *testing.T) {
+	receiver := &webhookReceiver{failures: 2}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	sink, err := NewWebhookSink(WebhookConfig{
+		URL:           server.URL,
+		Headers:       http.Header{"Authorization": []string{"Bearer token"}},
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		RetryBackoff:  time.Millisecond,
+	}, logr.Discard())
+	require.NoError(t, err)
+
+	for _, subject := range []string{"alice", "bob", "carol"} {
+		record := testRecord(subject)
+		require.NoError(t, sink.Write(context.Background(), &record))
+	}
+
+	// The first batch is posted once full, after two failed attempts.
+	require.Eventually(t, func() bool {
+		batches, _ := receiver.received()
+		return len(batches) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The remaining records are posted on close.
+	require.NoError(t, sink.Close())
+	batches, requests := receiver.received()
+	require.Len(t, batches, 2)
+	assert.Equal(t, 4, requests)
+	assert.Equal(t, "alice", batches[0][0].Subject)
+	assert.Equal(t, "bob", batches[0][1].Subject)
+	assert.Equal(t, "carol", batches[1][0].Subject)
+
+	record := testRecord("dave")
+	assert.ErrorIs(t, sink.Write(context.Background(), &record), errSinkClosed)
+}
+
+func TestWebhookSinkFlushInterval(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	sink, err := NewWebhookSink(WebhookConfig{
+		URL:           server.URL,
+		Headers:       http.Header{"Authorization": []string{"Bearer token"}},
+		FlushInterval: 10 * time.Millisecond,
+	}, logr.Discard())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = sink.Close()
+	})
+
+	record := testRecord("alice")
+	require.NoError(t, sink.Write(context.Background(), &record))
+	require.Eventually(t, func() bool {
+		batches, _ := receiver.received()
+		return len(batches) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebhookSinkDoesNotRetryClientErrors(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	sink, err := NewWebhookSink(WebhookConfig{URL: server.URL, RetryBackoff: time.Millisecond}, logr.Discard())
+	require.NoError(t, err)
+	record := testRecord("alice")
+	require.NoError(t, sink.Write(context.Background(), &record))
+	require.NoError(t, sink.Close())
+
+	batches, requests := receiver.received()
+	assert.Empty(t, batches)
+	assert.Equal(t, 1, requests)
+}
+
+func TestWebhookSinkWithoutRetries(t *testing.T) {
+	receiver := &webhookReceiver{failures: 1}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	sink, err := NewWebhookSink(WebhookConfig{
+		URL:          server.URL,
+		Headers:      http.Header{"Authorization": []string{"Bearer token"}},
+		MaxRetries:   ptr.To(0),
+		RetryBackoff: time.Millisecond,
+	}, logr.Discard())
+	require.NoError(t, err)
+	record := testRecord("alice")
+	require.NoError(t, sink.Write(context.Background(), &record))
+	require.NoError(t, sink.Close())
+
+	batches, requests := receiver.received()
+	assert.Empty(t, batches)
+	assert.Equal(t, 1, requests)
+}
+
+func TestWebhookSinkDropsRecordsWhenFull(t *testing.T) {
+	posting := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		select {
+		case posting <- struct{}{}:
+		default:
+		}
+		<-release
+	}))
+	t.Cleanup(server.Close)
+
+	sink, err := NewWebhookSink(WebhookConfig{URL: server.URL, BatchSize: 1}, logr.Discard())
+	require.NoError(t, err)
+	dropped := testutil.ToFloat64(fmetrics.AuditRecordsDropped.WithLabelValues("webhook"))
+
+	// The first record is being posted, so the queue of ten records fills up.
+	record := testRecord("alice")
+	require.NoError(t, sink.Write(context.Background(), &record))
+	<-posting
+	for range 15 {
+		require.NoError(t, sink.Write(context.Background(), &record))
+	}
+	assert.Equal(t, dropped+5, testutil.ToFloat64(fmetrics.AuditRecordsDropped.WithLabelValues("webhook")))
+
+	close(release)
+	require.NoError(t, sink.Close())
+}
+
+func TestSyslogSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	sinks, err := NewSinks(Config{SyslogAddress: "udp://" + conn.LocalAddr().String()}, logr.Discard())
+	require.NoError(t, err)
+	require.Len(t, sinks, 1)
+	logger := NewLogger(logr.Discard(), sinks...)
+
+	record := testRecord("alice")
+	record.Outcome = OutcomeDenied
+	logger.Record(context.Background(), record)
+	require.NoError(t, logger.Close())
+
+	buf := make([]byte, 4096)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	msg := string(buf[:n])
+	// auth facility (4) and warning severity (4)
+	assert.True(t, strings.HasPrefix(msg, "<36>"), msg)
+	assert.Contains(t, msg, "external-secrets-federation")
+	assert.Contains(t, msg, `"subject":"alice"`)
+	assert.Contains(t, msg, `"outcome":"denied"`)
+}
@@ -0,0 +1,48 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package audit implements the audit log of the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package audit
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+)
+
+// LogSink writes audit records to a logr logger. It is the sink of the federation server
+// when no other sink is configured.
+type LogSink struct {
+	log logr.Logger
+}
+
+// NewLogSink creates a sink writing to log.
+func NewLogSink(log logr.Logger) *LogSink {
+	return &LogSink{log: log}
+}
+
+// Write logs the record.
+func (s *LogSink) Write(_ context.Context, record *Record) error {
+	s.log.Info("federation "+string(record.Event)+" "+string(record.Outcome), "record", record)
+	return nil
+}
+
+// Close does nothing.
+func (s *LogSink) Close() error {
+	return nil
+}
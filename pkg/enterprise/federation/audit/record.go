@@ -0,0 +1,91 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package audit implements the audit log of the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package audit
+
+import "time"
+
+// Event is the kind of request an audit record is about.
+type Event string
+
+const (
+	// EventAuthentication records an authentication attempt.
+	EventAuthentication Event = "authentication"
+	// EventAuthorization records a request no authorization allowed, or that was over a limit.
+	EventAuthorization Event = "authorization"
+	// EventSecretRead records a read of a secret through a ClusterSecretStore.
+	EventSecretRead Event = "secret.read"
+	// EventSecretPush records a push of a secret into a ClusterSecretStore.
+	EventSecretPush Event = "secret.push"
+	// EventSecretDelete records the deletion of a pushed secret from a ClusterSecretStore.
+	EventSecretDelete Event = "secret.delete"
+	// EventGeneratorIssue records the issuance of credentials by a generator.
+	EventGeneratorIssue Event = "generator.issue"
+	// EventRevocation records the revocation of issued credentials.
+	EventRevocation Event = "revocation"
+	// EventLeaseRenewal records the renewal of the lease of issued credentials.
+	EventLeaseRenewal Event = "lease.renewal"
+)
+
+// Outcome is the result of the request an audit record is about.
+type Outcome string
+
+const (
+	// OutcomeSuccess is the outcome of requests that were served.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure is the outcome of requests that failed, e.g. on an invalid token or a provider error.
+	OutcomeFailure Outcome = "failure"
+	// OutcomeDenied is the outcome of requests that were not authorized.
+	OutcomeDenied Outcome = "denied"
+)
+
+// Record is an entry of the audit log. It identifies the caller and the requested resource,
+// and never holds secret values.
+type Record struct {
+	Time          time.Time `json:"time"`
+	RequestID     string    `json:"requestId,omitempty"`
+	Event         Event     `json:"event"`
+	Outcome       Outcome   `json:"outcome"`
+	Reason        string    `json:"reason,omitempty"`
+	Authenticator string    `json:"authenticator,omitempty"`
+	Issuer        string    `json:"issuer,omitempty"`
+	Subject       string    `json:"subject,omitempty"`
+	SourceIP      string    `json:"sourceIP,omitempty"`
+	HTTPMethod    string    `json:"httpMethod,omitempty"`
+	Path          string    `json:"path,omitempty"`
+	Workload      *Workload `json:"workload,omitempty"`
+	Resource      *Resource `json:"resource,omitempty"`
+}
+
+// Workload is the Kubernetes workload on whose behalf a request was made.
+type Workload struct {
+	Namespace      string `json:"namespace,omitempty"`
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	Pod            string `json:"pod,omitempty"`
+}
+
+// Resource is the ClusterSecretStore or generator a request was made for.
+type Resource struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Property  string `json:"property,omitempty"`
+	Version   string `json:"version,omitempty"`
+}
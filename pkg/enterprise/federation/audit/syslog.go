@@ -0,0 +1,61 @@
+//go:build !windows && !plan9
+
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package audit implements the audit log of the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+)
+
+// SyslogSink writes audit records as JSON messages to syslog with the auth facility.
+// Denied and failed requests are logged as warnings.
+type SyslogSink struct {
+	w *syslog.Writer
+}
+
+// NewSyslogSink connects to the syslog server at address over network, e.g. udp, tcp or unix.
+func NewSyslogSink(network, address, tag string) (*SyslogSink, error) {
+	w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_AUTH, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return &SyslogSink{w: w}, nil
+}
+
+// Write writes the record as a single message.
+func (s *SyslogSink) Write(_ context.Context, record *Record) error {
+	msg, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if record.Outcome == OutcomeSuccess {
+		return s.w.Info(string(msg))
+	}
+	return s.w.Warning(string(msg))
+}
+
+// Close closes the connection to syslog.
+func (s *SyslogSink) Close() error {
+	return s.w.Close()
+}
@@ -0,0 +1,45 @@
+//go:build windows || plan9
+
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package audit implements the audit log of the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package audit
+
+import (
+	"context"
+	"errors"
+)
+
+// SyslogSink is not supported on this platform.
+type SyslogSink struct{}
+
+// NewSyslogSink returns an error, as syslog is not supported on this platform.
+func NewSyslogSink(_, _, _ string) (*SyslogSink, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
+
+// Write does nothing.
+func (s *SyslogSink) Write(_ context.Context, _ *Record) error {
+	return nil
+}
+
+// Close does nothing.
+func (s *SyslogSink) Close() error {
+	return nil
+}
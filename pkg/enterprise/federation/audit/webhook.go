@@ -0,0 +1,217 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package audit implements the audit log of the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/fmetrics"
+)
+
+const (
+	defaultWebhookBatchSize     = 100
+	defaultWebhookFlushInterval = 5 * time.Second
+	defaultWebhookMaxRetries    = 5
+	defaultWebhookRetryBackoff  = time.Second
+	defaultWebhookTimeout       = 10 * time.Second
+)
+
+var errSinkClosed = errors.New("audit sink is closed")
+
+// WebhookConfig configures a WebhookSink.
+type WebhookConfig struct {
+	// URL batches of records are posted to as a JSON array.
+	URL string
+	// Headers are added to every request, e.g. an Authorization header.
+	Headers http.Header
+	// BatchSize is the maximum number of records posted at once. Defaults to 100.
+	BatchSize int
+	// FlushInterval is the maximum time a record waits before it is posted. Defaults to 5s.
+	FlushInterval time.Duration
+	// MaxRetries is the number of times a failed batch is posted again. Defaults to 5 if nil.
+	MaxRetries *int
+	// RetryBackoff is the wait before the first retry, doubled on every retry. Defaults to 1s.
+	RetryBackoff time.Duration
+	// Client posts the batches. Defaults to a client with a 10s timeout.
+	Client *http.Client
+}
+
+// WebhookSink posts batches of audit records to an HTTP endpoint. Batches are posted
+// in the background and retried on network errors, 429 and 5xx responses.
+// Records are dropped while the queue is full, so a slow endpoint never stalls requests.
+type WebhookSink struct {
+	cfg        WebhookConfig
+	maxRetries int
+	log        logr.Logger
+	mu         sync.RWMutex
+	closed     bool
+	records    chan Record
+	done       chan struct{}
+}
+
+// NewWebhookSink creates a WebhookSink and starts posting batches.
+func NewWebhookSink(cfg WebhookConfig, log logr.Logger) (*WebhookSink, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("webhook url is required")
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultWebhookBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultWebhookFlushInterval
+	}
+	maxRetries := defaultWebhookMaxRetries
+	if cfg.MaxRetries != nil {
+		maxRetries = max(*cfg.MaxRetries, 0)
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultWebhookRetryBackoff
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	s := &WebhookSink{
+		cfg:        cfg,
+		maxRetries: maxRetries,
+		log:        log,
+		records:    make(chan Record, cfg.BatchSize*10),
+		done:       make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// Write queues the record. The record is dropped and counted if the queue is full.
+func (s *WebhookSink) Write(_ context.Context, record *Record) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errSinkClosed
+	}
+	select {
+	case s.records <- *record:
+	default:
+		fmetrics.AuditRecordsDropped.WithLabelValues("webhook").Inc()
+	}
+	return nil
+}
+
+// Close posts the queued records and stops the sink.
+func (s *WebhookSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.records)
+	s.mu.Unlock()
+	<-s.done
+	return nil
+}
+
+func (s *WebhookSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, s.cfg.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.post(batch); err != nil {
+			s.log.Error(err, "failed to post audit records", "records", len(batch))
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case record, ok := <-s.records:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= s.cfg.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// post posts the batch, retrying with exponential backoff.
+func (s *WebhookSink) post(batch []Record) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	backoff := s.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.send(body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.maxRetries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// send posts the body once and reports whether a failure can be retried.
+func (s *WebhookSink) send(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for name, values := range s.cfg.Headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+	retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	return retry, fmt.Errorf("audit webhook responded with status %s", res.Status)
+}
@@ -0,0 +1,243 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package provider implements the federation provider.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSTSEndpoint is the global STS endpoint.
+	DefaultSTSEndpoint = "https://sts.amazonaws.com"
+	// AWSIAMServerIDHeader is the header callers sign to bind their request to a federation server.
+	AWSIAMServerIDHeader = "X-External-Secrets-Server-Id"
+
+	stsAPIVersion = "2011-06-15"
+)
+
+// SignedRequest is a sts:GetCallerIdentity request signed by the caller with its AWS credentials.
+type SignedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Body    []byte      `json:"body"`
+	Headers http.Header `json:"headers"`
+}
+
+// CallerIdentity is the identity STS resolved a signed request to.
+type CallerIdentity struct {
+	ARN     string
+	Account string
+	UserID  string
+	// PrincipalARN is the ARN of the IAM user or role of the caller. Assumed role sessions map to their role.
+	PrincipalARN string
+	// SessionName is the session name of assumed roles.
+	SessionName string
+}
+
+// AWSIAMProvider implements the AWS IAM provider. Callers are authenticated by sending the
+// sts:GetCallerIdentity request they signed to STS, which only succeeds for valid signatures.
+type AWSIAMProvider struct {
+	STSEndpoint string
+	ServerID    string
+	AccountIDs  []string
+	httpClient  *http.Client
+}
+
+// NewAWSIAMProvider creates a new AWS IAM provider.
+func NewAWSIAMProvider(stsEndpoint, serverID string, accountIDs []string) *AWSIAMProvider {
+	if stsEndpoint == "" {
+		stsEndpoint = DefaultSTSEndpoint
+	}
+	return &AWSIAMProvider{
+		STSEndpoint: strings.TrimSuffix(stsEndpoint, "/"),
+		ServerID:    serverID,
+		AccountIDs:  accountIDs,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// GetJWKS is not supported, as callers authenticate with signed requests instead of tokens.
+func (p *AWSIAMProvider) GetJWKS(_ context.Context, _, _ string, _ []byte) (map[string]map[string]string, error) {
+	return nil, errors.New("AWS IAM federation does not verify tokens")
+}
+
+// CheckIdentityExists always returns true. Checking IAM principals requires IAM
+// credentials the federation server does not hold.
+func (p *AWSIAMProvider) CheckIdentityExists(_ context.Context, _ string) (bool, error) {
+	return true, nil
+}
+
+// GetCallerIdentity checks that the signed request is a sts:GetCallerIdentity request for the
+// STS endpoint of this federation and sends it to STS. The request is always sent to the
+// configured endpoint, never to a URL chosen by the caller.
+func (p *AWSIAMProvider) GetCallerIdentity(ctx context.Context, signed *SignedRequest) (*CallerIdentity, error) {
+	if err := p.validate(signed); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.STSEndpoint+"/", bytes.NewReader(signed.Body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range signed.Headers {
+		if strings.EqualFold(name, "Host") || strings.EqualFold(name, "Content-Length") {
+			continue
+		}
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call STS: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read STS response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("STS rejected the request with status %d", resp.StatusCode)
+	}
+
+	identity, err := parseCallerIdentity(resp.Header.Get("Content-Type"), body)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.AccountIDs) > 0 && !slices.Contains(p.AccountIDs, identity.Account) {
+		return nil, fmt.Errorf("account %s is not allowed", identity.Account)
+	}
+	identity.PrincipalARN, identity.SessionName, err = principalARN(identity.ARN)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (p *AWSIAMProvider) validate(signed *SignedRequest) error {
+	if signed.Method != http.MethodPost {
+		return fmt.Errorf("unexpected method %q", signed.Method)
+	}
+	reqURL, err := url.Parse(signed.URL)
+	if err != nil {
+		return fmt.Errorf("invalid request url: %w", err)
+	}
+	if reqURL.Scheme+"://"+reqURL.Host != p.STSEndpoint || strings.TrimPrefix(reqURL.Path, "/") != "" || reqURL.RawQuery != "" {
+		return fmt.Errorf("request is not signed for %s", p.STSEndpoint)
+	}
+	values, err := url.ParseQuery(string(signed.Body))
+	if err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	if len(values) != 2 || values.Get("Action") != "GetCallerIdentity" || values.Get("Version") != stsAPIVersion {
+		return errors.New("request is not a sts:GetCallerIdentity request")
+	}
+	authorization := signed.Headers.Get("Authorization")
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 ") {
+		return errors.New("request is not signed with AWS signature version 4")
+	}
+	if p.ServerID == "" {
+		return nil
+	}
+	if signed.Headers.Get(AWSIAMServerIDHeader) != p.ServerID {
+		return fmt.Errorf("missing or invalid %s header", AWSIAMServerIDHeader)
+	}
+	if !slices.Contains(signedHeaders(authorization), strings.ToLower(AWSIAMServerIDHeader)) {
+		return fmt.Errorf("%s header is not signed", AWSIAMServerIDHeader)
+	}
+	return nil
+}
+
+// signedHeaders returns the SignedHeaders of a signature version 4 Authorization header.
+func signedHeaders(authorization string) []string {
+	for _, part := range strings.Split(strings.TrimPrefix(authorization, "AWS4-HMAC-SHA256 "), ",") {
+		if headers, ok := strings.CutPrefix(strings.TrimSpace(part), "SignedHeaders="); ok {
+			return strings.Split(headers, ";")
+		}
+	}
+	return nil
+}
+
+type getCallerIdentityResult struct {
+	Arn     string `xml:"Arn" json:"Arn"`
+	UserID  string `xml:"UserId" json:"UserId"`
+	Account string `xml:"Account" json:"Account"`
+}
+
+// parseCallerIdentity parses the GetCallerIdentity response, in XML or in JSON if the caller signed
+// an Accept: application/json header.
+func parseCallerIdentity(contentType string, body []byte) (*CallerIdentity, error) {
+	var result getCallerIdentityResult
+	if strings.HasPrefix(contentType, "application/json") {
+		var resp struct {
+			GetCallerIdentityResponse struct {
+				GetCallerIdentityResult getCallerIdentityResult
+			}
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse STS response: %w", err)
+		}
+		result = resp.GetCallerIdentityResponse.GetCallerIdentityResult
+	} else {
+		var resp struct {
+			Result getCallerIdentityResult `xml:"GetCallerIdentityResult"`
+		}
+		if err := xml.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse STS response: %w", err)
+		}
+		result = resp.Result
+	}
+	if result.Arn == "" || result.Account == "" {
+		return nil, errors.New("STS response missing caller identity")
+	}
+	return &CallerIdentity{ARN: result.Arn, Account: result.Account, UserID: result.UserID}, nil
+}
+
+// principalARN maps the ARN of a caller to its IAM principal, e.g. the session
+// arn:aws:sts::123456789012:assumed-role/ci/session maps to arn:aws:iam::123456789012:role/ci.
+func principalARN(arn string) (string, string, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return "", "", fmt.Errorf("invalid ARN %q", arn)
+	}
+	partition, service, account, resource := parts[1], parts[2], parts[4], parts[5]
+	if service == "sts" {
+		role, session, ok := strings.Cut(strings.TrimPrefix(resource, "assumed-role/"), "/")
+		if !strings.HasPrefix(resource, "assumed-role/") || !ok {
+			return "", "", fmt.Errorf("unsupported STS ARN %q", arn)
+		}
+		return fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, account, role), session, nil
+	}
+	return arn, "", nil
+}
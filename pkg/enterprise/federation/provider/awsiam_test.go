@@ -0,0 +1,144 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package provider implements the federation provider.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSTSBody          = "Action=GetCallerIdentity&Version=2011-06-15"
+	testSTSAuthorization = "AWS4-HMAC-SHA256 Credential=AKIA/20250101/us-east-1/sts/aws4_request, SignedHeaders=host;x-amz-date;x-external-secrets-server-id, Signature=abc"
+)
+
+// newSTS starts a stand-in STS that accepts requests with testSTSAuthorization and answers for arn.
+func newSTS(t *testing.T, arn, contentType string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || string(body) != testSTSBody || r.Header.Get("Authorization") != testSTSAuthorization {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		if contentType == "application/json" {
+			_, _ = io.WriteString(w, `{"GetCallerIdentityResponse":{"GetCallerIdentityResult":{"Arn":"`+arn+`","UserId":"AROA:session","Account":"123456789012"}}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>`+arn+`</Arn>
+    <UserId>AROA:session</UserId>
+    <Account>123456789012</Account>
+  </GetCallerIdentityResult>
+</GetCallerIdentityResponse>`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func signedRequest(url string) *SignedRequest {
+	return &SignedRequest{
+		Method: http.MethodPost,
+		URL:    url + "/",
+		Body:   []byte(testSTSBody),
+		Headers: http.Header{
+			"Authorization":      {testSTSAuthorization},
+			"X-Amz-Date":         {"20250101T000000Z"},
+			AWSIAMServerIDHeader: {"federation.example.com"},
+			"Content-Type":       {"application/x-www-form-urlencoded; charset=utf-8"},
+			"Host":               {"attacker.example.com"},
+			"Content-Length":     {"43"},
+		},
+	}
+}
+
+func TestAWSIAMProvider_GetCallerIdentity(t *testing.T) {
+	sts := newSTS(t, "arn:aws:sts::123456789012:assumed-role/app/i-0123", "text/xml")
+	p := NewAWSIAMProvider(sts.URL, "federation.example.com", []string{"123456789012"})
+
+	identity, err := p.GetCallerIdentity(context.Background(), signedRequest(sts.URL))
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:sts::123456789012:assumed-role/app/i-0123", identity.ARN)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/app", identity.PrincipalARN)
+	assert.Equal(t, "i-0123", identity.SessionName)
+	assert.Equal(t, "123456789012", identity.Account)
+	assert.Equal(t, "AROA:session", identity.UserID)
+
+	tests := []struct {
+		name    string
+		mutate  func(*SignedRequest)
+		wantErr string
+	}{
+		{name: "other endpoint", mutate: func(r *SignedRequest) { r.URL = "https://sts.amazonaws.com/" }, wantErr: "is not signed for"},
+		{name: "query", mutate: func(r *SignedRequest) { r.URL += "?Action=GetCallerIdentity" }, wantErr: "is not signed for"},
+		{name: "method", mutate: func(r *SignedRequest) { r.Method = http.MethodGet }, wantErr: "unexpected method"},
+		{name: "other action", mutate: func(r *SignedRequest) { r.Body = []byte("Action=AssumeRole&Version=2011-06-15") }, wantErr: "not a sts:GetCallerIdentity request"},
+		{name: "extra parameter", mutate: func(r *SignedRequest) { r.Body = []byte(testSTSBody + "&RoleArn=x") }, wantErr: "not a sts:GetCallerIdentity request"},
+		{name: "not signed", mutate: func(r *SignedRequest) { r.Headers.Del("Authorization") }, wantErr: "signature version 4"},
+		{name: "other server", mutate: func(r *SignedRequest) { r.Headers.Set(AWSIAMServerIDHeader, "other") }, wantErr: "invalid X-External-Secrets-Server-Id header"},
+		{name: "server id not signed", mutate: func(r *SignedRequest) {
+			r.Headers.Set("Authorization", "AWS4-HMAC-SHA256 Credential=AKIA/20250101/us-east-1/sts/aws4_request, SignedHeaders=host;x-amz-date, Signature=abc")
+		}, wantErr: "header is not signed"},
+		{name: "invalid signature", mutate: func(r *SignedRequest) {
+			r.Headers.Set("Authorization", testSTSAuthorization+"def")
+		}, wantErr: "STS rejected the request with status 403"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := signedRequest(sts.URL)
+			tt.mutate(req)
+			_, err := p.GetCallerIdentity(context.Background(), req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestAWSIAMProvider_GetCallerIdentityAccounts(t *testing.T) {
+	sts := newSTS(t, "arn:aws:iam::123456789012:user/ci", "application/json")
+
+	identity, err := NewAWSIAMProvider(sts.URL, "", nil).GetCallerIdentity(context.Background(), signedRequest(sts.URL))
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:user/ci", identity.PrincipalARN)
+	assert.Empty(t, identity.SessionName)
+
+	_, err = NewAWSIAMProvider(sts.URL, "", []string{"210987654321"}).GetCallerIdentity(context.Background(), signedRequest(sts.URL))
+	assert.ErrorContains(t, err, "account 123456789012 is not allowed")
+}
+
+func TestPrincipalARN(t *testing.T) {
+	principal, session, err := principalARN("arn:aws-us-gov:sts::123456789012:assumed-role/app/session")
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws-us-gov:iam::123456789012:role/app", principal)
+	assert.Equal(t, "session", session)
+
+	_, _, err = principalARN("arn:aws:sts::123456789012:federated-user/bob")
+	assert.Error(t, err)
+	_, _, err = principalARN("not-an-arn")
+	assert.Error(t, err)
+}
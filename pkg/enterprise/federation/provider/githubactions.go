@@ -0,0 +1,212 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package provider implements the federation provider.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// GitHubActionsIssuer is the issuer of GitHub Actions OIDC tokens on github.com.
+	GitHubActionsIssuer = "https://token.actions.githubusercontent.com"
+
+	defaultGitHubActionsJWKSCacheTTL = 1 * time.Hour
+)
+
+// GitHubActionsProvider implements the GitHub Actions OIDC provider.
+type GitHubActionsProvider struct {
+	Issuer       string
+	Audience     string
+	Repositories []string
+	Refs         []string
+	Environments []string
+	httpClient   *http.Client
+	jwksCache    map[string]map[string]string
+	cacheMutex   sync.RWMutex
+	lastFetch    time.Time
+	cacheTTL     time.Duration
+}
+
+// NewGitHubActionsProvider creates a new GitHub Actions provider.
+func NewGitHubActionsProvider(issuer, audience string, repositories, refs, environments []string) *GitHubActionsProvider {
+	if issuer == "" {
+		issuer = GitHubActionsIssuer
+	}
+	return &GitHubActionsProvider{
+		Issuer:       strings.TrimSuffix(issuer, "/"),
+		Audience:     audience,
+		Repositories: repositories,
+		Refs:         refs,
+		Environments: environments,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		jwksCache: make(map[string]map[string]string),
+		cacheTTL:  defaultGitHubActionsJWKSCacheTTL,
+	}
+}
+
+// GetJWKS returns the JSON Web Key Set of the issuer, for tokens of workflows this federation accepts.
+// The restrictions are checked on the claims of the token before its keys are returned, so a token
+// only verifies if its claims match, whichever authenticator verifies it.
+func (p *GitHubActionsProvider) GetJWKS(ctx context.Context, token, issuer string, _ []byte) (map[string]map[string]string, error) {
+	if strings.TrimSuffix(issuer, "/") != p.Issuer {
+		return nil, fmt.Errorf("unexpected issuer %q", issuer)
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+	if err := p.VerifyClaims(claims); err != nil {
+		return nil, err
+	}
+
+	p.cacheMutex.RLock()
+	if time.Since(p.lastFetch) < p.cacheTTL && len(p.jwksCache) > 0 {
+		cachedJWKS := p.jwksCache
+		p.cacheMutex.RUnlock()
+		return cachedJWKS, nil
+	}
+	p.cacheMutex.RUnlock()
+
+	p.cacheMutex.Lock()
+	defer p.cacheMutex.Unlock()
+	jwks, err := fetchJWKS(ctx, p.httpClient, p.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	p.jwksCache = jwks
+	p.lastFetch = time.Now()
+	return jwks, nil
+}
+
+// VerifyClaims checks the audience, repository, ref and environment of a workflow token.
+// A federation without an audience accepts no token.
+func (p *GitHubActionsProvider) VerifyClaims(claims jwt.MapClaims) error {
+	if p.Audience == "" {
+		return errors.New("no audience configured")
+	}
+	audiences, err := claims.GetAudience()
+	if err != nil || !slices.Contains(audiences, p.Audience) {
+		return fmt.Errorf("token is not issued for audience %q", p.Audience)
+	}
+	if err := matchClaim(claims, "repository", p.Repositories); err != nil {
+		return err
+	}
+	if err := matchClaim(claims, "ref", p.Refs); err != nil {
+		return err
+	}
+	return matchClaim(claims, "environment", p.Environments)
+}
+
+// CheckIdentityExists always returns true. Workflow tokens are short lived and
+// tied to a single job, so there is no identity to check.
+func (p *GitHubActionsProvider) CheckIdentityExists(_ context.Context, _ string) (bool, error) {
+	return true, nil
+}
+
+// matchClaim matches a string claim against globs. Any value matches when there are no globs.
+func matchClaim(claims jwt.MapClaims, name string, globs []string) error {
+	if len(globs) == 0 {
+		return nil
+	}
+	value, _ := claims[name].(string)
+	if value == "" {
+		return fmt.Errorf("token missing %s claim", name)
+	}
+	for _, glob := range globs {
+		if ok, _ := path.Match(glob, value); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %q is not allowed", name, value)
+}
+
+// fetchJWKS fetches the JSON Web Key Set of an OIDC issuer through its discovery document.
+func fetchJWKS(ctx context.Context, httpClient *http.Client, issuer string) (map[string]map[string]string, error) {
+	var discovery struct {
+		JwksURI string `json:"jwks_uri"`
+	}
+	if err := getJSON(ctx, httpClient, issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("failed to fetch discovery document: %w", err)
+	}
+	if discovery.JwksURI == "" {
+		return nil, errors.New("discovery document missing jwks_uri field")
+	}
+
+	var jwksResponse struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	if err := getJSON(ctx, httpClient, discovery.JwksURI, &jwksResponse); err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	jwks := make(map[string]map[string]string)
+	for _, key := range jwksResponse.Keys {
+		kid, ok := key["kid"].(string)
+		if !ok {
+			continue
+		}
+		stringKey := make(map[string]string)
+		for k, v := range key {
+			if strVal, ok := v.(string); ok {
+				stringKey[k] = strVal
+			}
+		}
+		jwks[kid] = stringKey
+	}
+	if len(jwks) == 0 {
+		return nil, errors.New("no valid keys found in JWKS response")
+	}
+	return jwks, nil
+}
+
+func getJSON(ctx context.Context, httpClient *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
+	}
+	return json.Unmarshal(body, v)
+}
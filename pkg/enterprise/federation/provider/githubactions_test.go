@@ -0,0 +1,125 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package provider implements the federation provider.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOIDCIssuer starts a stand-in OIDC issuer serving a discovery document and a JWKS.
+func newOIDCIssuer(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+	jwksFetches := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]string{"issuer": srv.URL, "jwks_uri": srv.URL + "/.well-known/jwks"})
+		case "/.well-known/jwks":
+			jwksFetches++
+			_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{"kid": "key-1", "kty": "RSA", "n": "AQAB", "e": "AQAB"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &jwksFetches
+}
+
+func workflowToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("unverified"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestGitHubActionsProvider_VerifyClaims(t *testing.T) {
+	p := NewGitHubActionsProvider("", "https://federation.example.com",
+		[]string{"my-org/*"}, []string{"refs/heads/main", "refs/heads/release/*"}, []string{"production"})
+	assert.Equal(t, GitHubActionsIssuer, p.Issuer)
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"aud":         "https://federation.example.com",
+			"repository":  "my-org/app",
+			"ref":         "refs/heads/release/v1",
+			"environment": "production",
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(jwt.MapClaims)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(jwt.MapClaims) {}},
+		{name: "other audience", mutate: func(c jwt.MapClaims) { c["aud"] = "sts.amazonaws.com" }, wantErr: "audience"},
+		{name: "other organization", mutate: func(c jwt.MapClaims) { c["repository"] = "other-org/app" }, wantErr: `repository "other-org/app" is not allowed`},
+		{name: "feature branch", mutate: func(c jwt.MapClaims) { c["ref"] = "refs/heads/feature" }, wantErr: "ref"},
+		{name: "missing environment", mutate: func(c jwt.MapClaims) { delete(c, "environment") }, wantErr: "token missing environment claim"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			tt.mutate(claims)
+			err := p.VerifyClaims(claims)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	// Any workflow of the audience matches without restrictions.
+	aud := "https://federation.example.com"
+	assert.NoError(t, NewGitHubActionsProvider("", aud, nil, nil, nil).VerifyClaims(jwt.MapClaims{"aud": aud}))
+	// No token matches without an audience.
+	assert.ErrorContains(t, NewGitHubActionsProvider("", "", nil, nil, nil).VerifyClaims(jwt.MapClaims{"aud": aud}), "no audience configured")
+}
+
+func TestGitHubActionsProvider_GetJWKS(t *testing.T) {
+	issuer, jwksFetches := newOIDCIssuer(t)
+	p := NewGitHubActionsProvider(issuer.URL+"/", "https://federation.example.com", []string{"my-org/app"}, nil, nil)
+
+	token := workflowToken(t, jwt.MapClaims{"iss": issuer.URL, "aud": "https://federation.example.com", "repository": "my-org/app"})
+	jwks, err := p.GetJWKS(context.Background(), token, issuer.URL, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "RSA", jwks["key-1"]["kty"])
+
+	_, err = p.GetJWKS(context.Background(), token, issuer.URL, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *jwksFetches, "JWKS should be cached")
+
+	_, err = p.GetJWKS(context.Background(), token, "https://other.example.com", nil)
+	assert.ErrorContains(t, err, "unexpected issuer")
+
+	other := workflowToken(t, jwt.MapClaims{"iss": issuer.URL, "aud": "https://federation.example.com", "repository": "my-org/other"})
+	_, err = p.GetJWKS(context.Background(), other, issuer.URL, nil)
+	assert.ErrorContains(t, err, "is not allowed")
+}
@@ -31,7 +31,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 )
 
@@ -76,7 +75,11 @@ func (k *KubernetesProvider) GetJWKS(ctx context.Context, token, _ string, caCrt
 	pool := x509.NewCertPool()
 	pool.AddCert(ca)
 	oidcConfigURL := fmt.Sprintf("%s/.well-known/openid-configuration", k.URL)
-	sni := strings.TrimPrefix(k.URL, "https://")
+	apiURL, err := url.Parse(k.URL)
+	if err != nil {
+		return nil, err
+	}
+	sni := apiURL.Hostname()
 	// TODO[gusfcarvalho]: factor out a method to generate http Clients
 	httpClient := &http.Client{
 		Timeout: 10 * time.Second,
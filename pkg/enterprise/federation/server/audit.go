@@ -0,0 +1,114 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/labstack/echo/v4"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+)
+
+// SetAuditLogger sets the audit log of the handler. By default, audit records are written
+// to the log of the handler.
+func (s *Handler) SetAuditLogger(logger *audit.Logger) {
+	s.auditor = logger
+}
+
+// SetTrustedProxies sets the proxies the source IP of audit records is read from the
+// X-Forwarded-For header of. By default, the source IP is the address of the peer and
+// X-Forwarded-For is ignored, as any client can set it.
+func (s *Handler) SetTrustedProxies(cidrs []string) error {
+	if len(cidrs) == 0 {
+		s.ipExtractor = echo.ExtractIPDirect()
+		return nil
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy range %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	s.ipExtractor = echo.ExtractIPFromXFFHeader(options...)
+	return nil
+}
+
+// audit records the request to the audit log.
+func (s *Handler) audit(c echo.Context, event audit.Event, outcome audit.Outcome, resource *audit.Resource, reason string) {
+	record := newAuditRecord(c, event, outcome)
+	record.Resource = resource
+	record.Reason = reason
+	s.auditor.Record(c.Request().Context(), record)
+}
+
+// newAuditRecord builds an audit record of the request and of its caller, once authenticated.
+func newAuditRecord(c echo.Context, event audit.Event, outcome audit.Outcome) audit.Record {
+	record := audit.Record{
+		RequestID:  c.Response().Header().Get(echo.HeaderXRequestID),
+		Event:      event,
+		Outcome:    outcome,
+		SourceIP:   c.RealIP(),
+		HTTPMethod: c.Request().Method,
+		Path:       c.Request().URL.Path,
+	}
+	if authInfo, _ := c.Get("authInfo").(*auth.Info); authInfo != nil {
+		record.Authenticator = authInfo.Method
+		record.Issuer = authInfo.Provider
+		record.Subject = authInfo.Subject
+	}
+	if workloadInfo, _ := c.Get("workloadInfo").(*auth.WorkloadInfo); workloadInfo != nil {
+		record.Workload = &audit.Workload{Namespace: workloadInfo.Namespace}
+		if workloadInfo.ServiceAccount != nil {
+			record.Workload.ServiceAccount = workloadInfo.ServiceAccount.Name
+		}
+		if workloadInfo.Pod != nil {
+			record.Workload.Pod = workloadInfo.Pod.Name
+		}
+	}
+	return record
+}
+
+func secretResource(storeName string, ref esv1.ExternalSecretDataRemoteRef) *audit.Resource {
+	return &audit.Resource{
+		Kind:     esv1.ClusterSecretStoreKind,
+		Name:     storeName,
+		Key:      ref.Key,
+		Property: ref.Property,
+		Version:  ref.Version,
+	}
+}
+
+func generatorResource(name, kind, namespace string) *audit.Resource {
+	return &audit.Resource{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+	}
+}
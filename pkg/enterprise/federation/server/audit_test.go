@@ -0,0 +1,140 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+)
+
+// recordingSink keeps the audit records written to it.
+type recordingSink struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+func (s *recordingSink) Write(_ context.Context, record *audit.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, *record)
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	return nil
+}
+
+func TestNewAuditRecord(t *testing.T) {
+	sink := &recordingSink{}
+	s := &Handler{auditor: audit.NewLogger(logr.Discard(), sink)}
+	// httptest requests come from 192.0.2.1.
+	require.NoError(t, s.SetTrustedProxies([]string{"192.0.2.0/24"}))
+	e := echo.New()
+	e.IPExtractor = s.ipExtractor
+	req := httptest.NewRequest(http.MethodPost, "/generators/apps/Password/db", http.NoBody)
+	req.Header.Set(echo.HeaderXForwardedFor, "10.0.0.7")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Response().Header().Set(echo.HeaderXRequestID, "request-1")
+	setAuthContext(c, &auth.Info{
+		Method:   "spiffe",
+		Provider: "example.org",
+		Subject:  "spiffe://example.org/ns/apps/sa/api",
+		KubeAttributes: &auth.KubeAttributes{
+			Namespace:      "apps",
+			ServiceAccount: &auth.ServiceAccount{Name: "api"},
+			Pod:            &auth.PodInfo{Name: "api-0"},
+		},
+	})
+
+	s.audit(c, audit.EventGeneratorIssue, audit.OutcomeSuccess, generatorResource("db", "Password", "apps"), "")
+
+	require.Len(t, sink.records, 1)
+	record := sink.records[0]
+	assert.False(t, record.Time.IsZero())
+	record.Time = record.Time.UTC().Truncate(0)
+	assert.Equal(t, audit.Record{
+		Time:          record.Time,
+		RequestID:     "request-1",
+		Event:         audit.EventGeneratorIssue,
+		Outcome:       audit.OutcomeSuccess,
+		Authenticator: "spiffe",
+		Issuer:        "example.org",
+		Subject:       "spiffe://example.org/ns/apps/sa/api",
+		SourceIP:      "10.0.0.7",
+		HTTPMethod:    http.MethodPost,
+		Path:          "/generators/apps/Password/db",
+		Workload:      &audit.Workload{Namespace: "apps", ServiceAccount: "api", Pod: "api-0"},
+		Resource:      &audit.Resource{Kind: "Password", Name: "db", Namespace: "apps"},
+	}, record)
+}
+
+func TestNewAuditRecordSourceIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		want           string
+	}{
+		{
+			name: "X-Forwarded-For is ignored by default",
+			want: "192.0.2.1",
+		},
+		{
+			name:           "X-Forwarded-For of untrusted proxies is ignored",
+			trustedProxies: []string{"198.51.100.0/24"},
+			want:           "192.0.2.1",
+		},
+		{
+			name:           "X-Forwarded-For of trusted proxies is used",
+			trustedProxies: []string{"192.0.2.0/24"},
+			want:           "10.0.0.7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", false, nil)
+			require.NoError(t, s.SetTrustedProxies(tt.trustedProxies))
+			e := echo.New()
+			e.IPExtractor = s.ipExtractor
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.Header.Set(echo.HeaderXForwardedFor, "10.0.0.7")
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			record := newAuditRecord(c, audit.EventAuthentication, audit.OutcomeSuccess)
+			assert.Equal(t, tt.want, record.SourceIP)
+		})
+	}
+}
+
+func TestSetTrustedProxiesInvalid(t *testing.T) {
+	s := NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", false, nil)
+	assert.ErrorContains(t, s.SetTrustedProxies([]string{"10.0.0.1"}), "invalid trusted proxy range")
+}
@@ -20,11 +20,11 @@
 package auth
 
 import (
-	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
 )
 
 // Info contains information about the authenticated user.
@@ -37,6 +37,11 @@ type Info struct {
 	Subject string `json:"subject"`
 	// KubeAttributes contains information about the user's Kubernetes context.
 	KubeAttributes *KubeAttributes `json:"kubeAttributes"`
+	// Claims contains the verified claims of the user's token, used to match and template authorizations.
+	Claims map[string]interface{} `json:"claims,omitempty"`
+	// Federation is the federation that verified the caller, for methods verified by a single
+	// federation. Only the Authorizations of this federation apply to the caller.
+	Federation *fedv1alpha1.FederationRef `json:"federation,omitempty"`
 }
 
 // KubeAttributes contains information about the user's Kubernetes context.
@@ -73,6 +78,9 @@ type WorkloadInfo struct {
 	ServiceAccount *ServiceAccount `json:"serviceaccount"`
 	// Pod is the workload's pod, if any.
 	Pod *PodInfo `json:"pod,omitempty"`
+	// Federations are the federations the issuer of the x-workload-token is bound to.
+	// Only the Authorizations of these federations apply to the workload.
+	Federations []fedv1alpha1.FederationRef `json:"-"`
 }
 
 // Authenticator is the interface that an authentication implementation must
@@ -109,56 +117,11 @@ type WorkloadTokenClaims struct {
 	} `json:"kubernetes.io"`
 }
 
-// ParseWorkloadToken parses the x-workload-token header and extracts workload information.
-// It performs unverified parsing to extract claims without signature validation.
-// Returns nil if the token is missing (not an error).
-// Returns an error if the token is present but malformed.
-func ParseWorkloadToken(r *http.Request) (*WorkloadInfo, error) {
-	tokenString := r.Header.Get("x-workload-token")
-	if tokenString == "" {
-		// No token provided - this is acceptable
-		return nil, nil
-	}
-
-	// Parse token without verification to extract claims
-	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
-	token, _, err := parser.ParseUnverified(tokenString, &WorkloadTokenClaims{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse x-workload-token: %w", err)
-	}
-
-	claims, ok := token.Claims.(*WorkloadTokenClaims)
-	if !ok {
-		return nil, errors.New("invalid x-workload-token claims format")
-	}
-
-	// Validate required fields
-	if claims.Kubernetes.Namespace == "" {
-		return nil, errors.New("x-workload-token missing kubernetes.io.namespace")
+// tokenClaims returns all claims of a token that has already been verified.
+func tokenClaims(tokenString string) map[string]interface{} {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, claims); err != nil {
+		return nil
 	}
-	if claims.Kubernetes.ServiceAccount.Name == "" {
-		return nil, errors.New("x-workload-token missing kubernetes.io.serviceaccount.name")
-	}
-	if claims.Kubernetes.ServiceAccount.UID == "" {
-		return nil, errors.New("x-workload-token missing kubernetes.io.serviceaccount.uid")
-	}
-
-	// Build WorkloadInfo
-	workloadInfo := &WorkloadInfo{
-		Namespace: claims.Kubernetes.Namespace,
-		ServiceAccount: &ServiceAccount{
-			Name: claims.Kubernetes.ServiceAccount.Name,
-			UID:  claims.Kubernetes.ServiceAccount.UID,
-		},
-	}
-
-	// Add pod info if available
-	if claims.Kubernetes.Pod != nil {
-		workloadInfo.Pod = &PodInfo{
-			Name: claims.Kubernetes.Pod.Name,
-			UID:  claims.Kubernetes.Pod.UID,
-		}
-	}
-
-	return workloadInfo, nil
+	return claims
 }
@@ -0,0 +1,114 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package auth implements the federation server authorization.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	idfedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/identity/v1alpha1"
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/provider"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+// AWSIAMRequestHeader is the header holding the base64 encoded JSON of a signed
+// sts:GetCallerIdentity request, see provider.SignedRequest.
+const AWSIAMRequestHeader = "X-Aws-Iam-Request"
+
+// AWSIAMAuthenticator authenticates AWS IAM principals. Callers sign a sts:GetCallerIdentity
+// request with their credentials and send it instead of a token. The request is sent to the STS
+// endpoint of an AWSIAMFederation, and the caller is the principal STS resolves it to.
+// Authorizations match the STS endpoint as their issuer and the IAM user or role ARN as their subject.
+type AWSIAMAuthenticator struct{}
+
+// NewAWSIAMAuthenticator creates a new AWSIAMAuthenticator.
+func NewAWSIAMAuthenticator() *AWSIAMAuthenticator {
+	return &AWSIAMAuthenticator{}
+}
+
+// Authenticate implements Authenticator.
+func (a *AWSIAMAuthenticator) Authenticate(r *http.Request) (*Info, error) {
+	header := r.Header.Get(AWSIAMRequestHeader)
+	if header == "" {
+		return nil, fmt.Errorf("missing %s header", AWSIAMRequestHeader)
+	}
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s header: %w", AWSIAMRequestHeader, err)
+	}
+	signed := &provider.SignedRequest{}
+	if err := json.Unmarshal(raw, signed); err != nil {
+		return nil, fmt.Errorf("failed to parse signed request: %w", err)
+	}
+	reqURL, err := url.Parse(signed.URL)
+	if err != nil || reqURL.Host == "" {
+		return nil, errors.New("invalid signed request url")
+	}
+	issuer := reqURL.Scheme + "://" + reqURL.Host
+
+	specs := federationSpecs(issuer, idfedv1alpha1.AWSIAMFederationKind)
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no authorization configured for issuer: %s", issuer)
+	}
+	var errs []error
+	seen := map[fedv1alpha1.FederationRef]bool{}
+	for _, spec := range specs {
+		ref := spec.FederationRef
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		prov, ok := store.GetStore(ref).(*provider.AWSIAMProvider)
+		if !ok {
+			continue
+		}
+		identity, err := prov.GetCallerIdentity(r.Context(), signed)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return &Info{
+			Method:   "aws-iam",
+			Provider: issuer,
+			Subject:  identity.PrincipalARN,
+			Claims: map[string]interface{}{
+				"arn":          identity.ARN,
+				"principal":    identity.PrincipalARN,
+				"account":      identity.Account,
+				"user_id":      identity.UserID,
+				"session_name": identity.SessionName,
+			},
+			Federation: &ref,
+		}, nil
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no AWS IAM federation found for issuer: %s", issuer)
+	}
+	return nil, fmt.Errorf("failed to verify signed request: %w", errors.Join(errs...))
+}
+
+func init() {
+	Register("aws-iam", NewAWSIAMAuthenticator())
+}
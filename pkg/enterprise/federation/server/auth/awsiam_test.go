@@ -0,0 +1,108 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package auth implements the federation server authorization.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	idfedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/identity/v1alpha1"
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/provider"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+const testAWSAuthorization = "AWS4-HMAC-SHA256 Credential=AKIA/20250101/us-east-1/sts/aws4_request, SignedHeaders=host;x-amz-date, Signature=abc"
+
+func awsIAMRequest(t *testing.T, signed *provider.SignedRequest) *http.Request {
+	t.Helper()
+	raw, err := json.Marshal(signed)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/secretstore/hub/secrets/db", http.NoBody)
+	req.Header.Set(AWSIAMRequestHeader, base64.StdEncoding.EncodeToString(raw))
+	return req
+}
+
+func TestAWSIAMAuthenticator_Authenticate(t *testing.T) {
+	stsCalls := 0
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stsCalls++
+		if r.Header.Get("Authorization") != testAWSAuthorization {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = io.WriteString(w, `<GetCallerIdentityResponse><GetCallerIdentityResult>
+<Arn>arn:aws:sts::123456789012:assumed-role/app/i-0123</Arn><UserId>AROA:i-0123</UserId><Account>123456789012</Account>
+</GetCallerIdentityResult></GetCallerIdentityResponse>`)
+	}))
+	t.Cleanup(sts.Close)
+
+	ref := fedv1alpha1.FederationRef{Kind: idfedv1alpha1.AWSIAMFederationKind, Name: "aws-prod"}
+	store.AddStore(ref, provider.NewAWSIAMProvider(sts.URL, "", []string{"123456789012"}))
+	specs := []*fedv1alpha1.AuthorizationSpec{{FederationRef: ref}, {FederationRef: ref}}
+	for _, spec := range specs {
+		store.Add(sts.URL, spec)
+	}
+	t.Cleanup(func() {
+		store.Remove(sts.URL, nil)
+	})
+	signed := func() *provider.SignedRequest {
+		return &provider.SignedRequest{
+			Method:  http.MethodPost,
+			URL:     sts.URL + "/",
+			Body:    []byte("Action=GetCallerIdentity&Version=2011-06-15"),
+			Headers: http.Header{"Authorization": {testAWSAuthorization}},
+		}
+	}
+	a := NewAWSIAMAuthenticator()
+
+	info, err := a.Authenticate(awsIAMRequest(t, signed()))
+	require.NoError(t, err)
+	assert.Equal(t, "aws-iam", info.Method)
+	assert.Equal(t, sts.URL, info.Provider)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/app", info.Subject)
+	assert.Equal(t, "i-0123", info.Claims["session_name"])
+	assert.Equal(t, &ref, info.Federation)
+	assert.Equal(t, 1, stsCalls, "authorizations of the same federation should call STS once")
+
+	t.Run("invalid signature", func(t *testing.T) {
+		req := signed()
+		req.Headers.Set("Authorization", testAWSAuthorization+"def")
+		_, err := a.Authenticate(awsIAMRequest(t, req))
+		assert.ErrorContains(t, err, "STS rejected the request")
+	})
+	t.Run("unknown STS endpoint", func(t *testing.T) {
+		req := signed()
+		req.URL = "https://sts.eu-west-1.amazonaws.com/"
+		_, err := a.Authenticate(awsIAMRequest(t, req))
+		assert.ErrorContains(t, err, "no authorization configured for issuer")
+	})
+	t.Run("missing header", func(t *testing.T) {
+		_, err := a.Authenticate(httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		assert.ErrorContains(t, err, "missing X-Aws-Iam-Request header")
+	})
+}
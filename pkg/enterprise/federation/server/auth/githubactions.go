@@ -0,0 +1,145 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package auth implements the federation server authorization.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	idfedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/identity/v1alpha1"
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+// GitHubActionsAuthenticator authenticates GitHub Actions workflows with their OIDC token.
+// The repository, ref and environment restrictions of GitHubActionsFederations are checked
+// by their provider before the keys of the issuer are returned.
+type GitHubActionsAuthenticator struct {
+	clockSkewLeeway time.Duration
+}
+
+// NewGitHubActionsAuthenticator creates a new GitHubActionsAuthenticator.
+func NewGitHubActionsAuthenticator() *GitHubActionsAuthenticator {
+	return &GitHubActionsAuthenticator{
+		clockSkewLeeway: defaultClockSkewLeeway,
+	}
+}
+
+// Authenticate implements Authenticator.
+func (a *GitHubActionsAuthenticator) Authenticate(r *http.Request) (*Info, error) {
+	authHeader := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return nil, errors.New("invalid Authorization header format, expected 'Bearer <token>'")
+	}
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+
+	unverified := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, unverified); err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+	issuer, err := unverified.GetIssuer()
+	if err != nil || issuer == "" {
+		return nil, errors.New("token missing issuer claim")
+	}
+
+	specs := federationSpecs(issuer, idfedv1alpha1.GitHubActionsFederationKind)
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no authorization configured for issuer: %s", issuer)
+	}
+	// Each federation of the issuer checks the token against its own restrictions, so the
+	// caller is bound to the federation that verified it.
+	var errs []error
+	seen := map[fedv1alpha1.FederationRef]bool{}
+	for _, spec := range specs {
+		ref := spec.FederationRef
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		prov := store.GetStore(ref)
+		if prov == nil {
+			continue
+		}
+		jwks, err := prov.GetJWKS(r.Context(), tokenString, issuer, nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		claims := jwt.MapClaims{}
+		_, err = jwt.ParseWithClaims(tokenString, claims, rsaKeyFunc(jwks),
+			jwt.WithExpirationRequired(), jwt.WithLeeway(a.clockSkewLeeway), jwt.WithIssuer(issuer))
+		if err != nil {
+			return nil, fmt.Errorf("token validation failed: %w", err)
+		}
+		subject, err := claims.GetSubject()
+		if err != nil || subject == "" {
+			return nil, errors.New("token missing subject claim")
+		}
+		return &Info{
+			Method:     "github-actions",
+			Provider:   issuer,
+			Subject:    subject,
+			Claims:     claims,
+			Federation: &ref,
+		}, nil
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no GitHub Actions federation found for issuer: %s", issuer)
+	}
+	return nil, fmt.Errorf("failed to get JWKS: %w", errors.Join(errs...))
+}
+
+// federationSpecs returns the authorizations of an issuer that reference a federation of the given kind.
+func federationSpecs(issuer, kind string) []*fedv1alpha1.AuthorizationSpec {
+	var specs []*fedv1alpha1.AuthorizationSpec
+	for _, spec := range store.Get(issuer) {
+		if spec.FederationRef.Kind == kind {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+// rsaKeyFunc returns a function that looks up the RSA signing key of a token in a JWKS.
+func rsaKeyFunc(jwks map[string]map[string]string) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("token missing 'kid' in header")
+		}
+		key, ok := jwks[kid]
+		if !ok {
+			return nil, fmt.Errorf("key with kid '%s' not found in JWKS", kid)
+		}
+		return parseRSAPublicKey(key)
+	}
+}
+
+func init() {
+	Register("github-actions", NewGitHubActionsAuthenticator())
+}
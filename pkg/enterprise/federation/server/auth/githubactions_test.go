@@ -0,0 +1,125 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package auth implements the federation server authorization.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	idfedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/identity/v1alpha1"
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/provider"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+// newGitHubActionsIssuer starts a stand-in for the GitHub Actions token issuer signing with key.
+func newGitHubActionsIssuer(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": srv.URL, "jwks_uri": srv.URL + "/.well-known/jwks"})
+	})
+	mux.HandleFunc("/.well-known/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{createTestJWKSMap(&key.PublicKey, "gha")["gha"]}})
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func workflowClaims(issuer, repository string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":         issuer,
+		"sub":         "repo:" + repository + ":environment:production",
+		"aud":         "https://federation.example.com",
+		"exp":         time.Now().Add(5 * time.Minute).Unix(),
+		"repository":  repository,
+		"ref":         "refs/heads/main",
+		"environment": "production",
+	}
+}
+
+func signWorkflowToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "gha"
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestGitHubActionsAuthenticator_Authenticate(t *testing.T) {
+	key := generateTestRSAKey(t)
+	issuer := newGitHubActionsIssuer(t, key)
+	ref := fedv1alpha1.FederationRef{Kind: idfedv1alpha1.GitHubActionsFederationKind, Name: "github-ci"}
+	store.AddStore(ref, provider.NewGitHubActionsProvider(issuer.URL, "https://federation.example.com", []string{"my-org/*"}, []string{"refs/heads/main"}, nil))
+	spec := &fedv1alpha1.AuthorizationSpec{FederationRef: ref}
+	store.Add(issuer.URL, spec)
+	t.Cleanup(func() {
+		store.Remove(issuer.URL, spec)
+	})
+	a := NewGitHubActionsAuthenticator()
+	authenticate := func(token string) (*Info, error) {
+		req := httptest.NewRequest(http.MethodGet, "/secretstore/hub/secrets/db", http.NoBody)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return a.Authenticate(req)
+	}
+
+	info, err := authenticate(signWorkflowToken(t, key, workflowClaims(issuer.URL, "my-org/app")))
+	require.NoError(t, err)
+	assert.Equal(t, "github-actions", info.Method)
+	assert.Equal(t, issuer.URL, info.Provider)
+	assert.Equal(t, "repo:my-org/app:environment:production", info.Subject)
+	assert.Equal(t, "refs/heads/main", info.Claims["ref"])
+	assert.Equal(t, &ref, info.Federation)
+
+	t.Run("repository not allowed", func(t *testing.T) {
+		_, err := authenticate(signWorkflowToken(t, key, workflowClaims(issuer.URL, "other-org/app")))
+		assert.ErrorContains(t, err, "failed to get JWKS")
+	})
+	t.Run("expired", func(t *testing.T) {
+		claims := workflowClaims(issuer.URL, "my-org/app")
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		_, err := authenticate(signWorkflowToken(t, key, claims))
+		assert.ErrorContains(t, err, "token validation failed")
+	})
+	t.Run("signed by another key", func(t *testing.T) {
+		_, err := authenticate(signWorkflowToken(t, generateTestRSAKey(t), workflowClaims(issuer.URL, "my-org/app")))
+		assert.ErrorContains(t, err, "token validation failed")
+	})
+	t.Run("issuer of another federation kind", func(t *testing.T) {
+		other := &fedv1alpha1.AuthorizationSpec{FederationRef: fedv1alpha1.FederationRef{Kind: "KubernetesFederation", Name: "cluster"}}
+		store.Add("https://kubernetes.example.com", other)
+		t.Cleanup(func() {
+			store.Remove("https://kubernetes.example.com", other)
+		})
+		_, err := authenticate(signWorkflowToken(t, key, workflowClaims("https://kubernetes.example.com", "my-org/app")))
+		assert.ErrorContains(t, err, "no authorization configured for issuer")
+	})
+}
@@ -96,6 +96,7 @@ func (a *OIDCAuthenticator) Authenticate(r *http.Request) (*Info, error) {
 			Namespace:      claim.Namespace,
 			ServiceAccount: &ServiceAccount{Name: claim.ServiceAccount.Name, UID: claim.ServiceAccount.UID},
 		},
+		Claims: tokenClaims(onlyToken),
 	}
 	if claim.Pod != nil {
 		authInfo.KubeAttributes.Pod = &PodInfo{Name: claim.Pod.Name, UID: claim.Pod.UID}
@@ -195,12 +196,18 @@ func (a *OIDCAuthenticator) genParseToken(ctx context.Context, onlyToken, caCrt
 		}
 	}
 }
+
+// readCaCrt reads the ca.crt field of the JSON body of the request, and restores the body.
 func readCaCrt(r *http.Request) (string, error) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		return "", err
 	}
 	r.Body = io.NopCloser(bytes.NewReader(body))
+	// Requests without a body carry no CA.
+	if len(body) == 0 {
+		return "", nil
+	}
 
 	var tmp struct {
 		CaCert string `json:"ca.crt"`
@@ -142,6 +142,7 @@ func (a *OktaAuthenticator) Authenticate(r *http.Request) (*Info, error) {
 		Subject:  subject,
 		// KubeAttributes will be nil for now - to be implemented in future
 		KubeAttributes: nil,
+		Claims:         tokenClaims(tokenString),
 	}
 
 	return authInfo, nil
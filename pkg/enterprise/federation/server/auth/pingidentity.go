@@ -145,6 +145,7 @@ func (a *PingIdentityAuthenticator) Authenticate(r *http.Request) (*Info, error)
 		Subject:  subject,
 		// KubeAttributes will be nil for now - to be implemented in future
 		KubeAttributes: nil,
+		Claims:         tokenClaims(tokenString),
 	}
 
 	return authInfo, nil
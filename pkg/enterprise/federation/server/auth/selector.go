@@ -0,0 +1,156 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package auth implements the federation server authorization.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	idfedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/identity/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+// AuthenticatorHeader selects the authenticator of a request by its registered name.
+const AuthenticatorHeader = "X-Federation-Authenticator"
+
+var (
+	// ErrAmbiguousCredentials is returned when a request carries credentials of more than one
+	// type, e.g. a client certificate and a bearer token, and selects no authenticator.
+	ErrAmbiguousCredentials = errors.New("ambiguous credentials")
+	// ErrUnknownAuthenticator is returned when a request selects an authenticator that is not registered.
+	ErrUnknownAuthenticator = errors.New("unknown authenticator")
+)
+
+// DefaultPriority is the order authenticators are tried in when several may accept a request.
+// Authenticators registered but not listed are tried last, by name.
+var DefaultPriority = []string{"spiffe", "aws-iam", "oidc", "github-actions", "okta", "pingidentity"}
+
+// federationAuthenticators maps federation kinds to the authenticator of their bearer tokens.
+var federationAuthenticators = map[string]string{
+	"KubernetesFederation":                    "oidc",
+	"OktaFederation":                          "okta",
+	"PingIdentityFederation":                  "pingidentity",
+	idfedv1alpha1.GitHubActionsFederationKind: "github-actions",
+}
+
+// Selector selects the authenticators of a request, so a request is only checked by the
+// authenticators its credentials are meant for, in a deterministic order.
+// The zero value tries authenticators in DefaultPriority order.
+type Selector struct {
+	priority []string
+}
+
+// NewSelector creates a Selector trying authenticators in the given priority order,
+// or in DefaultPriority if none is given. All authenticators must be registered.
+func NewSelector(priority []string) (*Selector, error) {
+	for _, name := range priority {
+		if _, ok := Registry[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAuthenticator, name)
+		}
+	}
+	return &Selector{priority: priority}, nil
+}
+
+// Select returns the names of the authenticators to try for the request, in order.
+// The authenticator named by selected, if any, is the only one tried. Otherwise the credentials
+// of the request narrow the authenticators down: client certificates to spiffe, signed AWS requests
+// to aws-iam, and bearer tokens to the authenticators of the federations of their issuer.
+// All authenticators are tried, in priority order, when the credentials are not recognized.
+func (s *Selector) Select(r *http.Request, selected string) ([]string, error) {
+	if selected != "" {
+		if _, ok := Registry[selected]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAuthenticator, selected)
+		}
+		return []string{selected}, nil
+	}
+
+	var credentials []string
+	var candidates []string
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		credentials = append(credentials, "client certificate")
+		candidates = append(candidates, "spiffe")
+	}
+	if r.Header.Get(AWSIAMRequestHeader) != "" {
+		credentials = append(credentials, "signed AWS request")
+		candidates = append(candidates, "aws-iam")
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		credentials = append(credentials, "bearer token")
+		candidates = append(candidates, bearerAuthenticators(token)...)
+	}
+	if len(credentials) > 1 {
+		return nil, fmt.Errorf("%w: %s", ErrAmbiguousCredentials, strings.Join(credentials, ", "))
+	}
+
+	chain := s.chain()
+	if len(candidates) == 0 {
+		return chain, nil
+	}
+	return slices.DeleteFunc(chain, func(name string) bool {
+		return !slices.Contains(candidates, name)
+	}), nil
+}
+
+// chain returns the registered authenticators in priority order.
+func (s *Selector) chain() []string {
+	priority := s.priority
+	if len(priority) == 0 {
+		priority = DefaultPriority
+	}
+	chain := make([]string, 0, len(Registry))
+	for _, name := range priority {
+		if _, ok := Registry[name]; ok {
+			chain = append(chain, name)
+		}
+	}
+	var rest []string
+	for name := range Registry {
+		if !slices.Contains(chain, name) {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(chain, rest...)
+}
+
+// bearerAuthenticators returns the authenticators of the federations the issuer of a JWT is authorized for.
+func bearerAuthenticators(token string) []string {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return nil
+	}
+	issuer, err := claims.GetIssuer()
+	if err != nil || issuer == "" {
+		return nil
+	}
+	var names []string
+	for _, spec := range store.Get(issuer) {
+		if name, ok := federationAuthenticators[spec.FederationRef.Kind]; ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
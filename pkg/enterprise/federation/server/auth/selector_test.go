@@ -0,0 +1,135 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package auth implements the federation server authorization.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+func TestSelector_Select(t *testing.T) {
+	const issuer = "https://selector.example.com"
+	specs := []*fedv1alpha1.AuthorizationSpec{
+		{FederationRef: fedv1alpha1.FederationRef{Kind: "OktaFederation", Name: "okta"}},
+		{FederationRef: fedv1alpha1.FederationRef{Kind: "KubernetesFederation", Name: "cluster"}},
+	}
+	for _, spec := range specs {
+		store.Add(issuer, spec)
+	}
+	t.Cleanup(func() {
+		store.Remove(issuer, nil)
+	})
+	bearer := func(iss string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": iss}).SignedString([]byte("unverified"))
+		require.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	tests := []struct {
+		name     string
+		priority []string
+		selected string
+		request  func(r *http.Request)
+		want     []string
+		wantErr  error
+	}{
+		{
+			name:    "client certificate",
+			request: func(r *http.Request) { r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{}}} },
+			want:    []string{"spiffe"},
+		},
+		{
+			name:    "signed AWS request",
+			request: func(r *http.Request) { r.Header.Set(AWSIAMRequestHeader, "e30=") },
+			want:    []string{"aws-iam"},
+		},
+		{
+			name:    "bearer token of a known issuer",
+			request: func(r *http.Request) { r.Header.Set("Authorization", bearer(issuer)) },
+			want:    []string{"oidc", "okta"},
+		},
+		{
+			name:     "bearer token of a known issuer with priority",
+			priority: []string{"okta", "oidc"},
+			request:  func(r *http.Request) { r.Header.Set("Authorization", bearer(issuer)) },
+			want:     []string{"okta", "oidc"},
+		},
+		{
+			name:    "bearer token of an unknown issuer",
+			request: func(r *http.Request) { r.Header.Set("Authorization", bearer("https://unknown.example.com")) },
+			want:    DefaultPriority,
+		},
+		{
+			name:    "no credentials",
+			request: func(*http.Request) {},
+			want:    DefaultPriority,
+		},
+		{
+			name: "ambiguous credentials",
+			request: func(r *http.Request) {
+				r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{}}}
+				r.Header.Set("Authorization", bearer(issuer))
+			},
+			wantErr: ErrAmbiguousCredentials,
+		},
+		{
+			name:     "selected authenticator",
+			selected: "okta",
+			request:  func(r *http.Request) { r.Header.Set(AWSIAMRequestHeader, "e30=") },
+			want:     []string{"okta"},
+		},
+		{
+			name:     "unknown authenticator",
+			selected: "ldap",
+			request:  func(*http.Request) {},
+			wantErr:  ErrUnknownAuthenticator,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := NewSelector(tt.priority)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			tt.request(req)
+			got, err := selector.Select(req, tt.selected)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewSelector(t *testing.T) {
+	_, err := NewSelector([]string{"oidc", "ldap"})
+	assert.ErrorIs(t, err, ErrUnknownAuthenticator)
+}
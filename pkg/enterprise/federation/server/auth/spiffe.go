@@ -62,6 +62,22 @@ func (a *SpiffeAuthenticator) Authenticate(r *http.Request) (*Info, error) {
 		Provider:       id.TrustDomain().Name(),
 		Subject:        id.String(),
 		KubeAttributes: kubeAttributes,
+		Claims: map[string]interface{}{
+			"sub":          id.String(),
+			"trust_domain": id.TrustDomain().Name(),
+			"path":         id.Path(),
+			"kubernetes.io": map[string]interface{}{
+				"namespace": kubeAttributes.Namespace,
+				"serviceaccount": map[string]interface{}{
+					"name": kubeAttributes.ServiceAccount.Name,
+					"uid":  kubeAttributes.ServiceAccount.UID,
+				},
+				"pod": map[string]interface{}{
+					"name": kubeAttributes.Pod.Name,
+					"uid":  kubeAttributes.Pod.UID,
+				},
+			},
+		},
 	}
 	return authInfo, nil
 }
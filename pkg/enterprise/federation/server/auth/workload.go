@@ -0,0 +1,248 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package auth implements the federation server authorization.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+const (
+	// WorkloadTokenHeader is the header carrying the service account token of the workload
+	// on whose behalf the authenticated caller acts.
+	WorkloadTokenHeader = "x-workload-token"
+
+	// defaultJWKSCacheTTL is how long the keys of an issuer are cached.
+	defaultJWKSCacheTTL = 10 * time.Minute
+	// defaultJWKSMinRefreshInterval bounds how often the keys of an issuer are refetched
+	// because a token is signed with an unknown key.
+	defaultJWKSMinRefreshInterval = 30 * time.Second
+)
+
+// cachedJWKS holds the keys fetched for an issuer.
+type cachedJWKS struct {
+	keys      map[string]map[string]string
+	fetchedAt time.Time
+}
+
+// WorkloadTokenVerifier verifies the x-workload-token header against the JWKS of its issuer.
+// The issuer must be bound through an Authorization to a federation the caller is authorized
+// through, and its keys are fetched from that federation, e.g. from the URL of a KubernetesFederation.
+type WorkloadTokenVerifier struct {
+	mu                 sync.Mutex
+	audiences          []string
+	cacheTTL           time.Duration
+	minRefreshInterval time.Duration
+	clockSkewLeeway    time.Duration
+	cache              map[string]*cachedJWKS
+	now                func() time.Time
+}
+
+// NewWorkloadTokenVerifier creates a new WorkloadTokenVerifier accepting tokens issued for any of the given audiences.
+// The audience of tokens is not checked if no audiences are given.
+func NewWorkloadTokenVerifier(audiences []string) *WorkloadTokenVerifier {
+	return &WorkloadTokenVerifier{
+		audiences:          audiences,
+		cacheTTL:           defaultJWKSCacheTTL,
+		minRefreshInterval: defaultJWKSMinRefreshInterval,
+		clockSkewLeeway:    defaultClockSkewLeeway,
+		cache:              map[string]*cachedJWKS{},
+		now:                time.Now,
+	}
+}
+
+// Verify verifies the x-workload-token header of the authenticated caller and extracts workload information.
+// The signature, issuer, audience (if configured) and expiry of the token are validated.
+// Returns nil if the token is missing (not an error).
+// Returns an error if the token is present but malformed or invalid.
+func (v *WorkloadTokenVerifier) Verify(r *http.Request, caller *Info) (*WorkloadInfo, error) {
+	tokenString := r.Header.Get(WorkloadTokenHeader)
+	if tokenString == "" {
+		// No token provided - this is acceptable
+		return nil, nil
+	}
+	// The issuer is read before verification to find the federation holding its keys.
+	unverified, _, err := jwt.NewParser().ParseUnverified(tokenString, &WorkloadTokenClaims{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse x-workload-token: %w", err)
+	}
+	issuer, err := unverified.Claims.GetIssuer()
+	if err != nil || issuer == "" {
+		return nil, errors.New("x-workload-token missing issuer claim")
+	}
+	specs, federations := boundAuthorizations(store.Get(issuer), store.Get(caller.Provider))
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("x-workload-token issuer %s is not bound to a federation of the caller", issuer)
+	}
+
+	caCrt, err := readOptionalCaCrt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.clockSkewLeeway),
+		jwt.WithTimeFunc(v.now),
+	}
+	if len(v.audiences) > 0 {
+		opts = append(opts, jwt.WithAudience(v.audiences...))
+	}
+	parser := jwt.NewParser(opts...)
+	token, err := parser.ParseWithClaims(tokenString, &WorkloadTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("token missing 'kid' in header")
+		}
+		key, err := v.getKey(r.Context(), specs, issuer, kid, tokenString, caCrt)
+		if err != nil {
+			return nil, err
+		}
+		if alg, ok := key["alg"]; ok && alg != token.Method.Alg() {
+			return nil, fmt.Errorf("key %s is not valid for algorithm %s", kid, token.Method.Alg())
+		}
+		return parseRSAPublicKeyFromJWK(key)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify x-workload-token: %w", err)
+	}
+
+	claims, ok := token.Claims.(*WorkloadTokenClaims)
+	if !ok {
+		return nil, errors.New("invalid x-workload-token claims format")
+	}
+	workloadInfo, err := workloadInfoFromClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+	workloadInfo.Federations = federations
+	return workloadInfo, nil
+}
+
+// boundAuthorizations returns the Authorizations of the workload token issuer whose federation
+// the caller is also authorized through, and these federations.
+func boundAuthorizations(issuerSpecs, callerSpecs []*fedv1alpha1.AuthorizationSpec) ([]*fedv1alpha1.AuthorizationSpec, []fedv1alpha1.FederationRef) {
+	var specs []*fedv1alpha1.AuthorizationSpec
+	var federations []fedv1alpha1.FederationRef
+	for _, spec := range issuerSpecs {
+		for _, callerSpec := range callerSpecs {
+			if spec.FederationRef != callerSpec.FederationRef {
+				continue
+			}
+			specs = append(specs, spec)
+			if !slices.Contains(federations, spec.FederationRef) {
+				federations = append(federations, spec.FederationRef)
+			}
+			break
+		}
+	}
+	return specs, federations
+}
+
+// getKey returns the key of the issuer with the given kid. Keys are cached per issuer and
+// refetched once the cache expires, or when a token is signed with a key that is not cached,
+// which happens after the issuer rotated its keys.
+func (v *WorkloadTokenVerifier) getKey(ctx context.Context, specs []*fedv1alpha1.AuthorizationSpec, issuer, kid, token string, caCrt []byte) (map[string]string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	cached, ok := v.cache[issuer]
+	if ok && now.Sub(cached.fetchedAt) < v.cacheTTL {
+		if key, found := cached.keys[kid]; found {
+			return key, nil
+		}
+		if now.Sub(cached.fetchedAt) < v.minRefreshInterval {
+			return nil, fmt.Errorf("key with kid '%s' not found in JWKS", kid)
+		}
+	}
+
+	keys, err := store.GetJWKS(ctx, specs, token, issuer, caCrt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JWKS of issuer %s: %w", issuer, err)
+	}
+	v.cache[issuer] = &cachedJWKS{keys: keys, fetchedAt: now}
+
+	key, found := keys[kid]
+	if !found {
+		return nil, fmt.Errorf("key with kid '%s' not found in JWKS", kid)
+	}
+	return key, nil
+}
+
+// readOptionalCaCrt reads the ca.crt of the issuer from the request body, if any.
+func readOptionalCaCrt(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return nil, nil
+	}
+	caCrt, err := readCaCrt(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca.crt from request body: %w", err)
+	}
+	if caCrt == "" {
+		return nil, nil
+	}
+	return []byte(caCrt), nil
+}
+
+// workloadInfoFromClaims validates the Kubernetes claims of a workload token and builds the WorkloadInfo.
+func workloadInfoFromClaims(claims *WorkloadTokenClaims) (*WorkloadInfo, error) {
+	// Validate required fields
+	if claims.Kubernetes.Namespace == "" {
+		return nil, errors.New("x-workload-token missing kubernetes.io.namespace")
+	}
+	if claims.Kubernetes.ServiceAccount.Name == "" {
+		return nil, errors.New("x-workload-token missing kubernetes.io.serviceaccount.name")
+	}
+	if claims.Kubernetes.ServiceAccount.UID == "" {
+		return nil, errors.New("x-workload-token missing kubernetes.io.serviceaccount.uid")
+	}
+
+	// Build WorkloadInfo
+	workloadInfo := &WorkloadInfo{
+		Namespace: claims.Kubernetes.Namespace,
+		ServiceAccount: &ServiceAccount{
+			Name: claims.Kubernetes.ServiceAccount.Name,
+			UID:  claims.Kubernetes.ServiceAccount.UID,
+		},
+	}
+
+	// Add pod info if available
+	if claims.Kubernetes.Pod != nil {
+		workloadInfo.Pod = &PodInfo{
+			Name: claims.Kubernetes.Pod.Name,
+			UID:  claims.Kubernetes.Pod.UID,
+		}
+	}
+
+	return workloadInfo, nil
+}
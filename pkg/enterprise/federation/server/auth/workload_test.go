@@ -0,0 +1,302 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package auth implements the federation server authorization.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/provider"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+// testOIDCIssuer is an in-process OIDC issuer serving discovery and JWKS endpoints,
+// like the service account issuer of a Kubernetes API server.
+type testOIDCIssuer struct {
+	server       *httptest.Server
+	mu           sync.Mutex
+	keys         map[string]*rsa.PrivateKey
+	jwksRequests int
+}
+
+func newTestOIDCIssuer(t *testing.T) *testOIDCIssuer {
+	t.Helper()
+	issuer := &testOIDCIssuer{keys: map[string]*rsa.PrivateKey{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.server.URL,
+			"jwks_uri": issuer.server.URL + "/openid/v1/jwks",
+		})
+	})
+	mux.HandleFunc("/openid/v1/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+		issuer.jwksRequests++
+		keys := []map[string]string{}
+		for kid, key := range issuer.keys {
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": kid,
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+	})
+	issuer.server = httptest.NewTLSServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// rotate adds a new signing key to the issuer and removes all previous keys.
+func (i *testOIDCIssuer) rotate(t *testing.T, kid string) {
+	t.Helper()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys = map[string]*rsa.PrivateKey{kid: generateTestRSAKey(t)}
+}
+
+func (i *testOIDCIssuer) requests() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.jwksRequests
+}
+
+func (i *testOIDCIssuer) caCrt() string {
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: i.server.Certificate().Raw})
+	return base64.StdEncoding.EncodeToString(certPEM)
+}
+
+func (i *testOIDCIssuer) sign(t *testing.T, kid string, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	if key == nil {
+		i.mu.Lock()
+		key = i.keys[kid]
+		i.mu.Unlock()
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+const testWorkloadTokenAudience = "external-secrets-federation"
+
+func workloadClaims(issuer string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss": issuer,
+		"sub": "system:serviceaccount:apps:api",
+		"aud": []string{testWorkloadTokenAudience},
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+		"kubernetes.io": map[string]any{
+			"namespace":      "apps",
+			"serviceaccount": map[string]string{"name": "api", "uid": "sa-uid"},
+			"pod":            map[string]string{"name": "api-0", "uid": "pod-uid"},
+		},
+	}
+}
+
+func workloadRequest(token, caCrt string) *http.Request {
+	body, _ := json.Marshal(map[string]string{"ca.crt": caCrt})
+	req := httptest.NewRequest(http.MethodPost, "/generators/apps/Password/pw", strings.NewReader(string(body)))
+	req.Header.Set(WorkloadTokenHeader, token)
+	return req
+}
+
+var testWorkloadFederation = fedv1alpha1.FederationRef{Kind: "KubernetesFederation", Name: "workload-cluster"}
+
+func bindIssuer(t *testing.T, issuer *testOIDCIssuer) {
+	t.Helper()
+	store.AddStore(testWorkloadFederation, provider.NewProvider(issuer.server.URL))
+	bindAuthority(t, issuer.server.URL, testWorkloadFederation)
+}
+
+// bindAuthority adds an Authorization of the authority to the federation.
+func bindAuthority(t *testing.T, authority string, ref fedv1alpha1.FederationRef) {
+	t.Helper()
+	spec := &fedv1alpha1.AuthorizationSpec{FederationRef: ref}
+	store.Add(authority, spec)
+	t.Cleanup(func() {
+		store.Remove(authority, spec)
+	})
+}
+
+// testCaller returns a caller authorized through the workload federation.
+func testCaller(t *testing.T) *Info {
+	t.Helper()
+	bindAuthority(t, "https://caller.example.com", testWorkloadFederation)
+	return &Info{Method: "oidc", Provider: "https://caller.example.com", Subject: "caller"}
+}
+
+func TestWorkloadTokenVerifier(t *testing.T) {
+	issuer := newTestOIDCIssuer(t)
+	issuer.rotate(t, "kid1")
+	bindIssuer(t, issuer)
+	other := newTestOIDCIssuer(t)
+	other.rotate(t, "kid1")
+	bindAuthority(t, other.server.URL, fedv1alpha1.FederationRef{Kind: "KubernetesFederation", Name: "other-cluster"})
+	caller := testCaller(t)
+	attacker := generateTestRSAKey(t)
+
+	expired := workloadClaims(issuer.server.URL)
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	wrongAudience := workloadClaims(issuer.server.URL)
+	wrongAudience["aud"] = []string{"https://kubernetes.default.svc"}
+	missingNamespace := workloadClaims(issuer.server.URL)
+	missingNamespace["kubernetes.io"] = map[string]any{"serviceaccount": map[string]string{"name": "api", "uid": "sa-uid"}}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    *WorkloadInfo
+		wantErr string
+	}{
+		{
+			name:  "valid token",
+			token: issuer.sign(t, "kid1", nil, workloadClaims(issuer.server.URL)),
+			want: &WorkloadInfo{
+				Namespace:      "apps",
+				ServiceAccount: &ServiceAccount{Name: "api", UID: "sa-uid"},
+				Pod:            &PodInfo{Name: "api-0", UID: "pod-uid"},
+				Federations:    []fedv1alpha1.FederationRef{testWorkloadFederation},
+			},
+		},
+		{
+			name:    "forged signature",
+			token:   issuer.sign(t, "kid1", attacker, workloadClaims(issuer.server.URL)),
+			wantErr: "signature is invalid",
+		},
+		{
+			name:    "expired token",
+			token:   issuer.sign(t, "kid1", nil, expired),
+			wantErr: "token is expired",
+		},
+		{
+			name:    "wrong audience",
+			token:   issuer.sign(t, "kid1", nil, wrongAudience),
+			wantErr: "token has invalid audience",
+		},
+		{
+			name:    "issuer not bound to a federation",
+			token:   issuer.sign(t, "kid1", attacker, workloadClaims("https://attacker.example.com")),
+			wantErr: "is not bound to a federation of the caller",
+		},
+		{
+			name:    "issuer bound to another federation than the caller",
+			token:   issuer.sign(t, "kid1", nil, workloadClaims(other.server.URL)),
+			wantErr: "is not bound to a federation of the caller",
+		},
+		{
+			name:    "missing kubernetes claims",
+			token:   issuer.sign(t, "kid1", nil, missingNamespace),
+			wantErr: "missing kubernetes.io.namespace",
+		},
+	}
+
+	verifier := NewWorkloadTokenVerifier([]string{testWorkloadTokenAudience})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := verifier.Verify(workloadRequest(tt.token, issuer.caCrt()), caller)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, info)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, info)
+		})
+	}
+}
+
+func TestWorkloadTokenVerifierWithoutAudiences(t *testing.T) {
+	issuer := newTestOIDCIssuer(t)
+	issuer.rotate(t, "kid1")
+	bindIssuer(t, issuer)
+	caller := testCaller(t)
+
+	// Tokens of any audience are accepted if no audience is configured.
+	claims := workloadClaims(issuer.server.URL)
+	claims["aud"] = []string{"https://kubernetes.default.svc"}
+	verifier := NewWorkloadTokenVerifier(nil)
+	info, err := verifier.Verify(workloadRequest(issuer.sign(t, "kid1", nil, claims), issuer.caCrt()), caller)
+	require.NoError(t, err)
+	assert.Equal(t, "apps", info.Namespace)
+}
+
+func TestWorkloadTokenVerifierMissingToken(t *testing.T) {
+	verifier := NewWorkloadTokenVerifier([]string{testWorkloadTokenAudience})
+	info, err := verifier.Verify(httptest.NewRequest(http.MethodGet, "/", http.NoBody), &Info{Provider: "https://caller.example.com"})
+	require.NoError(t, err)
+	assert.Nil(t, info)
+}
+
+func TestWorkloadTokenVerifierKeyRotation(t *testing.T) {
+	issuer := newTestOIDCIssuer(t)
+	issuer.rotate(t, "kid1")
+	bindIssuer(t, issuer)
+	caller := testCaller(t)
+
+	now := time.Now()
+	verifier := NewWorkloadTokenVerifier([]string{testWorkloadTokenAudience})
+	verifier.now = func() time.Time { return now }
+
+	// Keys are cached across requests.
+	for range 3 {
+		_, err := verifier.Verify(workloadRequest(issuer.sign(t, "kid1", nil, workloadClaims(issuer.server.URL)), issuer.caCrt()), caller)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, issuer.requests())
+
+	// Unknown keys do not trigger a refetch before the minimum refresh interval.
+	issuer.rotate(t, "kid2")
+	_, err := verifier.Verify(workloadRequest(issuer.sign(t, "kid2", nil, workloadClaims(issuer.server.URL)), issuer.caCrt()), caller)
+	require.ErrorContains(t, err, "key with kid 'kid2' not found")
+	assert.Equal(t, 1, issuer.requests())
+
+	// After the rotation the new key is fetched and the old key is no longer accepted.
+	now = now.Add(defaultJWKSMinRefreshInterval)
+	_, err = verifier.Verify(workloadRequest(issuer.sign(t, "kid2", nil, workloadClaims(issuer.server.URL)), issuer.caCrt()), caller)
+	require.NoError(t, err)
+	assert.Equal(t, 2, issuer.requests())
+
+	now = now.Add(defaultJWKSCacheTTL)
+	_, err = verifier.Verify(workloadRequest(issuer.sign(t, "kid1", generateTestRSAKey(t), workloadClaims(issuer.server.URL)), issuer.caCrt()), caller)
+	require.ErrorContains(t, err, "key with kid 'kid1' not found")
+	assert.Equal(t, 3, issuer.requests())
+}
@@ -0,0 +1,173 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/fmetrics"
+)
+
+const (
+	// authenticatorPathPrefix selects the authenticator of a request by path, e.g.
+	// /auth/okta/secretstore/vault/secrets/db is authenticated by the okta authenticator only.
+	authenticatorPathPrefix = "/auth/"
+	// selectedAuthenticatorKey is the context key of the authenticator selected by path.
+	selectedAuthenticatorKey = "selectedAuthenticator"
+)
+
+// SetAuthenticatorPriority sets the order authenticators are tried in when several may accept a request.
+func (s *Handler) SetAuthenticatorPriority(priority []string) error {
+	selector, err := auth.NewSelector(priority)
+	if err != nil {
+		return err
+	}
+	s.authSelector = *selector
+	return nil
+}
+
+// selectAuthenticatorByPath strips the authenticator path prefix, if any, before the request is routed.
+func (s *Handler) selectAuthenticatorByPath(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		u := c.Request().URL
+		rest, ok := strings.CutPrefix(u.Path, authenticatorPathPrefix)
+		if !ok {
+			return next(c)
+		}
+		name, path, _ := strings.Cut(rest, "/")
+		c.Set(selectedAuthenticatorKey, name)
+		u.Path = "/" + path
+		if u.RawPath != "" {
+			u.RawPath = strings.TrimPrefix(u.RawPath, authenticatorPathPrefix+name)
+		}
+		return next(c)
+	}
+}
+
+func (s *Handler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		info, err := s.authenticate(c)
+		if err != nil {
+			s.audit(c, audit.EventAuthentication, audit.OutcomeFailure, nil, err.Error())
+			var authErr *authenticationError
+			if errors.As(err, &authErr) {
+				return c.JSON(http.StatusUnauthorized, authErr.public())
+			}
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		c.Set("authInfo", info)
+
+		// Verify optional x-workload-token header
+		workloadInfo, err := s.verifyWorkloadToken(c.Request(), info)
+		if err != nil {
+			// The reason is only recorded, as it tells callers which issuers and claims are trusted.
+			reason := fmt.Sprintf("invalid x-workload-token: %s", err.Error())
+			s.log.V(1).Info("rejected x-workload-token", "reason", reason)
+			s.audit(c, audit.EventAuthentication, audit.OutcomeFailure, nil, reason)
+			return c.JSON(http.StatusUnauthorized, "invalid x-workload-token")
+		}
+
+		// Merge with existing KubeAttributes if present
+		if workloadInfo == nil && info.KubeAttributes != nil {
+			// Use KubeAttributes from authInfo (e.g., from SPIFFE)
+			workloadInfo = &auth.WorkloadInfo{
+				Namespace:      info.KubeAttributes.Namespace,
+				ServiceAccount: info.KubeAttributes.ServiceAccount,
+				Pod:            info.KubeAttributes.Pod,
+			}
+		}
+
+		// Set workloadInfo in context (may be nil)
+		c.Set("workloadInfo", workloadInfo)
+		s.audit(c, audit.EventAuthentication, audit.OutcomeSuccess, nil, "")
+
+		return next(c)
+	}
+}
+
+// authenticate authenticates the request with the authenticators selected for it, in order.
+func (s *Handler) authenticate(c echo.Context) (*auth.Info, error) {
+	selected := c.Request().Header.Get(auth.AuthenticatorHeader)
+	if byPath, _ := c.Get(selectedAuthenticatorKey).(string); byPath != "" {
+		if selected != "" && selected != byPath {
+			return nil, fmt.Errorf("%w: authenticator %q selected by path and %q by header", auth.ErrAmbiguousCredentials, byPath, selected)
+		}
+		selected = byPath
+	}
+	names, err := s.authSelector.Select(c.Request(), selected)
+	if err != nil {
+		return nil, err
+	}
+
+	authErr := &authenticationError{}
+	for _, name := range names {
+		start := time.Now()
+		info, err := auth.Registry[name].Authenticate(c.Request())
+		fmetrics.AuthenticationDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		if err != nil {
+			fmetrics.Authentications.WithLabelValues(name, fmetrics.AuthenticationFailure).Inc()
+			authErr.add(name, err)
+			continue
+		}
+		fmetrics.Authentications.WithLabelValues(name, fmetrics.AuthenticationSuccess).Inc()
+		return info, nil
+	}
+	return nil, authErr
+}
+
+// authenticationError holds the errors of the authenticators a request failed.
+type authenticationError struct {
+	names []string
+	errs  []error
+}
+
+func (e *authenticationError) add(name string, err error) {
+	e.names = append(e.names, name)
+	e.errs = append(e.errs, err)
+}
+
+// Error returns the errors of all authenticators. It is only recorded in the audit log,
+// as it may reveal the configuration of the server.
+func (e *authenticationError) Error() string {
+	if len(e.names) == 0 {
+		return "no authenticator accepts the credentials"
+	}
+	reasons := make([]string, len(e.names))
+	for i, name := range e.names {
+		reasons[i] = fmt.Sprintf("%s: %s", name, e.errs[i])
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// public returns the error returned to the caller, naming the authenticators tried only.
+func (e *authenticationError) public() string {
+	if len(e.names) == 0 {
+		return "authentication failed: no authenticator accepts the credentials"
+	}
+	return "authentication failed: rejected by " + strings.Join(e.names, ", ")
+}
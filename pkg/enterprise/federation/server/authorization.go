@@ -0,0 +1,347 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+)
+
+// latestVersion is the version AllowedSecrets rules list to allow reads without a version.
+const latestVersion = "latest"
+
+// regexCache holds the compiled regular expressions of subject and claim matchers.
+var regexCache sync.Map
+
+// caller holds the attributes of an authenticated caller that authorizations are matched
+// against and templated with.
+type caller struct {
+	authInfo *auth.Info
+	data     map[string]interface{}
+	// federations restricts the authorizations to the federations the workload token is bound to, if any.
+	federations []fedv1alpha1.FederationRef
+}
+
+// newCaller builds the caller attributes. The namespace, service account and pod are taken
+// from the verified workload context, if any, so entries like ns-{{ .namespace }} resolve to
+// the workload on whose behalf the request is made.
+func newCaller(authInfo *auth.Info, workloadInfo *auth.WorkloadInfo) *caller {
+	claims := authInfo.Claims
+	if claims == nil {
+		claims = map[string]interface{}{}
+	}
+	data := map[string]interface{}{
+		"subject": authInfo.Subject,
+		"issuer":  authInfo.Provider,
+		"method":  authInfo.Method,
+		"claims":  claims,
+	}
+	if workloadInfo == nil && authInfo.KubeAttributes != nil {
+		workloadInfo = &auth.WorkloadInfo{
+			Namespace:      authInfo.KubeAttributes.Namespace,
+			ServiceAccount: authInfo.KubeAttributes.ServiceAccount,
+			Pod:            authInfo.KubeAttributes.Pod,
+		}
+	}
+	var federations []fedv1alpha1.FederationRef
+	if workloadInfo != nil {
+		federations = workloadInfo.Federations
+		if workloadInfo.Namespace != "" {
+			data["namespace"] = workloadInfo.Namespace
+		}
+		if workloadInfo.ServiceAccount != nil {
+			data["serviceAccount"] = workloadInfo.ServiceAccount.Name
+		}
+		if workloadInfo.Pod != nil {
+			data["pod"] = workloadInfo.Pod.Name
+		}
+	}
+	return &caller{authInfo: authInfo, data: data, federations: federations}
+}
+
+// matches reports whether the authorization applies to the caller, either by its exact
+// principal or by its subject matcher. A caller acting for a verified workload only matches
+// the authorizations of the federations the workload token is bound to, and a caller verified
+// by a single federation only matches the authorizations of that federation.
+func (c *caller) matches(spec *fedv1alpha1.AuthorizationSpec) (bool, error) {
+	if c.federations != nil && !slices.Contains(c.federations, spec.FederationRef) {
+		return false, nil
+	}
+	if c.authInfo.Federation != nil && *c.authInfo.Federation != spec.FederationRef {
+		return false, nil
+	}
+	if spec.SubjectMatcher == nil {
+		principal, err := spec.Principal()
+		if err != nil {
+			return false, err
+		}
+		return principal == c.authInfo.Subject, nil
+	}
+
+	m := spec.SubjectMatcher
+	var conditions []bool
+	if m.Subject != nil {
+		ok, err := matchString(*m.Subject, c.authInfo.Subject)
+		if err != nil {
+			return false, err
+		}
+		conditions = append(conditions, ok)
+	}
+	if m.SpiffePathPrefix != "" {
+		conditions = append(conditions, c.matchSpiffePathPrefix(m.SpiffePathPrefix))
+	}
+	for _, claim := range m.Claims {
+		ok, err := matchClaim(claim, c.authInfo.Claims)
+		if err != nil {
+			return false, err
+		}
+		conditions = append(conditions, ok)
+	}
+	if len(conditions) == 0 {
+		return false, nil
+	}
+
+	if m.Operator == fedv1alpha1.MatchOperatorOr {
+		for _, ok := range conditions {
+			if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	for _, ok := range conditions {
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchSpiffePathPrefix matches the path of the SPIFFE ID of the caller by whole path segments.
+func (c *caller) matchSpiffePathPrefix(prefix string) bool {
+	if c.authInfo.Method != "spiffe" {
+		return false
+	}
+	id, err := spiffeid.FromString(c.authInfo.Subject)
+	if err != nil {
+		return false
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	return id.Path() == prefix || strings.HasPrefix(id.Path(), prefix+"/")
+}
+
+// allowsClusterSecretStore reports whether the authorization allows the ClusterSecretStore.
+func (c *caller) allowsClusterSecretStore(spec *fedv1alpha1.AuthorizationSpec, name string) bool {
+	for _, allowed := range spec.AllowedClusterSecretStores {
+		if c.render(allowed) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsSecret reports whether the AllowedSecrets rules of the authorization allow reading
+// the secret through the ClusterSecretStore. Authorizations without rules allow any secret.
+func (c *caller) allowsSecret(spec *fedv1alpha1.AuthorizationSpec, storeName string, ref esv1.ExternalSecretDataRemoteRef) (bool, error) {
+	if len(spec.AllowedSecrets) == 0 {
+		return true, nil
+	}
+	version := ref.Version
+	if version == "" {
+		version = latestVersion
+	}
+	for _, allowed := range spec.AllowedSecrets {
+		if allowed.ClusterSecretStore != "" && c.render(allowed.ClusterSecretStore) != storeName {
+			continue
+		}
+		if len(allowed.Properties) > 0 && !slices.Contains(allowed.Properties, ref.Property) {
+			continue
+		}
+		if len(allowed.Versions) > 0 && !slices.Contains(allowed.Versions, version) {
+			continue
+		}
+		key := fedv1alpha1.StringMatcher{
+			Glob:  c.render(allowed.Key.Glob),
+			Regex: c.render(allowed.Key.Regex),
+		}
+		ok, err := matchString(key, ref.Key)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// allowsPushTarget reports whether the authorization allows pushing the remote key into the
+// ClusterSecretStore, and deleting it when del is set.
+func (c *caller) allowsPushTarget(spec *fedv1alpha1.AuthorizationSpec, storeName, key string, del bool) (bool, error) {
+	for _, allowed := range spec.AllowedPushTargets {
+		if c.render(allowed.ClusterSecretStore) != storeName || (del && !allowed.AllowDelete) {
+			continue
+		}
+		if allowed.Key == nil {
+			return true, nil
+		}
+		matcher := fedv1alpha1.StringMatcher{
+			Glob:  c.render(allowed.Key.Glob),
+			Regex: c.render(allowed.Key.Regex),
+		}
+		ok, err := matchString(matcher, key)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// allowsGenerator reports whether the authorization allows the generator.
+func (c *caller) allowsGenerator(spec *fedv1alpha1.AuthorizationSpec, generator fedv1alpha1.AllowedGenerator) bool {
+	for _, allowed := range spec.AllowedGenerators {
+		if c.render(allowed.Name) == generator.Name && allowed.Kind == generator.Kind && c.render(allowed.Namespace) == generator.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsGeneratorState reports whether the authorization allows deleting GeneratorStates in the namespace.
+func (c *caller) allowsGeneratorState(spec *fedv1alpha1.AuthorizationSpec, namespace string) bool {
+	for _, allowed := range spec.AllowedGeneratorStates {
+		if c.render(allowed.Namespace) == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// render renders an entry of an authorization templated from the caller attributes.
+// Entries referencing attributes the caller does not have render to an empty string,
+// which never matches a requested resource.
+func (c *caller) render(value string) string {
+	if !strings.Contains(value, "{{") {
+		return value
+	}
+	tmpl, err := template.New("authorization").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, c.data); err != nil {
+		return ""
+	}
+	rendered := buf.String()
+	if rendered == "" || strings.Contains(rendered, "<no value>") {
+		return ""
+	}
+	return rendered
+}
+
+// matchString matches the value by the glob or the anchored regular expression of the matcher.
+func matchString(m fedv1alpha1.StringMatcher, value string) (bool, error) {
+	if m.Glob != "" {
+		ok, err := path.Match(m.Glob, value)
+		if err != nil {
+			return false, fmt.Errorf("invalid glob %q: %w", m.Glob, err)
+		}
+		return ok, nil
+	}
+	if m.Regex != "" {
+		re, err := compileRegex(m.Regex)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(value), nil
+	}
+	return false, nil
+}
+
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex %q: %w", expr, err)
+	}
+	regexCache.Store(expr, re)
+	return re, nil
+}
+
+// matchClaim matches a claim of the caller. Claims holding a list match if any element matches.
+func matchClaim(m fedv1alpha1.ClaimMatcher, claims map[string]interface{}) (bool, error) {
+	value, ok := lookupClaim(claims, m.Claim)
+	if !ok {
+		return false, nil
+	}
+	values, isList := value.([]interface{})
+	if !isList {
+		values = []interface{}{value}
+	}
+	for _, v := range values {
+		switch v.(type) {
+		case map[string]interface{}, []interface{}, nil:
+			continue
+		}
+		ok, err := matchString(m.StringMatcher, fmt.Sprint(v))
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// lookupClaim resolves a dot separated claim path. Claim names may contain dots themselves,
+// e.g. kubernetes.io.namespace resolves to the namespace of the kubernetes.io claim.
+func lookupClaim(claims map[string]interface{}, claimPath string) (interface{}, bool) {
+	if v, ok := claims[claimPath]; ok {
+		return v, true
+	}
+	for i := range len(claimPath) {
+		if claimPath[i] != '.' {
+			continue
+		}
+		nested, ok := claims[claimPath[:i]].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := lookupClaim(nested, claimPath[i+1:]); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,319 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+)
+
+func oidcCaller() *auth.Info {
+	return &auth.Info{
+		Method:   "oidc",
+		Provider: "https://token.actions.githubusercontent.com",
+		Subject:  "repo:acme/payments:ref:refs/heads/main",
+		Claims: map[string]interface{}{
+			"repository": "acme/payments",
+			"ref":        "refs/heads/main",
+			"groups":     []interface{}{"developers", "payments-admins"},
+			"kubernetes.io": map[string]interface{}{
+				"namespace": "payments",
+			},
+		},
+	}
+}
+
+func spiffeCaller() *auth.Info {
+	return &auth.Info{
+		Method:   "spiffe",
+		Provider: "example.org",
+		Subject:  "spiffe://example.org/ns/prod/sa/api",
+		KubeAttributes: &auth.KubeAttributes{
+			Namespace:      "prod",
+			ServiceAccount: &auth.ServiceAccount{Name: "api", UID: "sa-uid"},
+		},
+	}
+}
+
+func TestCallerMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		authInfo *auth.Info
+		spec     *fedv1alpha1.AuthorizationSpec
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name:     "exact principal",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{Subject: &fedv1alpha1.FederationSubject{
+				OIDC: &fedv1alpha1.FederationOIDC{Subject: "repo:acme/payments:ref:refs/heads/main"},
+			}},
+			want: true,
+		},
+		{
+			name:     "subject glob",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				Subject: &fedv1alpha1.StringMatcher{Glob: "repo:acme/*:ref:refs/heads/*"},
+			}},
+			want: true,
+		},
+		{
+			name:     "subject regex is anchored",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				Subject: &fedv1alpha1.StringMatcher{Regex: "acme/payments"},
+			}},
+			want: false,
+		},
+		{
+			name:     "invalid regex",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				Subject: &fedv1alpha1.StringMatcher{Regex: "("},
+			}},
+			wantErr: true,
+		},
+		{
+			name:     "claims combined with and",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				Claims: []fedv1alpha1.ClaimMatcher{
+					{Claim: "repository", StringMatcher: fedv1alpha1.StringMatcher{Glob: "acme/payments"}},
+					{Claim: "ref", StringMatcher: fedv1alpha1.StringMatcher{Glob: "refs/heads/release-*"}},
+				},
+			}},
+			want: false,
+		},
+		{
+			name:     "claims combined with or",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				Operator: fedv1alpha1.MatchOperatorOr,
+				Claims: []fedv1alpha1.ClaimMatcher{
+					{Claim: "repository", StringMatcher: fedv1alpha1.StringMatcher{Glob: "acme/payments"}},
+					{Claim: "ref", StringMatcher: fedv1alpha1.StringMatcher{Glob: "refs/heads/release-*"}},
+				},
+			}},
+			want: true,
+		},
+		{
+			name:     "list claim matches any element",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				Claims: []fedv1alpha1.ClaimMatcher{
+					{Claim: "groups", StringMatcher: fedv1alpha1.StringMatcher{Regex: ".*-admins"}},
+				},
+			}},
+			want: true,
+		},
+		{
+			name:     "nested claim with dotted name",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				Claims: []fedv1alpha1.ClaimMatcher{
+					{Claim: "kubernetes.io.namespace", StringMatcher: fedv1alpha1.StringMatcher{Glob: "payments"}},
+				},
+			}},
+			want: true,
+		},
+		{
+			name:     "missing claim",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				Claims: []fedv1alpha1.ClaimMatcher{
+					{Claim: "environment", StringMatcher: fedv1alpha1.StringMatcher{Glob: "*"}},
+				},
+			}},
+			want: false,
+		},
+		{
+			name:     "spiffe path prefix",
+			authInfo: spiffeCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				SpiffePathPrefix: "/ns/prod",
+			}},
+			want: true,
+		},
+		{
+			name: "spiffe path prefix matches whole segments",
+			authInfo: &auth.Info{
+				Method:  "spiffe",
+				Subject: "spiffe://example.org/ns/production/sa/api",
+			},
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				SpiffePathPrefix: "/ns/prod",
+			}},
+			want: false,
+		},
+		{
+			name:     "spiffe path prefix does not match oidc callers",
+			authInfo: oidcCaller(),
+			spec: &fedv1alpha1.AuthorizationSpec{SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				SpiffePathPrefix: "/",
+			}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newCaller(tt.authInfo, nil).matches(tt.spec)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCallerMatchesWorkloadFederations(t *testing.T) {
+	workload := &auth.WorkloadInfo{
+		Namespace:      "apps",
+		ServiceAccount: &auth.ServiceAccount{Name: "api"},
+		Federations:    []fedv1alpha1.FederationRef{{Kind: "KubernetesFederation", Name: "workload-cluster"}},
+	}
+	spec := func(federation string) *fedv1alpha1.AuthorizationSpec {
+		return &fedv1alpha1.AuthorizationSpec{
+			FederationRef: fedv1alpha1.FederationRef{Kind: "KubernetesFederation", Name: federation},
+			Subject: &fedv1alpha1.FederationSubject{
+				OIDC: &fedv1alpha1.FederationOIDC{Subject: "repo:acme/payments:ref:refs/heads/main"},
+			},
+		}
+	}
+
+	c := newCaller(oidcCaller(), workload)
+	matched, err := c.matches(spec("workload-cluster"))
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	// Authorizations of other federations do not apply to the workload.
+	matched, err = c.matches(spec("other-cluster"))
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	// Without a workload token, the authorizations of all federations apply.
+	matched, err = newCaller(oidcCaller(), nil).matches(spec("other-cluster"))
+	require.NoError(t, err)
+	assert.True(t, matched)
+}
+
+func TestCallerMatchesVerifyingFederation(t *testing.T) {
+	ref := fedv1alpha1.FederationRef{Kind: "GitHubActionsFederation", Name: "github-prod"}
+	info := &auth.Info{
+		Method:     "github-actions",
+		Provider:   "https://token.actions.githubusercontent.com",
+		Subject:    "repo:acme/payments:ref:refs/heads/main",
+		Federation: &ref,
+	}
+	spec := func(federation string) *fedv1alpha1.AuthorizationSpec {
+		return &fedv1alpha1.AuthorizationSpec{
+			FederationRef: fedv1alpha1.FederationRef{Kind: "GitHubActionsFederation", Name: federation},
+			SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+				Subject: &fedv1alpha1.StringMatcher{Glob: "repo:acme/*:ref:refs/heads/*"},
+			},
+		}
+	}
+
+	c := newCaller(info, nil)
+	matched, err := c.matches(spec("github-prod"))
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	// Authorizations of another federation of the same issuer do not apply.
+	matched, err = c.matches(spec("github-dev"))
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestCallerTemplatedResources(t *testing.T) {
+	spec := &fedv1alpha1.AuthorizationSpec{
+		AllowedClusterSecretStores: []string{"ns-{{ .namespace }}", "shared"},
+		AllowedGenerators: []fedv1alpha1.AllowedGenerator{
+			{Name: "{{ .serviceAccount }}-password", Kind: "Password", Namespace: "{{ .namespace }}"},
+		},
+		AllowedGeneratorStates: []fedv1alpha1.AllowedGeneratorState{{Namespace: "{{ .namespace }}"}},
+	}
+
+	c := newCaller(spiffeCaller(), nil)
+	assert.True(t, c.allowsClusterSecretStore(spec, "ns-prod"))
+	assert.True(t, c.allowsClusterSecretStore(spec, "shared"))
+	assert.False(t, c.allowsClusterSecretStore(spec, "ns-dev"))
+	assert.True(t, c.allowsGenerator(spec, fedv1alpha1.AllowedGenerator{Name: "api-password", Kind: "Password", Namespace: "prod"}))
+	assert.False(t, c.allowsGenerator(spec, fedv1alpha1.AllowedGenerator{Name: "api-password", Kind: "Password", Namespace: "dev"}))
+	assert.True(t, c.allowsGeneratorState(spec, "prod"))
+
+	// The verified workload context takes precedence over the caller's own attributes.
+	c = newCaller(spiffeCaller(), &auth.WorkloadInfo{Namespace: "dev", ServiceAccount: &auth.ServiceAccount{Name: "worker"}})
+	assert.True(t, c.allowsClusterSecretStore(spec, "ns-dev"))
+	assert.False(t, c.allowsClusterSecretStore(spec, "ns-prod"))
+
+	// Callers without a namespace never match templated entries.
+	c = newCaller(oidcCaller(), nil)
+	assert.False(t, c.allowsClusterSecretStore(spec, "ns-"))
+	assert.True(t, c.allowsClusterSecretStore(spec, "shared"))
+
+	claimSpec := &fedv1alpha1.AuthorizationSpec{AllowedClusterSecretStores: []string{`{{ index .claims "repository" | printf "%s" }}`}}
+	assert.True(t, c.allowsClusterSecretStore(claimSpec, "acme/payments"))
+}
+
+func TestCallerAllowsSecret(t *testing.T) {
+	spec := &fedv1alpha1.AuthorizationSpec{
+		AllowedSecrets: []fedv1alpha1.AllowedSecret{
+			{ClusterSecretStore: "ns-{{ .namespace }}", Key: fedv1alpha1.StringMatcher{Glob: "{{ .serviceAccount }}/*"}},
+			{Key: fedv1alpha1.StringMatcher{Regex: "shared/.+"}, Properties: []string{"url"}},
+		},
+	}
+	c := newCaller(spiffeCaller(), nil)
+
+	tests := []struct {
+		name  string
+		store string
+		ref   esv1.ExternalSecretDataRemoteRef
+		want  bool
+	}{
+		{name: "templated key", store: "ns-prod", ref: esv1.ExternalSecretDataRemoteRef{Key: "api/db"}, want: true},
+		{name: "templated key of another workload", store: "ns-prod", ref: esv1.ExternalSecretDataRemoteRef{Key: "worker/db"}},
+		{name: "templated store of another namespace", store: "ns-dev", ref: esv1.ExternalSecretDataRemoteRef{Key: "api/db"}},
+		{name: "glob does not match nested keys", store: "ns-prod", ref: esv1.ExternalSecretDataRemoteRef{Key: "api/db/password"}},
+		{name: "rule for any store", store: "shared", ref: esv1.ExternalSecretDataRemoteRef{Key: "shared/queue", Property: "url"}, want: true},
+		{name: "property not allowed", store: "shared", ref: esv1.ExternalSecretDataRemoteRef{Key: "shared/queue", Property: "password"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.allowsSecret(spec, tt.store, tt.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := c.allowsSecret(&fedv1alpha1.AuthorizationSpec{}, "any", esv1.ExternalSecretDataRemoteRef{Key: "any"})
+	require.NoError(t, err)
+	assert.True(t, got)
+}
@@ -0,0 +1,95 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package fmetrics provides metrics for the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package fmetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// FederationSubsystem is the Prometheus subsystem for federation server metrics.
+	FederationSubsystem = "federation"
+	// RateLimitedRequestsKey is the metric key for requests rejected by a rate limit or quota.
+	RateLimitedRequestsKey = "rate_limited_requests_total"
+	// RateLimitersKey is the metric key for the number of tracked token buckets.
+	RateLimitersKey = "rate_limiters"
+	// AuthenticationsKey is the metric key for authentication attempts.
+	AuthenticationsKey = "authentications_total"
+	// AuthenticationDurationKey is the metric key for the duration of authentication attempts.
+	AuthenticationDurationKey = "authentication_duration_seconds"
+	// AuditRecordsDroppedKey is the metric key for audit records dropped because a sink is full.
+	AuditRecordsDroppedKey = "audit_records_dropped_total"
+
+	// LimitPerSubject labels requests rejected by the per subject rate limit.
+	LimitPerSubject = "subject"
+	// LimitPerAuthorization labels requests rejected by the per authorization rate limit.
+	LimitPerAuthorization = "authorization"
+	// LimitGeneratorStates labels requests rejected by the generator state quota.
+	LimitGeneratorStates = "generator_states"
+
+	// AuthenticationSuccess labels authentication attempts that succeeded.
+	AuthenticationSuccess = "success"
+	// AuthenticationFailure labels authentication attempts that failed.
+	AuthenticationFailure = "failure"
+)
+
+var (
+	// RateLimitedRequests counts the requests rejected by a rate limit or quota, by limit and route.
+	RateLimitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: FederationSubsystem,
+		Name:      RateLimitedRequestsKey,
+		Help:      "The number of federation requests rejected by a rate limit or quota",
+	}, []string{"limit", "route"})
+
+	// RateLimiters is the number of token buckets tracked by the federation server.
+	RateLimiters = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: FederationSubsystem,
+		Name:      RateLimitersKey,
+		Help:      "The number of token buckets tracked by the federation server",
+	})
+
+	// Authentications counts the authentication attempts of the federation server, by authenticator and outcome.
+	Authentications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: FederationSubsystem,
+		Name:      AuthenticationsKey,
+		Help:      "The number of federation authentication attempts",
+	}, []string{"authenticator", "outcome"})
+
+	// AuthenticationDuration is the duration of the authentication attempts of the federation server, by authenticator.
+	AuthenticationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: FederationSubsystem,
+		Name:      AuthenticationDurationKey,
+		Help:      "The duration of federation authentication attempts",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"authenticator"})
+
+	// AuditRecordsDropped counts the audit records dropped because the queue of a sink is full, by sink.
+	AuditRecordsDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: FederationSubsystem,
+		Name:      AuditRecordsDroppedKey,
+		Help:      "The number of federation audit records dropped because the queue of a sink is full",
+	}, []string{"sink"})
+)
+
+// SetUpMetrics is called at the root to register the federation server metrics.
+func SetUpMetrics() {
+	metrics.Registry.MustRegister(RateLimitedRequests, RateLimiters, Authentications, AuthenticationDuration, AuditRecordsDropped)
+}
@@ -0,0 +1,284 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+	store "github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+const (
+	leaseIDLabel            = "federation.externalsecrets.com/lease-id"
+	leaseIssuedAtAnnotation = "federation.externalsecrets.com/lease-issued-at"
+	leaseTTLAnnotation      = "federation.externalsecrets.com/lease-ttl"
+	leaseMaxTTLAnnotation   = "federation.externalsecrets.com/lease-max-ttl"
+
+	headerLeaseID         = "X-Lease-Id"
+	headerLeaseDuration   = "X-Lease-Duration"
+	headerLeaseExpiration = "X-Lease-Expiration"
+)
+
+var errLeaseNotFound = errors.New("lease not found")
+
+// Lease is the lease of credentials issued by a generator. Credentials are revoked
+// when their lease expires, by deleting the GeneratorState they are bound to.
+type Lease struct {
+	ID       string        `json:"id"`
+	IssuedAt time.Time     `json:"issuedAt"`
+	TTL      time.Duration `json:"ttl"`
+	MaxTTL   time.Duration `json:"maxTTL,omitempty"`
+}
+
+// newLease starts the lease of credentials issued through an authorization.
+// A zero TTL is resolved from the cleanup policy of the generator on issuance.
+func newLease(policy *fedv1alpha1.LeasePolicy) *Lease {
+	lease := &Lease{
+		ID:       uuid.NewString(),
+		IssuedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if policy != nil {
+		lease.TTL = policy.TTL.Duration
+		if policy.MaxTTL != nil {
+			lease.MaxTTL = policy.MaxTTL.Duration
+		}
+	}
+	return lease
+}
+
+// expiresAt returns when the lease expires when extended by increment from now. The increment
+// defaults to and is capped at the TTL of the lease, and the lease never goes past its max TTL.
+func (l *Lease) expiresAt(now time.Time, increment time.Duration) time.Time {
+	if increment <= 0 || increment > l.TTL {
+		increment = l.TTL
+	}
+	expiration := now.Add(increment)
+	if l.MaxTTL > 0 {
+		if maxExpiration := l.IssuedAt.Add(l.MaxTTL); expiration.After(maxExpiration) {
+			return maxExpiration
+		}
+	}
+	return expiration
+}
+
+// apply binds the lease to the GeneratorState of the credentials. Leases without a TTL
+// fall back to the idle timeout of the cleanup policy of the generator, and otherwise never expire.
+func (l *Lease) apply(state *genv1alpha1.GeneratorState, cleanupPolicy *genv1alpha1.CleanupPolicy) {
+	if l.TTL == 0 && cleanupPolicy != nil && cleanupPolicy.Type == genv1alpha1.IdleCleanupPolicy {
+		l.TTL = cleanupPolicy.IdleTimeout.Duration
+	}
+	state.Labels[leaseIDLabel] = l.ID
+	state.Annotations[leaseIssuedAtAnnotation] = l.IssuedAt.Format(time.RFC3339)
+	if l.TTL == 0 {
+		return
+	}
+	state.Annotations[leaseTTLAnnotation] = l.TTL.String()
+	if l.MaxTTL > 0 {
+		state.Annotations[leaseMaxTTLAnnotation] = l.MaxTTL.String()
+	}
+	// The lease expires at a fixed time, even if the cleanup policy of the generator is idle.
+	state.Annotations[genv1alpha1.GeneratorStateAnnotationHardDeadline] = "true"
+	state.Spec.GarbageCollectionDeadline = &metav1.Time{Time: l.expiresAt(l.IssuedAt, l.TTL)}
+}
+
+// setHeaders returns the lease along with the issued credentials.
+func (l *Lease) setHeaders(c echo.Context) {
+	header := c.Response().Header()
+	header.Set(headerLeaseID, l.ID)
+	if l.TTL == 0 {
+		return
+	}
+	expiration := l.expiresAt(l.IssuedAt, l.TTL)
+	header.Set(headerLeaseDuration, strconv.Itoa(int(expiration.Sub(l.IssuedAt).Seconds())))
+	header.Set(headerLeaseExpiration, expiration.Format(time.RFC3339))
+}
+
+// leaseFromState reads the lease bound to a GeneratorState.
+func leaseFromState(state *genv1alpha1.GeneratorState) (*Lease, error) {
+	lease := &Lease{ID: state.Labels[leaseIDLabel]}
+	var err error
+	if lease.IssuedAt, err = time.Parse(time.RFC3339, state.Annotations[leaseIssuedAtAnnotation]); err != nil {
+		return nil, fmt.Errorf("invalid lease issuance time: %w", err)
+	}
+	if ttl, ok := state.Annotations[leaseTTLAnnotation]; ok {
+		if lease.TTL, err = time.ParseDuration(ttl); err != nil {
+			return nil, fmt.Errorf("invalid lease TTL: %w", err)
+		}
+	}
+	if maxTTL, ok := state.Annotations[leaseMaxTTLAnnotation]; ok {
+		if lease.MaxTTL, err = time.ParseDuration(maxTTL); err != nil {
+			return nil, fmt.Errorf("invalid lease max TTL: %w", err)
+		}
+	}
+	return lease, nil
+}
+
+type renewLeaseRequest struct {
+	// Increment is the duration to extend the lease by, from now. Defaults to and is capped at the TTL of the lease.
+	Increment string `json:"increment,omitempty"`
+}
+
+type leaseResponse struct {
+	LeaseID       string    `json:"leaseId"`
+	LeaseDuration int       `json:"leaseDuration"`
+	Expiration    time.Time `json:"expiration"`
+}
+
+// renewLease extends the lease of credentials issued to the caller by at most its TTL, up to the max TTL of the lease.
+// The caller must still be allowed to use the generator that issued them.
+func (s *Handler) renewLease(c echo.Context) error {
+	authInfo := c.Get("authInfo").(*auth.Info)
+	workloadInfo, _ := c.Get("workloadInfo").(*auth.WorkloadInfo)
+
+	leaseID := c.Param("leaseId")
+	leaseRes := &audit.Resource{Kind: "Lease", Name: leaseID}
+	var req renewLeaseRequest
+	if err := c.Bind(&req); err != nil {
+		s.audit(c, audit.EventLeaseRenewal, audit.OutcomeFailure, leaseRes, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var increment time.Duration
+	if req.Increment != "" {
+		var err error
+		increment, err = time.ParseDuration(req.Increment)
+		if err != nil || increment <= 0 {
+			s.audit(c, audit.EventLeaseRenewal, audit.OutcomeFailure, leaseRes, "invalid increment")
+			return c.JSON(http.StatusBadRequest, "invalid increment")
+		}
+	}
+
+	resource, err := newResource("", authInfo, workloadInfo)
+	if err != nil {
+		s.audit(c, audit.EventLeaseRenewal, audit.OutcomeFailure, leaseRes, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	state, err := s.getLeaseState(c.Request().Context(), leaseID)
+	if err != nil && !errors.Is(err, errLeaseNotFound) {
+		s.audit(c, audit.EventLeaseRenewal, audit.OutcomeFailure, leaseRes, err.Error())
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+	// Leases of other callers are not found, so their IDs cannot be probed.
+	if state == nil || !ownsState(resource, state) {
+		s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, leaseRes, errLeaseNotFound.Error())
+		return c.JSON(http.StatusNotFound, "Not Found")
+	}
+	leaseRes.Namespace = state.Namespace
+
+	generator := fedv1alpha1.AllowedGenerator{
+		Name:      state.Labels["federation.externalsecrets.com/generator"],
+		Kind:      state.Labels["federation.externalsecrets.com/generator-kind"],
+		Namespace: state.Namespace,
+	}
+	caller := newCaller(authInfo, workloadInfo)
+	for _, spec := range store.Get(authInfo.Provider) {
+		matched, err := caller.matches(spec)
+		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, leaseRes, err.Error())
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		if !matched || !caller.allowsGenerator(spec, generator) {
+			continue
+		}
+		if ok, err := s.rateLimit(c, spec, authInfo); !ok {
+			return err
+		}
+		return s.extendLease(c, state, increment, leaseRes)
+	}
+	s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, leaseRes, "no authorization allows the generator")
+	return c.JSON(http.StatusNotFound, "Not Found")
+}
+
+func (s *Handler) extendLease(c echo.Context, state *genv1alpha1.GeneratorState, increment time.Duration, leaseRes *audit.Resource) error {
+	lease, err := leaseFromState(state)
+	if err != nil {
+		s.audit(c, audit.EventLeaseRenewal, audit.OutcomeFailure, leaseRes, err.Error())
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+	if lease.TTL == 0 || state.Spec.GarbageCollectionDeadline == nil {
+		s.audit(c, audit.EventLeaseRenewal, audit.OutcomeFailure, leaseRes, "lease does not expire")
+		return c.JSON(http.StatusBadRequest, "lease does not expire")
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	if state.DeletionTimestamp != nil || !state.Spec.GarbageCollectionDeadline.After(now) {
+		s.audit(c, audit.EventLeaseRenewal, audit.OutcomeFailure, leaseRes, "lease expired")
+		return c.JSON(http.StatusGone, "lease expired")
+	}
+	expiration := lease.expiresAt(now, increment)
+
+	patch := client.MergeFrom(state.DeepCopy())
+	state.Spec.GarbageCollectionDeadline = &metav1.Time{Time: expiration}
+	if err := s.reconciler.Client.Patch(c.Request().Context(), state, patch); err != nil {
+		s.audit(c, audit.EventLeaseRenewal, audit.OutcomeFailure, leaseRes, err.Error())
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+	s.audit(c, audit.EventLeaseRenewal, audit.OutcomeSuccess, leaseRes, "")
+	return c.JSON(http.StatusOK, leaseResponse{
+		LeaseID:       lease.ID,
+		LeaseDuration: int(expiration.Sub(now).Seconds()),
+		Expiration:    expiration,
+	})
+}
+
+// getLeaseState returns the GeneratorState bound to a lease.
+func (s *Handler) getLeaseState(ctx context.Context, leaseID string) (*genv1alpha1.GeneratorState, error) {
+	if s.reconciler == nil || s.reconciler.Client == nil {
+		return nil, errLeaseNotFound
+	}
+	states := &genv1alpha1.GeneratorStateList{}
+	err := s.reconciler.Client.List(ctx, states, &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{leaseIDLabel: leaseID}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GeneratorStates: %w", err)
+	}
+	if len(states.Items) == 0 {
+		return nil, errLeaseNotFound
+	}
+	return &states.Items[0], nil
+}
+
+// ownsState returns whether the GeneratorState was created for the owner of the resource.
+func ownsState(resource *Resource, state *genv1alpha1.GeneratorState) bool {
+	if state.Labels["federation.externalsecrets.com/owner"] != resource.Owner {
+		return false
+	}
+	var attributes map[string]string
+	if err := json.Unmarshal([]byte(state.Annotations["federation.externalsecrets.com/owner-attributes"]), &attributes); err != nil {
+		return false
+	}
+	return maps.Equal(attributes, resource.OwnerAttributes)
+}
@@ -0,0 +1,234 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	externalsecrets "github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+	store "github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+func TestLeaseApply(t *testing.T) {
+	issuedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newState := func() *genv1alpha1.GeneratorState {
+		return &genv1alpha1.GeneratorState{ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		}}
+	}
+	idle := &genv1alpha1.CleanupPolicy{Type: genv1alpha1.IdleCleanupPolicy, IdleTimeout: metav1.Duration{Duration: 2 * time.Hour}}
+
+	t.Run("authorization TTL", func(t *testing.T) {
+		lease := &Lease{ID: "lease", IssuedAt: issuedAt, TTL: time.Hour, MaxTTL: 30 * time.Minute}
+		state := newState()
+		lease.apply(state, idle)
+		assert.Equal(t, "lease", state.Labels[leaseIDLabel])
+		assert.Equal(t, "true", state.Annotations[genv1alpha1.GeneratorStateAnnotationHardDeadline])
+		// The first expiration is capped by the max TTL.
+		assert.Equal(t, issuedAt.Add(30*time.Minute), state.Spec.GarbageCollectionDeadline.Time)
+
+		parsed, err := leaseFromState(state)
+		require.NoError(t, err)
+		assert.Equal(t, lease, parsed)
+	})
+
+	t.Run("idle timeout of the generator", func(t *testing.T) {
+		lease := &Lease{ID: "lease", IssuedAt: issuedAt}
+		state := newState()
+		lease.apply(state, idle)
+		assert.Equal(t, 2*time.Hour, lease.TTL)
+		assert.Equal(t, issuedAt.Add(2*time.Hour), state.Spec.GarbageCollectionDeadline.Time)
+	})
+
+	t.Run("lease without TTL", func(t *testing.T) {
+		lease := &Lease{ID: "lease", IssuedAt: issuedAt}
+		state := newState()
+		lease.apply(state, &genv1alpha1.CleanupPolicy{Type: genv1alpha1.RetainLatestPolicy})
+		assert.Equal(t, "lease", state.Labels[leaseIDLabel])
+		assert.Nil(t, state.Spec.GarbageCollectionDeadline)
+		assert.NotContains(t, state.Annotations, genv1alpha1.GeneratorStateAnnotationHardDeadline)
+	})
+}
+
+func TestGenerateSecretsLeaseHeaders(t *testing.T) {
+	const testIssuer = "lease-issuer"
+	authInfo := &auth.Info{Method: "oidc", Provider: testIssuer, Subject: "lease"}
+	spec := rateLimitedSpec("lease")
+	spec.RateLimits = nil
+	spec.Lease = &fedv1alpha1.LeasePolicy{TTL: metav1.Duration{Duration: time.Hour}}
+	store.Add(testIssuer, spec)
+	t.Cleanup(func() {
+		store.Remove(testIssuer, spec)
+	})
+
+	s := NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true, nil)
+	var lease *Lease
+	s.generateSecretFn = func(_ context.Context, _, _, _ string, resource *Resource) (map[string]string, string, string, error) {
+		lease = resource.Lease
+		return map[string]string{"password": "value"}, "", "", nil
+	}
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", http.NoBody), rec)
+	c.SetParamNames("generatorNamespace", "generatorKind", "generatorName")
+	c.SetParamValues("test-ns", "Password", "test-generator")
+	setAuthContext(c, authInfo)
+	require.NoError(t, s.generateSecrets(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	require.NotNil(t, lease)
+	assert.Equal(t, lease.ID, rec.Header().Get(headerLeaseID))
+	assert.Equal(t, "3600", rec.Header().Get(headerLeaseDuration))
+	assert.Equal(t, lease.IssuedAt.Add(time.Hour).Format(time.RFC3339), rec.Header().Get(headerLeaseExpiration))
+}
+
+func TestRenewLease(t *testing.T) {
+	const testIssuer = "renew-issuer"
+	owner := &auth.Info{Method: "oidc", Provider: testIssuer, Subject: "renew"}
+	spec := rateLimitedSpec("*")
+	spec.RateLimits = nil
+	store.Add(testIssuer, spec)
+	t.Cleanup(func() {
+		store.Remove(testIssuer, spec)
+	})
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, genv1alpha1.AddToScheme(scheme))
+	newHandler := func(state *genv1alpha1.GeneratorState) (*Handler, client.Client) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(state).Build()
+		s := NewHandler(&externalsecrets.Reconciler{Client: c}, ":8080", ":8081", "unix:///spire.sock", true, nil)
+		return s, c
+	}
+	newState := func(lease *Lease) *genv1alpha1.GeneratorState {
+		resource, err := newResource("test-generator", owner, nil)
+		require.NoError(t, err)
+		attributes, err := json.Marshal(resource.OwnerAttributes)
+		require.NoError(t, err)
+		state := &genv1alpha1.GeneratorState{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "password-state",
+				Namespace: "test-ns",
+				Labels: map[string]string{
+					"federation.externalsecrets.com/owner":          resource.Owner,
+					"federation.externalsecrets.com/generator":      "test-generator",
+					"federation.externalsecrets.com/generator-kind": "Password",
+				},
+				Annotations: map[string]string{
+					"federation.externalsecrets.com/owner-attributes": string(attributes),
+				},
+			},
+		}
+		lease.apply(state, nil)
+		return state
+	}
+	renew := func(s *Handler, authInfo *auth.Info, leaseID, body string) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("leaseId")
+		c.SetParamValues(leaseID)
+		setAuthContext(c, authInfo)
+		require.NoError(t, s.renewLease(c))
+		return rec
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("renews up to the max TTL", func(t *testing.T) {
+		lease := &Lease{ID: "lease-a", IssuedAt: now.Add(-45 * time.Minute), TTL: time.Hour, MaxTTL: 90 * time.Minute}
+		s, c := newHandler(newState(lease))
+
+		rec := renew(s, owner, "lease-a", `{"increment":"30m"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp leaseResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "lease-a", resp.LeaseID)
+		assert.InDelta(t, (30 * time.Minute).Seconds(), resp.LeaseDuration, 2)
+
+		rec = renew(s, owner, "lease-a", `{"increment":"24h"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.True(t, lease.IssuedAt.Add(90*time.Minute).Equal(resp.Expiration))
+
+		state := &genv1alpha1.GeneratorState{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "password-state", Namespace: "test-ns"}, state))
+		assert.True(t, lease.IssuedAt.Add(90*time.Minute).Equal(state.Spec.GarbageCollectionDeadline.Time))
+	})
+
+	t.Run("caps the increment at the TTL", func(t *testing.T) {
+		s, _ := newHandler(newState(&Lease{ID: "lease-g", IssuedAt: now, TTL: time.Hour}))
+		rec := renew(s, owner, "lease-g", `{"increment":"24h"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp leaseResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.InDelta(t, time.Hour.Seconds(), resp.LeaseDuration, 2)
+	})
+
+	t.Run("defaults to the TTL", func(t *testing.T) {
+		s, _ := newHandler(newState(&Lease{ID: "lease-b", IssuedAt: now, TTL: time.Hour}))
+		rec := renew(s, owner, "lease-b", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp leaseResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.InDelta(t, time.Hour.Seconds(), resp.LeaseDuration, 2)
+	})
+
+	t.Run("lease of another caller", func(t *testing.T) {
+		s, _ := newHandler(newState(&Lease{ID: "lease-c", IssuedAt: now, TTL: time.Hour}))
+		other := &auth.Info{Method: "oidc", Provider: testIssuer, Subject: "other"}
+		assert.Equal(t, http.StatusNotFound, renew(s, other, "lease-c", "").Code)
+		assert.Equal(t, http.StatusNotFound, renew(s, owner, "unknown", "").Code)
+	})
+
+	t.Run("expired lease", func(t *testing.T) {
+		s, _ := newHandler(newState(&Lease{ID: "lease-d", IssuedAt: now.Add(-2 * time.Hour), TTL: time.Hour}))
+		assert.Equal(t, http.StatusGone, renew(s, owner, "lease-d", "").Code)
+	})
+
+	t.Run("lease without TTL", func(t *testing.T) {
+		s, _ := newHandler(newState(&Lease{ID: "lease-e", IssuedAt: now}))
+		assert.Equal(t, http.StatusBadRequest, renew(s, owner, "lease-e", "").Code)
+	})
+
+	t.Run("invalid increment", func(t *testing.T) {
+		s, _ := newHandler(newState(&Lease{ID: "lease-f", IssuedAt: now, TTL: time.Hour}))
+		assert.Equal(t, http.StatusBadRequest, renew(s, owner, "lease-f", `{"increment":"-1h"}`).Code)
+	})
+}
@@ -0,0 +1,196 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	v1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+	store "github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+// pushSecretRequest is the payload of a PushSecret made through the federation server.
+// The remote key is the path escaped secretName parameter, and the property is read from the query.
+type pushSecretRequest struct {
+	// Data holds the secret to push. When SecretKey is set, only that key is pushed.
+	Data      map[string][]byte   `json:"data"`
+	SecretKey string              `json:"secretKey,omitempty"`
+	Metadata  *apiextensions.JSON `json:"metadata,omitempty"`
+}
+
+type secretExistsResponse struct {
+	Exists bool `json:"exists"`
+}
+
+// pushSecret pushes a secret into a ClusterSecretStore allowed by AllowedPushTargets.
+func (s *Handler) pushSecret(c echo.Context) error {
+	storeName, ref, res, err := pushRemoteRef(c)
+	if err != nil {
+		s.audit(c, audit.EventSecretPush, audit.OutcomeFailure, res, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var req pushSecretRequest
+	if err := c.Bind(&req); err != nil {
+		s.audit(c, audit.EventSecretPush, audit.OutcomeFailure, res, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if req.SecretKey != "" {
+		if _, ok := req.Data[req.SecretKey]; !ok {
+			s.audit(c, audit.EventSecretPush, audit.OutcomeFailure, res, "secretKey not found in data")
+			return c.JSON(http.StatusBadRequest, "secretKey not found in data")
+		}
+	}
+	if ok, err := s.authorizePush(c, storeName, ref.RemoteKey, false, res); !ok {
+		return err
+	}
+
+	secret := &v1.Secret{Data: req.Data}
+	data := esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{
+			SecretKey: req.SecretKey,
+			RemoteRef: ref,
+		},
+		Metadata: req.Metadata,
+	}
+	err = s.useSecretsClientFn(c.Request().Context(), storeName, func(client esv1.SecretsClient) error {
+		return client.PushSecret(c.Request().Context(), secret, data)
+	})
+	if err != nil {
+		s.audit(c, audit.EventSecretPush, audit.OutcomeFailure, res, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	s.audit(c, audit.EventSecretPush, audit.OutcomeSuccess, res, "")
+	return c.JSON(http.StatusOK, nil)
+}
+
+// deleteSecret deletes a pushed secret from a ClusterSecretStore allowed by AllowedPushTargets
+// with AllowDelete set.
+func (s *Handler) deleteSecret(c echo.Context) error {
+	storeName, ref, res, err := pushRemoteRef(c)
+	if err != nil {
+		s.audit(c, audit.EventSecretDelete, audit.OutcomeFailure, res, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if ok, err := s.authorizePush(c, storeName, ref.RemoteKey, true, res); !ok {
+		return err
+	}
+	err = s.useSecretsClientFn(c.Request().Context(), storeName, func(client esv1.SecretsClient) error {
+		return client.DeleteSecret(c.Request().Context(), ref)
+	})
+	if err != nil {
+		s.audit(c, audit.EventSecretDelete, audit.OutcomeFailure, res, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	s.audit(c, audit.EventSecretDelete, audit.OutcomeSuccess, res, "")
+	return c.JSON(http.StatusOK, nil)
+}
+
+// secretExists reports whether a secret that can be pushed exists in the ClusterSecretStore.
+func (s *Handler) secretExists(c echo.Context) error {
+	storeName, ref, res, err := pushRemoteRef(c)
+	if err != nil {
+		s.audit(c, audit.EventSecretRead, audit.OutcomeFailure, res, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if ok, err := s.authorizePush(c, storeName, ref.RemoteKey, false, res); !ok {
+		return err
+	}
+	var exists bool
+	err = s.useSecretsClientFn(c.Request().Context(), storeName, func(client esv1.SecretsClient) error {
+		var err error
+		exists, err = client.SecretExists(c.Request().Context(), ref)
+		return err
+	})
+	if err != nil {
+		s.audit(c, audit.EventSecretRead, audit.OutcomeFailure, res, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	s.audit(c, audit.EventSecretRead, audit.OutcomeSuccess, res, "")
+	return c.JSON(http.StatusOK, secretExistsResponse{Exists: exists})
+}
+
+// pushRemoteRef reads the ClusterSecretStore and the remote ref of a write request.
+func pushRemoteRef(c echo.Context) (string, esv1alpha1.PushSecretRemoteRef, *audit.Resource, error) {
+	storeName := c.Param("secretStoreName")
+	key, err := url.PathUnescape(c.Param("secretName"))
+	if err != nil {
+		return storeName, esv1alpha1.PushSecretRemoteRef{}, nil, err
+	}
+	ref := esv1alpha1.PushSecretRemoteRef{
+		RemoteKey: key,
+		Property:  c.QueryParam("property"),
+	}
+	res := secretResource(storeName, esv1.ExternalSecretDataRemoteRef{Key: ref.RemoteKey, Property: ref.Property})
+	return storeName, ref, res, nil
+}
+
+// authorizePush finds an authorization allowing the caller to write the remote key into the
+// ClusterSecretStore, and applies its rate limits. It returns false after responding otherwise.
+func (s *Handler) authorizePush(c echo.Context, storeName, key string, del bool, res *audit.Resource) (bool, error) {
+	authInfo := c.Get("authInfo").(*auth.Info)
+	workloadInfo, _ := c.Get("workloadInfo").(*auth.WorkloadInfo)
+
+	caller := newCaller(authInfo, workloadInfo)
+	for _, spec := range store.Get(authInfo.Provider) {
+		matched, err := caller.matches(spec)
+		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, res, err.Error())
+			return false, c.JSON(http.StatusBadRequest, err.Error())
+		}
+		if !matched {
+			continue
+		}
+		allowed, err := caller.allowsPushTarget(spec, storeName, key, del)
+		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, res, err.Error())
+			return false, c.JSON(http.StatusBadRequest, err.Error())
+		}
+		if allowed {
+			return s.rateLimit(c, spec, authInfo)
+		}
+	}
+	s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, res, "no authorization allows pushing to the ClusterSecretStore")
+	return false, c.JSON(http.StatusNotFound, "Not Found")
+}
+
+// useSecretsClient calls fn with a client of the ClusterSecretStore, closed once fn returns.
+func (s *Handler) useSecretsClient(ctx context.Context, storeName string, fn func(esv1.SecretsClient) error) error {
+	storeRef := esv1.SecretStoreRef{
+		Name: storeName,
+		Kind: esv1.ClusterSecretStoreKind,
+	}
+	mgr := secretstore.NewManager(s.reconciler.Client, s.reconciler.ControllerClass, s.reconciler.EnableFloodGate)
+	client, err := mgr.Get(ctx, storeRef, "", nil)
+	if err != nil {
+		return errors.Join(err, mgr.Close(ctx))
+	}
+	return errors.Join(fn(client), mgr.Close(ctx))
+}
@@ -0,0 +1,145 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+	store "github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+	"github.com/external-secrets/external-secrets/runtime/testing/fake"
+)
+
+func TestCallerAllowsPushTarget(t *testing.T) {
+	spec := &fedv1alpha1.AuthorizationSpec{
+		AllowedPushTargets: []fedv1alpha1.AllowedPushTarget{
+			{ClusterSecretStore: "ns-{{ .namespace }}", Key: &fedv1alpha1.StringMatcher{Glob: "{{ .serviceAccount }}/*"}},
+			{ClusterSecretStore: "shared", AllowDelete: true},
+		},
+	}
+	c := newCaller(spiffeCaller(), nil)
+
+	tests := []struct {
+		name  string
+		store string
+		key   string
+		del   bool
+		want  bool
+	}{
+		{name: "templated key", store: "ns-prod", key: "api/db", want: true},
+		{name: "key of another workload", store: "ns-prod", key: "worker/db"},
+		{name: "store of another namespace", store: "ns-dev", key: "api/db"},
+		{name: "delete not allowed", store: "ns-prod", key: "api/db", del: true},
+		{name: "any key", store: "shared", key: "anything", want: true},
+		{name: "delete allowed", store: "shared", key: "anything", del: true, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.allowsPushTarget(spec, tt.store, tt.key, tt.del)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := c.allowsPushTarget(&fedv1alpha1.AuthorizationSpec{}, "shared", "anything", false)
+	require.NoError(t, err)
+	assert.False(t, got)
+}
+
+func TestPushSecretHandlers(t *testing.T) {
+	const testIssuer = "push-issuer"
+	authInfo := &auth.Info{Method: "oidc", Provider: testIssuer, Subject: "pusher"}
+	spec := &fedv1alpha1.AuthorizationSpec{
+		FederationRef:  fedv1alpha1.FederationRef{Name: "test-federation", Kind: "Kubernetes"},
+		SubjectMatcher: &fedv1alpha1.SubjectMatcher{Subject: &fedv1alpha1.StringMatcher{Glob: "pusher"}},
+		AllowedPushTargets: []fedv1alpha1.AllowedPushTarget{
+			{ClusterSecretStore: "hub", Key: &fedv1alpha1.StringMatcher{Glob: "spoke/*"}},
+		},
+	}
+	store.Add(testIssuer, spec)
+	t.Cleanup(func() {
+		store.Remove(testIssuer, spec)
+	})
+
+	provider := fake.New()
+	provider.SecretExistsFn = func(_ context.Context, ref esv1.PushSecretRemoteRef) (bool, error) {
+		return ref.GetRemoteKey() == "spoke/db", nil
+	}
+	s := NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true, nil)
+	s.useSecretsClientFn = func(_ context.Context, storeName string, fn func(esv1.SecretsClient) error) error {
+		assert.Equal(t, "hub", storeName)
+		return fn(provider)
+	}
+	call := func(method, key, suffix string, body io.Reader) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(method, "/?property=password", body)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("secretStoreName", "secretName")
+		c.SetParamValues("hub", url.PathEscape(key))
+		setAuthContext(c, authInfo)
+		switch {
+		case method == http.MethodPut:
+			require.NoError(t, s.pushSecret(c))
+		case method == http.MethodDelete:
+			require.NoError(t, s.deleteSecret(c))
+		case suffix == "exists":
+			require.NoError(t, s.secretExists(c))
+		}
+		return rec
+	}
+
+	// dmFsdWU= is "value" base64 encoded, as encoding/json encodes []byte.
+	rec := call(http.MethodPut, "spoke/db", "", strings.NewReader(`{"data":{"password":"dmFsdWU=","other":"b3RoZXI="},"secretKey":"password"}`))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	pushed := provider.GetPushSecretData()["spoke/db"]
+	assert.Equal(t, []byte("value"), pushed.Value)
+	assert.Equal(t, "password", pushed.RemoteRef.GetProperty())
+
+	rec = call(http.MethodPut, "spoke/db", "", strings.NewReader(`{"data":{},"secretKey":"password"}`))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = call(http.MethodPut, "hub/db", "", strings.NewReader(`{"data":{"password":"dmFsdWU="}}`))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = call(http.MethodGet, "spoke/db", "exists", http.NoBody)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"exists":true}`, rec.Body.String())
+
+	// Deleting requires AllowDelete.
+	rec = call(http.MethodDelete, "spoke/db", "", http.NoBody)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	spec.AllowedPushTargets[0].AllowDelete = true
+	rec = call(http.MethodDelete, "spoke/db", "", http.NoBody)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
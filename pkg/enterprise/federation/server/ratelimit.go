@@ -0,0 +1,299 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/fmetrics"
+)
+
+const (
+	// rateLimiterIdleTimeout is how long the token bucket of an idle caller is kept.
+	rateLimiterIdleTimeout = 10 * time.Minute
+	// rateLimiterSweepInterval is how often idle token buckets are removed.
+	rateLimiterSweepInterval = time.Minute
+	// generatorStateQuotaRetryAfter is the Retry-After of requests over the generator state quota.
+	// GeneratorStates are only released by revoking credentials, so there is no exact time to retry at.
+	generatorStateQuotaRetryAfter = time.Minute
+)
+
+// rateLimiter holds the token buckets of the rate limits of authorizations.
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		buckets: map[string]*tokenBucket{},
+		now:     time.Now,
+	}
+}
+
+// allow takes a token from the per authorization and the per subject buckets of the caller.
+// If either bucket is empty no token is taken, and the time until both buckets hold a token
+// is returned along with the limit that was hit.
+func (r *rateLimiter) allow(spec *fedv1alpha1.AuthorizationSpec, subject string) (bool, string, time.Duration) {
+	if spec.RateLimits == nil {
+		return true, "", 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.sweep(now)
+
+	key := authorizationKey(spec)
+	type reservation struct {
+		limit string
+		*rate.Reservation
+	}
+	var reservations []reservation
+	if spec.RateLimits.PerAuthorization != nil {
+		reservations = append(reservations, reservation{fmetrics.LimitPerAuthorization, r.bucket(key, spec.RateLimits.PerAuthorization, now).ReserveN(now, 1)})
+	}
+	if spec.RateLimits.PerSubject != nil {
+		reservations = append(reservations, reservation{fmetrics.LimitPerSubject, r.bucket(key+"/"+subject, spec.RateLimits.PerSubject, now).ReserveN(now, 1)})
+	}
+
+	var limit string
+	var delay time.Duration
+	for _, res := range reservations {
+		if d := res.DelayFrom(now); d > delay {
+			limit, delay = res.limit, d
+		}
+	}
+	if delay == 0 {
+		return true, "", 0
+	}
+	for _, res := range reservations {
+		res.CancelAt(now)
+	}
+	return false, limit, delay
+}
+
+// bucket returns the limiter of the key, updating it to the configured limit.
+func (r *rateLimiter) bucket(key string, config *fedv1alpha1.TokenBucket, now time.Time) *rate.Limiter {
+	limit := rate.Limit(float64(config.RequestsPerMinute) / time.Minute.Seconds())
+	burst := int(config.Burst)
+	if burst == 0 {
+		burst = int(config.RequestsPerMinute)
+	}
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &tokenBucket{limiter: rate.NewLimiter(limit, burst)}
+		r.buckets[key] = b
+		fmetrics.RateLimiters.Set(float64(len(r.buckets)))
+	}
+	if b.limiter.Limit() != limit {
+		b.limiter.SetLimitAt(now, limit)
+	}
+	if b.limiter.Burst() != burst {
+		b.limiter.SetBurstAt(now, burst)
+	}
+	b.lastSeen = now
+	return b.limiter
+}
+
+// sweep removes the buckets of callers idle for longer than rateLimiterIdleTimeout.
+func (r *rateLimiter) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < rateLimiterSweepInterval {
+		return
+	}
+	r.lastSweep = now
+	for key, b := range r.buckets {
+		if now.Sub(b.lastSeen) > rateLimiterIdleTimeout {
+			delete(r.buckets, key)
+		}
+	}
+	fmetrics.RateLimiters.Set(float64(len(r.buckets)))
+}
+
+// authorizationKey identifies the token buckets of an authorization by what it applies to,
+// so the buckets are kept when the authorization is reconciled again.
+func authorizationKey(spec *fedv1alpha1.AuthorizationSpec) string {
+	key, _ := json.Marshal(struct {
+		FederationRef  fedv1alpha1.FederationRef      `json:"federationRef"`
+		Subject        *fedv1alpha1.FederationSubject `json:"subject,omitempty"`
+		SubjectMatcher *fedv1alpha1.SubjectMatcher    `json:"subjectMatcher,omitempty"`
+	}{spec.FederationRef, spec.Subject, spec.SubjectMatcher})
+	return string(key)
+}
+
+// rateLimit applies the rate limits of the authorization to the caller. It returns false after
+// responding with 429 Too Many Requests if the request is over a limit.
+func (s *Handler) rateLimit(c echo.Context, spec *fedv1alpha1.AuthorizationSpec, authInfo *auth.Info) (bool, error) {
+	ok, limit, delay := s.rateLimiter.allow(spec, authInfo.Subject)
+	if ok {
+		return true, nil
+	}
+	return false, s.tooManyRequests(c, limit, delay)
+}
+
+// identityLocks holds a lock per AuthorizedIdentity. A lock is removed once no request holds
+// or waits for it, so the locks of callers that stopped sending requests are not kept.
+type identityLocks struct {
+	mu    sync.Mutex
+	locks map[string]*identityLock
+}
+
+type identityLock struct {
+	sync.Mutex
+	users int
+}
+
+func newIdentityLocks() *identityLocks {
+	return &identityLocks{
+		locks: map[string]*identityLock{},
+	}
+}
+
+// lock takes the lock of the identity, and returns the function releasing it.
+func (l *identityLocks) lock(name string) func() {
+	l.mu.Lock()
+	lock, ok := l.locks[name]
+	if !ok {
+		lock = &identityLock{}
+		l.locks[name] = lock
+	}
+	lock.users++
+	l.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		lock.users--
+		if lock.users == 0 {
+			delete(l.locks, name)
+		}
+	}
+}
+
+// lockGeneratorStates serializes the generator requests of the AuthorizedIdentity of the caller
+// while the authorization has a generator state quota, so concurrent requests cannot all pass
+// the quota before their GeneratorStates are counted. It returns the function releasing the lock.
+func (s *Handler) lockGeneratorStates(spec *fedv1alpha1.AuthorizationSpec, authInfo *auth.Info) func() {
+	if spec.Quotas == nil || spec.Quotas.MaxGeneratorStates == nil {
+		return func() {}
+	}
+	return s.generatorStateLocks.lock(identityName(authInfo))
+}
+
+// checkGeneratorStateQuota applies the generator state quota of the authorization to the caller.
+// It returns false after responding with 429 Too Many Requests if the caller is over the quota.
+func (s *Handler) checkGeneratorStateQuota(c echo.Context, spec *fedv1alpha1.AuthorizationSpec, authInfo *auth.Info) (bool, error) {
+	if spec.Quotas == nil || spec.Quotas.MaxGeneratorStates == nil {
+		return true, nil
+	}
+	count, err := s.countGeneratorStatesFn(c.Request().Context(), authInfo)
+	if err != nil {
+		return false, c.JSON(http.StatusInternalServerError, err.Error())
+	}
+	if count < int(*spec.Quotas.MaxGeneratorStates) {
+		return true, nil
+	}
+	return false, s.tooManyRequests(c, fmetrics.LimitGeneratorStates, generatorStateQuotaRetryAfter)
+}
+
+func (s *Handler) tooManyRequests(c echo.Context, limit string, retryAfter time.Duration) error {
+	reason := fmt.Sprintf("%s limit exceeded", limit)
+	fmetrics.RateLimitedRequests.WithLabelValues(limit, c.Path()).Inc()
+	s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, nil, reason)
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Response().Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+	return c.JSON(http.StatusTooManyRequests, reason)
+}
+
+// countGeneratorStates counts the GeneratorStates bound to the AuthorizedIdentity of the caller
+// that are not being deleted. They are read through the API reader, as the cache can lag behind
+// the credentials issued by the previous request.
+func (s *Handler) countGeneratorStates(ctx context.Context, authInfo *auth.Info) (int, error) {
+	reader := s.uncachedReader()
+	if reader == nil {
+		return 0, nil
+	}
+	identity := &fedv1alpha1.AuthorizedIdentity{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: identityName(authInfo)}, identity); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get AuthorizedIdentity: %w", err)
+	}
+	count := 0
+	for _, credential := range identity.Spec.IssuedCredentials {
+		if credential.StateRef == nil || credential.StateRef.Namespace == nil {
+			continue
+		}
+		state := &genv1alpha1.GeneratorState{}
+		err := reader.Get(ctx, client.ObjectKey{Name: credential.StateRef.Name, Namespace: *credential.StateRef.Namespace}, state)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to get GeneratorState: %w", err)
+		}
+		if state.DeletionTimestamp == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// SetAPIReader sets the reader of the objects that have to be read without the cache of the client.
+func (s *Handler) SetAPIReader(reader client.Reader) {
+	s.apiReader = reader
+}
+
+// uncachedReader returns the API reader, falling back to the client of the reconciler when it is not set.
+func (s *Handler) uncachedReader() client.Reader {
+	if s.apiReader != nil {
+		return s.apiReader
+	}
+	if s.reconciler == nil || s.reconciler.Client == nil {
+		return nil
+	}
+	return s.reconciler.Client
+}
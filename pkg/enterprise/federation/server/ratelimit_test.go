@@ -0,0 +1,278 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	externalsecrets "github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/fmetrics"
+	store "github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+func rateLimitedSpec(subject string) *fedv1alpha1.AuthorizationSpec {
+	return &fedv1alpha1.AuthorizationSpec{
+		FederationRef: fedv1alpha1.FederationRef{Name: "test-federation", Kind: "Kubernetes"},
+		SubjectMatcher: &fedv1alpha1.SubjectMatcher{
+			Subject: &fedv1alpha1.StringMatcher{Glob: subject},
+		},
+		AllowedClusterSecretStores: []string{"test-store"},
+		AllowedGenerators: []fedv1alpha1.AllowedGenerator{
+			{Name: "test-generator", Kind: "Password", Namespace: "test-ns"},
+		},
+		RateLimits: &fedv1alpha1.RateLimits{
+			PerSubject:       &fedv1alpha1.TokenBucket{RequestsPerMinute: 60, Burst: 2},
+			PerAuthorization: &fedv1alpha1.TokenBucket{RequestsPerMinute: 60, Burst: 3},
+		},
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter()
+	limiter.now = func() time.Time { return now }
+	spec := rateLimitedSpec("*")
+
+	// The burst of the subject is spent first.
+	for range 2 {
+		ok, _, _ := limiter.allow(spec, "alice")
+		require.True(t, ok)
+	}
+	ok, limit, delay := limiter.allow(spec, "alice")
+	assert.False(t, ok)
+	assert.Equal(t, fmetrics.LimitPerSubject, limit)
+	assert.Equal(t, time.Second, delay)
+
+	// Rejected requests do not take a token from the authorization bucket.
+	ok, _, _ = limiter.allow(spec, "bob")
+	require.True(t, ok)
+	ok, limit, _ = limiter.allow(spec, "bob")
+	assert.False(t, ok)
+	assert.Equal(t, fmetrics.LimitPerAuthorization, limit)
+
+	// Buckets refill at the configured rate.
+	now = now.Add(time.Second)
+	ok, _, _ = limiter.allow(spec, "alice")
+	assert.True(t, ok)
+
+	// Reconciling the authorization again keeps its buckets.
+	ok, _, _ = limiter.allow(rateLimitedSpec("*"), "alice")
+	assert.False(t, ok)
+
+	// Idle buckets are removed.
+	now = now.Add(rateLimiterIdleTimeout + time.Minute)
+	limiter.sweep(now)
+	assert.Empty(t, limiter.buckets)
+}
+
+func TestPostSecretsRateLimited(t *testing.T) {
+	const testIssuer = "rate-limit-issuer"
+	authInfo := &auth.Info{Method: "oidc", Provider: testIssuer, Subject: "rate-limited"}
+	spec := rateLimitedSpec("rate-limited")
+	store.Add(testIssuer, spec)
+	t.Cleanup(func() {
+		store.Remove(testIssuer, spec)
+	})
+
+	s := NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true, nil)
+	s.getSecretFn = func(_ context.Context, _ string, _ esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		return []byte("value"), nil
+	}
+
+	var codes []int
+	var rec *httptest.ResponseRecorder
+	for range 3 {
+		e := echo.New()
+		rec = httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", http.NoBody), rec)
+		c.SetParamNames("secretStoreName", "secretName")
+		c.SetParamValues("test-store", "test-secret")
+		setAuthContext(c, authInfo)
+		require.NoError(t, s.postSecrets(c))
+		codes = append(codes, rec.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+}
+
+func TestGenerateSecretsGeneratorStateQuota(t *testing.T) {
+	const testIssuer = "quota-issuer"
+	authInfo := &auth.Info{Method: "oidc", Provider: testIssuer, Subject: "quota"}
+	spec := rateLimitedSpec("quota")
+	spec.RateLimits = nil
+	spec.Quotas = &fedv1alpha1.Quotas{MaxGeneratorStates: ptr.To[int32](2)}
+	store.Add(testIssuer, spec)
+	t.Cleanup(func() {
+		store.Remove(testIssuer, spec)
+	})
+
+	s := NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true, nil)
+	states := 0
+	s.countGeneratorStatesFn = func(_ context.Context, info *auth.Info) (int, error) {
+		assert.Equal(t, authInfo, info)
+		return states, nil
+	}
+	s.generateSecretFn = func(_ context.Context, _, _, _ string, _ *Resource) (map[string]string, string, string, error) {
+		states++
+		return map[string]string{"password": "value"}, "", "", nil
+	}
+
+	var codes []int
+	var rec *httptest.ResponseRecorder
+	for range 3 {
+		e := echo.New()
+		rec = httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", http.NoBody), rec)
+		c.SetParamNames("generatorNamespace", "generatorKind", "generatorName")
+		c.SetParamValues("test-ns", "Password", "test-generator")
+		setAuthContext(c, authInfo)
+		require.NoError(t, s.generateSecrets(c))
+		codes = append(codes, rec.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.Equal(t, 2, states)
+}
+
+func TestGenerateSecretsGeneratorStateQuotaConcurrent(t *testing.T) {
+	const testIssuer = "concurrent-quota-issuer"
+	authInfo := &auth.Info{Method: "oidc", Provider: testIssuer, Subject: "concurrent-quota"}
+	spec := rateLimitedSpec("concurrent-quota")
+	spec.RateLimits = nil
+	spec.Quotas = &fedv1alpha1.Quotas{MaxGeneratorStates: ptr.To[int32](2)}
+	store.Add(testIssuer, spec)
+	t.Cleanup(func() {
+		store.Remove(testIssuer, spec)
+	})
+
+	s := NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true, nil)
+	states := 0
+	s.countGeneratorStatesFn = func(_ context.Context, _ *auth.Info) (int, error) {
+		return states, nil
+	}
+	s.generateSecretFn = func(_ context.Context, _, _, _ string, _ *Resource) (map[string]string, string, string, error) {
+		states++
+		return map[string]string{"password": "value"}, "", "", nil
+	}
+
+	// Concurrent requests are counted one after another, so only the quota passes.
+	codes := make([]int, 10)
+	var wg sync.WaitGroup
+	for i := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", http.NoBody), rec)
+			c.SetParamNames("generatorNamespace", "generatorKind", "generatorName")
+			c.SetParamValues("test-ns", "Password", "test-generator")
+			setAuthContext(c, authInfo)
+			assert.NoError(t, s.generateSecrets(c))
+			codes[i] = rec.Code
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2, states)
+	ok := 0
+	for _, code := range codes {
+		if code == http.StatusOK {
+			ok++
+		}
+	}
+	assert.Equal(t, 2, ok)
+}
+
+func TestIdentityLocks(t *testing.T) {
+	locks := newIdentityLocks()
+	unlock := locks.lock("oidc-a")
+
+	// A second request of the identity waits for the first one.
+	acquired := make(chan func())
+	go func() {
+		acquired <- locks.lock("oidc-a")
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("lock of the identity taken twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	(<-acquired)()
+
+	// Locks are removed once released by every request.
+	locks.mu.Lock()
+	defer locks.mu.Unlock()
+	assert.Empty(t, locks.locks)
+}
+
+func TestCountGeneratorStatesReadsAPIReader(t *testing.T) {
+	authInfo := &auth.Info{Method: "oidc", Provider: "count-issuer", Subject: "count"}
+	scheme := runtime.NewScheme()
+	require.NoError(t, fedv1alpha1.AddToScheme(scheme))
+	require.NoError(t, genv1alpha1.AddToScheme(scheme))
+	stateRef := func(name string) *fedv1alpha1.StateRef {
+		return &fedv1alpha1.StateRef{Kind: "GeneratorState", Name: name, Namespace: ptr.To("test-ns")}
+	}
+	identity := &fedv1alpha1.AuthorizedIdentity{
+		ObjectMeta: metav1.ObjectMeta{Name: identityName(authInfo)},
+		Spec: fedv1alpha1.AuthorizedIdentitySpec{
+			IssuedCredentials: []fedv1alpha1.IssuedCredential{
+				{StateRef: stateRef("state-a")},
+				{StateRef: stateRef("state-b")},
+				{StateRef: stateRef("revoked")},
+			},
+		},
+	}
+	states := []client.Object{
+		&genv1alpha1.GeneratorState{ObjectMeta: metav1.ObjectMeta{Name: "state-a", Namespace: "test-ns"}},
+		&genv1alpha1.GeneratorState{ObjectMeta: metav1.ObjectMeta{Name: "state-b", Namespace: "test-ns"}},
+	}
+
+	// The client of the reconciler stands in for a cache that has not seen the credentials yet.
+	cached := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := NewHandler(&externalsecrets.Reconciler{Client: cached}, ":8080", ":8081", "unix:///spire.sock", true, nil)
+	count, err := s.countGeneratorStates(context.Background(), authInfo)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	s.SetAPIReader(fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(states, identity)...).Build())
+	count, err = s.countGeneratorStates(context.Background(), authInfo)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
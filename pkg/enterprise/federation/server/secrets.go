@@ -0,0 +1,149 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+	store "github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+)
+
+// postSecretMap reads a secret through a ClusterSecretStore as a map of its properties,
+// as dataFrom.extract does.
+func (s *Handler) postSecretMap(c echo.Context) error {
+	return s.readSecret(c, func(ctx context.Context, storeName string, ref esv1.ExternalSecretDataRemoteRef) (any, error) {
+		var secretMap map[string][]byte
+		err := s.useSecretsClientFn(ctx, storeName, func(client esv1.SecretsClient) error {
+			var err error
+			secretMap, err = client.GetSecretMap(ctx, ref)
+			return err
+		})
+		return secretMap, err
+	})
+}
+
+// findSecrets finds secrets of a ClusterSecretStore by path, name or tags, as dataFrom.find does.
+// Secrets the AllowedSecrets rules of the authorization do not allow are left out.
+func (s *Handler) findSecrets(c echo.Context) error {
+	authInfo := c.Get("authInfo").(*auth.Info)
+	workloadInfo, _ := c.Get("workloadInfo").(*auth.WorkloadInfo)
+
+	storeName := c.Param("secretStoreName")
+	storeRes := &audit.Resource{Kind: esv1.ClusterSecretStoreKind, Name: storeName}
+	var find esv1.ExternalSecretFind
+	if err := c.Bind(&find); err != nil {
+		s.audit(c, audit.EventSecretRead, audit.OutcomeFailure, storeRes, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	// Every authorization that allows the store contributes the secrets it allows.
+	caller := newCaller(authInfo, workloadInfo)
+	var specs []*fedv1alpha1.AuthorizationSpec
+	for _, spec := range store.Get(authInfo.Provider) {
+		matched, err := caller.matches(spec)
+		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, storeRes, err.Error())
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		if matched && caller.allowsClusterSecretStore(spec, storeName) {
+			specs = append(specs, spec)
+		}
+	}
+	if len(specs) == 0 {
+		s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, storeRes, "no authorization allows the ClusterSecretStore")
+		return c.JSON(http.StatusNotFound, "Not Found")
+	}
+	spec := specs[0]
+	if ok, err := s.rateLimit(c, spec, authInfo); !ok {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	var secrets map[string][]byte
+	err := s.useSecretsClientFn(ctx, storeName, func(client esv1.SecretsClient) error {
+		var err error
+		secrets, err = client.GetAllSecrets(ctx, find)
+		return err
+	})
+	if err != nil {
+		s.audit(c, audit.EventSecretRead, audit.OutcomeFailure, storeRes, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	for key := range secrets {
+		allowed := false
+		for _, spec := range specs {
+			allowed, err = caller.allowsSecret(spec, storeName, esv1.ExternalSecretDataRemoteRef{Key: key})
+			if err != nil {
+				s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, storeRes, err.Error())
+				return c.JSON(http.StatusBadRequest, err.Error())
+			}
+			if allowed {
+				break
+			}
+		}
+		if !allowed {
+			delete(secrets, key)
+		}
+	}
+
+	if err := s.upsertIdentity(ctx, authInfo, workloadInfo, &spec.FederationRef, storeName, "", "", "", nil); err != nil {
+		s.log.Error(err, "failed to upsert identity for secret store access")
+	}
+
+	s.audit(c, audit.EventSecretRead, audit.OutcomeSuccess, storeRes, "")
+	return c.JSON(http.StatusOK, secrets)
+}
+
+// pingSecretStore checks that the caller is authenticated and allowed to read from or
+// push to the ClusterSecretStore. It is used to validate stores of the federation provider.
+func (s *Handler) pingSecretStore(c echo.Context) error {
+	authInfo := c.Get("authInfo").(*auth.Info)
+	workloadInfo, _ := c.Get("workloadInfo").(*auth.WorkloadInfo)
+
+	storeName := c.Param("secretStoreName")
+	caller := newCaller(authInfo, workloadInfo)
+	for _, spec := range store.Get(authInfo.Provider) {
+		matched, err := caller.matches(spec)
+		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, nil, err.Error())
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		if !matched {
+			continue
+		}
+		if caller.allowsClusterSecretStore(spec, storeName) {
+			return c.JSON(http.StatusOK, "pong")
+		}
+		for _, target := range spec.AllowedPushTargets {
+			if caller.render(target.ClusterSecretStore) == storeName {
+				return c.JSON(http.StatusOK, "pong")
+			}
+		}
+	}
+	s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, &audit.Resource{Kind: esv1.ClusterSecretStoreKind, Name: storeName}, "no authorization allows the ClusterSecretStore")
+	return c.JSON(http.StatusNotFound, "Not Found")
+}
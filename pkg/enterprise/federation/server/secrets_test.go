@@ -0,0 +1,145 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package server implements the federation server.
+// Copyright External Secrets Inc.
+// All Rights Reserved.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
+	store "github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
+	"github.com/external-secrets/external-secrets/runtime/testing/fake"
+)
+
+func TestReadSecretHandlers(t *testing.T) {
+	const testIssuer = "read-issuer"
+	authInfo := &auth.Info{Method: "oidc", Provider: testIssuer, Subject: "reader"}
+	spec := &fedv1alpha1.AuthorizationSpec{
+		FederationRef:              fedv1alpha1.FederationRef{Name: "test-federation", Kind: "Kubernetes"},
+		SubjectMatcher:             &fedv1alpha1.SubjectMatcher{Subject: &fedv1alpha1.StringMatcher{Glob: "reader"}},
+		AllowedClusterSecretStores: []string{"hub"},
+		AllowedSecrets: []fedv1alpha1.AllowedSecret{
+			{Key: fedv1alpha1.StringMatcher{Glob: "app/*"}},
+		},
+		AllowedPushTargets: []fedv1alpha1.AllowedPushTarget{{ClusterSecretStore: "push-only"}},
+	}
+	store.Add(testIssuer, spec)
+	t.Cleanup(func() {
+		store.Remove(testIssuer, spec)
+	})
+
+	provider := fake.New().
+		WithGetSecretMap(map[string][]byte{"username": []byte("admin")}, nil).
+		WithGetAllSecrets(map[string][]byte{"app/db": []byte("a"), "other/db": []byte("b")}, nil)
+	var find esv1.ExternalSecretFind
+	getAllSecrets := provider.GetAllSecretsFn
+	provider.GetAllSecretsFn = func(ctx context.Context, ref esv1.ExternalSecretFind) (map[string][]byte, error) {
+		find = ref
+		// findSecrets drops the keys that are not allowed from the map it is given.
+		secrets, err := getAllSecrets(ctx, ref)
+		return maps.Clone(secrets), err
+	}
+	s := NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true, nil)
+	s.useSecretsClientFn = func(_ context.Context, _ string, fn func(esv1.SecretsClient) error) error {
+		return fn(provider)
+	}
+	newContext := func(method, body string, names, values []string) (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames(names...)
+		c.SetParamValues(values...)
+		setAuthContext(c, authInfo)
+		return c, rec
+	}
+
+	t.Run("secret map", func(t *testing.T) {
+		c, rec := newContext(http.MethodPost, "", []string{"secretStoreName", "secretName"}, []string{"hub", "app%2Fdb"})
+		require.NoError(t, s.postSecretMap(c))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var secretMap map[string][]byte
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &secretMap))
+		assert.Equal(t, map[string][]byte{"username": []byte("admin")}, secretMap)
+
+		c, rec = newContext(http.MethodPost, "", []string{"secretStoreName", "secretName"}, []string{"hub", "other%2Fdb"})
+		require.NoError(t, s.postSecretMap(c))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("find filters secrets not allowed", func(t *testing.T) {
+		c, rec := newContext(http.MethodPost, `{"name":{"regexp":".*/db"},"tags":{"team":"a"}}`, []string{"secretStoreName"}, []string{"hub"})
+		require.NoError(t, s.findSecrets(c))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var secrets map[string][]byte
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &secrets))
+		assert.Equal(t, map[string][]byte{"app/db": []byte("a")}, secrets)
+		require.NotNil(t, find.Name)
+		assert.Equal(t, ".*/db", find.Name.RegExp)
+		assert.Equal(t, map[string]string{"team": "a"}, find.Tags)
+
+		c, rec = newContext(http.MethodPost, `{}`, []string{"secretStoreName"}, []string{"other"})
+		require.NoError(t, s.findSecrets(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("find keeps secrets allowed by any authorization", func(t *testing.T) {
+		other := &fedv1alpha1.AuthorizationSpec{
+			FederationRef:              fedv1alpha1.FederationRef{Name: "test-federation", Kind: "Kubernetes"},
+			SubjectMatcher:             &fedv1alpha1.SubjectMatcher{Subject: &fedv1alpha1.StringMatcher{Glob: "reader"}},
+			AllowedClusterSecretStores: []string{"hub"},
+			AllowedSecrets: []fedv1alpha1.AllowedSecret{
+				{Key: fedv1alpha1.StringMatcher{Glob: "other/*"}},
+			},
+		}
+		store.Add(testIssuer, other)
+		t.Cleanup(func() {
+			store.Remove(testIssuer, other)
+			store.Add(testIssuer, spec)
+		})
+
+		c, rec := newContext(http.MethodPost, `{}`, []string{"secretStoreName"}, []string{"hub"})
+		require.NoError(t, s.findSecrets(c))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var secrets map[string][]byte
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &secrets))
+		assert.Equal(t, map[string][]byte{"app/db": []byte("a"), "other/db": []byte("b")}, secrets)
+	})
+
+	t.Run("ping", func(t *testing.T) {
+		for store, want := range map[string]int{"hub": http.StatusOK, "push-only": http.StatusOK, "other": http.StatusNotFound} {
+			c, rec := newContext(http.MethodGet, "", []string{"secretStoreName"}, []string{store})
+			require.NoError(t, s.pingSecretStore(c))
+			assert.Equal(t, want, rec.Code, store)
+		}
+	})
+}
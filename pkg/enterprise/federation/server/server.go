@@ -26,7 +26,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"slices"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -36,11 +36,13 @@ import (
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	externalsecrets "github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
 	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
 	store "github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
 	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
 	"github.com/go-logr/logr"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
 	v1 "k8s.io/api/core/v1"
@@ -63,12 +65,22 @@ type Handler struct {
 	tlsEnabled             bool
 	spireAgentSocketPath   string
 	generateSecretFn       func(ctx context.Context, generatorName string, generatorKind string, namespace string, resource *Resource) (map[string]string, string, string, error)
-	getSecretFn            func(ctx context.Context, storeName string, name string) ([]byte, error)
+	getSecretFn            func(ctx context.Context, storeName string, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error)
+	useSecretsClientFn     func(ctx context.Context, storeName string, fn func(esv1.SecretsClient) error) error
 	deleteGeneratorStateFn func(ctx context.Context, namespace string, labels labels.Selector) error
+	countGeneratorStatesFn func(ctx context.Context, authInfo *auth.Info) (int, error)
+	generatorStateLocks    *identityLocks
+	apiReader              client.Reader
+	auditor                *audit.Logger
+	rateLimiter            *rateLimiter
+	workloadTokenVerifier  *auth.WorkloadTokenVerifier
+	authSelector           auth.Selector
+	ipExtractor            echo.IPExtractor
 }
 
 // NewHandler creates a new Handler.
-func NewHandler(reconciler *externalsecrets.Reconciler, port, tlsPort, socketPath string, tlsEnabled bool) *Handler {
+// Workload tokens are only accepted when they are issued for one of the given audiences.
+func NewHandler(reconciler *externalsecrets.Reconciler, port, tlsPort, socketPath string, tlsEnabled bool, workloadTokenAudiences []string) *Handler {
 	log := ctrl.Log.WithName("federationserver")
 	s := &Handler{
 		log:        log,
@@ -81,27 +93,50 @@ func NewHandler(reconciler *externalsecrets.Reconciler, port, tlsPort, socketPat
 	s.spireAgentSocketPath = socketPath
 	s.generateSecretFn = s.generateSecret
 	s.getSecretFn = s.getSecret
+	s.useSecretsClientFn = s.useSecretsClient
 	s.deleteGeneratorStateFn = s.deleteGeneratorState
+	s.countGeneratorStatesFn = s.countGeneratorStates
+	s.auditor = audit.NewLogger(log, audit.NewLogSink(log.WithName("audit")))
+	s.rateLimiter = newRateLimiter()
+	s.generatorStateLocks = newIdentityLocks()
+	s.workloadTokenVerifier = auth.NewWorkloadTokenVerifier(workloadTokenAudiences)
+	s.ipExtractor = echo.ExtractIPDirect()
 	return s
 }
 
 // SetupEcho sets up the echo server.
 func (s *Handler) SetupEcho(ctx context.Context) *echo.Echo {
 	e := echo.New()
+	e.IPExtractor = s.ipExtractor
 	e.Server.BaseContext = func(_ net.Listener) context.Context {
 		return ctx
 	}
+	e.Pre(s.selectAuthenticatorByPath)
+	e.Use(middleware.RequestID())
 	e.Use(s.authMiddleware)
 
 	e.POST("/secretstore/:secretStoreName/secrets/:secretName", s.postSecrets)
+	e.PUT("/secretstore/:secretStoreName/secrets/:secretName", s.pushSecret)
+	e.DELETE("/secretstore/:secretStoreName/secrets/:secretName", s.deleteSecret)
+	e.GET("/secretstore/:secretStoreName/secrets/:secretName/exists", s.secretExists)
+	e.POST("/secretstore/:secretStoreName/secrets/:secretName/map", s.postSecretMap)
+	e.POST("/secretstore/:secretStoreName/find", s.findSecrets)
+	e.GET("/secretstore/:secretStoreName/ping", s.pingSecretStore)
 	e.POST("/generators/:generatorNamespace/:generatorKind/:generatorName", s.generateSecrets)
 	e.DELETE("/generators/:generatorNamespace/:generatorKind/:generatorName", s.revokeSelf)
 	e.POST("/generators/:generatorNamespace/revoke", s.revokeCredentialsOf)
+	e.PUT("/leases/:leaseId/renew", s.renewLease)
 
 	s.startHTTPServer(ctx, e)
 	if s.tlsEnabled {
 		s.startMTLSServer(ctx, e)
 	}
+	go func() {
+		<-ctx.Done()
+		if err := s.auditor.Close(); err != nil {
+			s.log.Error(err, "failed to close audit log")
+		}
+	}()
 
 	return e
 }
@@ -150,41 +185,15 @@ func (s *Handler) startMTLSServer(ctx context.Context, e *echo.Echo) {
 	}()
 }
 
-func (s *Handler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		var lastErr error
-		for _, authenticator := range auth.Registry {
-			info, err := authenticator.Authenticate(c.Request())
-			if err != nil {
-				lastErr = err
-				continue
-			}
-			c.Set("authInfo", info)
-
-			// Parse optional x-workload-token header
-			workloadInfo, err := auth.ParseWorkloadToken(c.Request())
-			if err != nil {
-				// If token is malformed, return error
-				return c.JSON(http.StatusBadRequest, fmt.Sprintf("invalid x-workload-token: %s", err.Error()))
-			}
-
-			// Merge with existing KubeAttributes if present
-			if workloadInfo == nil && info.KubeAttributes != nil {
-				// Use KubeAttributes from authInfo (e.g., from SPIFFE)
-				workloadInfo = &auth.WorkloadInfo{
-					Namespace:      info.KubeAttributes.Namespace,
-					ServiceAccount: info.KubeAttributes.ServiceAccount,
-					Pod:            info.KubeAttributes.Pod,
-				}
-			}
-
-			// Set workloadInfo in context (may be nil)
-			c.Set("workloadInfo", workloadInfo)
-
-			return next(c)
+// verifyWorkloadToken verifies the x-workload-token header of the authenticated caller, if any.
+func (s *Handler) verifyWorkloadToken(r *http.Request, info *auth.Info) (*auth.WorkloadInfo, error) {
+	if s.workloadTokenVerifier == nil {
+		if r.Header.Get(auth.WorkloadTokenHeader) != "" {
+			return nil, errors.New("workload tokens are not accepted")
 		}
-		return c.JSON(http.StatusUnauthorized, lastErr.Error())
+		return nil, nil
 	}
+	return s.workloadTokenVerifier.Verify(r, info)
 }
 
 func (s *Handler) generateSecrets(c echo.Context) error {
@@ -200,55 +209,33 @@ func (s *Handler) generateSecrets(c echo.Context) error {
 		Kind:      generatorKind,
 		Namespace: generatorNamespace,
 	}
+	generator := generatorResource(generatorName, generatorKind, generatorNamespace)
 
-	// Build resource based on workload context
-	var resource *Resource
-	if workloadInfo != nil {
-		// Has workload context (from x-workload-token or KubeAttributes)
-		if workloadInfo.ServiceAccount == nil {
-			return c.JSON(http.StatusBadRequest, "missing kubernetes service account")
-		}
-
-		owner := workloadInfo.ServiceAccount.Name
-		if workloadInfo.Pod != nil {
-			owner = workloadInfo.Pod.Name
-		}
-
-		resource = &Resource{
-			Name:       generatorName,
-			AuthMethod: "KubernetesServiceAccount",
-			Owner:      owner,
-			OwnerAttributes: map[string]string{
-				"namespace":            workloadInfo.Namespace,
-				"issuer":               authInfo.Provider,
-				"serviceaccount-uid":   workloadInfo.ServiceAccount.UID,
-				"service-account-name": workloadInfo.ServiceAccount.Name,
-			},
-		}
-		if workloadInfo.Pod != nil {
-			resource.OwnerAttributes["pod-uid"] = workloadInfo.Pod.UID
-		}
-	} else {
-		// OAuth2-based authentication (Okta, OIDC, etc. - no workload context)
-		resource = &Resource{
-			Name:       generatorName,
-			AuthMethod: authInfo.Method, // "okta", "oidc", etc.
-			Owner:      authInfo.Subject,
-			OwnerAttributes: map[string]string{
-				"issuer":  authInfo.Provider,
-				"subject": authInfo.Subject,
-				"method":  authInfo.Method,
-			},
-		}
+	resource, err := newResource(generatorName, authInfo, workloadInfo)
+	if err != nil {
+		s.audit(c, audit.EventGeneratorIssue, audit.OutcomeFailure, generator, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
 	}
+	caller := newCaller(authInfo, workloadInfo)
 	for _, spec := range AuthorizationSpecs {
-		principal, err := spec.Principal()
+		matched, err := caller.matches(spec)
 		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, generator, err.Error())
 			return c.JSON(http.StatusBadRequest, err.Error())
 		}
-		if contains(spec.AllowedGenerators, d) && principal == authInfo.Subject {
+		if matched && caller.allowsGenerator(spec, d) {
+			if ok, err := s.rateLimit(c, spec, authInfo); !ok {
+				return err
+			}
+			unlock := s.lockGeneratorStates(spec, authInfo)
+			defer unlock()
+			if ok, err := s.checkGeneratorStateQuota(c, spec, authInfo); !ok {
+				return err
+			}
+			resource.Lease = newLease(spec.Lease)
 			secret, stateName, stateNamespace, err := s.generateSecretFn(c.Request().Context(), generatorName, generatorKind, generatorNamespace, resource)
 			if err != nil {
+				s.audit(c, audit.EventGeneratorIssue, audit.OutcomeFailure, generator, err.Error())
 				return c.JSON(http.StatusBadRequest, err.Error())
 			}
 
@@ -258,61 +245,89 @@ func (s *Handler) generateSecrets(c echo.Context) error {
 				s.log.Error(err, "failed to upsert identity for generator access")
 			}
 
+			resource.Lease.setHeaders(c)
+			s.audit(c, audit.EventGeneratorIssue, audit.OutcomeSuccess, generator, "")
 			return c.JSON(http.StatusOK, secret)
 		}
 	}
+	s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, generator, "no authorization allows the generator")
 	return c.JSON(http.StatusNotFound, "Not Found")
 }
 
-func contains[T fedv1alpha1.AllowedGenerator | fedv1alpha1.AllowedGeneratorState](slice []T, item T) bool {
-	switch any(item).(type) {
-	case fedv1alpha1.AllowedGenerator:
-		for _, v := range slice {
-			sliceGen := any(v).(fedv1alpha1.AllowedGenerator)
-			itemGen := any(item).(fedv1alpha1.AllowedGenerator)
-
-			if sliceGen.Name == itemGen.Name && sliceGen.Kind == itemGen.Kind && sliceGen.Namespace == itemGen.Namespace {
-				return true
-			}
-		}
-	case fedv1alpha1.AllowedGeneratorState:
-		for _, v := range slice {
-			sliceState := any(v).(fedv1alpha1.AllowedGeneratorState)
-			itemState := any(item).(fedv1alpha1.AllowedGeneratorState)
-			if sliceState.Namespace == itemState.Namespace {
-				return true
-			}
+// postSecrets reads a secret through a ClusterSecretStore. The key is the path escaped
+// secretName parameter, and the property and version are read from the query.
+func (s *Handler) postSecrets(c echo.Context) error {
+	return s.readSecret(c, func(ctx context.Context, storeName string, ref esv1.ExternalSecretDataRemoteRef) (any, error) {
+		secret, err := s.getSecretFn(ctx, storeName, ref)
+		if err != nil {
+			return nil, err
 		}
-	}
-	return false
+		return string(secret), nil
+	})
 }
 
-func (s *Handler) postSecrets(c echo.Context) error {
+// readSecret authorizes reading the secret of the request through a ClusterSecretStore,
+// and responds with what read returns.
+func (s *Handler) readSecret(c echo.Context, read func(ctx context.Context, storeName string, ref esv1.ExternalSecretDataRemoteRef) (any, error)) error {
 	authInfo := c.Get("authInfo").(*auth.Info)
 	workloadInfo, _ := c.Get("workloadInfo").(*auth.WorkloadInfo)
 
 	AuthorizationSpecs := store.Get(authInfo.Provider)
 	storeName := c.Param("secretStoreName")
-	name := c.Param("secretName")
+	name, err := url.PathUnescape(c.Param("secretName"))
+	if err != nil {
+		s.audit(c, audit.EventSecretRead, audit.OutcomeFailure, nil, err.Error())
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	ref := esv1.ExternalSecretDataRemoteRef{
+		Key:      name,
+		Property: c.QueryParam("property"),
+		Version:  c.QueryParam("version"),
+	}
+	secretRes := secretResource(storeName, ref)
+	caller := newCaller(authInfo, workloadInfo)
+	denied := false
 	for _, spec := range AuthorizationSpecs {
-		principal, err := spec.Principal()
+		matched, err := caller.matches(spec)
 		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, secretRes, err.Error())
 			return c.JSON(http.StatusBadRequest, err.Error())
 		}
-		if slices.Contains(spec.AllowedClusterSecretStores, storeName) && principal == authInfo.Subject {
-			secret, err := s.getSecretFn(c.Request().Context(), storeName, name)
-			if err != nil {
-				return c.JSON(http.StatusBadRequest, err.Error())
-			}
+		if !matched || !caller.allowsClusterSecretStore(spec, storeName) {
+			continue
+		}
+		allowed, err := caller.allowsSecret(spec, storeName, ref)
+		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, secretRes, err.Error())
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		if !allowed {
+			denied = true
+			continue
+		}
+		if ok, err := s.rateLimit(c, spec, authInfo); !ok {
+			return err
+		}
 
-			// Create or update AuthorizedIdentity
-			if err := s.upsertIdentity(c.Request().Context(), authInfo, workloadInfo, &spec.FederationRef, storeName, name, "", "", nil); err != nil {
-				s.log.Error(err, "failed to upsert identity for secret store access")
-			}
+		secret, err := read(c.Request().Context(), storeName, ref)
+		if err != nil {
+			s.audit(c, audit.EventSecretRead, audit.OutcomeFailure, secretRes, err.Error())
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
 
-			return c.JSON(http.StatusOK, string(secret))
+		// Create or update AuthorizedIdentity
+		if err := s.upsertIdentity(c.Request().Context(), authInfo, workloadInfo, &spec.FederationRef, storeName, name, "", "", nil); err != nil {
+			s.log.Error(err, "failed to upsert identity for secret store access")
 		}
+
+		s.audit(c, audit.EventSecretRead, audit.OutcomeSuccess, secretRes, "")
+		return c.JSON(http.StatusOK, secret)
 	}
+	if denied {
+		s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, secretRes, "secret is not allowed by allowedSecrets")
+		return c.JSON(http.StatusForbidden, "Forbidden")
+	}
+	s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, secretRes, "no authorization allows the ClusterSecretStore")
 	return c.JSON(http.StatusNotFound, "Not Found")
 }
 
@@ -324,25 +339,30 @@ func (s *Handler) revokeSelf(c echo.Context) error {
 	generatorNamespace := c.Param("generatorNamespace")
 	generatorName := c.Param("generatorName")
 	generatorKind := c.Param("generatorKind")
+	generator := generatorResource(generatorName, generatorKind, generatorNamespace)
 
 	if workloadInfo == nil {
+		s.audit(c, audit.EventRevocation, audit.OutcomeFailure, generator, "missing workload context")
 		return c.JSON(http.StatusBadRequest, "missing workload context")
 	}
 
 	if workloadInfo.ServiceAccount == nil {
+		s.audit(c, audit.EventRevocation, audit.OutcomeFailure, generator, "missing kubernetes service account")
 		return c.JSON(http.StatusBadRequest, "missing kubernetes service account")
 	}
 
+	caller := newCaller(authInfo, workloadInfo)
 	for _, spec := range AuthorizationSpecs {
-		principal, err := spec.Principal()
+		matched, err := caller.matches(spec)
 		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, generator, err.Error())
 			return c.JSON(http.StatusBadRequest, err.Error())
 		}
-		if !contains(spec.AllowedGenerators, fedv1alpha1.AllowedGenerator{
+		if !matched || !caller.allowsGenerator(spec, fedv1alpha1.AllowedGenerator{
 			Name:      generatorName,
 			Kind:      generatorKind,
 			Namespace: generatorNamespace,
-		}) || principal != authInfo.Subject {
+		}) {
 			continue
 		}
 		owner := workloadInfo.ServiceAccount.Name
@@ -356,10 +376,13 @@ func (s *Handler) revokeSelf(c echo.Context) error {
 		})
 		err = s.deleteGeneratorStateFn(c.Request().Context(), generatorNamespace, labels)
 		if err != nil {
+			s.audit(c, audit.EventRevocation, audit.OutcomeFailure, generator, err.Error())
 			return c.JSON(http.StatusBadRequest, err.Error())
 		}
+		s.audit(c, audit.EventRevocation, audit.OutcomeSuccess, generator, "")
 		return c.JSON(http.StatusOK, nil)
 	}
+	s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, generator, "no authorization allows the generator")
 	return c.JSON(http.StatusNotFound, "Not Found")
 }
 
@@ -372,32 +395,38 @@ func (s *Handler) revokeCredentialsOf(c echo.Context) error {
 	var req deleteRequest
 	err := c.Bind(&req)
 	if err != nil {
+		s.audit(c, audit.EventRevocation, audit.OutcomeFailure, nil, err.Error())
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
 	authInfo := c.Get("authInfo").(*auth.Info)
+	workloadInfo, _ := c.Get("workloadInfo").(*auth.WorkloadInfo)
 
 	AuthorizationSpecs := store.Get(authInfo.Provider)
 	generatorNamespace := c.Param("generatorNamespace")
+	states := &audit.Resource{Kind: "GeneratorState", Name: req.Owner, Namespace: req.Namespace}
+	caller := newCaller(authInfo, workloadInfo)
 	for _, spec := range AuthorizationSpecs {
-		principal, err := spec.Principal()
+		matched, err := caller.matches(spec)
 		if err != nil {
+			s.audit(c, audit.EventAuthorization, audit.OutcomeFailure, states, err.Error())
 			return c.JSON(http.StatusBadRequest, err.Error())
 		}
 
-		if contains(spec.AllowedGeneratorStates, fedv1alpha1.AllowedGeneratorState{
-			Namespace: generatorNamespace,
-		}) && principal == authInfo.Subject {
+		if matched && caller.allowsGeneratorState(spec, generatorNamespace) {
 			labels := labels.SelectorFromSet(labels.Set{
 				"federation.externalsecrets.com/owner": req.Owner,
 			})
 			err = s.deleteGeneratorStateFn(c.Request().Context(), req.Namespace, labels)
 			if err != nil {
+				s.audit(c, audit.EventRevocation, audit.OutcomeFailure, states, err.Error())
 				return c.JSON(http.StatusBadRequest, err.Error())
 			}
+			s.audit(c, audit.EventRevocation, audit.OutcomeSuccess, states, "")
 			return c.JSON(http.StatusOK, "GeneratorState deleted")
 		}
 	}
+	s.audit(c, audit.EventAuthorization, audit.OutcomeDenied, states, "no authorization allows the GeneratorState namespace")
 	return c.JSON(http.StatusNotFound, "Not Found")
 }
 
@@ -419,7 +448,7 @@ func (s *Handler) deleteGeneratorState(ctx context.Context, namespace string, la
 	return nil
 }
 
-func (s *Handler) getSecret(ctx context.Context, storeName, name string) ([]byte, error) {
+func (s *Handler) getSecret(ctx context.Context, storeName string, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	storeRef := esv1.SecretStoreRef{
 		Name: storeName,
 		Kind: esv1.ClusterSecretStoreKind,
@@ -429,9 +458,6 @@ func (s *Handler) getSecret(ctx context.Context, storeName, name string) ([]byte
 	if err != nil {
 		return nil, err
 	}
-	ref := esv1.ExternalSecretDataRemoteRef{
-		Key: name,
-	}
 	return client.GetSecret(ctx, ref)
 }
 
@@ -455,6 +481,10 @@ func (s *Handler) generateSecret(ctx context.Context, generatorName, generatorKi
 	if err != nil {
 		return nil, "", "", err
 	}
+	cleanupPolicy, err := generator.GetCleanupPolicy(obj)
+	if err != nil {
+		return nil, "", "", err
+	}
 	attributes, err := json.Marshal(resource.OwnerAttributes)
 	if err != nil {
 		return nil, "", "", err
@@ -480,6 +510,9 @@ func (s *Handler) generateSecret(ctx context.Context, generatorName, generatorKi
 			State:    stateJSON,
 		},
 	}
+	if resource.Lease != nil {
+		resource.Lease.apply(&generatorState, cleanupPolicy)
+	}
 	// We can bind the Generator State to a GC-linked object
 	if resource.AuthMethod == "KubernetesServiceAccount" {
 		var cobj client.Object
@@ -521,6 +554,49 @@ type Resource struct {
 	Owner           string            `json:"owner"`
 	OwnerAttributes map[string]string `json:"ownerAttributes"`
 	AuthMethod      string            `json:"authMethod"`
+	Lease           *Lease            `json:"lease,omitempty"`
+}
+
+// newResource builds the resource generated for the caller, owned by its workload if any.
+func newResource(name string, authInfo *auth.Info, workloadInfo *auth.WorkloadInfo) (*Resource, error) {
+	if workloadInfo == nil {
+		// OAuth2-based authentication (Okta, OIDC, etc. - no workload context)
+		return &Resource{
+			Name:       name,
+			AuthMethod: authInfo.Method, // "okta", "oidc", etc.
+			Owner:      authInfo.Subject,
+			OwnerAttributes: map[string]string{
+				"issuer":  authInfo.Provider,
+				"subject": authInfo.Subject,
+				"method":  authInfo.Method,
+			},
+		}, nil
+	}
+	// Has workload context (from x-workload-token or KubeAttributes)
+	if workloadInfo.ServiceAccount == nil {
+		return nil, errors.New("missing kubernetes service account")
+	}
+
+	owner := workloadInfo.ServiceAccount.Name
+	if workloadInfo.Pod != nil {
+		owner = workloadInfo.Pod.Name
+	}
+
+	resource := &Resource{
+		Name:       name,
+		AuthMethod: "KubernetesServiceAccount",
+		Owner:      owner,
+		OwnerAttributes: map[string]string{
+			"namespace":            workloadInfo.Namespace,
+			"issuer":               authInfo.Provider,
+			"serviceaccount-uid":   workloadInfo.ServiceAccount.UID,
+			"service-account-name": workloadInfo.ServiceAccount.Name,
+		},
+	}
+	if workloadInfo.Pod != nil {
+		resource.OwnerAttributes["pod-uid"] = workloadInfo.Pod.UID
+	}
+	return resource, nil
 }
 
 // buildIdentitySpec constructs an IdentitySpec from authInfo and federationRef.
@@ -664,12 +740,9 @@ func (s *Handler) upsertIdentity(
 		LastIssuedAt:    metav1.Now(),
 	}
 
-	// Generate a deterministic name for the AuthorizedIdentity
-	identityName := fmt.Sprintf("%s-%s", authInfo.Method, sanitizeName(authInfo.Subject))
-
 	// Try to get existing AuthorizedIdentity
 	identity := &fedv1alpha1.AuthorizedIdentity{}
-	err := s.reconciler.Client.Get(ctx, client.ObjectKey{Name: identityName}, identity)
+	err := s.reconciler.Client.Get(ctx, client.ObjectKey{Name: identityName(authInfo)}, identity)
 
 	if err != nil {
 		// If error is not NotFound, return early (e.g., connection errors)
@@ -680,7 +753,7 @@ func (s *Handler) upsertIdentity(
 		// Create new AuthorizedIdentity
 		identity = &fedv1alpha1.AuthorizedIdentity{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: identityName,
+				Name: identityName(authInfo),
 			},
 			Spec: fedv1alpha1.AuthorizedIdentitySpec{
 				IdentitySpec:      identitySpec,
@@ -710,6 +783,11 @@ func (s *Handler) upsertIdentity(
 	return s.reconciler.Client.Update(ctx, identity)
 }
 
+// identityName returns the deterministic name of the AuthorizedIdentity of the caller.
+func identityName(authInfo *auth.Info) string {
+	return fmt.Sprintf("%s-%s", authInfo.Method, sanitizeName(authInfo.Subject))
+}
+
 // credentialsMatch checks if two credentials reference the same source.
 func credentialsMatch(a, b fedv1alpha1.IssuedCredential) bool {
 	// Compare SourceRef
@@ -28,6 +28,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/suite"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -38,7 +40,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	externalsecrets "github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/audit"
 	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
 	store "github.com/external-secrets/external-secrets/pkg/enterprise/federation/store"
 )
@@ -66,7 +70,7 @@ type GenerateSecretsTestSuite struct {
 
 func (s *GenerateSecretsTestSuite) SetupTest() {
 	// Initialize the server handler
-	s.server = NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true)
+	s.server = NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true, nil)
 
 	// Initialize specs slice for cleanup
 	s.specs = []*fedv1alpha1.AuthorizationSpec{}
@@ -929,7 +933,7 @@ type PostSecretsTestSuite struct {
 
 func (s *PostSecretsTestSuite) SetupTest() {
 	// Initialize the server handler
-	s.server = NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true)
+	s.server = NewHandler(nil, ":8080", ":8081", "unix:///spire.sock", true, nil)
 
 	// Initialize specs slice for cleanup
 	s.specs = []*fedv1alpha1.AuthorizationSpec{}
@@ -956,7 +960,7 @@ func (s *PostSecretsTestSuite) TestPostSecrets() {
 	tests := []struct {
 		name           string
 		setup          func() echo.Context
-		mockGetSecret  func(ctx context.Context, storeName string, name string) ([]byte, error)
+		mockGetSecret  func(ctx context.Context, storeName string, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error)
 		expectedStatus int
 		expectedBody   string
 	}{
@@ -998,10 +1002,10 @@ func (s *PostSecretsTestSuite) TestPostSecrets() {
 
 				return c
 			},
-			mockGetSecret: func(ctx context.Context, storeName string, name string) ([]byte, error) {
+			mockGetSecret: func(ctx context.Context, storeName string, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
 				// Check that the parameters match what we expect
-				if storeName != "test-store" || name != "test-secret" {
-					return nil, fmt.Errorf("unexpected parameters: %s, %s", storeName, name)
+				if storeName != "test-store" || ref.Key != "test-secret" {
+					return nil, fmt.Errorf("unexpected parameters: %s, %s", storeName, ref.Key)
 				}
 				// Return a mock secret
 				return []byte(`myvalue-is-here`), nil
@@ -1047,7 +1051,7 @@ func (s *PostSecretsTestSuite) TestPostSecrets() {
 
 				return c
 			},
-			mockGetSecret: func(ctx context.Context, storeName string, name string) ([]byte, error) {
+			mockGetSecret: func(ctx context.Context, storeName string, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
 				// This should not be called
 				s.T().Fatalf("mockGetSecret should not be called in this test case")
 				return nil, nil
@@ -1093,7 +1097,7 @@ func (s *PostSecretsTestSuite) TestPostSecrets() {
 
 				return c
 			},
-			mockGetSecret: func(ctx context.Context, storeName string, name string) ([]byte, error) {
+			mockGetSecret: func(ctx context.Context, storeName string, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
 				return nil, fmt.Errorf("error getting secret")
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -1129,27 +1133,158 @@ func (s *PostSecretsTestSuite) TestPostSecrets() {
 	}
 }
 
+func (s *PostSecretsTestSuite) TestPostSecretsAllowedSecrets() {
+	const (
+		testIssuer  = "test-issuer"
+		testSubject = "test-subject"
+	)
+
+	authInfo := &auth.Info{
+		Method:   "oidc",
+		Provider: testIssuer,
+		Subject:  testSubject,
+	}
+	spec := &fedv1alpha1.AuthorizationSpec{
+		FederationRef: fedv1alpha1.FederationRef{
+			Name: "test-federation",
+			Kind: "Kubernetes",
+		},
+		Subject: &fedv1alpha1.FederationSubject{
+			OIDC: &fedv1alpha1.FederationOIDC{
+				Subject: testSubject,
+				Issuer:  testIssuer,
+			},
+		},
+		AllowedClusterSecretStores: []string{"shared-store"},
+		AllowedSecrets: []fedv1alpha1.AllowedSecret{
+			{
+				ClusterSecretStore: "shared-store",
+				Key:                fedv1alpha1.StringMatcher{Glob: "db/app1"},
+				Properties:         []string{"password"},
+				Versions:           []string{"latest", "v2"},
+			},
+		},
+	}
+	store.Add(testIssuer, spec)
+	s.specs = append(s.specs, spec)
+
+	sink := &recordingSink{}
+	s.server.SetAuditLogger(audit.NewLogger(logr.Discard(), sink))
+	var refs []esv1.ExternalSecretDataRemoteRef
+	s.server.getSecretFn = func(_ context.Context, _ string, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		refs = append(refs, ref)
+		return []byte("s3cr3t"), nil
+	}
+
+	tests := []struct {
+		name           string
+		secretName     string
+		query          string
+		expectedStatus int
+		expectedRef    esv1.ExternalSecretDataRemoteRef
+	}{
+		{
+			name:           "allowed property",
+			secretName:     "db%2Fapp1",
+			query:          "?property=password",
+			expectedStatus: http.StatusOK,
+			expectedRef:    esv1.ExternalSecretDataRemoteRef{Key: "db/app1", Property: "password"},
+		},
+		{
+			name:           "allowed version",
+			secretName:     "db%2Fapp1",
+			query:          "?property=password&version=v2",
+			expectedStatus: http.StatusOK,
+			expectedRef:    esv1.ExternalSecretDataRemoteRef{Key: "db/app1", Property: "password", Version: "v2"},
+		},
+		{
+			name:           "whole secret",
+			secretName:     "db%2Fapp1",
+			expectedStatus: http.StatusForbidden,
+			expectedRef:    esv1.ExternalSecretDataRemoteRef{Key: "db/app1"},
+		},
+		{
+			name:           "other property",
+			secretName:     "db%2Fapp1",
+			query:          "?property=username",
+			expectedStatus: http.StatusForbidden,
+			expectedRef:    esv1.ExternalSecretDataRemoteRef{Key: "db/app1", Property: "username"},
+		},
+		{
+			name:           "other version",
+			secretName:     "db%2Fapp1",
+			query:          "?property=password&version=v1",
+			expectedStatus: http.StatusForbidden,
+			expectedRef:    esv1.ExternalSecretDataRemoteRef{Key: "db/app1", Property: "password", Version: "v1"},
+		},
+		{
+			name:           "other key",
+			secretName:     "db%2Fapp2",
+			query:          "?property=password",
+			expectedStatus: http.StatusForbidden,
+			expectedRef:    esv1.ExternalSecretDataRemoteRef{Key: "db/app2", Property: "password"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			sink.records = nil
+			refs = nil
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/"+tt.query, http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("secretStoreName", "secretName")
+			c.SetParamValues("shared-store", tt.secretName)
+			setAuthContext(c, authInfo)
+
+			s.Require().NoError(s.server.postSecrets(c))
+			s.Equal(tt.expectedStatus, rec.Code)
+
+			s.Require().Len(sink.records, 1)
+			record := sink.records[0]
+			s.Equal(testSubject, record.Subject)
+			s.Equal(secretResource("shared-store", tt.expectedRef), record.Resource)
+			if tt.expectedStatus == http.StatusOK {
+				s.Equal([]esv1.ExternalSecretDataRemoteRef{tt.expectedRef}, refs)
+				s.Equal(audit.EventSecretRead, record.Event)
+				s.Equal(audit.OutcomeSuccess, record.Outcome)
+				return
+			}
+			s.Empty(refs)
+			s.Equal(audit.EventAuthorization, record.Event)
+			s.Equal(audit.OutcomeDenied, record.Outcome)
+			s.NotContains(rec.Body.String(), "s3cr3t")
+		})
+	}
+}
+
 func TestPostSecretsTestSuite(t *testing.T) {
 	suite.Run(t, new(PostSecretsTestSuite))
 }
 
 type fakeAuthProvider struct {
-	info *auth.Info
-	err  error
+	info  *auth.Info
+	err   error
+	calls int
 }
 
 func (f *fakeAuthProvider) Authenticate(req *http.Request) (*auth.Info, error) {
+	f.calls++
 	return f.info, f.err
 }
 
 type AuthMiddlewareSuite struct {
 	suite.Suite
 	server       *Handler
+	audit        *recordingSink
 	origRegistry map[string]auth.Authenticator
 }
 
 func (s *AuthMiddlewareSuite) SetupTest() {
-	s.server = &Handler{}
+	s.audit = &recordingSink{}
+	s.server = &Handler{auditor: audit.NewLogger(logr.Discard(), s.audit)}
 
 	s.origRegistry = auth.Registry
 }
@@ -1182,6 +1317,11 @@ func (s *AuthMiddlewareSuite) Test_FirstProviderSucceeds() {
 	s.True(nextCalled, "handler wasn't called")
 	s.Equal(http.StatusOK, rec.Code)
 	s.Equal("ok", rec.Body.String())
+	s.Require().Len(s.audit.records, 1)
+	s.Equal(audit.EventAuthentication, s.audit.records[0].Event)
+	s.Equal(audit.OutcomeSuccess, s.audit.records[0].Outcome)
+	s.Equal("xyz", s.audit.records[0].Subject)
+	s.Equal("oidc", s.audit.records[0].Authenticator)
 }
 
 func (s *AuthMiddlewareSuite) Test_SecondProviderSucceeds() {
@@ -1230,7 +1370,147 @@ func (s *AuthMiddlewareSuite) Test_AllProvidersFail() {
 	err := mw(c)
 	s.NoError(err)
 	s.Equal(http.StatusUnauthorized, rec.Code)
-	s.Equal(`"errB"`+"\n", rec.Body.String())
+	s.Equal(`"authentication failed: rejected by first, second"`+"\n", rec.Body.String())
+	s.Require().Len(s.audit.records, 1)
+	s.Equal(audit.EventAuthentication, s.audit.records[0].Event)
+	s.Equal(audit.OutcomeFailure, s.audit.records[0].Outcome)
+	s.Equal("first: errA; second: errB", s.audit.records[0].Reason)
+}
+
+func (s *AuthMiddlewareSuite) Test_PriorityChain() {
+	auth.Registry = map[string]auth.Authenticator{
+		"first":  &fakeAuthProvider{info: &auth.Info{Method: "first"}},
+		"second": &fakeAuthProvider{info: &auth.Info{Method: "second"}},
+	}
+	s.Require().NoError(s.server.SetAuthenticatorPriority([]string{"second", "first"}))
+	s.ErrorIs(s.server.SetAuthenticatorPriority([]string{"third"}), auth.ErrUnknownAuthenticator)
+
+	rec, info := s.authenticate(httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("second", info.Method)
+}
+
+func (s *AuthMiddlewareSuite) Test_SelectedAuthenticator() {
+	auth.Registry = map[string]auth.Authenticator{
+		"first":  &fakeAuthProvider{info: &auth.Info{Method: "first"}},
+		"second": &fakeAuthProvider{info: &auth.Info{Method: "second"}},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(auth.AuthenticatorHeader, "second")
+	rec, info := s.authenticate(req)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("second", info.Method)
+
+	req = httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(auth.AuthenticatorHeader, "third")
+	rec, _ = s.authenticate(req)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Contains(rec.Body.String(), "unknown authenticator")
+}
+
+func (s *AuthMiddlewareSuite) Test_SelectedAuthenticatorByPath() {
+	auth.Registry = map[string]auth.Authenticator{
+		"first":  &fakeAuthProvider{info: &auth.Info{Method: "first"}},
+		"second": &fakeAuthProvider{info: &auth.Info{Method: "second"}},
+	}
+	e := echo.New()
+	e.Pre(s.server.selectAuthenticatorByPath)
+	e.Use(s.server.authMiddleware)
+	e.GET("/secretstore/:secretStoreName/secrets/:secretName", func(c echo.Context) error {
+		info := c.Get("authInfo").(*auth.Info)
+		return c.String(http.StatusOK, info.Method+" "+c.Param("secretName"))
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/auth/second/secretstore/hub/secrets/app%2Fdb", http.NoBody))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("second app%2Fdb", rec.Body.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/second/secretstore/hub/secrets/db", http.NoBody)
+	req.Header.Set(auth.AuthenticatorHeader, "first")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *AuthMiddlewareSuite) Test_AmbiguousCredentialsRejected() {
+	auth.Registry = map[string]auth.Authenticator{
+		"spiffe":  &fakeAuthProvider{info: &auth.Info{Method: "spiffe"}},
+		"aws-iam": &fakeAuthProvider{info: &auth.Info{Method: "aws-iam"}},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(auth.AWSIAMRequestHeader, "e30=")
+	req.Header.Set("Authorization", "Bearer token")
+	rec, _ := s.authenticate(req)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Contains(rec.Body.String(), "ambiguous credentials")
+
+	// Selecting an authenticator resolves the ambiguity.
+	req.Header.Set(auth.AuthenticatorHeader, "aws-iam")
+	rec, info := s.authenticate(req)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("aws-iam", info.Method)
+}
+
+func (s *AuthMiddlewareSuite) Test_CredentialTypeSelectsAuthenticator() {
+	failing := &fakeAuthProvider{err: errors.New("should not be called")}
+	auth.Registry = map[string]auth.Authenticator{
+		"spiffe":  failing,
+		"oidc":    failing,
+		"aws-iam": &fakeAuthProvider{info: &auth.Info{Method: "aws-iam"}},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(auth.AWSIAMRequestHeader, "e30=")
+	rec, info := s.authenticate(req)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("aws-iam", info.Method)
+	s.Zero(failing.calls)
+}
+
+// authenticate runs the request through the auth middleware and returns the authenticated caller, if any.
+func (s *AuthMiddlewareSuite) authenticate(req *http.Request) (*httptest.ResponseRecorder, *auth.Info) {
+	var info *auth.Info
+	next := echo.HandlerFunc(func(c echo.Context) error {
+		info = c.Get("authInfo").(*auth.Info)
+		return c.NoContent(http.StatusOK)
+	})
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	s.Require().NoError(s.server.authMiddleware(next)(c))
+	return rec, info
+}
+
+func (s *AuthMiddlewareSuite) Test_InvalidWorkloadTokenRejected() {
+	s.server.workloadTokenVerifier = auth.NewWorkloadTokenVerifier(nil)
+	auth.Registry = map[string]auth.Authenticator{
+		"test": &fakeAuthProvider{info: &auth.Info{Method: "oidc", Provider: "test", Subject: "xyz"}, err: nil},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://unknown-issuer.example.com",
+		"kubernetes.io": map[string]any{
+			"namespace":      "kube-system",
+			"serviceaccount": map[string]string{"name": "admin", "uid": "uid"},
+		},
+	}).SignedString([]byte("forged"))
+	s.Require().NoError(err)
+
+	next := echo.HandlerFunc(func(c echo.Context) error {
+		s.Fail("should not be called with an unverified workload token")
+		return nil
+	})
+
+	mw := s.server.authMiddleware(next)
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(auth.WorkloadTokenHeader, token)
+	rec := httptest.NewRecorder()
+	e := echo.New()
+	c := e.NewContext(req, rec)
+
+	err = mw(c)
+	s.NoError(err)
+	s.Equal(http.StatusUnauthorized, rec.Code)
+	s.JSONEq(`"invalid x-workload-token"`, rec.Body.String())
 }
 
 func TestAuthMiddlewareSuite(t *testing.T) {
@@ -24,14 +24,27 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/federation/server/auth"
 )
 
 var (
 	allowedMu  sync.RWMutex
 	allowedIDs = map[string]struct{}{}
+	// allowedTrustDomains maps the names of Authorizations matching SPIFFE IDs by patterns
+	// to their trust domain and spec. Only IDs of the trust domain matched by the
+	// subject matcher of the spec pass the handshake.
+	allowedTrustDomains = map[string]allowedTrustDomain{}
 )
 
+type allowedTrustDomain struct {
+	trustDomain string
+	spec        *fedv1alpha1.AuthorizationSpec
+}
+
 // AddTLSAllowedID adds a new allowed ID to the TLS store.
 func AddTLSAllowedID(id string) {
 	allowedMu.Lock()
@@ -46,12 +59,38 @@ func RemoveTLSAllowedID(id string) {
 	delete(allowedIDs, id)
 }
 
+// AddTLSAllowedTrustDomain allows the IDs of a trust domain matched by the subject matcher
+// of the named Authorization.
+func AddTLSAllowedTrustDomain(name, trustDomain string, spec *fedv1alpha1.AuthorizationSpec) {
+	allowedMu.Lock()
+	defer allowedMu.Unlock()
+	allowedTrustDomains[name] = allowedTrustDomain{trustDomain: trustDomain, spec: spec}
+}
+
+// RemoveTLSAllowedTrustDomain removes the trust domain allowed for the named Authorization.
+func RemoveTLSAllowedTrustDomain(name string) {
+	allowedMu.Lock()
+	defer allowedMu.Unlock()
+	delete(allowedTrustDomains, name)
+}
+
 // isAllowed checks if the given ID is allowed.
-func isAllowed(id string) bool {
+func isAllowed(id spiffeid.ID) bool {
 	allowedMu.RLock()
 	defer allowedMu.RUnlock()
-	_, ok := allowedIDs[id]
-	return ok
+	if _, ok := allowedIDs[id.String()]; ok {
+		return true
+	}
+	c := newCaller(&auth.Info{Method: "spiffe", Subject: id.String(), Provider: id.TrustDomain().Name()}, nil)
+	for _, allowed := range allowedTrustDomains {
+		if allowed.trustDomain != id.TrustDomain().Name() {
+			continue
+		}
+		if ok, err := c.matches(allowed.spec); err == nil && ok {
+			return true
+		}
+	}
+	return false
 }
 
 // verifyConnection verifies the connection state.
@@ -66,7 +105,7 @@ func verifyConnection(cs tls.ConnectionState) error {
 		return fmt.Errorf("error extracting spiffe id: %w", err)
 	}
 
-	if !isAllowed(id.String()) {
+	if !isAllowed(id) {
 		return fmt.Errorf("not authorized spiffe id: %s", id.String())
 	}
 	return nil
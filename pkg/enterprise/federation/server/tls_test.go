@@ -26,6 +26,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	fedv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/federation/v1alpha1"
 )
 
 func makeCertWithSPIFFEURI(uri string) (*x509.Certificate, error) {
@@ -89,3 +91,29 @@ func TestVerifyConnection_Success(t *testing.T) {
 	err = verifyConnection(cs)
 	assert.NoError(t, err)
 }
+
+func TestVerifyConnection_AllowedTrustDomain(t *testing.T) {
+	uri := "spiffe://example.org/app/ns/prod-ns/sa/uid/other-sa/pod/pid/other-pod"
+	leaf, err := makeCertWithSPIFFEURI(uri)
+	assert.NoError(t, err)
+
+	cs := tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{leaf},
+	}
+
+	AddTLSAllowedTrustDomain("prod-workloads", "example.org", &fedv1alpha1.AuthorizationSpec{
+		SubjectMatcher: &fedv1alpha1.SubjectMatcher{SpiffePathPrefix: "/app/ns/prod-ns"},
+	})
+	err = verifyConnection(cs)
+	assert.NoError(t, err)
+
+	// IDs of the trust domain not matched by the subject matcher fail the handshake.
+	other, err := makeCertWithSPIFFEURI("spiffe://example.org/app/ns/dev-ns/sa/uid/other-sa/pod/pid/other-pod")
+	assert.NoError(t, err)
+	err = verifyConnection(tls.ConnectionState{PeerCertificates: []*x509.Certificate{other}})
+	assert.Error(t, err)
+
+	RemoveTLSAllowedTrustDomain("prod-workloads")
+	err = verifyConnection(cs)
+	assert.Error(t, err)
+}
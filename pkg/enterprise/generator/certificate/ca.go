@@ -0,0 +1,154 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+const (
+	defaultCACertificateKey = "tls.crt"
+	defaultCAPrivateKeyKey  = "tls.key"
+)
+
+// authority is a CA that signs certificates and CRLs.
+type authority struct {
+	cert *x509.Certificate
+	// chain are the certificates following the CA certificate in the Secret.
+	chain  []*x509.Certificate
+	signer crypto.Signer
+}
+
+// certificates returns the CA certificate followed by its chain.
+func (a *authority) certificates() []*x509.Certificate {
+	return append([]*x509.Certificate{a.cert}, a.chain...)
+}
+
+// intermediates returns the certificates of the chain that are not self-signed.
+func (a *authority) intermediates() []*x509.Certificate {
+	var intermediates []*x509.Certificate
+	for _, cert := range a.certificates() {
+		if !isSelfSigned(cert) {
+			intermediates = append(intermediates, cert)
+		}
+	}
+	return intermediates
+}
+
+// root returns the last certificate of the chain.
+func (a *authority) root() *x509.Certificate {
+	certs := a.certificates()
+	return certs[len(certs)-1]
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+func loadCA(ctx context.Context, spec *enterprise.CertificateCA, kclient client.Client, ns string) (*authority, error) {
+	certKey := defaultCACertificateKey
+	if spec.CertificateKey != "" {
+		certKey = spec.CertificateKey
+	}
+	keyKey := defaultCAPrivateKeyKey
+	if spec.PrivateKeyKey != "" {
+		keyKey = spec.PrivateKeyKey
+	}
+	certPEM, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      spec.SecretName,
+		Key:       certKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CA certificate: %w", err)
+	}
+	keyPEM, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      spec.SecretName,
+		Key:       keyKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CA private key: %w", err)
+	}
+	return parseCA([]byte(certPEM), []byte(keyPEM))
+}
+
+func parseCA(certPEM, keyPEM []byte) (*authority, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(certPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no CA certificate found")
+	}
+	if !certs[0].IsCA {
+		return nil, errors.New("the CA certificate is not a certificate authority")
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no CA private key found")
+	}
+	signer, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(certs[0].PublicKey) {
+		return nil, errors.New("the CA private key does not match the CA certificate")
+	}
+	return &authority{cert: certs[0], chain: certs[1:], signer: signer}, nil
+}
+
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.New("failed to parse CA private key")
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported CA private key type %T", key)
+	}
+	return signer, nil
+}
@@ -0,0 +1,387 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+// Package certificate implements X.509 certificate generator.
+package certificate
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"time"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+// Generator implements the X.509 certificate generator.
+type Generator struct{}
+
+const (
+	defaultTTL     = 90 * 24 * time.Hour
+	defaultRSASize = 2048
+	defaultECSize  = 256
+
+	// backdate tolerates clock skew between the controller and the peers validating the certificate.
+	backdate = 5 * time.Minute
+)
+
+var (
+	keyUsages = map[enterprise.CertificateKeyUsage]x509.KeyUsage{
+		enterprise.CertificateKeyUsageDigitalSignature:  x509.KeyUsageDigitalSignature,
+		enterprise.CertificateKeyUsageKeyEncipherment:   x509.KeyUsageKeyEncipherment,
+		enterprise.CertificateKeyUsageKeyAgreement:      x509.KeyUsageKeyAgreement,
+		enterprise.CertificateKeyUsageDataEncipherment:  x509.KeyUsageDataEncipherment,
+		enterprise.CertificateKeyUsageContentCommitment: x509.KeyUsageContentCommitment,
+	}
+	extKeyUsages = map[enterprise.CertificateKeyUsage]x509.ExtKeyUsage{
+		enterprise.CertificateKeyUsageServerAuth:      x509.ExtKeyUsageServerAuth,
+		enterprise.CertificateKeyUsageClientAuth:      x509.ExtKeyUsageClientAuth,
+		enterprise.CertificateKeyUsageCodeSigning:     x509.ExtKeyUsageCodeSigning,
+		enterprise.CertificateKeyUsageEmailProtection: x509.ExtKeyUsageEmailProtection,
+		enterprise.CertificateKeyUsageOCSPSigning:     x509.ExtKeyUsageOCSPSigning,
+	}
+)
+
+// Generate issues a new certificate and key signed by the CA.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace, time.Now())
+}
+
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string, now time.Time) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	spec := &res.Spec
+
+	ca, err := loadCA(ctx, &spec.CA, kube, namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load CA: %w", err)
+	}
+	key, err := generateKey(&spec.PrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	template, err := certificateTemplate(spec, key, ca, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.signer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to sign certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal private key: %w", err)
+	}
+	chain := append([]*x509.Certificate{cert}, ca.intermediates()...)
+	out := map[string][]byte{
+		"tls.crt": encodeCertificates(chain...),
+		"tls.key": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		"ca.crt":  encodeCertificates(ca.root()),
+	}
+	if spec.PKCS12 != nil {
+		keystore, err := encodePKCS12(ctx, spec.PKCS12, key, cert, ca, kube, namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		out["keystore.p12"] = keystore
+	}
+
+	// Publish the CRL as soon as the CA issues certificates, and keep it from expiring.
+	if spec.CRL != nil {
+		if err := updateCRL(ctx, spec.CRL, ca, nil, kube, namespace, now); err != nil {
+			return nil, nil, err
+		}
+		scheduleCRLRefresh(spec, kube, namespace)
+	}
+
+	rawState, err := json.Marshal(&enterprise.CertificateState{
+		SerialNumber: cert.SerialNumber.Text(16),
+		NotAfter:     metav1.NewTime(cert.NotAfter),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal state: %w", err)
+	}
+	return out, &apiextensions.JSON{Raw: rawState}, nil
+}
+
+// Cleanup revokes the certificate by adding it to the CRL of the CA.
+// Expired certificates are not revoked, as they are rejected anyway.
+func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, previousStatus genv1alpha1.GeneratorProviderState, kclient client.Client, namespace string) error {
+	if previousStatus == nil {
+		return fmt.Errorf("missing previous status")
+	}
+	status, err := parseStatus(previousStatus.Raw)
+	if err != nil {
+		return err
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if res.Spec.CRL == nil || !now.Before(status.NotAfter.Time) {
+		return nil
+	}
+	serial, ok := new(big.Int).SetString(status.SerialNumber, 16)
+	if !ok {
+		return fmt.Errorf("invalid serial number %q", status.SerialNumber)
+	}
+	ca, err := loadCA(ctx, &res.Spec.CA, kclient, namespace)
+	if err != nil {
+		return fmt.Errorf("unable to load CA: %w", err)
+	}
+	err = updateCRL(ctx, res.Spec.CRL, ca, &revocation{serial: serial, notAfter: status.NotAfter.Time}, kclient, namespace, now)
+	if err != nil {
+		return err
+	}
+	scheduleCRLRefresh(&res.Spec, kclient, namespace)
+	return nil
+}
+
+// GetCleanupPolicy returns the cleanup policy for this generator.
+func (g *Generator) GetCleanupPolicy(obj *apiextensions.JSON) (*genv1alpha1.CleanupPolicy, error) {
+	res, err := parseSpec(obj.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return res.Spec.CleanupPolicy, nil
+}
+
+// LastActivityTime returns the last activity time for generated resources.
+func (g *Generator) LastActivityTime(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) (time.Time, bool, error) {
+	return time.Time{}, false, nil
+}
+
+// GetKeys returns the keys generated by this generator.
+func (g *Generator) GetKeys() map[string]string {
+	return map[string]string{
+		"tls.crt":      "PEM encoded certificate, followed by the intermediate certificates of the CA",
+		"tls.key":      "PEM encoded PKCS#8 private key of the certificate",
+		"ca.crt":       "PEM encoded root certificate of the chain of the CA",
+		"keystore.p12": "PKCS#12 keystore with the certificate, its key and the CA, if enabled",
+	}
+}
+
+func generateKey(spec *enterprise.CertificatePrivateKey) (crypto.Signer, error) {
+	switch spec.Algorithm {
+	case enterprise.CertificateKeyAlgorithmRSA:
+		size := spec.Size
+		if size == 0 {
+			size = defaultRSASize
+		}
+		if size != 2048 && size != 3072 && size != 4096 {
+			return nil, fmt.Errorf("unsupported RSA key size: %d", size)
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+	case enterprise.CertificateKeyAlgorithmECDSA, "":
+		var curve elliptic.Curve
+		switch spec.Size {
+		case 256, 0:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA key size: %d", spec.Size)
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case enterprise.CertificateKeyAlgorithmEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm: %s", spec.Algorithm)
+	}
+}
+
+func certificateTemplate(spec *enterprise.CertificateSpec, key crypto.Signer, ca *authority, now time.Time) (*x509.Certificate, error) {
+	if spec.CommonName == "" && len(spec.DNSNames) == 0 && len(spec.IPAddresses) == 0 && len(spec.URIs) == 0 {
+		return nil, errors.New("at least one of commonName, dnsNames, ipAddresses or uris is required")
+	}
+	ips := make([]net.IP, 0, len(spec.IPAddresses))
+	for _, s := range spec.IPAddresses {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", s)
+		}
+		ips = append(ips, ip)
+	}
+	uris := make([]*url.URL, 0, len(spec.URIs))
+	for _, s := range spec.URIs {
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme == "" {
+			return nil, fmt.Errorf("invalid URI %q", s)
+		}
+		uris = append(uris, u)
+	}
+	keyUsage, extKeyUsage, err := usages(spec.Usages, key)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := defaultTTL
+	if spec.TTL.Duration > 0 {
+		ttl = spec.TTL.Duration
+	}
+	notAfter := now.Add(ttl)
+	if notAfter.After(ca.cert.NotAfter) {
+		notAfter = ca.cert.NotAfter
+	}
+	if !notAfter.After(now) {
+		return nil, fmt.Errorf("CA certificate expired at %s", ca.cert.NotAfter)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   spec.CommonName,
+			Organization: spec.Organizations,
+		},
+		DNSNames:              spec.DNSNames,
+		IPAddresses:           ips,
+		URIs:                  uris,
+		NotBefore:             now.Add(-backdate),
+		NotAfter:              notAfter,
+		KeyUsage:              keyUsage,
+		ExtKeyUsage:           extKeyUsage,
+		BasicConstraintsValid: true,
+	}
+	if spec.CRL != nil {
+		template.CRLDistributionPoints = spec.CRL.DistributionPoints
+	}
+	return template, nil
+}
+
+// usages returns the key usages of the certificate. By default, the certificate can
+// authenticate TLS servers and clients, and RSA keys can be used for key exchange.
+func usages(names []enterprise.CertificateKeyUsage, key crypto.Signer) (x509.KeyUsage, []x509.ExtKeyUsage, error) {
+	if len(names) == 0 {
+		names = []enterprise.CertificateKeyUsage{
+			enterprise.CertificateKeyUsageDigitalSignature,
+			enterprise.CertificateKeyUsageServerAuth,
+			enterprise.CertificateKeyUsageClientAuth,
+		}
+		if _, ok := key.(*rsa.PrivateKey); ok {
+			names = append(names, enterprise.CertificateKeyUsageKeyEncipherment)
+		}
+	}
+	var keyUsage x509.KeyUsage
+	var extKeyUsage []x509.ExtKeyUsage
+	for _, name := range names {
+		if usage, ok := keyUsages[name]; ok {
+			keyUsage |= usage
+			continue
+		}
+		if usage, ok := extKeyUsages[name]; ok {
+			extKeyUsage = append(extKeyUsage, usage)
+			continue
+		}
+		return 0, nil, fmt.Errorf("unsupported usage %q", name)
+	}
+	return keyUsage, extKeyUsage, nil
+}
+
+// serialNumber returns a random positive 128 bit serial number.
+func serialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate serial number: %w", err)
+	}
+	return serial.Add(serial, big.NewInt(1)), nil
+}
+
+func encodeCertificates(certs ...*x509.Certificate) []byte {
+	var out []byte
+	for _, cert := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return out
+}
+
+func encodePKCS12(ctx context.Context, spec *enterprise.CertificatePKCS12, key crypto.Signer, cert *x509.Certificate, ca *authority, kclient client.Client, ns string) ([]byte, error) {
+	var password string
+	if spec.Password != nil {
+		var err error
+		password, err = resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+			Namespace: &ns,
+			Name:      spec.Password.Name,
+			Key:       spec.Password.Key,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get keystore password: %w", err)
+		}
+	}
+	keystore, err := gopkcs12.Modern.Encode(key, cert, ca.certificates(), password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode keystore: %w", err)
+	}
+	return keystore, nil
+}
+
+func parseSpec(data []byte) (*enterprise.Certificate, error) {
+	var spec enterprise.Certificate
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func parseStatus(data []byte) (*enterprise.CertificateState, error) {
+	var state enterprise.CertificateState
+	err := json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+	return &state, err
+}
+
+func init() {
+	genv1alpha1.Register(enterprise.CertificateKind, &Generator{})
+	genv1alpha1.RegisterGeneric(enterprise.CertificateKind, &enterprise.Certificate{})
+}
@@ -0,0 +1,532 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// /*
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package certificate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/scheduler"
+)
+
+// testJobs records the jobs scheduled by the generator.
+var testJobs = &testScheduler{jobs: map[string]testJob{}}
+
+type testJob struct {
+	interval time.Duration
+	fn       func(context.Context, logr.Logger)
+}
+
+type testScheduler struct {
+	mu   sync.Mutex
+	jobs map[string]testJob
+}
+
+func (s *testScheduler) ScheduleInterval(key string, interval, _ time.Duration, fn func(context.Context, logr.Logger)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[key] = testJob{interval: interval, fn: fn}
+}
+
+func (s *testScheduler) Cancel(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, key)
+}
+
+func (s *testScheduler) Start(context.Context) error { return nil }
+
+func (s *testScheduler) get(key string) (testJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[key]
+	return job, ok
+}
+
+func TestMain(m *testing.M) {
+	scheduler.SetGlobal(testJobs)
+	os.Exit(m.Run())
+}
+
+const (
+	testNamespace = "default"
+	testCASecret  = "ca"
+	testCRLSecret = "crl"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string, parent *testCA) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	issuer, signer := template, any(key)
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) secret(t *testing.T, chain ...*testCA) *corev1.Secret {
+	t.Helper()
+	keyDER, err := x509.MarshalECPrivateKey(ca.key)
+	require.NoError(t, err)
+	certs := []*x509.Certificate{ca.cert}
+	for _, c := range chain {
+		certs = append(certs, c.cert)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testCASecret},
+		Data: map[string][]byte{
+			"tls.crt": encodeCertificates(certs...),
+			"tls.key": pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+func specJSON(t *testing.T, spec enterprise.CertificateSpec) *apiextensions.JSON {
+	t.Helper()
+	raw, err := yaml.Marshal(&enterprise.Certificate{Spec: spec})
+	require.NoError(t, err)
+	return &apiextensions.JSON{Raw: raw}
+}
+
+func parseCertificates(t *testing.T, data []byte) []*x509.Certificate {
+	t.Helper()
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		certs = append(certs, cert)
+	}
+	return certs
+}
+
+func TestGenerate(t *testing.T) {
+	ca := newTestCA(t, "root", nil)
+	kube := fake.NewClientBuilder().WithObjects(ca.secret(t)).Build()
+
+	gen := &Generator{}
+	out, state, err := gen.Generate(context.Background(), specJSON(t, enterprise.CertificateSpec{
+		CA:          enterprise.CertificateCA{SecretName: testCASecret},
+		CommonName:  "orders",
+		DNSNames:    []string{"orders.default.svc"},
+		IPAddresses: []string{"10.0.0.1"},
+		URIs:        []string{"spiffe://cluster.local/ns/default/sa/orders"},
+		TTL:         metav1.Duration{Duration: time.Hour},
+	}), kube, testNamespace)
+	require.NoError(t, err)
+
+	certs := parseCertificates(t, out["tls.crt"])
+	require.Len(t, certs, 1)
+	cert := certs[0]
+	assert.Equal(t, "orders", cert.Subject.CommonName)
+	assert.Equal(t, []string{"orders.default.svc"}, cert.DNSNames)
+	assert.True(t, cert.IPAddresses[0].Equal(net.ParseIP("10.0.0.1")))
+	assert.Equal(t, "spiffe://cluster.local/ns/default/sa/orders", cert.URIs[0].String())
+	assert.Equal(t, x509.KeyUsageDigitalSignature, cert.KeyUsage)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	assert.False(t, cert.IsCA)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cert.NotAfter, time.Minute)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "orders.default.svc", KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	assert.NoError(t, err)
+	assert.Equal(t, encodeCertificates(ca.cert), out["ca.crt"])
+
+	block, _ := pem.Decode(out["tls.key"])
+	require.NotNil(t, block)
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	assert.True(t, key.(*ecdsa.PrivateKey).PublicKey.Equal(cert.PublicKey))
+	assert.NotContains(t, out, "keystore.p12")
+
+	status, err := parseStatus(state.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, cert.SerialNumber.Text(16), status.SerialNumber)
+	assert.True(t, cert.NotAfter.Equal(status.NotAfter.Time))
+
+	// Without a CRL, the certificate is not revoked.
+	require.NoError(t, gen.Cleanup(context.Background(), specJSON(t, enterprise.CertificateSpec{CA: enterprise.CertificateCA{SecretName: testCASecret}}), state, kube, testNamespace))
+}
+
+func TestGenerateKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     enterprise.CertificatePrivateKey
+		check   func(t *testing.T, key any)
+		wantErr string
+	}{
+		{
+			name: "RSA",
+			key:  enterprise.CertificatePrivateKey{Algorithm: enterprise.CertificateKeyAlgorithmRSA},
+			check: func(t *testing.T, key any) {
+				assert.Equal(t, 2048, key.(*rsa.PrivateKey).N.BitLen())
+			},
+		},
+		{
+			name: "ECDSA P-384",
+			key:  enterprise.CertificatePrivateKey{Algorithm: enterprise.CertificateKeyAlgorithmECDSA, Size: 384},
+			check: func(t *testing.T, key any) {
+				assert.Equal(t, elliptic.P384(), key.(*ecdsa.PrivateKey).Curve)
+			},
+		},
+		{
+			name: "Ed25519",
+			key:  enterprise.CertificatePrivateKey{Algorithm: enterprise.CertificateKeyAlgorithmEd25519},
+			check: func(t *testing.T, key any) {
+				assert.IsType(t, ed25519.PrivateKey{}, key)
+			},
+		},
+		{
+			name:    "invalid RSA size",
+			key:     enterprise.CertificatePrivateKey{Algorithm: enterprise.CertificateKeyAlgorithmRSA, Size: 1024},
+			wantErr: "unsupported RSA key size: 1024",
+		},
+		{
+			name:    "invalid ECDSA size",
+			key:     enterprise.CertificatePrivateKey{Algorithm: enterprise.CertificateKeyAlgorithmECDSA, Size: 224},
+			wantErr: "unsupported ECDSA key size: 224",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := generateKey(&tt.key)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, key)
+		})
+	}
+}
+
+func TestCertificateTemplate(t *testing.T) {
+	ca := newTestCA(t, "root", nil)
+	authority := &authority{cert: ca.cert, signer: ca.key}
+	key, err := generateKey(&enterprise.CertificatePrivateKey{Algorithm: enterprise.CertificateKeyAlgorithmRSA})
+	require.NoError(t, err)
+	now := time.Now()
+
+	template, err := certificateTemplate(&enterprise.CertificateSpec{
+		CommonName: "orders",
+		TTL:        metav1.Duration{Duration: 10 * 365 * 24 * time.Hour},
+		CRL:        &enterprise.CertificateCRL{DistributionPoints: []string{"http://pki.example.com/ca.crl"}},
+	}, key, authority, now)
+	require.NoError(t, err)
+	assert.Equal(t, ca.cert.NotAfter, template.NotAfter, "the certificate does not outlive the CA")
+	assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment, template.KeyUsage)
+	assert.Equal(t, []string{"http://pki.example.com/ca.crl"}, template.CRLDistributionPoints)
+	assert.Equal(t, 1, template.SerialNumber.Sign())
+
+	template, err = certificateTemplate(&enterprise.CertificateSpec{
+		CommonName: "signer",
+		Usages:     []enterprise.CertificateKeyUsage{enterprise.CertificateKeyUsageDigitalSignature, enterprise.CertificateKeyUsageCodeSigning},
+	}, key, authority, now)
+	require.NoError(t, err)
+	assert.Equal(t, x509.KeyUsageDigitalSignature, template.KeyUsage)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}, template.ExtKeyUsage)
+
+	_, err = certificateTemplate(&enterprise.CertificateSpec{}, key, authority, now)
+	assert.ErrorContains(t, err, "at least one of commonName")
+	_, err = certificateTemplate(&enterprise.CertificateSpec{IPAddresses: []string{"10.0.0"}}, key, authority, now)
+	assert.EqualError(t, err, `invalid IP address "10.0.0"`)
+	_, err = certificateTemplate(&enterprise.CertificateSpec{URIs: []string{"orders"}}, key, authority, now)
+	assert.EqualError(t, err, `invalid URI "orders"`)
+	_, err = certificateTemplate(&enterprise.CertificateSpec{CommonName: "orders"}, key, authority, ca.cert.NotAfter.Add(time.Hour))
+	assert.ErrorContains(t, err, "CA certificate expired")
+}
+
+func TestGenerateWithIntermediateCA(t *testing.T) {
+	root := newTestCA(t, "root", nil)
+	intermediate := newTestCA(t, "intermediate", root)
+	kube := fake.NewClientBuilder().WithObjects(intermediate.secret(t, root)).Build()
+
+	gen := &Generator{}
+	out, _, err := gen.Generate(context.Background(), specJSON(t, enterprise.CertificateSpec{
+		CA:         enterprise.CertificateCA{SecretName: testCASecret},
+		CommonName: "orders",
+	}), kube, testNamespace)
+	require.NoError(t, err)
+
+	certs := parseCertificates(t, out["tls.crt"])
+	require.Len(t, certs, 2)
+	assert.Equal(t, intermediate.cert, certs[1])
+	assert.Equal(t, encodeCertificates(root.cert), out["ca.crt"])
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root.cert)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(certs[1])
+	_, err = certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+	assert.NoError(t, err)
+}
+
+func TestGenerateWithPKCS12(t *testing.T) {
+	ca := newTestCA(t, "root", nil)
+	password := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "keystore"},
+		Data:       map[string][]byte{"password": []byte("changeit")},
+	}
+	kube := fake.NewClientBuilder().WithObjects(ca.secret(t), password).Build()
+
+	gen := &Generator{}
+	out, _, err := gen.Generate(context.Background(), specJSON(t, enterprise.CertificateSpec{
+		CA:         enterprise.CertificateCA{SecretName: testCASecret},
+		CommonName: "orders",
+		PKCS12: &enterprise.CertificatePKCS12{
+			Password: &esmeta.SecretKeySelector{Name: "keystore", Key: "password"},
+		},
+	}), kube, testNamespace)
+	require.NoError(t, err)
+
+	key, cert, caCerts, err := gopkcs12.DecodeChain(out["keystore.p12"], "changeit")
+	require.NoError(t, err)
+	assert.Equal(t, parseCertificates(t, out["tls.crt"])[0], cert)
+	assert.True(t, key.(*ecdsa.PrivateKey).PublicKey.Equal(cert.PublicKey))
+	assert.Equal(t, []*x509.Certificate{ca.cert}, caCerts)
+}
+
+func TestParseCA(t *testing.T) {
+	ca := newTestCA(t, "root", nil)
+	other := newTestCA(t, "other", nil)
+	secret := ca.secret(t)
+
+	_, err := parseCA(secret.Data["tls.crt"], other.secret(t).Data["tls.key"])
+	assert.EqualError(t, err, "the CA private key does not match the CA certificate")
+	_, err = parseCA(nil, secret.Data["tls.key"])
+	assert.EqualError(t, err, "no CA certificate found")
+	_, err = parseCA(secret.Data["tls.crt"], nil)
+	assert.EqualError(t, err, "no CA private key found")
+
+	leafGen := &Generator{}
+	kube := fake.NewClientBuilder().WithObjects(secret).Build()
+	out, _, err := leafGen.Generate(context.Background(), specJSON(t, enterprise.CertificateSpec{
+		CA:         enterprise.CertificateCA{SecretName: testCASecret},
+		CommonName: "orders",
+	}), kube, testNamespace)
+	require.NoError(t, err)
+	_, err = parseCA(out["tls.crt"], out["tls.key"])
+	assert.EqualError(t, err, "the CA certificate is not a certificate authority")
+}
+
+func getCRL(t *testing.T, kube client.Client, ca *testCA) *x509.RevocationList {
+	t.Helper()
+	secret := &corev1.Secret{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testCRLSecret}, secret))
+	block, _ := pem.Decode(secret.Data["ca.crl"])
+	require.NotNil(t, block)
+	assert.Equal(t, "X509 CRL", block.Type)
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	require.NoError(t, err)
+	require.NoError(t, crl.CheckSignatureFrom(ca.cert))
+	return crl
+}
+
+func TestCRL(t *testing.T) {
+	ca := newTestCA(t, "root", nil)
+	kube := fake.NewClientBuilder().WithObjects(ca.secret(t)).Build()
+	spec := specJSON(t, enterprise.CertificateSpec{
+		CA:         enterprise.CertificateCA{SecretName: testCASecret},
+		CommonName: "orders",
+		CRL:        &enterprise.CertificateCRL{SecretName: testCRLSecret},
+	})
+
+	gen := &Generator{}
+	_, state, err := gen.Generate(context.Background(), spec, kube, testNamespace)
+	require.NoError(t, err)
+	crl := getCRL(t, kube, ca)
+	assert.Empty(t, crl.RevokedCertificateEntries)
+	assert.Equal(t, int64(1), crl.Number.Int64())
+	assert.WithinDuration(t, time.Now().Add(defaultCRLValidity), crl.NextUpdate, time.Minute)
+
+	// The CRL is fresh, so issuing another certificate does not sign it again.
+	_, _, err = gen.Generate(context.Background(), spec, kube, testNamespace)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), getCRL(t, kube, ca).Number.Int64())
+
+	require.NoError(t, gen.Cleanup(context.Background(), spec, state, kube, testNamespace))
+	crl = getCRL(t, kube, ca)
+	status, err := parseStatus(state.Raw)
+	require.NoError(t, err)
+	require.Len(t, crl.RevokedCertificateEntries, 1)
+	assert.Equal(t, status.SerialNumber, crl.RevokedCertificateEntries[0].SerialNumber.Text(16))
+	assert.Equal(t, int64(2), crl.Number.Int64())
+
+	// Cleanup is idempotent.
+	require.NoError(t, gen.Cleanup(context.Background(), spec, state, kube, testNamespace))
+	assert.Equal(t, int64(2), getCRL(t, kube, ca).Number.Int64())
+
+	// Expired certificates are not revoked.
+	status.NotAfter = metav1.NewTime(time.Now().Add(-time.Minute))
+	status.SerialNumber = "abcdef"
+	expired, err := json.Marshal(status)
+	require.NoError(t, err)
+	require.NoError(t, gen.Cleanup(context.Background(), spec, &apiextensions.JSON{Raw: expired}, kube, testNamespace))
+	assert.Len(t, getCRL(t, kube, ca).RevokedCertificateEntries, 1)
+}
+
+func TestCRLRefresh(t *testing.T) {
+	ca := newTestCA(t, "root", nil)
+	kube := fake.NewClientBuilder().WithObjects(ca.secret(t)).Build()
+	crlSpec := &enterprise.CertificateCRL{SecretName: testCRLSecret, Validity: metav1.Duration{Duration: 24 * time.Hour}}
+	spec := specJSON(t, enterprise.CertificateSpec{
+		CA:         enterprise.CertificateCA{SecretName: testCASecret},
+		CommonName: "orders",
+		TTL:        metav1.Duration{Duration: time.Hour},
+		CRL:        crlSpec,
+	})
+
+	gen := &Generator{}
+	_, state, err := gen.Generate(context.Background(), spec, kube, testNamespace)
+	require.NoError(t, err)
+	require.NoError(t, gen.Cleanup(context.Background(), spec, state, kube, testNamespace))
+
+	// The CRL is refreshed periodically, before half of its validity has passed.
+	job, ok := testJobs.get(fmt.Sprintf(crlRefreshIDFmt, testNamespace, testCRLSecret, defaultCRLKey))
+	require.True(t, ok)
+	assert.Equal(t, 6*time.Hour, job.interval)
+	job.fn(context.Background(), logr.Discard())
+	crl := getCRL(t, kube, ca)
+	assert.Equal(t, int64(2), crl.Number.Int64())
+	require.Len(t, crl.RevokedCertificateEntries, 1)
+
+	caSpec := &enterprise.CertificateCA{SecretName: testCASecret}
+	require.NoError(t, refreshCRL(context.Background(), caSpec, crlSpec, kube, testNamespace, time.Now().Add(30*time.Minute)))
+	assert.Equal(t, int64(2), getCRL(t, kube, ca).Number.Int64())
+
+	// The revoked certificate is dropped once it has expired.
+	require.NoError(t, refreshCRL(context.Background(), caSpec, crlSpec, kube, testNamespace, time.Now().Add(2*time.Hour)))
+	crl = getCRL(t, kube, ca)
+	assert.Equal(t, int64(3), crl.Number.Int64())
+	assert.Empty(t, crl.RevokedCertificateEntries)
+	secret := &corev1.Secret{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testCRLSecret}, secret))
+	assert.JSONEq(t, `{}`, string(secret.Data[defaultCRLKey+crlExpirySuffix]))
+
+	// The CRL is signed again once half of its validity has passed.
+	require.NoError(t, refreshCRL(context.Background(), caSpec, crlSpec, kube, testNamespace, time.Now().Add(15*time.Hour)))
+	crl = getCRL(t, kube, ca)
+	assert.Equal(t, int64(4), crl.Number.Int64())
+	assert.WithinDuration(t, time.Now().Add(39*time.Hour), crl.NextUpdate, time.Minute)
+}
+
+func TestNextCRL(t *testing.T) {
+	now := time.Now()
+	validity := 24 * time.Hour
+	current := &x509.RevocationList{
+		Number:     big.NewInt(3),
+		ThisUpdate: now.Add(-time.Hour),
+		NextUpdate: now.Add(23 * time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: big.NewInt(10), RevocationTime: now.Add(-time.Hour)},
+			{SerialNumber: big.NewInt(12), RevocationTime: now.Add(-time.Hour)},
+		},
+	}
+	expiry := map[string]time.Time{"a": now.Add(13 * time.Hour)}
+
+	next, nextExpiry, changed := nextCRL(nil, nil, nil, validity, now)
+	assert.True(t, changed)
+	assert.Equal(t, int64(1), next.Number.Int64())
+	assert.Empty(t, next.RevokedCertificateEntries)
+	assert.Empty(t, nextExpiry)
+
+	_, nextExpiry, changed = nextCRL(current, expiry, nil, validity, now)
+	assert.False(t, changed)
+	assert.Equal(t, expiry, nextExpiry)
+	_, _, changed = nextCRL(current, expiry, &revocation{serial: big.NewInt(10), notAfter: now.Add(13 * time.Hour)}, validity, now)
+	assert.False(t, changed)
+
+	next, _, changed = nextCRL(current, expiry, nil, validity, now.Add(12*time.Hour))
+	assert.True(t, changed)
+	assert.Equal(t, int64(4), next.Number.Int64())
+	assert.Len(t, next.RevokedCertificateEntries, 2)
+
+	next, nextExpiry, changed = nextCRL(current, expiry, &revocation{serial: big.NewInt(11), notAfter: now.Add(time.Hour)}, validity, now)
+	assert.True(t, changed)
+	assert.Len(t, next.RevokedCertificateEntries, 3)
+	assert.Equal(t, now.Add(validity), next.NextUpdate)
+	assert.Equal(t, map[string]time.Time{"a": now.Add(13 * time.Hour), "b": now.Add(time.Hour)}, nextExpiry)
+
+	// Expired certificates are dropped, certificates of unknown expiration are kept.
+	next, nextExpiry, changed = nextCRL(current, expiry, nil, validity, now.Add(14*time.Hour))
+	assert.True(t, changed)
+	require.Len(t, next.RevokedCertificateEntries, 1)
+	assert.Equal(t, int64(12), next.RevokedCertificateEntries[0].SerialNumber.Int64())
+	assert.Empty(t, nextExpiry)
+}
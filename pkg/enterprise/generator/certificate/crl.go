@@ -0,0 +1,231 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+package certificate
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/scheduler"
+)
+
+const (
+	defaultCRLKey      = "ca.crl"
+	defaultCRLValidity = 7 * 24 * time.Hour
+	// crlExpirySuffix is the suffix of the key the expiration times of the revoked certificates are stored in.
+	crlExpirySuffix = ".expiry"
+	crlRefreshIDFmt = "certificate-crl-%s/%s:%s"
+)
+
+// revocation is a certificate to add to the CRL.
+type revocation struct {
+	serial   *big.Int
+	notAfter time.Time
+}
+
+func crlKey(spec *enterprise.CertificateCRL) string {
+	if spec.Key != "" {
+		return spec.Key
+	}
+	return defaultCRLKey
+}
+
+func crlValidity(spec *enterprise.CertificateCRL) time.Duration {
+	if spec.Validity.Duration > 0 {
+		return spec.Validity.Duration
+	}
+	return defaultCRLValidity
+}
+
+// scheduleCRLRefresh refreshes the CRL periodically, so that it does not expire when no
+// certificate is issued or revoked for a while.
+func scheduleCRLRefresh(spec *enterprise.CertificateSpec, kclient client.Client, ns string) {
+	caSpec, crlSpec := spec.CA, *spec.CRL
+	schedID := fmt.Sprintf(crlRefreshIDFmt, ns, crlSpec.SecretName, crlKey(&crlSpec))
+	scheduler.Global().ScheduleInterval(schedID, crlValidity(&crlSpec)/4, time.Minute, func(ctx context.Context, log logr.Logger) {
+		err := refreshCRL(ctx, &caSpec, &crlSpec, kclient, ns, time.Now())
+		if err != nil {
+			log.Error(err, "failed to refresh CRL", "namespace", ns, "secret", crlSpec.SecretName)
+		}
+	})
+}
+
+// refreshCRL signs the CRL again once half of its validity has passed, and drops the expired certificates.
+func refreshCRL(ctx context.Context, caSpec *enterprise.CertificateCA, spec *enterprise.CertificateCRL, kclient client.Client, ns string, now time.Time) error {
+	ca, err := loadCA(ctx, caSpec, kclient, ns)
+	if err != nil {
+		return fmt.Errorf("unable to load CA: %w", err)
+	}
+	return updateCRL(ctx, spec, ca, nil, kclient, ns, now)
+}
+
+// updateCRL adds the revoked certificate to the CRL stored in a Secret, and signs it again.
+// Without a revoked certificate, the CRL is only created if it does not exist, and signed again
+// once half of its validity has passed or a revoked certificate has expired.
+func updateCRL(ctx context.Context, spec *enterprise.CertificateCRL, ca *authority, revoked *revocation, kclient client.Client, ns string, now time.Time) error {
+	key := crlKey(spec)
+	validity := crlValidity(spec)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+		err := kclient.Get(ctx, types.NamespacedName{Namespace: ns, Name: spec.SecretName}, secret)
+		notFound := apierrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return err
+		}
+		if notFound {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns,
+					Name:      spec.SecretName,
+				},
+				Type: corev1.SecretTypeOpaque,
+			}
+		}
+
+		current, err := parseCRL(secret.Data[key], ca)
+		if err != nil {
+			return err
+		}
+		expiry := map[string]time.Time{}
+		if current != nil {
+			expiry, err = parseCRLExpiry(secret.Data[key+crlExpirySuffix])
+			if err != nil {
+				return err
+			}
+		}
+		crl, expiry, changed := nextCRL(current, expiry, revoked, validity, now)
+		if !changed {
+			return nil
+		}
+		der, err := x509.CreateRevocationList(rand.Reader, crl, ca.cert, ca.signer)
+		if err != nil {
+			return fmt.Errorf("failed to sign CRL: %w", err)
+		}
+		rawExpiry, err := json.Marshal(expiry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal CRL expiry: %w", err)
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[key] = pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+		secret.Data[key+crlExpirySuffix] = rawExpiry
+		if notFound {
+			return kclient.Create(ctx, secret)
+		}
+		return kclient.Update(ctx, secret)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update CRL: %w", err)
+	}
+	return nil
+}
+
+// parseCRL returns the CRL of the CA, or nil if there is none. A CRL signed by another
+// CA, e.g. before the CA was rotated, is discarded.
+func parseCRL(data []byte, ca *authority) (*x509.RevocationList, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode CRL")
+	}
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CRL: %w", err)
+	}
+	if crl.CheckSignatureFrom(ca.cert) != nil {
+		return nil, nil
+	}
+	return crl, nil
+}
+
+// parseCRLExpiry returns the expiration times of the revoked certificates by hex encoded serial number.
+func parseCRLExpiry(data []byte) (map[string]time.Time, error) {
+	expiry := map[string]time.Time{}
+	if len(data) == 0 {
+		return expiry, nil
+	}
+	if err := json.Unmarshal(data, &expiry); err != nil {
+		return nil, fmt.Errorf("failed to parse CRL expiry: %w", err)
+	}
+	return expiry, nil
+}
+
+// nextCRL returns the next CRL with the expiration times of its certificates, and whether
+// it has to be signed. Certificates are dropped from the CRL once they have expired, as
+// they are rejected anyway.
+func nextCRL(current *x509.RevocationList, expiry map[string]time.Time, revoked *revocation, validity time.Duration, now time.Time) (*x509.RevocationList, map[string]time.Time, bool) {
+	next := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: now,
+		NextUpdate: now.Add(validity),
+	}
+	nextExpiry := map[string]time.Time{}
+	changed := current == nil
+	if current != nil {
+		next.Number = new(big.Int).Add(current.Number, big.NewInt(1))
+		for _, entry := range current.RevokedCertificateEntries {
+			serial := entry.SerialNumber.Text(16)
+			notAfter, ok := expiry[serial]
+			if ok && !now.Before(notAfter) {
+				changed = true
+				continue
+			}
+			if revoked != nil && entry.SerialNumber.Cmp(revoked.serial) == 0 {
+				revoked = nil
+			}
+			next.RevokedCertificateEntries = append(next.RevokedCertificateEntries, entry)
+			if ok {
+				nextExpiry[serial] = notAfter
+			}
+		}
+	}
+	if revoked != nil {
+		next.RevokedCertificateEntries = append(next.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   revoked.serial,
+			RevocationTime: now,
+		})
+		nextExpiry[revoked.serial.Text(16)] = revoked.notAfter
+		changed = true
+	}
+	if !changed {
+		refresh := current.ThisUpdate.Add(current.NextUpdate.Sub(current.ThisUpdate) / 2)
+		changed = !now.Before(refresh)
+	}
+	return next, nextExpiry, changed
+}
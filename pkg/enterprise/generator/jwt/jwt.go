@@ -0,0 +1,212 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+// Package jwt implements JSON Web Token generator.
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	estemplate "github.com/external-secrets/external-secrets/runtime/template/v2"
+)
+
+// Generator implements the JSON Web Token generator.
+type Generator struct{}
+
+const defaultTTL = time.Hour
+
+// registeredClaims are the claims set by the generator, which cannot be overridden by custom claims.
+var registeredClaims = map[string]struct{}{
+	"iss": {},
+	"sub": {},
+	"aud": {},
+	"exp": {},
+	"nbf": {},
+	"iat": {},
+	"jti": {},
+}
+
+// Generate issues a new token signed by the signing key.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace, time.Now())
+}
+
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string, now time.Time) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	if jsonSpec == nil {
+		return nil, nil, errors.New("no config spec provided")
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse spec: %w", err)
+	}
+	spec := &res.Spec
+
+	key, err := loadSigningKey(ctx, &spec.SigningKey, kube, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	ttl := defaultTTL
+	if spec.TTL.Duration > 0 {
+		ttl = spec.TTL.Duration
+	}
+	expiresAt := now.Add(ttl)
+	claims, err := tokenClaims(spec, namespace, now, expiresAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	if key.id != "" {
+		token.Header["kid"] = key.id
+	}
+	signed, err := token.SignedString(key.signer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to sign token: %w", err)
+	}
+
+	out := map[string][]byte{
+		"token":     []byte(signed),
+		"expiresAt": []byte(expiresAt.UTC().Format(time.RFC3339)),
+	}
+	if key.id != "" {
+		out["kid"] = []byte(key.id)
+	}
+	if key.jwks != nil {
+		out["jwks"] = key.jwks
+	}
+	return out, nil, nil
+}
+
+// Cleanup does nothing, as issued tokens cannot be revoked.
+func (g *Generator) Cleanup(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) error {
+	return nil
+}
+
+// GetCleanupPolicy returns the cleanup policy for this generator.
+func (g *Generator) GetCleanupPolicy(_ *apiextensions.JSON) (*genv1alpha1.CleanupPolicy, error) {
+	return nil, nil
+}
+
+// LastActivityTime returns the last activity time for generated resources.
+func (g *Generator) LastActivityTime(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) (time.Time, bool, error) {
+	return time.Time{}, false, nil
+}
+
+// GetKeys returns the keys generated by this generator.
+func (g *Generator) GetKeys() map[string]string {
+	return map[string]string{
+		"token":     "Signed JSON Web Token",
+		"expiresAt": "Expiration time of the token in RFC 3339 format",
+		"kid":       "Key ID of the signing key, if set",
+		"jwks":      "JSON Web Key Set with the public key that verifies the token, for asymmetric algorithms",
+	}
+}
+
+// tokenClaims returns the claims of the token. The issuer, subject, audience
+// and custom claims are templates executed with the namespace of the generator.
+func tokenClaims(spec *enterprise.JWTSpec, namespace string, now, expiresAt time.Time) (jwt.MapClaims, error) {
+	data := map[string]string{
+		"namespace": namespace,
+	}
+	claims := jwt.MapClaims{
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": expiresAt.Unix(),
+		"jti": uuid.NewString(),
+	}
+	if spec.Issuer != "" {
+		iss, err := render("issuer", spec.Issuer, data)
+		if err != nil {
+			return nil, err
+		}
+		claims["iss"] = iss
+	}
+	if spec.Subject != "" {
+		sub, err := render("subject", spec.Subject, data)
+		if err != nil {
+			return nil, err
+		}
+		claims["sub"] = sub
+	}
+	if len(spec.Audience) > 0 {
+		aud := make([]string, 0, len(spec.Audience))
+		for _, tpl := range spec.Audience {
+			value, err := render("audience", tpl, data)
+			if err != nil {
+				return nil, err
+			}
+			aud = append(aud, value)
+		}
+		// A single audience is a string, as most verifiers expect.
+		if len(aud) == 1 {
+			claims["aud"] = aud[0]
+		} else {
+			claims["aud"] = aud
+		}
+	}
+	for name, tpl := range spec.Claims {
+		if _, ok := registeredClaims[name]; ok {
+			return nil, fmt.Errorf("claim %q is set by the generator", name)
+		}
+		value, err := render(name, tpl, data)
+		if err != nil {
+			return nil, err
+		}
+		claims[name] = value
+	}
+	return claims, nil
+}
+
+func render(name, tpl string, data map[string]string) (string, error) {
+	t, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(estemplate.FuncMap()).
+		Parse(tpl)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse template of claim %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("unable to execute template of claim %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+func parseSpec(data []byte) (*enterprise.JWT, error) {
+	var spec enterprise.JWT
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(enterprise.JWTKind, &Generator{})
+	genv1alpha1.RegisterGeneric(enterprise.JWTKind, &enterprise.JWT{})
+}
@@ -0,0 +1,263 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// /*
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	testNamespace = "default"
+	testSecret    = "signing-key"
+	testSecretKey = "key"
+)
+
+func encodeKey(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func generate(t *testing.T, spec *enterprise.JWTSpec, key []byte, now time.Time) (map[string][]byte, error) {
+	t.Helper()
+	spec.SigningKey.SecretRef = esmeta.SecretKeySelector{Name: testSecret, Key: testSecretKey}
+	raw, err := yaml.Marshal(&enterprise.JWT{Spec: *spec})
+	require.NoError(t, err)
+	kube := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testSecret},
+		Data:       map[string][]byte{testSecretKey: key},
+	}).Build()
+	g := &Generator{}
+	out, state, err := g.generate(context.Background(), &apiextensions.JSON{Raw: raw}, kube, testNamespace, now)
+	assert.Nil(t, state)
+	return out, err
+}
+
+// verifyWithJWKS verifies the token with the key of the JWKS matching its kid header.
+func verifyWithJWKS(t *testing.T, token, jwks []byte) jwt.MapClaims {
+	t.Helper()
+	set, err := jwk.Parse(jwks)
+	require.NoError(t, err)
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(string(token), claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := set.LookupKeyID(kid)
+		require.True(t, ok, "kid %q not found in JWKS", kid)
+		assert.Equal(t, token.Method.Alg(), key.Algorithm().String())
+		var pub any
+		require.NoError(t, key.Raw(&pub))
+		return pub, nil
+	})
+	require.NoError(t, err)
+	return claims
+}
+
+func TestGenerateAsymmetric(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		algorithm enterprise.JWTAlgorithm
+		key       []byte
+	}{
+		{name: "RS256", algorithm: enterprise.JWTAlgorithmRS256, key: encodeKey(t, rsaKey)},
+		{name: "RS256 PKCS#1", algorithm: enterprise.JWTAlgorithmRS256, key: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})},
+		{name: "ES256", algorithm: enterprise.JWTAlgorithmES256, key: encodeKey(t, ecKey)},
+		{name: "EdDSA", algorithm: enterprise.JWTAlgorithmEdDSA, key: encodeKey(t, edKey)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now().Truncate(time.Second)
+			out, err := generate(t, &enterprise.JWTSpec{
+				SigningKey: enterprise.JWTSigningKey{Algorithm: tt.algorithm},
+				Issuer:     "https://issuer.example.com",
+				Subject:    "system:serviceaccount:{{ .namespace }}:billing",
+				Audience:   []string{"orders"},
+				Claims:     map[string]string{"tenant": "{{ .namespace | upper }}"},
+				TTL:        metav1.Duration{Duration: 15 * time.Minute},
+			}, tt.key, now)
+			require.NoError(t, err)
+			assert.NotEmpty(t, out["kid"])
+			assert.Equal(t, now.Add(15*time.Minute).UTC().Format(time.RFC3339), string(out["expiresAt"]))
+
+			claims := verifyWithJWKS(t, out["token"], out["jwks"])
+			assert.Equal(t, "https://issuer.example.com", claims["iss"])
+			assert.Equal(t, "system:serviceaccount:default:billing", claims["sub"])
+			assert.Equal(t, "orders", claims["aud"])
+			assert.Equal(t, "DEFAULT", claims["tenant"])
+			assert.NotEmpty(t, claims["jti"])
+			assert.InDelta(t, float64(now.Unix()), claims["iat"], 0)
+			assert.InDelta(t, float64(now.Add(15*time.Minute).Unix()), claims["exp"], 0)
+		})
+	}
+}
+
+func TestGenerateKeyID(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	spec := &enterprise.JWTSpec{SigningKey: enterprise.JWTSigningKey{Algorithm: enterprise.JWTAlgorithmES256}}
+
+	// The default key ID is the thumbprint of the public key, stable across tokens.
+	first, err := generate(t, spec, encodeKey(t, key), time.Now())
+	require.NoError(t, err)
+	second, err := generate(t, spec, encodeKey(t, key), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, first["kid"], second["kid"])
+	assert.NotEqual(t, first["token"], second["token"])
+
+	spec.SigningKey.KeyID = "2025-01"
+	out, err := generate(t, spec, encodeKey(t, key), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", string(out["kid"]))
+	verifyWithJWKS(t, out["token"], out["jwks"])
+}
+
+func TestGenerateHS256(t *testing.T) {
+	secret := []byte(strings.Repeat("s", minHMACKeySize))
+	out, err := generate(t, &enterprise.JWTSpec{
+		SigningKey: enterprise.JWTSigningKey{Algorithm: enterprise.JWTAlgorithmHS256},
+		Audience:   []string{"orders", "payments"},
+	}, secret, time.Now())
+	require.NoError(t, err)
+	assert.NotContains(t, out, "jwks")
+	assert.NotContains(t, out, "kid")
+
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(string(out["token"]), claims, func(*jwt.Token) (any, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{"HS256"}))
+	require.NoError(t, err)
+	assert.NotContains(t, token.Header, "kid")
+	aud, err := claims.GetAudience()
+	require.NoError(t, err)
+	assert.Equal(t, jwt.ClaimStrings{"orders", "payments"}, aud)
+
+	_, err = generate(t, &enterprise.JWTSpec{
+		SigningKey: enterprise.JWTSigningKey{Algorithm: enterprise.JWTAlgorithmHS256},
+	}, []byte("short"), time.Now())
+	assert.EqualError(t, err, "HS256 requires a shared secret of at least 32 bytes")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		spec    enterprise.JWTSpec
+		key     []byte
+		wantErr string
+	}{
+		{
+			name:    "key type mismatch",
+			spec:    enterprise.JWTSpec{SigningKey: enterprise.JWTSigningKey{Algorithm: enterprise.JWTAlgorithmEdDSA}},
+			key:     encodeKey(t, rsaKey),
+			wantErr: "EdDSA requires an Ed25519 key",
+		},
+		{
+			name:    "wrong curve",
+			spec:    enterprise.JWTSpec{SigningKey: enterprise.JWTSigningKey{Algorithm: enterprise.JWTAlgorithmES256}},
+			key:     encodeKey(t, p384Key),
+			wantErr: "ES256 requires an ECDSA P-256 key",
+		},
+		{
+			name:    "RSA key too small",
+			spec:    enterprise.JWTSpec{SigningKey: enterprise.JWTSigningKey{Algorithm: enterprise.JWTAlgorithmRS256}},
+			key:     encodeKey(t, weakKey),
+			wantErr: "RS256 requires an RSA key of at least 2048 bits",
+		},
+		{
+			name:    "not PEM",
+			spec:    enterprise.JWTSpec{SigningKey: enterprise.JWTSigningKey{Algorithm: enterprise.JWTAlgorithmRS256}},
+			key:     []byte("secret"),
+			wantErr: "signing key is not PEM encoded",
+		},
+		{
+			name: "registered claim",
+			spec: enterprise.JWTSpec{
+				SigningKey: enterprise.JWTSigningKey{Algorithm: enterprise.JWTAlgorithmRS256},
+				Claims:     map[string]string{"exp": "0"},
+			},
+			key:     encodeKey(t, rsaKey),
+			wantErr: `claim "exp" is set by the generator`,
+		},
+		{
+			name: "missing template key",
+			spec: enterprise.JWTSpec{
+				SigningKey: enterprise.JWTSigningKey{Algorithm: enterprise.JWTAlgorithmRS256},
+				Subject:    "{{ .name }}",
+			},
+			key:     encodeKey(t, rsaKey),
+			wantErr: "unable to execute template of claim subject",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generate(t, &tt.spec, tt.key, time.Now())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
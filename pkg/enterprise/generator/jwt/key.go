@@ -0,0 +1,161 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+const (
+	// minHMACKeySize is the minimum size of HS256 shared secrets, as required by RFC 7518.
+	minHMACKeySize = 32
+	// minRSAKeyBits is the minimum size of RS256 keys, as required by RFC 7518.
+	minRSAKeyBits = 2048
+)
+
+// signingKey is a key that signs tokens.
+type signingKey struct {
+	method jwt.SigningMethod
+	signer any
+	// id is the kid header of the tokens.
+	id string
+	// jwks is the JSON encoded JSON Web Key Set with the public key, nil for HS256.
+	jwks []byte
+}
+
+func loadSigningKey(ctx context.Context, spec *enterprise.JWTSigningKey, kclient client.Client, ns string) (*signingKey, error) {
+	value, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      spec.SecretRef.Name,
+		Key:       spec.SecretRef.Key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get signing key: %w", err)
+	}
+	return parseSigningKey(spec, []byte(value))
+}
+
+func parseSigningKey(spec *enterprise.JWTSigningKey, data []byte) (*signingKey, error) {
+	if spec.Algorithm == enterprise.JWTAlgorithmHS256 {
+		if len(data) < minHMACKeySize {
+			return nil, fmt.Errorf("HS256 requires a shared secret of at least %d bytes", minHMACKeySize)
+		}
+		return &signingKey{method: jwt.SigningMethodHS256, signer: data, id: spec.KeyID}, nil
+	}
+
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+	var method jwt.SigningMethod
+	var alg jwa.SignatureAlgorithm
+	switch spec.Algorithm {
+	case enterprise.JWTAlgorithmRS256, "":
+		k, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("RS256 requires an RSA key")
+		}
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RS256 requires an RSA key of at least %d bits", minRSAKeyBits)
+		}
+		method, alg = jwt.SigningMethodRS256, jwa.RS256
+	case enterprise.JWTAlgorithmES256:
+		if k, ok := key.(*ecdsa.PrivateKey); !ok || k.Curve != elliptic.P256() {
+			return nil, errors.New("ES256 requires an ECDSA P-256 key")
+		}
+		method, alg = jwt.SigningMethodES256, jwa.ES256
+	case enterprise.JWTAlgorithmEdDSA:
+		if _, ok := key.(ed25519.PrivateKey); !ok {
+			return nil, errors.New("EdDSA requires an Ed25519 key")
+		}
+		method, alg = jwt.SigningMethodEdDSA, jwa.EdDSA
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", spec.Algorithm)
+	}
+
+	pub, err := jwk.FromRaw(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create JSON Web Key: %w", err)
+	}
+	if spec.KeyID == "" {
+		err = jwk.AssignKeyID(pub)
+	} else {
+		err = pub.Set(jwk.KeyIDKey, spec.KeyID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to set key ID: %w", err)
+	}
+	if err := pub.Set(jwk.AlgorithmKey, alg); err != nil {
+		return nil, fmt.Errorf("unable to set key algorithm: %w", err)
+	}
+	if err := pub.Set(jwk.KeyUsageKey, jwk.ForSignature); err != nil {
+		return nil, fmt.Errorf("unable to set key usage: %w", err)
+	}
+	set := jwk.NewSet()
+	if err := set.AddKey(pub); err != nil {
+		return nil, fmt.Errorf("unable to create JSON Web Key Set: %w", err)
+	}
+	jwks, err := json.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal JSON Web Key Set: %w", err)
+	}
+	return &signingKey{method: method, signer: key, id: pub.KeyID(), jwks: jwks}, nil
+}
+
+// parsePrivateKey parses a PEM encoded PKCS#1, SEC 1 or PKCS#8 private key.
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("signing key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signing key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+	return signer, nil
+}
@@ -0,0 +1,492 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+// Package kafka implements Kafka SCRAM user and ACL generator.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/generators/v1/password"
+	utils "github.com/external-secrets/external-secrets/runtime/esutils"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+// Generator implements the Kafka SCRAM user and ACL generator.
+type Generator struct{}
+
+const (
+	defaultSuffixSize = 8
+	defaultIterations = 8192
+	jaasConfigFmt     = `org.apache.kafka.common.security.scram.ScramLoginModule required username="%s" password="%s";`
+)
+
+var (
+	// usernameRegexp matches the usernames that can be used in a principal and a JAAS config as is.
+	usernameRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+	operations = map[string]kadm.ACLOperation{
+		"All":             kadm.OpAll,
+		"Read":            kadm.OpRead,
+		"Write":           kadm.OpWrite,
+		"Create":          kadm.OpCreate,
+		"Delete":          kadm.OpDelete,
+		"Alter":           kadm.OpAlter,
+		"Describe":        kadm.OpDescribe,
+		"ClusterAction":   kadm.OpClusterAction,
+		"DescribeConfigs": kadm.OpDescribeConfigs,
+		"AlterConfigs":    kadm.OpAlterConfigs,
+		"IdempotentWrite": kadm.OpIdempotentWrite,
+	}
+)
+
+// Generate creates SCRAM credentials for a new user and binds the ACLs of the spec to its principal.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	username, err := generateUsername(&res.Spec.User)
+	if err != nil {
+		return nil, nil, err
+	}
+	acls, err := aclBuilders(res.Spec.User.ACLs, principal(username))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adm, err := newClient(ctx, &res.Spec, kube, namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create kafka client: %w", err)
+	}
+	defer adm.Close()
+
+	user, err := createUser(ctx, adm, &res.Spec, username, acls)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create user: %w", err)
+	}
+
+	rawState, err := json.Marshal(&enterprise.KafkaUserState{
+		Username:  username,
+		Mechanism: scramMechanism(&res.Spec.User),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal state: %w", err)
+	}
+
+	return user, &apiextensions.JSON{Raw: rawState}, nil
+}
+
+// Cleanup deletes the ACLs and the SCRAM credentials of the user.
+func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, previousStatus genv1alpha1.GeneratorProviderState, kclient client.Client, namespace string) error {
+	if previousStatus == nil {
+		return fmt.Errorf("missing previous status")
+	}
+	status, err := parseStatus(previousStatus.Raw)
+	if err != nil {
+		return err
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return err
+	}
+	adm, err := newClient(ctx, &res.Spec, kclient, namespace)
+	if err != nil {
+		return err
+	}
+	defer adm.Close()
+
+	return deleteUser(ctx, adm, status.Username, status.Mechanism)
+}
+
+// GetCleanupPolicy returns the cleanup policy for this generator.
+// The idle policy is rejected, as the activity of a user is not tracked.
+func (g *Generator) GetCleanupPolicy(obj *apiextensions.JSON) (*genv1alpha1.CleanupPolicy, error) {
+	res, err := parseSpec(obj.Raw)
+	if err != nil {
+		return nil, err
+	}
+	if res.Spec.CleanupPolicy != nil && res.Spec.CleanupPolicy.Type == genv1alpha1.IdleCleanupPolicy {
+		return nil, errors.New("the idle cleanup policy is not supported by the Kafka generator")
+	}
+	return res.Spec.CleanupPolicy, nil
+}
+
+// LastActivityTime returns the last activity time for generated resources.
+// Kafka does not expose the activity of a principal, so it is not tracked.
+func (g *Generator) LastActivityTime(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) (time.Time, bool, error) {
+	return time.Time{}, false, nil
+}
+
+// GetKeys returns the keys generated by this generator.
+func (g *Generator) GetKeys() map[string]string {
+	return map[string]string{
+		"bootstrapServers": "Comma separated list of the Kafka bootstrap servers",
+		"username":         "Kafka SCRAM username",
+		"password":         "Kafka SCRAM password",
+		"mechanism":        "SASL mechanism of the credentials",
+		"jaasConfig":       "JAAS configuration of the SCRAM login module, for the sasl.jaas.config property",
+	}
+}
+
+func newClient(ctx context.Context, spec *enterprise.KafkaSpec, kclient client.Client, ns string) (*kadm.Client, error) {
+	opts := []kgo.Opt{kgo.SeedBrokers(spec.BootstrapServers...)}
+
+	tlsConfig, err := newTLSConfig(ctx, spec.TLS, kclient, ns)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure tls: %w", err)
+	}
+	if tlsConfig != nil {
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+	if spec.Auth != nil {
+		mechanism, err := saslMechanism(ctx, spec.Auth, kclient, ns)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+
+	cl, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return kadm.NewClient(cl), nil
+}
+
+func saslMechanism(ctx context.Context, auth *enterprise.KafkaAuth, kclient client.Client, ns string) (sasl.Mechanism, error) {
+	pass, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      auth.Password.Name,
+		Key:       auth.Password.Key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch auth.Mechanism {
+	case enterprise.KafkaSASLMechanismPlain:
+		return plain.Auth{User: auth.Username, Pass: pass}.AsMechanism(), nil
+	case enterprise.KafkaSASLMechanismScramSHA256:
+		return scram.Auth{User: auth.Username, Pass: pass}.AsSha256Mechanism(), nil
+	case enterprise.KafkaSASLMechanismScramSHA512, "":
+		return scram.Auth{User: auth.Username, Pass: pass}.AsSha512Mechanism(), nil
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism: %s", auth.Mechanism)
+	}
+}
+
+func generateUsername(user *enterprise.KafkaUser) (string, error) {
+	if !usernameRegexp.MatchString(user.Username) {
+		return "", fmt.Errorf("invalid username %q", user.Username)
+	}
+	suffixSize := defaultSuffixSize
+	if user.SuffixSize != nil {
+		suffixSize = *user.SuffixSize
+	}
+	suffix, err := utils.GenerateRandomString(suffixSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random suffix: %w", err)
+	}
+	if suffix == "" {
+		return user.Username, nil
+	}
+	return fmt.Sprintf("%s_%s", user.Username, suffix), nil
+}
+
+func principal(username string) string {
+	return "User:" + username
+}
+
+func scramMechanism(user *enterprise.KafkaUser) enterprise.KafkaScramMechanism {
+	if user.Mechanism == "" {
+		return enterprise.KafkaScramMechanismSHA512
+	}
+	return user.Mechanism
+}
+
+func toScramMechanism(mechanism enterprise.KafkaScramMechanism) (kadm.ScramMechanism, error) {
+	switch mechanism {
+	case enterprise.KafkaScramMechanismSHA256:
+		return kadm.ScramSha256, nil
+	case enterprise.KafkaScramMechanismSHA512:
+		return kadm.ScramSha512, nil
+	default:
+		return 0, fmt.Errorf("unsupported SCRAM mechanism: %s", mechanism)
+	}
+}
+
+// aclBuilders returns a builder for each ACL of the spec, bound to the principal.
+func aclBuilders(acls []enterprise.KafkaACL, principal string) ([]*kadm.ACLBuilder, error) {
+	builders := make([]*kadm.ACLBuilder, 0, len(acls))
+	for i, acl := range acls {
+		b := kadm.NewACLs()
+		if acl.ResourceType != enterprise.KafkaResourceTypeCluster && acl.Name == "" {
+			return nil, fmt.Errorf("acls[%d]: name is required for resource type %s", i, acl.ResourceType)
+		}
+		switch acl.ResourceType {
+		case enterprise.KafkaResourceTypeTopic:
+			b.Topics(acl.Name)
+		case enterprise.KafkaResourceTypeGroup:
+			b.Groups(acl.Name)
+		case enterprise.KafkaResourceTypeTransactionalID:
+			b.TransactionalIDs(acl.Name)
+		case enterprise.KafkaResourceTypeCluster:
+			b.Clusters()
+		default:
+			return nil, fmt.Errorf("acls[%d]: unsupported resource type %q", i, acl.ResourceType)
+		}
+
+		switch acl.PatternType {
+		case enterprise.KafkaPatternTypeLiteral, "":
+			b.ResourcePatternType(kadm.ACLPatternLiteral)
+		case enterprise.KafkaPatternTypePrefixed:
+			b.ResourcePatternType(kadm.ACLPatternPrefixed)
+		default:
+			return nil, fmt.Errorf("acls[%d]: unsupported pattern type %q", i, acl.PatternType)
+		}
+
+		ops := make([]kadm.ACLOperation, 0, len(acl.Operations))
+		for _, name := range acl.Operations {
+			op, ok := operations[name]
+			if !ok {
+				return nil, fmt.Errorf("acls[%d]: unsupported operation %q", i, name)
+			}
+			ops = append(ops, op)
+		}
+		if len(ops) == 0 {
+			return nil, fmt.Errorf("acls[%d]: at least one operation is required", i)
+		}
+		b.Operations(ops...)
+
+		var hosts []string
+		if acl.Host != "" {
+			hosts = []string{acl.Host}
+		}
+		switch acl.Permission {
+		case enterprise.KafkaACLPermissionAllow, "":
+			b.Allow(principal).MaybeAllowHosts(hosts...)
+		case enterprise.KafkaACLPermissionDeny:
+			b.Deny(principal).MaybeDenyHosts(hosts...)
+		default:
+			return nil, fmt.Errorf("acls[%d]: unsupported permission %q", i, acl.Permission)
+		}
+
+		if err := b.ValidateCreate(); err != nil {
+			return nil, fmt.Errorf("acls[%d]: %w", i, err)
+		}
+		builders = append(builders, b)
+	}
+	return builders, nil
+}
+
+func createUser(ctx context.Context, adm *kadm.Client, spec *enterprise.KafkaSpec, username string, acls []*kadm.ACLBuilder) (map[string][]byte, error) {
+	mechanism := scramMechanism(&spec.User)
+	scramMech, err := toScramMechanism(mechanism)
+	if err != nil {
+		return nil, err
+	}
+	iterations := spec.User.Iterations
+	if iterations == 0 {
+		iterations = defaultIterations
+	}
+
+	// The password is alphanumeric, so it can be embedded in a JAAS config without escaping.
+	pass, err := generatePassword(genv1alpha1.Password{
+		Spec: genv1alpha1.PasswordSpec{
+			Length:      32,
+			Symbols:     new(int),
+			AllowRepeat: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	altered, err := adm.AlterUserSCRAMs(ctx, nil, []kadm.UpsertSCRAM{{
+		User:       username,
+		Mechanism:  scramMech,
+		Iterations: iterations,
+		Password:   string(pass),
+	}})
+	if err == nil {
+		err = alteredError(altered)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SCRAM credentials: %w", err)
+	}
+
+	for _, b := range acls {
+		created, err := adm.CreateACLs(ctx, b)
+		if err == nil {
+			err = createdError(created)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to create ACLs: %w", err)
+			// Do not leave credentials without the ACLs they were requested with.
+			if delErr := deleteUser(ctx, adm, username, mechanism); delErr != nil {
+				err = errors.Join(err, delErr)
+			}
+			return nil, err
+		}
+	}
+
+	return map[string][]byte{
+		"bootstrapServers": []byte(strings.Join(spec.BootstrapServers, ",")),
+		"username":         []byte(username),
+		"password":         pass,
+		"mechanism":        []byte(mechanism),
+		"jaasConfig":       []byte(jaasConfig(username, string(pass))),
+	}, nil
+}
+
+// deleteUser deletes every ACL of the principal of the user and its SCRAM credentials.
+// Deleting a user that does not exist anymore is not an error.
+func deleteUser(ctx context.Context, adm *kadm.Client, username string, mechanism enterprise.KafkaScramMechanism) error {
+	p := principal(username)
+	filter := kadm.NewACLs().
+		AnyResource().
+		ResourcePatternType(kadm.ACLPatternAny).
+		Operations().
+		Allow(p).AllowHosts().
+		Deny(p).DenyHosts()
+	deleted, err := adm.DeleteACLs(ctx, filter)
+	if err == nil {
+		err = deletedError(deleted)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete ACLs: %w", err)
+	}
+
+	scramMech, err := toScramMechanism(mechanism)
+	if err != nil {
+		return err
+	}
+	altered, err := adm.AlterUserSCRAMs(ctx, []kadm.DeleteSCRAM{{
+		User:      username,
+		Mechanism: scramMech,
+	}}, nil)
+	if err == nil {
+		err = alteredError(altered)
+	}
+	if err != nil && !errors.Is(err, kerr.ResourceNotFound) {
+		return fmt.Errorf("failed to delete SCRAM credentials: %w", err)
+	}
+	return nil
+}
+
+func alteredError(altered kadm.AlteredUserSCRAMs) error {
+	for _, a := range altered.Sorted() {
+		if a.Err != nil {
+			return withMessage(a.Err, a.ErrMessage)
+		}
+	}
+	return nil
+}
+
+func createdError(created kadm.CreateACLsResults) error {
+	for _, c := range created {
+		if c.Err != nil {
+			return withMessage(fmt.Errorf("%s %s on %s %q: %w", c.Permission, c.Operation, c.Type, c.Name, c.Err), c.ErrMessage)
+		}
+	}
+	return nil
+}
+
+func deletedError(deleted kadm.DeleteACLsResults) error {
+	for _, d := range deleted {
+		if d.Err != nil {
+			return withMessage(d.Err, d.ErrMessage)
+		}
+		for _, acl := range d.Deleted {
+			if acl.Err != nil {
+				return withMessage(acl.Err, acl.ErrMessage)
+			}
+		}
+	}
+	return nil
+}
+
+func withMessage(err error, msg string) error {
+	if msg == "" {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, msg)
+}
+
+func jaasConfig(username, pass string) string {
+	return fmt.Sprintf(jaasConfigFmt, username, pass)
+}
+
+func generatePassword(
+	passSpec genv1alpha1.Password,
+) ([]byte, error) {
+	gen := password.Generator{}
+	rawPassSpec, err := yaml.Marshal(passSpec)
+	if err != nil {
+		return nil, err
+	}
+	passMap, _, err := gen.Generate(context.TODO(), &apiextensions.JSON{Raw: rawPassSpec}, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	pass, ok := passMap["password"]
+	if !ok {
+		return nil, fmt.Errorf("password not found in generated map")
+	}
+	return pass, nil
+}
+
+func parseSpec(data []byte) (*enterprise.Kafka, error) {
+	var spec enterprise.Kafka
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func parseStatus(data []byte) (*enterprise.KafkaUserState, error) {
+	var state enterprise.KafkaUserState
+	err := json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+	return &state, err
+}
+
+func init() {
+	genv1alpha1.Register(enterprise.KafkaKind, &Generator{})
+	genv1alpha1.RegisterGeneric(enterprise.KafkaKind, &enterprise.Kafka{})
+}
@@ -0,0 +1,275 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// /*
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	tckafka "github.com/testcontainers/testcontainers-go/modules/kafka"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+const (
+	testPass       = "strongpassword"
+	testNamespace  = "default"
+	testSecretName = "testpass"
+	testSecretKey  = "password"
+)
+
+type generatorMockClient struct {
+	client.Client
+}
+
+func (m generatorMockClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	if key.Name == testSecretName {
+		obj.(*corev1.Secret).Data = map[string][]byte{
+			testSecretKey: []byte(testPass),
+		}
+	}
+	return nil
+}
+
+func TestGenerateUsername(t *testing.T) {
+	username, err := generateUsername(&enterprise.KafkaUser{Username: "orders"})
+	require.NoError(t, err)
+	assert.Regexp(t, `^orders_[a-zA-Z0-9]{8}$`, username)
+
+	username, err = generateUsername(&enterprise.KafkaUser{Username: "orders", SuffixSize: ptr.To(0)})
+	require.NoError(t, err)
+	assert.Equal(t, "orders", username)
+
+	_, err = generateUsername(&enterprise.KafkaUser{Username: `orders" password="x`})
+	assert.ErrorContains(t, err, "invalid username")
+}
+
+func TestACLBuilders(t *testing.T) {
+	tests := []struct {
+		name    string
+		acls    []enterprise.KafkaACL
+		wantErr string
+	}{
+		{
+			name: "valid",
+			acls: []enterprise.KafkaACL{
+				{ResourceType: enterprise.KafkaResourceTypeTopic, Name: "orders", Operations: []string{"Read", "Describe"}},
+				{ResourceType: enterprise.KafkaResourceTypeGroup, Name: "orders-", PatternType: enterprise.KafkaPatternTypePrefixed, Operations: []string{"Read"}},
+				{ResourceType: enterprise.KafkaResourceTypeCluster, Operations: []string{"IdempotentWrite"}, Permission: enterprise.KafkaACLPermissionDeny, Host: "10.0.0.1"},
+			},
+		},
+		{
+			name:    "missing name",
+			acls:    []enterprise.KafkaACL{{ResourceType: enterprise.KafkaResourceTypeTopic, Operations: []string{"Read"}}},
+			wantErr: "acls[0]: name is required for resource type Topic",
+		},
+		{
+			name:    "unknown operation",
+			acls:    []enterprise.KafkaACL{{ResourceType: enterprise.KafkaResourceTypeTopic, Name: "orders", Operations: []string{"Any"}}},
+			wantErr: `acls[0]: unsupported operation "Any"`,
+		},
+		{
+			name:    "missing operations",
+			acls:    []enterprise.KafkaACL{{ResourceType: enterprise.KafkaResourceTypeTopic, Name: "orders"}},
+			wantErr: "acls[0]: at least one operation is required",
+		},
+		{
+			name:    "unknown resource type",
+			acls:    []enterprise.KafkaACL{{ResourceType: "DelegationToken", Name: "token", Operations: []string{"Describe"}}},
+			wantErr: `acls[0]: unsupported resource type "DelegationToken"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builders, err := aclBuilders(tt.acls, principal("orders"))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, builders, len(tt.acls))
+		})
+	}
+}
+
+func TestJAASConfig(t *testing.T) {
+	assert.Equal(t,
+		`org.apache.kafka.common.security.scram.ScramLoginModule required username="orders_abc" password="secret";`,
+		jaasConfig("orders_abc", "secret"))
+}
+
+type KafkaTestSuite struct {
+	suite.Suite
+	ctx     context.Context
+	client  generatorMockClient
+	adm     *kadm.Client
+	brokers []string
+}
+
+func TestGetCleanupPolicy(t *testing.T) {
+	policy := func(policy *genv1alpha1.CleanupPolicy) *apiextensions.JSON {
+		raw, err := yaml.Marshal(&enterprise.Kafka{Spec: enterprise.KafkaSpec{CleanupPolicy: policy}})
+		require.NoError(t, err)
+		return &apiextensions.JSON{Raw: raw}
+	}
+	gen := &Generator{}
+
+	got, err := gen.GetCleanupPolicy(policy(nil))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = gen.GetCleanupPolicy(policy(&genv1alpha1.CleanupPolicy{Type: genv1alpha1.RetainLatestPolicy}))
+	require.NoError(t, err)
+	assert.Equal(t, genv1alpha1.RetainLatestPolicy, got.Type)
+
+	// The activity of a user is not tracked, so idle users would never be cleaned up.
+	_, err = gen.GetCleanupPolicy(policy(&genv1alpha1.CleanupPolicy{Type: genv1alpha1.IdleCleanupPolicy}))
+	assert.ErrorContains(t, err, "idle cleanup policy is not supported")
+}
+
+func TestKafkaGeneratorTestSuite(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	suite.Run(t, new(KafkaTestSuite))
+}
+
+func (s *KafkaTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	// ACLs require an authorizer. Clients of the plaintext listener are anonymous,
+	// so they are made super users to administer the broker.
+	container, err := tckafka.Run(s.ctx,
+		"confluentinc/confluent-local:7.6.0",
+		testcontainers.WithEnv(map[string]string{
+			"KAFKA_AUTHORIZER_CLASS_NAME": "org.apache.kafka.metadata.authorizer.StandardAuthorizer",
+			"KAFKA_SUPER_USERS":           "User:ANONYMOUS",
+		}),
+	)
+	require.NoError(s.T(), err)
+	s.brokers, err = container.Brokers(s.ctx)
+	require.NoError(s.T(), err)
+
+	cl, err := kgo.NewClient(kgo.SeedBrokers(s.brokers...))
+	require.NoError(s.T(), err)
+	s.adm = kadm.NewClient(cl)
+
+	s.T().Cleanup(func() {
+		s.adm.Close()
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			s.T().Logf("failed to terminate container: %s", err)
+		}
+	})
+}
+
+func (s *KafkaTestSuite) newGeneratorSpec() *enterprise.Kafka {
+	return &enterprise.Kafka{
+		Spec: enterprise.KafkaSpec{
+			BootstrapServers: s.brokers,
+			User: enterprise.KafkaUser{
+				Username:  "orders",
+				Mechanism: enterprise.KafkaScramMechanismSHA256,
+				ACLs: []enterprise.KafkaACL{
+					{ResourceType: enterprise.KafkaResourceTypeTopic, Name: "orders", Operations: []string{"Read", "Write", "Describe"}},
+					{ResourceType: enterprise.KafkaResourceTypeGroup, Name: "orders-", PatternType: enterprise.KafkaPatternTypePrefixed, Operations: []string{"Read"}},
+				},
+			},
+		},
+	}
+}
+
+func (s *KafkaTestSuite) acls(username string) kadm.DescribedACLs {
+	p := principal(username)
+	described, err := s.adm.DescribeACLs(s.ctx, kadm.NewACLs().
+		AnyResource().
+		ResourcePatternType(kadm.ACLPatternAny).
+		Operations().
+		Allow(p).AllowHosts().
+		Deny(p).DenyHosts())
+	require.NoError(s.T(), err)
+	var acls kadm.DescribedACLs
+	for _, d := range described {
+		require.NoError(s.T(), d.Err)
+		acls = append(acls, d.Described...)
+	}
+	return acls
+}
+
+func (s *KafkaTestSuite) TestGenerateAndCleanup() {
+	t := s.T()
+	spec := s.newGeneratorSpec()
+	specJSON, err := yaml.Marshal(spec)
+	require.NoError(t, err)
+
+	gen := &Generator{}
+	result, state, err := gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	require.NoError(t, err)
+	username := string(result["username"])
+	assert.Regexp(t, `^orders_[a-zA-Z0-9]{8}$`, username)
+	assert.Equal(t, s.brokers[0], string(result["bootstrapServers"]))
+	assert.Equal(t, "SCRAM-SHA-256", string(result["mechanism"]))
+	assert.Contains(t, string(result["jaasConfig"]), `password="`+string(result["password"])+`"`)
+
+	described, err := s.adm.DescribeUserSCRAMs(s.ctx, username)
+	require.NoError(t, err)
+	require.NoError(t, described[username].Err)
+	require.Len(t, described[username].CredInfos, 1)
+	assert.Equal(t, kadm.ScramSha256, described[username].CredInfos[0].Mechanism)
+	assert.Equal(t, int32(defaultIterations), described[username].CredInfos[0].Iterations)
+
+	acls := s.acls(username)
+	assert.Len(t, acls, 4)
+	for _, acl := range acls {
+		assert.Equal(t, kmsg.ACLPermissionTypeAllow, acl.Permission)
+		assert.Equal(t, "*", acl.Host)
+	}
+
+	require.NoError(t, gen.Cleanup(s.ctx, &apiextensions.JSON{Raw: specJSON}, state, s.client, testNamespace))
+	assert.Empty(t, s.acls(username))
+	described, err = s.adm.DescribeUserSCRAMs(s.ctx, username)
+	require.NoError(t, err)
+	assert.ErrorIs(t, described[username].Err, kerr.ResourceNotFound)
+
+	// Cleaning up a deleted user succeeds.
+	require.NoError(t, gen.Cleanup(s.ctx, &apiextensions.JSON{Raw: specJSON}, state, s.client, testNamespace))
+}
@@ -0,0 +1,54 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/generator/tlsutil"
+)
+
+// newTLSConfig returns the TLS configuration of the connections, or nil if TLS is disabled.
+// The server name is set by the client to the host of each broker it dials.
+func newTLSConfig(ctx context.Context, spec *enterprise.KafkaTLS, kclient client.Client, ns string) (*tls.Config, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	roots, err := tlsutil.CAPool(ctx, spec.CABundle, spec.CAProvider, kclient, ns)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		RootCAs:            roots,
+		InsecureSkipVerify: spec.InsecureSkipVerify, //nolint:gosec // opt-in for brokers with self-signed certificates
+	}
+	if spec.ClientCertificate != nil || spec.ClientKey != nil {
+		cert, err := tlsutil.ClientCertificate(ctx, spec.ClientCertificate, spec.ClientKey, kclient, ns)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{*cert}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,419 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+// Package ldap implements LDAP and Active Directory password generator.
+package ldap
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+	"unicode/utf16"
+
+	"github.com/go-ldap/ldap/v3"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/generators/v1/password"
+	utils "github.com/external-secrets/external-secrets/runtime/esutils"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+// Generator implements the LDAP password generator.
+type Generator struct{}
+
+const (
+	defaultSuffixSize = 8
+	dialTimeout       = 10 * time.Second
+
+	// maxSAMAccountNameLength is the maximum length of the pre-Windows 2000 logon name.
+	maxSAMAccountNameLength = 20
+	// adNormalAccount is the userAccountControl of an enabled Active Directory user.
+	adNormalAccount = "512"
+)
+
+var (
+	defaultObjectClasses = map[enterprise.LDAPDialect][]string{
+		enterprise.LDAPDialectActiveDirectory: {"top", "person", "organizationalPerson", "user"},
+		enterprise.LDAPDialectOpenLDAP:        {"top", "person", "organizationalPerson", "inetOrgPerson"},
+	}
+	// usernameAttributes are the attributes with the logon name of the users.
+	usernameAttributes = map[enterprise.LDAPDialect]string{
+		enterprise.LDAPDialectActiveDirectory: "sAMAccountName",
+		enterprise.LDAPDialectOpenLDAP:        "uid",
+	}
+)
+
+// Generate sets a new password on the entry, creating it first if users are created in an OU.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	spec := &res.Spec
+	if err := validateSpec(spec); err != nil {
+		return nil, nil, err
+	}
+
+	pass, err := generatePassword(spec.Password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	conn, err := newConnection(ctx, spec, kube, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeConnection(conn)
+
+	var username, dn string
+	created := spec.User.OU != ""
+	if created {
+		username, dn, err = createUser(conn, spec, string(pass))
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create user: %w", err)
+		}
+	} else {
+		dn = spec.User.DN
+		username, err = lookupUsername(conn, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := setPassword(conn, spec, dn, string(pass)); err != nil {
+			return nil, nil, fmt.Errorf("unable to set password: %w", err)
+		}
+	}
+
+	rawState, err := json.Marshal(&enterprise.LDAPUserState{
+		DN:      dn,
+		Created: created,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal state: %w", err)
+	}
+	return map[string][]byte{
+		"username": []byte(username),
+		"password": pass,
+		"dn":       []byte(dn),
+	}, &apiextensions.JSON{Raw: rawState}, nil
+}
+
+// Cleanup deletes the users created by the generator.
+// Existing entries are left in place with their last password.
+func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, previousStatus genv1alpha1.GeneratorProviderState, kclient client.Client, namespace string) error {
+	if previousStatus == nil {
+		return fmt.Errorf("missing previous status")
+	}
+	status, err := parseStatus(previousStatus.Raw)
+	if err != nil {
+		return err
+	}
+	if !status.Created {
+		return nil
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return err
+	}
+	conn, err := newConnection(ctx, &res.Spec, kclient, namespace)
+	if err != nil {
+		return err
+	}
+	defer closeConnection(conn)
+
+	return deleteUser(conn, status.DN)
+}
+
+// GetCleanupPolicy returns the cleanup policy for this generator.
+func (g *Generator) GetCleanupPolicy(obj *apiextensions.JSON) (*genv1alpha1.CleanupPolicy, error) {
+	res, err := parseSpec(obj.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return res.Spec.CleanupPolicy, nil
+}
+
+// LastActivityTime returns the last activity time for generated resources.
+func (g *Generator) LastActivityTime(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) (time.Time, bool, error) {
+	return time.Time{}, false, nil
+}
+
+// GetKeys returns the keys generated by this generator.
+func (g *Generator) GetKeys() map[string]string {
+	return map[string]string{
+		"username": "Logon name of the user: the sAMAccountName in Active Directory, and the uid in OpenLDAP",
+		"password": "Password of the user",
+		"dn":       "DN of the user",
+	}
+}
+
+func validateSpec(spec *enterprise.LDAPSpec) error {
+	u, err := url.Parse(spec.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	switch u.Scheme {
+	case "ldaps":
+		if spec.TLS.StartTLS {
+			return errors.New("startTLS cannot be used with ldaps:// URLs")
+		}
+	case "ldap":
+		if !spec.TLS.StartTLS {
+			return errors.New("ldap:// URLs require startTLS, passwords are only sent over TLS")
+		}
+	default:
+		return fmt.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+
+	user := &spec.User
+	if (user.DN == "") == (user.OU == "") {
+		return errors.New("exactly one of user.dn and user.ou must be set")
+	}
+	if user.OU != "" && user.Username == "" {
+		return errors.New("user.username is required to create users")
+	}
+	if user.OU != "" && dialect(spec) == enterprise.LDAPDialectActiveDirectory {
+		if n := len(user.Username) + 1 + suffixSize(user); n > maxSAMAccountNameLength {
+			return fmt.Errorf("username with its suffix is %d characters long, Active Directory allows %d", n, maxSAMAccountNameLength)
+		}
+	}
+	return nil
+}
+
+func dialect(spec *enterprise.LDAPSpec) enterprise.LDAPDialect {
+	if spec.Dialect == "" {
+		return enterprise.LDAPDialectOpenLDAP
+	}
+	return spec.Dialect
+}
+
+func suffixSize(user *enterprise.LDAPUser) int {
+	if user.SuffixSize != nil {
+		return *user.SuffixSize
+	}
+	return defaultSuffixSize
+}
+
+func newConnection(ctx context.Context, spec *enterprise.LDAPSpec, kclient client.Client, ns string) (*ldap.Conn, error) {
+	password, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      spec.Auth.Password.Name,
+		Key:       spec.Auth.Password.Key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	tlsConfig, err := newTLSConfig(ctx, &spec.TLS, u.Hostname(), kclient, ns)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure tls: %w", err)
+	}
+
+	conn, err := ldap.DialURL(spec.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the server: %w", err)
+	}
+	if spec.TLS.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			closeConnection(conn)
+			return nil, fmt.Errorf("unable to start tls: %w", err)
+		}
+	}
+	if err := conn.Bind(spec.Auth.BindDN, password); err != nil {
+		closeConnection(conn)
+		return nil, fmt.Errorf("unable to bind: %w", err)
+	}
+	return conn, nil
+}
+
+func closeConnection(conn *ldap.Conn) {
+	if err := conn.Close(); err != nil {
+		fmt.Printf("failed to close ldap connection: %v", err)
+	}
+}
+
+// createUser adds a user to the OU and sets its password. Active Directory users are
+// created enabled with their password in a single operation, as the domain rejects
+// enabled accounts without a password.
+func createUser(conn *ldap.Conn, spec *enterprise.LDAPSpec, pass string) (string, string, error) {
+	user := &spec.User
+	username := user.Username
+	suffix, err := utils.GenerateRandomString(suffixSize(user))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate random suffix: %w", err)
+	}
+	if suffix != "" {
+		username = fmt.Sprintf("%s_%s", username, suffix)
+	}
+	dn := fmt.Sprintf("cn=%s,%s", ldap.EscapeDN(username), user.OU)
+
+	objectClasses := user.ObjectClasses
+	if len(objectClasses) == 0 {
+		objectClasses = defaultObjectClasses[dialect(spec)]
+	}
+	req := ldap.NewAddRequest(dn, nil)
+	req.Attribute("objectClass", objectClasses)
+	req.Attribute("cn", []string{username})
+	for name, values := range user.Attributes {
+		req.Attribute(name, values)
+	}
+
+	ad := dialect(spec) == enterprise.LDAPDialectActiveDirectory
+	if ad {
+		req.Attribute("sAMAccountName", []string{username})
+		req.Attribute("userAccountControl", []string{adNormalAccount})
+		req.Attribute("unicodePwd", []string{encodeUnicodePwd(pass)})
+	} else {
+		req.Attribute("uid", []string{username})
+		if _, ok := user.Attributes["sn"]; !ok {
+			req.Attribute("sn", []string{username})
+		}
+	}
+	if err := conn.Add(req); err != nil {
+		return "", "", err
+	}
+	if ad {
+		return username, dn, nil
+	}
+
+	if err := setPassword(conn, spec, dn, pass); err != nil {
+		// Do not leave a user without the generated password.
+		if delErr := deleteUser(conn, dn); delErr != nil {
+			err = errors.Join(err, delErr)
+		}
+		return "", "", fmt.Errorf("unable to set password: %w", err)
+	}
+	return username, dn, nil
+}
+
+func deleteUser(conn *ldap.Conn, dn string) error {
+	err := conn.Del(ldap.NewDelRequest(dn, nil))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return fmt.Errorf("unable to delete user: %w", err)
+	}
+	return nil
+}
+
+// lookupUsername returns the logon name of an existing entry, or the value of its RDN
+// if it has none.
+func lookupUsername(conn *ldap.Conn, spec *enterprise.LDAPSpec) (string, error) {
+	attr := usernameAttributes[dialect(spec)]
+	res, err := conn.Search(ldap.NewSearchRequest(
+		spec.User.DN, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, 0, false,
+		"(objectClass=*)", []string{attr}, nil,
+	))
+	if err != nil {
+		return "", fmt.Errorf("unable to find user %s: %w", spec.User.DN, err)
+	}
+	if len(res.Entries) != 1 {
+		return "", fmt.Errorf("unable to find user %s", spec.User.DN)
+	}
+	if username := res.Entries[0].GetAttributeValue(attr); username != "" {
+		return username, nil
+	}
+	dn, err := ldap.ParseDN(spec.User.DN)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return "", fmt.Errorf("invalid dn %q", spec.User.DN)
+	}
+	return dn.RDNs[0].Attributes[0].Value, nil
+}
+
+// setPassword sets the password of the entry. Active Directory passwords are replaced in
+// the unicodePwd attribute, OpenLDAP passwords with the password modify extended operation
+// or in the userPassword attribute.
+func setPassword(conn *ldap.Conn, spec *enterprise.LDAPSpec, dn, pass string) error {
+	if dialect(spec) == enterprise.LDAPDialectActiveDirectory {
+		req := ldap.NewModifyRequest(dn, nil)
+		req.Replace("unicodePwd", []string{encodeUnicodePwd(pass)})
+		return conn.Modify(req)
+	}
+	switch spec.PasswordMethod {
+	case enterprise.LDAPPasswordMethodPasswordModify, "":
+		_, err := conn.PasswordModify(ldap.NewPasswordModifyRequest(dn, "", pass))
+		return err
+	case enterprise.LDAPPasswordMethodUserPassword:
+		req := ldap.NewModifyRequest(dn, nil)
+		req.Replace("userPassword", []string{pass})
+		return conn.Modify(req)
+	default:
+		return fmt.Errorf("unsupported password method: %s", spec.PasswordMethod)
+	}
+}
+
+// encodeUnicodePwd encodes the password as Active Directory expects it in the unicodePwd
+// attribute: the quoted password encoded in UTF-16LE.
+func encodeUnicodePwd(pass string) string {
+	quoted := utf16.Encode([]rune(`"` + pass + `"`))
+	out := make([]byte, 0, 2*len(quoted))
+	for _, c := range quoted {
+		out = binary.LittleEndian.AppendUint16(out, c)
+	}
+	return string(out)
+}
+
+func generatePassword(passSpec genv1alpha1.PasswordSpec) ([]byte, error) {
+	gen := password.Generator{}
+	rawPassSpec, err := yaml.Marshal(passSpec)
+	if err != nil {
+		return nil, err
+	}
+	passMap, _, err := gen.Generate(context.TODO(), &apiextensions.JSON{Raw: rawPassSpec}, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	pass, ok := passMap["password"]
+	if !ok {
+		return nil, errors.New("password not found in generated map")
+	}
+	return pass, nil
+}
+
+func parseSpec(data []byte) (*enterprise.LDAP, error) {
+	var spec enterprise.LDAP
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func parseStatus(data []byte) (*enterprise.LDAPUserState, error) {
+	var state enterprise.LDAPUserState
+	err := json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+	return &state, err
+}
+
+func init() {
+	genv1alpha1.Register(enterprise.LDAPKind, &Generator{})
+	genv1alpha1.RegisterGeneric(enterprise.LDAPKind, &enterprise.LDAP{})
+}
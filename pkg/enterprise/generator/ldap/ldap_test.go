@@ -0,0 +1,333 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// /*
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	testNamespace = "default"
+	testPeopleOU  = "ou=people,dc=example,dc=org"
+	testServiceOU = "ou=services,dc=example,dc=org"
+)
+
+func newSpec(s *testServer, user enterprise.LDAPUser) *enterprise.LDAPSpec {
+	return &enterprise.LDAPSpec{
+		URL: s.url(),
+		Auth: enterprise.LDAPAuth{
+			BindDN:   testAdminDN,
+			Password: esmeta.SecretKeySelector{Name: "ldap-admin", Key: "password"},
+		},
+		TLS: enterprise.LDAPTLS{
+			StartTLS: !s.ldaps,
+			CABundle: s.caPEM,
+		},
+		User: user,
+	}
+}
+
+func newClient(password string) client.Client {
+	return fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "ldap-admin"},
+		Data:       map[string][]byte{"password": []byte(password)},
+	}).Build()
+}
+
+func marshalSpec(t *testing.T, spec *enterprise.LDAPSpec) *apiextensions.JSON {
+	t.Helper()
+	raw, err := yaml.Marshal(&enterprise.LDAP{Spec: *spec})
+	require.NoError(t, err)
+	return &apiextensions.JSON{Raw: raw}
+}
+
+func generate(t *testing.T, spec *enterprise.LDAPSpec) (map[string][]byte, *enterprise.LDAPUserState, error) {
+	t.Helper()
+	g := &Generator{}
+	out, rawState, err := g.Generate(context.Background(), marshalSpec(t, spec), newClient(testAdminPassword), testNamespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	var state enterprise.LDAPUserState
+	require.NoError(t, json.Unmarshal(rawState.Raw, &state))
+	return out, &state, nil
+}
+
+func cleanup(t *testing.T, spec *enterprise.LDAPSpec, state *enterprise.LDAPUserState) error {
+	t.Helper()
+	raw, err := json.Marshal(state)
+	require.NoError(t, err)
+	g := &Generator{}
+	return g.Cleanup(context.Background(), marshalSpec(t, spec), &apiextensions.JSON{Raw: raw}, newClient(testAdminPassword), testNamespace)
+}
+
+func TestValidateSpec(t *testing.T) {
+	suffix := 4
+	tests := []struct {
+		name    string
+		spec    enterprise.LDAPSpec
+		wantErr string
+	}{
+		{
+			name: "ldaps",
+			spec: enterprise.LDAPSpec{URL: "ldaps://dc1:636", User: enterprise.LDAPUser{DN: "cn=app"}},
+		},
+		{
+			name: "starttls",
+			spec: enterprise.LDAPSpec{URL: "ldap://dc1", TLS: enterprise.LDAPTLS{StartTLS: true}, User: enterprise.LDAPUser{DN: "cn=app"}},
+		},
+		{
+			name:    "plain text",
+			spec:    enterprise.LDAPSpec{URL: "ldap://dc1", User: enterprise.LDAPUser{DN: "cn=app"}},
+			wantErr: "ldap:// URLs require startTLS, passwords are only sent over TLS",
+		},
+		{
+			name:    "starttls over ldaps",
+			spec:    enterprise.LDAPSpec{URL: "ldaps://dc1", TLS: enterprise.LDAPTLS{StartTLS: true}, User: enterprise.LDAPUser{DN: "cn=app"}},
+			wantErr: "startTLS cannot be used with ldaps:// URLs",
+		},
+		{
+			name:    "unsupported scheme",
+			spec:    enterprise.LDAPSpec{URL: "ldapi:///", User: enterprise.LDAPUser{DN: "cn=app"}},
+			wantErr: `unsupported url scheme "ldapi"`,
+		},
+		{
+			name:    "dn and ou",
+			spec:    enterprise.LDAPSpec{URL: "ldaps://dc1", User: enterprise.LDAPUser{DN: "cn=app", OU: "ou=apps"}},
+			wantErr: "exactly one of user.dn and user.ou must be set",
+		},
+		{
+			name:    "neither dn nor ou",
+			spec:    enterprise.LDAPSpec{URL: "ldaps://dc1"},
+			wantErr: "exactly one of user.dn and user.ou must be set",
+		},
+		{
+			name:    "missing username",
+			spec:    enterprise.LDAPSpec{URL: "ldaps://dc1", User: enterprise.LDAPUser{OU: "ou=apps"}},
+			wantErr: "user.username is required to create users",
+		},
+		{
+			name: "sAMAccountName too long",
+			spec: enterprise.LDAPSpec{
+				URL:     "ldaps://dc1",
+				Dialect: enterprise.LDAPDialectActiveDirectory,
+				User:    enterprise.LDAPUser{OU: "ou=apps", Username: "billing-service"},
+			},
+			wantErr: "username with its suffix is 24 characters long, Active Directory allows 20",
+		},
+		{
+			name: "sAMAccountName with short suffix",
+			spec: enterprise.LDAPSpec{
+				URL:     "ldaps://dc1",
+				Dialect: enterprise.LDAPDialectActiveDirectory,
+				User:    enterprise.LDAPUser{OU: "ou=apps", Username: "billing-service", SuffixSize: &suffix},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpec(&tt.spec)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEncodeUnicodePwd(t *testing.T) {
+	assert.Equal(t, "\"\x00p\x00\xe4\x00\"\x00", encodeUnicodePwd("pä"))
+	pass, ok := decodeUnicodePwd([]string{encodeUnicodePwd("s3cr€t")})
+	assert.True(t, ok)
+	assert.Equal(t, "s3cr€t", pass)
+}
+
+func TestRotateOpenLDAP(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		ldaps  bool
+		method enterprise.LDAPPasswordMethod
+	}{
+		{name: "password modify over StartTLS", method: enterprise.LDAPPasswordMethodPasswordModify},
+		{name: "userPassword over LDAPS", ldaps: true, method: enterprise.LDAPPasswordMethodUserPassword},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := startServer(t, tt.ldaps, testPeopleOU)
+			dn := "uid=svc-billing,ou=people,dc=example,dc=org"
+			s.addEntry(dn, map[string][]string{"uid": {"svc-billing"}})
+
+			spec := newSpec(s, enterprise.LDAPUser{DN: dn})
+			spec.PasswordMethod = tt.method
+			out, state, err := generate(t, spec)
+			require.NoError(t, err)
+			assert.Equal(t, "svc-billing", string(out["username"]))
+			assert.Equal(t, dn, string(out["dn"]))
+			assert.Len(t, out["password"], 24)
+			assert.Equal(t, string(out["password"]), s.password(dn))
+			assert.Equal(t, &enterprise.LDAPUserState{DN: dn}, state)
+
+			// Existing entries are left in place.
+			require.NoError(t, cleanup(t, spec, state))
+			_, ok := s.entry(dn)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestRotateActiveDirectory(t *testing.T) {
+	s := startServer(t, true, testServiceOU)
+	dn := "CN=Billing Service,OU=services,DC=example,DC=org"
+	s.addEntry(dn, map[string][]string{"sAMAccountName": {"svc-billing"}})
+
+	spec := newSpec(s, enterprise.LDAPUser{DN: dn})
+	spec.Dialect = enterprise.LDAPDialectActiveDirectory
+	out, _, err := generate(t, spec)
+	require.NoError(t, err)
+	assert.Equal(t, "svc-billing", string(out["username"]))
+	assert.Equal(t, string(out["password"]), s.password(dn))
+}
+
+func TestRotateUsernameFromRDN(t *testing.T) {
+	s := startServer(t, true, testServiceOU)
+	dn := "cn=billing,ou=services,dc=example,dc=org"
+	s.addEntry(dn, map[string][]string{})
+
+	out, _, err := generate(t, newSpec(s, enterprise.LDAPUser{DN: dn}))
+	require.NoError(t, err)
+	assert.Equal(t, "billing", string(out["username"]))
+}
+
+func TestRotateMissingEntry(t *testing.T) {
+	s := startServer(t, true, testPeopleOU)
+	_, _, err := generate(t, newSpec(s, enterprise.LDAPUser{DN: "uid=missing,ou=people,dc=example,dc=org"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to find user uid=missing,ou=people,dc=example,dc=org")
+}
+
+func TestCreateAndDeleteOpenLDAPUser(t *testing.T) {
+	s := startServer(t, false, testServiceOU)
+	spec := newSpec(s, enterprise.LDAPUser{
+		OU:         testServiceOU,
+		Username:   "billing",
+		Attributes: map[string][]string{"description": {"managed by external-secrets"}},
+	})
+	out, state, err := generate(t, spec)
+	require.NoError(t, err)
+
+	username := string(out["username"])
+	assert.Regexp(t, "^billing_[a-zA-Z0-9]{8}$", username)
+	dn := "cn=" + username + "," + testServiceOU
+	assert.Equal(t, &enterprise.LDAPUserState{DN: dn, Created: true}, state)
+	attrs, ok := s.entry(dn)
+	require.True(t, ok)
+	assert.Equal(t, []string{"top", "person", "organizationalPerson", "inetOrgPerson"}, attrs["objectClass"])
+	assert.Equal(t, []string{username}, attrs["uid"])
+	assert.Equal(t, []string{username}, attrs["sn"])
+	assert.Equal(t, []string{"managed by external-secrets"}, attrs["description"])
+	assert.Equal(t, string(out["password"]), s.password(dn))
+
+	require.NoError(t, cleanup(t, spec, state))
+	_, ok = s.entry(dn)
+	assert.False(t, ok)
+	// Deleting a user that is already gone is not an error.
+	require.NoError(t, cleanup(t, spec, state))
+}
+
+func TestCreateActiveDirectoryUser(t *testing.T) {
+	s := startServer(t, true, testServiceOU)
+	suffix := 0
+	spec := newSpec(s, enterprise.LDAPUser{OU: testServiceOU, Username: "svc-billing", SuffixSize: &suffix})
+	spec.Dialect = enterprise.LDAPDialectActiveDirectory
+	out, state, err := generate(t, spec)
+	require.NoError(t, err)
+
+	dn := "cn=svc-billing," + testServiceOU
+	assert.Equal(t, "svc-billing", string(out["username"]))
+	assert.Equal(t, dn, state.DN)
+	attrs, ok := s.entry(dn)
+	require.True(t, ok)
+	assert.Equal(t, []string{"top", "person", "organizationalPerson", "user"}, attrs["objectClass"])
+	assert.Equal(t, []string{"svc-billing"}, attrs["sAMAccountName"])
+	assert.Equal(t, []string{"512"}, attrs["userAccountControl"])
+	assert.Equal(t, string(out["password"]), s.password(dn))
+
+	// The user exists, so generating it again fails.
+	_, _, err = generate(t, spec)
+	assert.True(t, ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists), err)
+}
+
+func TestCreateUserRollback(t *testing.T) {
+	s := startServer(t, true, testServiceOU)
+	s.mu.Lock()
+	s.rejectPasswords = true
+	s.mu.Unlock()
+
+	suffix := 0
+	_, _, err := generate(t, newSpec(s, enterprise.LDAPUser{OU: testServiceOU, Username: "billing", SuffixSize: &suffix}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to set password")
+	_, ok := s.entry("cn=billing," + testServiceOU)
+	assert.False(t, ok, "the user without password must be deleted")
+}
+
+func TestInvalidCredentials(t *testing.T) {
+	s := startServer(t, true, testPeopleOU)
+	g := &Generator{}
+	spec := newSpec(s, enterprise.LDAPUser{DN: "uid=app,ou=people,dc=example,dc=org"})
+	_, _, err := g.Generate(context.Background(), marshalSpec(t, spec), newClient("wrong"), testNamespace)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to bind")
+}
+
+func TestUntrustedCertificate(t *testing.T) {
+	s := startServer(t, true, testPeopleOU)
+	spec := newSpec(s, enterprise.LDAPUser{DN: "uid=app,ou=people,dc=example,dc=org"})
+	spec.TLS.CABundle = nil
+	_, _, err := generate(t, spec)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to connect to the server")
+}
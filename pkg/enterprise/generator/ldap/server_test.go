@@ -0,0 +1,451 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// /*
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAdminDN       = "cn=admin,dc=example,dc=org"
+	testAdminPassword = "admin-password"
+
+	oidStartTLS       = "1.3.6.1.4.1.1466.20037"
+	oidPasswordModify = "1.3.6.1.4.1.4203.1.11.1"
+)
+
+// testServer is an in-process LDAP server with the subset of the protocol used by the generator:
+// simple binds, StartTLS, base searches, adds, modifies, deletes and password modify operations.
+// Like Active Directory and OpenLDAP, it rejects writes from anonymous connections and passwords
+// sent in clear text.
+type testServer struct {
+	listener net.Listener
+	ldaps    bool
+	tls      *tls.Config
+	caPEM    []byte
+
+	mu        sync.Mutex
+	entries   map[string]map[string][]string
+	passwords map[string]string
+	// rejectPasswords makes the server reject new passwords, as a password policy would.
+	rejectPasswords bool
+}
+
+func startServer(t *testing.T, ldaps bool, entries ...string) *testServer {
+	t.Helper()
+	certPEM, keyPEM := selfSignedCertificate(t)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	s := &testServer{
+		ldaps:     ldaps,
+		tls:       &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12},
+		caPEM:     certPEM,
+		entries:   map[string]map[string][]string{},
+		passwords: map[string]string{},
+	}
+	s.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	if ldaps {
+		s.listener = tls.NewListener(s.listener, s.tls)
+	}
+	t.Cleanup(func() { _ = s.listener.Close() })
+
+	for _, dn := range append([]string{"dc=example,dc=org"}, entries...) {
+		s.addEntry(dn, map[string][]string{})
+	}
+	go s.serve()
+	return s
+}
+
+func (s *testServer) url() string {
+	if s.ldaps {
+		return "ldaps://" + s.listener.Addr().String()
+	}
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *testServer) addEntry(dn string, attrs map[string][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[normalizeDN(dn)] = attrs
+}
+
+func (s *testServer) entry(dn string) (map[string][]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attrs, ok := s.entries[normalizeDN(dn)]
+	return attrs, ok
+}
+
+func (s *testServer) password(dn string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.passwords[normalizeDN(dn)]
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	secure := s.ldaps
+	var bound string
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationUnbindRequest:
+			return
+		case ldap.ApplicationBindRequest:
+			dn, pass := op.Children[1].Data.String(), op.Children[2].Data.String()
+			code := s.bind(dn, pass)
+			if code == ldap.LDAPResultSuccess {
+				bound = normalizeDN(dn)
+			}
+			s.write(conn, result(id, ldap.ApplicationBindResponse, code))
+		case ldap.ApplicationExtendedRequest:
+			switch op.Children[0].Data.String() {
+			case oidStartTLS:
+				s.write(conn, result(id, ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess))
+				tlsConn := tls.Server(conn, s.tls)
+				if err := tlsConn.Handshake(); err != nil {
+					return
+				}
+				conn, secure = tlsConn, true
+			case oidPasswordModify:
+				code := s.authorize(bound, secure)
+				if code == ldap.LDAPResultSuccess {
+					code = s.passwordModify(op.Children[1].Data.Bytes())
+				}
+				s.write(conn, result(id, ldap.ApplicationExtendedResponse, code))
+			default:
+				s.write(conn, result(id, ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError))
+			}
+		case ldap.ApplicationSearchRequest:
+			s.search(conn, id, op)
+		case ldap.ApplicationAddRequest:
+			code := s.authorize(bound, true)
+			if code == ldap.LDAPResultSuccess {
+				code = s.add(op, secure)
+			}
+			s.write(conn, result(id, ldap.ApplicationAddResponse, code))
+		case ldap.ApplicationModifyRequest:
+			code := s.authorize(bound, true)
+			if code == ldap.LDAPResultSuccess {
+				code = s.modify(op, secure)
+			}
+			s.write(conn, result(id, ldap.ApplicationModifyResponse, code))
+		case ldap.ApplicationDelRequest:
+			code := s.authorize(bound, true)
+			if code == ldap.LDAPResultSuccess {
+				code = s.delete(op.Data.String())
+			}
+			s.write(conn, result(id, ldap.ApplicationDelResponse, code))
+		default:
+			s.write(conn, result(id, ldap.ApplicationExtendedResponse, ldap.LDAPResultUnwillingToPerform))
+		}
+	}
+}
+
+func (s *testServer) write(conn net.Conn, packet *ber.Packet) {
+	_, _ = conn.Write(packet.Bytes())
+}
+
+func (s *testServer) bind(dn, pass string) uint16 {
+	if normalizeDN(dn) == normalizeDN(testAdminDN) && pass == testAdminPassword {
+		return ldap.LDAPResultSuccess
+	}
+	if pass != "" && s.password(dn) == pass {
+		return ldap.LDAPResultSuccess
+	}
+	return ldap.LDAPResultInvalidCredentials
+}
+
+// authorize only lets the admin write, and passwords be set over TLS.
+func (s *testServer) authorize(bound string, secure bool) uint16 {
+	if bound != normalizeDN(testAdminDN) {
+		return ldap.LDAPResultInsufficientAccessRights
+	}
+	if !secure {
+		return ldap.LDAPResultConfidentialityRequired
+	}
+	return ldap.LDAPResultSuccess
+}
+
+func (s *testServer) passwordModify(value []byte) uint16 {
+	var dn, pass string
+	for _, child := range ber.DecodePacket(value).Children {
+		switch child.Tag {
+		case 0:
+			dn = child.Data.String()
+		case 2:
+			pass = child.Data.String()
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[normalizeDN(dn)]; !ok {
+		return ldap.LDAPResultNoSuchObject
+	}
+	return s.setPassword(dn, pass)
+}
+
+// setPassword must be called with the lock held.
+func (s *testServer) setPassword(dn, pass string) uint16 {
+	if s.rejectPasswords || pass == "" {
+		return ldap.LDAPResultConstraintViolation
+	}
+	s.passwords[normalizeDN(dn)] = pass
+	return ldap.LDAPResultSuccess
+}
+
+func (s *testServer) add(op *ber.Packet, secure bool) uint16 {
+	dn := op.Children[0].Data.String()
+	attrs := map[string][]string{}
+	for _, attr := range op.Children[1].Children {
+		attrs[attr.Children[0].Data.String()] = values(attr.Children[1])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := normalizeDN(dn)
+	if _, ok := s.entries[key]; ok {
+		return ldap.LDAPResultEntryAlreadyExists
+	}
+	if _, parent, _ := strings.Cut(key, ","); s.entries[parent] == nil {
+		return ldap.LDAPResultNoSuchObject
+	}
+	if vals, ok := attrs["unicodePwd"]; ok {
+		delete(attrs, "unicodePwd")
+		if !secure {
+			return ldap.LDAPResultConfidentialityRequired
+		}
+		pass, ok := decodeUnicodePwd(vals)
+		if !ok {
+			return ldap.LDAPResultUnwillingToPerform
+		}
+		if code := s.setPassword(dn, pass); code != ldap.LDAPResultSuccess {
+			return code
+		}
+	}
+	s.entries[key] = attrs
+	return ldap.LDAPResultSuccess
+}
+
+func (s *testServer) modify(op *ber.Packet, secure bool) uint16 {
+	dn := op.Children[0].Data.String()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attrs, ok := s.entries[normalizeDN(dn)]
+	if !ok {
+		return ldap.LDAPResultNoSuchObject
+	}
+	for _, change := range op.Children[1].Children {
+		if operation, _ := change.Children[0].Value.(int64); operation != ldap.ReplaceAttribute {
+			return ldap.LDAPResultUnwillingToPerform
+		}
+		name := change.Children[1].Children[0].Data.String()
+		vals := values(change.Children[1].Children[1])
+		switch name {
+		case "unicodePwd":
+			if !secure {
+				return ldap.LDAPResultConfidentialityRequired
+			}
+			pass, ok := decodeUnicodePwd(vals)
+			if !ok {
+				return ldap.LDAPResultUnwillingToPerform
+			}
+			if code := s.setPassword(dn, pass); code != ldap.LDAPResultSuccess {
+				return code
+			}
+		case "userPassword":
+			if !secure {
+				return ldap.LDAPResultConfidentialityRequired
+			}
+			if len(vals) != 1 {
+				return ldap.LDAPResultConstraintViolation
+			}
+			if code := s.setPassword(dn, vals[0]); code != ldap.LDAPResultSuccess {
+				return code
+			}
+			attrs[name] = vals
+		default:
+			attrs[name] = vals
+		}
+	}
+	return ldap.LDAPResultSuccess
+}
+
+func (s *testServer) delete(dn string) uint16 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := normalizeDN(dn)
+	if _, ok := s.entries[key]; !ok {
+		return ldap.LDAPResultNoSuchObject
+	}
+	delete(s.entries, key)
+	delete(s.passwords, key)
+	return ldap.LDAPResultSuccess
+}
+
+// search only supports base object searches, which is what the generator uses.
+func (s *testServer) search(conn net.Conn, id int64, op *ber.Packet) {
+	dn := op.Children[0].Data.String()
+	if scope, _ := op.Children[1].Value.(int64); scope != ldap.ScopeBaseObject {
+		s.write(conn, result(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultUnwillingToPerform))
+		return
+	}
+	attrs, ok := s.entry(dn)
+	if !ok {
+		s.write(conn, result(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultNoSuchObject))
+		return
+	}
+
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "Object Name"))
+	list := ber.NewSequence("Attributes")
+	for _, requested := range op.Children[7].Children {
+		for name, vals := range attrs {
+			if !strings.EqualFold(name, requested.Data.String()) {
+				continue
+			}
+			attr := ber.NewSequence("Attribute")
+			attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+			set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+			for _, val := range vals {
+				set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, val, "Value"))
+			}
+			attr.AppendChild(set)
+			list.AppendChild(attr)
+		}
+	}
+	entry.AppendChild(list)
+	s.write(conn, envelope(id, entry))
+	s.write(conn, result(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+}
+
+func envelope(id int64, op *ber.Packet) *ber.Packet {
+	packet := ber.NewSequence("LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+	packet.AppendChild(op)
+	return packet
+}
+
+func result(id int64, tag ber.Tag, code uint16) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return envelope(id, op)
+}
+
+func values(set *ber.Packet) []string {
+	vals := make([]string, 0, len(set.Children))
+	for _, val := range set.Children {
+		vals = append(vals, val.Data.String())
+	}
+	return vals
+}
+
+// decodeUnicodePwd decodes a quoted UTF-16LE password, as Active Directory does.
+func decodeUnicodePwd(vals []string) (string, bool) {
+	if len(vals) != 1 || len(vals[0])%2 != 0 {
+		return "", false
+	}
+	raw := []byte(vals[0])
+	units := make([]uint16, 0, len(raw)/2)
+	for i := 0; i < len(raw); i += 2 {
+		units = append(units, binary.LittleEndian.Uint16(raw[i:]))
+	}
+	quoted := string(utf16.Decode(units))
+	if len(quoted) < 2 || !strings.HasPrefix(quoted, `"`) || !strings.HasSuffix(quoted, `"`) {
+		return "", false
+	}
+	return quoted[1 : len(quoted)-1], true
+}
+
+func normalizeDN(dn string) string {
+	return strings.ToLower(strings.ReplaceAll(dn, " ", ""))
+}
+
+func selfSignedCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
@@ -56,11 +56,16 @@ const (
 	errMissingState     = "missing generator state"
 	errCreateState      = "could not create generator state: %w"
 	errMissingAdminUser = "missing admin username"
+	errAlternatingState = "the alternating rotation strategy requires the generator state to be enabled"
+	errAlternatingName  = "a user name is required to alternate users"
 )
 
 const (
 	// DefaultUsernameLength is the default length for generated usernames.
 	DefaultUsernameLength = 8
+
+	// codeUserNotFound is the error code of the dropUser command when the user does not exist.
+	codeUserNotFound = 11
 )
 
 // MongoClient defines the interface for MongoDB client operations.
@@ -86,7 +91,35 @@ func (g *MongoDB) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kc
 	if err != nil {
 		return nil, nil, err
 	}
+	if gen.Spec.RotationPolicy != nil && gen.Spec.RotationPolicy.Strategy == genv1alpha1.AlternatingRotationStrategy {
+		return nil, nil, errors.New(errAlternatingState)
+	}
+
+	username, err := buildUsername(gen.Spec.User.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g.generate(ctx, gen, kclient, ns, username, nil)
+}
 
+// GenerateAlternating creates the user of the given slot or resets its password.
+func (g *MongoDB) GenerateAlternating(ctx context.Context, jsonSpec *apiextensions.JSON, kclient client.Client, ns, slot string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	gen, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	if gen.Spec.User.Name == "" {
+		return nil, nil, errors.New(errAlternatingName)
+	}
+
+	users := []string{
+		gen.Spec.User.Name + "_" + genv1alpha1.AlternatingSlotA,
+		gen.Spec.User.Name + "_" + genv1alpha1.AlternatingSlotB,
+	}
+	return g.generate(ctx, gen, kclient, ns, gen.Spec.User.Name+"_"+slot, users)
+}
+
+func (g *MongoDB) generate(ctx context.Context, gen *enterprise.MongoDB, kclient client.Client, ns, username string, users []string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
 	adminUsername, adminPwd, err := getAdminCredentials(ctx, gen, kclient, ns)
 	if err != nil {
 		return nil, nil, err
@@ -109,10 +142,6 @@ func (g *MongoDB) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kc
 
 	adminDB := client.Database(gen.Spec.Database.AdminDB)
 
-	username, err := buildUsername(gen.Spec.User.Name)
-	if err != nil {
-		return nil, nil, err
-	}
 	password, err := generatePassword()
 	if err != nil {
 		return nil, nil, err
@@ -124,7 +153,8 @@ func (g *MongoDB) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kc
 	}
 
 	rawState, err := json.Marshal(&enterprise.MongoDBUserState{
-		User: username,
+		User:      username,
+		Usernames: users,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf(errCreateState, err)
@@ -172,12 +202,30 @@ func (g *MongoDB) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, gen
 	}
 
 	adminDB := client.Database(gen.Spec.Database.AdminDB)
-	cmd := bson.D{{Key: "dropUser", Value: state.User}}
-	res := adminDB.RunCommand(ctx, cmd)
-	if err := res.Err(); err != nil {
-		return fmt.Errorf(errDeleteUser, state.User, err)
+	if len(state.Usernames) == 0 {
+		return dropUser(ctx, adminDB, state.User)
 	}
 
+	// The second alternating user is only created on the second rotation.
+	for _, user := range state.Usernames {
+		err := dropUser(ctx, adminDB, user)
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && cmdErr.Code == codeUserNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropUser(ctx context.Context, db *mongo.Database, username string) error {
+	cmd := bson.D{{Key: "dropUser", Value: username}}
+	res := db.RunCommand(ctx, cmd)
+	if err := res.Err(); err != nil {
+		return fmt.Errorf(errDeleteUser, username, err)
+	}
 	return nil
 }
 
@@ -186,6 +234,15 @@ func (g *MongoDB) GetCleanupPolicy(_ *apiextensions.JSON) (*genv1alpha1.CleanupP
 	return nil, nil
 }
 
+// GetRotationPolicy returns the rotation policy of the generator.
+func (g *MongoDB) GetRotationPolicy(obj *apiextensions.JSON) (*genv1alpha1.RotationPolicy, error) {
+	gen, err := parseSpec(obj.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return gen.Spec.RotationPolicy, nil
+}
+
 // LastActivityTime returns the last activity time for generated resources.
 func (g *MongoDB) LastActivityTime(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) (time.Time, bool, error) {
 	return time.Time{}, false, nil
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -243,3 +244,45 @@ func (s *MongoDBTestSuite) Test_Cleanup_Failure_InexistentUser() {
 	require.Error(s.T(), err)
 	require.ErrorContains(s.T(), err, "could not delete user does_not_exist")
 }
+
+func (s *MongoDBTestSuite) Test_GenerateAlternating_Success() {
+	spec := newGeneratorSpec(s.T(), s.host, s.port)
+	spec.Spec.User.Name = "app"
+	spec.Spec.RotationPolicy = &genv1alpha1.RotationPolicy{Strategy: genv1alpha1.AlternatingRotationStrategy}
+	raw, err := json.Marshal(spec)
+	require.NoError(s.T(), err)
+	jsonSpec := &apiextensionsv1.JSON{Raw: raw}
+
+	_, _, err = s.generator.Generate(s.ctx, jsonSpec, s.kubeClient, "default")
+	require.ErrorContains(s.T(), err, "requires the generator state")
+
+	first, _, err := s.generator.GenerateAlternating(s.ctx, jsonSpec, s.kubeClient, "default", genv1alpha1.AlternatingSlotA)
+	require.NoError(s.T(), err)
+	s.Assert().Equal("app_a", string(first["username"]))
+
+	reset, state, err := s.generator.GenerateAlternating(s.ctx, jsonSpec, s.kubeClient, "default", genv1alpha1.AlternatingSlotA)
+	require.NoError(s.T(), err)
+	s.Assert().Equal("app_a", string(reset["username"]))
+	s.Assert().NotEqual(first["password"], reset["password"])
+
+	var st enterprise.MongoDBUserState
+	require.NoError(s.T(), json.Unmarshal(state.Raw, &st))
+	s.Assert().Equal("app_a", st.User)
+	s.Assert().Equal([]string{"app_a", "app_b"}, st.Usernames)
+
+	// Only the first user exists until the users are flipped.
+	err = s.generator.Cleanup(s.ctx, jsonSpec, state, s.kubeClient, "default")
+	require.NoError(s.T(), err)
+}
+
+func (s *MongoDBTestSuite) Test_GenerateAlternating_Failure_MissingName() {
+	spec := newGeneratorSpec(s.T(), s.host, s.port)
+	spec.Spec.User.Name = ""
+	spec.Spec.RotationPolicy = &genv1alpha1.RotationPolicy{Strategy: genv1alpha1.AlternatingRotationStrategy}
+	raw, err := json.Marshal(spec)
+	require.NoError(s.T(), err)
+	jsonSpec := &apiextensionsv1.JSON{Raw: raw}
+
+	_, _, err = s.generator.GenerateAlternating(s.ctx, jsonSpec, s.kubeClient, "default", genv1alpha1.AlternatingSlotA)
+	require.ErrorContains(s.T(), err, errAlternatingName)
+}
@@ -0,0 +1,109 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Package mysql implements the MySQL bootstrap process.
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/labstack/gommon/log"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+// Bootstrap implements the bootstrap process for MySQL.
+type Bootstrap struct {
+	mgr    manager.Manager
+	client client.Client
+}
+
+// NewBootstrap creates a new MySQL bootstrap.
+func NewBootstrap(client client.Client, mgr manager.Manager) *Bootstrap {
+	return &Bootstrap{
+		client: client,
+		mgr:    mgr,
+	}
+}
+
+// Start starts the bootstrap process. It resumes the session observation of the
+// generated users with an idle cleanup policy.
+func (b *Bootstrap) Start(ctx context.Context) error {
+	if ok := b.mgr.GetCache().WaitForCacheSync(ctx); !ok {
+		return ctx.Err()
+	}
+
+	var list genv1alpha1.GeneratorStateList
+	if err := b.mgr.GetClient().List(ctx, &list); err != nil {
+		return err
+	}
+	for _, gs := range list.Items {
+		if gs.Spec.GarbageCollectionDeadline == nil {
+			log.Info("skipping generator state without garbage collection deadline")
+			continue
+		}
+
+		spec, err := parseSpec(gs.Spec.Resource.Raw)
+		if err != nil {
+			return err
+		}
+		if spec.Kind != enterprise.MySQLKind {
+			// not a MySQL spec. skipping
+			continue
+		}
+
+		cleanupPolicy := spec.Spec.CleanupPolicy
+		if cleanupPolicy == nil || cleanupPolicy.Type != genv1alpha1.IdleCleanupPolicy {
+			continue
+		}
+		if err := b.setupObservation(ctx, spec, gs.GetNamespace()); err != nil {
+			return err
+		}
+		scheduleObservation(spec, b.client, gs.GetNamespace())
+	}
+
+	return nil
+}
+
+func (b *Bootstrap) setupObservation(ctx context.Context, spec *enterprise.MySQL, namespace string) error {
+	db, err := newConnection(ctx, &spec.Spec, b.client, namespace)
+	if err != nil {
+		return fmt.Errorf("unable to create db connection: %w", err)
+	}
+	defer closeConnection(db)
+
+	if err := setupObservation(ctx, db, observationDatabase(&spec.Spec)); err != nil {
+		return fmt.Errorf("unable to setup observation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,573 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+// Package mysql implements MySQL user generator.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-sql-driver/mysql"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/generators/v1/password"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/scheduler"
+	utils "github.com/external-secrets/external-secrets/runtime/esutils"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+// Generator implements the MySQL user generator.
+type Generator struct{}
+
+const (
+	defaultPort                = "3306"
+	defaultUser                = "root"
+	defaultHost                = "%"
+	defaultSuffixSize          = 8
+	defaultObservationDatabase = "external_secrets"
+	schedIDFmt                 = "mysql-session-observation-%s-%s:%s"
+
+	// maxUsernameLength is the maximum length of MySQL user names.
+	maxUsernameLength = 32
+)
+
+// privilegeRegexp matches privilege names, e.g. SELECT or ALL PRIVILEGES. Privileges are
+// keywords that can not be quoted, so anything else is rejected.
+var privilegeRegexp = regexp.MustCompile(`^[A-Za-z]+( [A-Za-z]+)*$`)
+
+// Generate creates a new user in the database.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateUser(&res.Spec.User); err != nil {
+		return nil, nil, err
+	}
+
+	db, err := newConnection(ctx, &res.Spec, kube, namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create db connection: %w", err)
+	}
+	defer closeConnection(db)
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to ping the database: %w", err)
+	}
+
+	cleanupPolicy := res.Spec.CleanupPolicy
+	if cleanupPolicy != nil && cleanupPolicy.Type == genv1alpha1.IdleCleanupPolicy {
+		err = setupObservation(ctx, db, observationDatabase(&res.Spec))
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to setup observation: %w", err)
+		}
+		scheduleObservation(res, kube, namespace)
+	}
+
+	user, hosts, err := createUser(ctx, db, &res.Spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create user: %w", err)
+	}
+
+	rawState, err := json.Marshal(&enterprise.MySQLUserState{
+		Username: string(user["username"]),
+		Hosts:    hosts,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal state: %w", err)
+	}
+
+	return user, &apiextensions.JSON{Raw: rawState}, nil
+}
+
+// Cleanup terminates the sessions of the user and removes it from the database.
+func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, previousStatus genv1alpha1.GeneratorProviderState, kclient client.Client, namespace string) error {
+	if previousStatus == nil {
+		return fmt.Errorf("missing previous status")
+	}
+	status, err := parseStatus(previousStatus.Raw)
+	if err != nil {
+		return err
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return err
+	}
+	db, err := newConnection(ctx, &res.Spec, kclient, namespace)
+	if err != nil {
+		return err
+	}
+	defer closeConnection(db)
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to ping the database: %w", err)
+	}
+
+	err = dropUser(ctx, db, status.Username, status.Hosts)
+	if err != nil {
+		return fmt.Errorf("unable to drop user: %w", err)
+	}
+	return nil
+}
+
+// GetCleanupPolicy returns the cleanup policy of the generator.
+func (g *Generator) GetCleanupPolicy(obj *apiextensions.JSON) (*genv1alpha1.CleanupPolicy, error) {
+	res, err := parseSpec(obj.Raw)
+	if err != nil {
+		return nil, err
+	}
+	if res.Spec.CleanupPolicy == nil {
+		return nil, nil
+	}
+
+	policy := genv1alpha1.CleanupPolicy{
+		Type:        res.Spec.CleanupPolicy.Type,
+		IdleTimeout: res.Spec.CleanupPolicy.IdleTimeout,
+		GracePeriod: res.Spec.CleanupPolicy.GracePeriod,
+	}
+	return &policy, nil
+}
+
+// LastActivityTime returns the last time a session of the user was observed.
+func (g *Generator) LastActivityTime(ctx context.Context, obj *apiextensions.JSON, state genv1alpha1.GeneratorProviderState, kube client.Client, namespace string) (time.Time, bool, error) {
+	status, err := parseStatus(state.Raw)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	res, err := parseSpec(obj.Raw)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	db, err := newConnection(ctx, &res.Spec, kube, namespace)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	defer closeConnection(db)
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("unable to ping the database: %w", err)
+	}
+
+	lastActivity, err := getUserActivity(ctx, db, observationDatabase(&res.Spec), status.Username)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return lastActivity, true, nil
+}
+
+// GetKeys returns the keys that are generated by the generator.
+func (g *Generator) GetKeys() map[string]string {
+	return map[string]string{
+		"username": "MySQL database username",
+		"password": "MySQL user password",
+	}
+}
+
+func newConnection(ctx context.Context, spec *enterprise.MySQLSpec, kclient client.Client, ns string) (*sql.DB, error) {
+	port := defaultPort
+	if spec.Port != "" {
+		port = spec.Port
+	}
+	username := defaultUser
+	if spec.Auth.Username != "" {
+		username = spec.Auth.Username
+	}
+	password, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      spec.Auth.Password.Name,
+		Key:       spec.Auth.Password.Key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.User = username
+	cfg.Passwd = password
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(spec.Host, port)
+	cfg.ParseTime = true
+	// Account management statements can not be prepared with placeholders for
+	// user names, so parameters are escaped by the driver instead.
+	cfg.InterpolateParams = true
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(connector)
+	// All statements of a user must run on the same session.
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+func closeConnection(db *sql.DB) {
+	if err := db.Close(); err != nil {
+		fmt.Printf("failed to close db: %v", err)
+	}
+}
+
+func observationDatabase(spec *enterprise.MySQLSpec) string {
+	if spec.CleanupPolicy != nil && spec.CleanupPolicy.ObservationDatabase != "" {
+		return spec.CleanupPolicy.ObservationDatabase
+	}
+	return defaultObservationDatabase
+}
+
+// setupObservation creates the table the sessions of the server are recorded in.
+func setupObservation(ctx context.Context, db *sql.DB, database string) error {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", quoteIdentifier(database))); err != nil {
+		return fmt.Errorf("failed to create database %s: %w", database, err)
+	}
+	query := fmt.Sprintf(`
+CREATE TABLE IF NOT EXISTS %s.session_observation (
+    id         BIGINT UNSIGNED NOT NULL,
+    user       VARCHAR(128) NOT NULL,
+    host       VARCHAR(255) NOT NULL DEFAULT '',
+    db         VARCHAR(64),
+    command    VARCHAR(16),
+    first_seen DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id, user, host),
+    INDEX (user)
+)`, quoteIdentifier(database))
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create session_observation table: %w", err)
+	}
+	return nil
+}
+
+func scheduleObservation(res *enterprise.MySQL, kube client.Client, namespace string) {
+	schedID := fmt.Sprintf(schedIDFmt, res.UID, res.Spec.Host, res.Spec.Port)
+	scheduler.Global().ScheduleInterval(schedID, res.Spec.CleanupPolicy.ActivityTrackingInterval.Duration, time.Minute, func(ctx context.Context, log logr.Logger) {
+		err := triggerSessionSnapshot(ctx, &res.Spec, kube, namespace)
+		if err != nil {
+			log.Error(err, "failed to trigger session observation")
+			return
+		}
+	})
+}
+
+// triggerSessionSnapshot records the sessions of information_schema.processlist. Sessions are
+// keyed by their user and host as well as their ID, as IDs are reused after a restart.
+func triggerSessionSnapshot(ctx context.Context, spec *enterprise.MySQLSpec, kube client.Client, namespace string) error {
+	db, err := newConnection(ctx, spec, kube, namespace)
+	if err != nil {
+		return err
+	}
+	defer closeConnection(db)
+
+	query := fmt.Sprintf(`
+INSERT INTO %s.session_observation (id, user, host, db, command)
+SELECT ID, USER, COALESCE(HOST, ''), DB, COMMAND
+FROM information_schema.PROCESSLIST
+WHERE ID <> CONNECTION_ID() AND USER IS NOT NULL AND USER <> ''
+ON DUPLICATE KEY UPDATE
+    command = VALUES(command),
+    last_seen = CURRENT_TIMESTAMP`, quoteIdentifier(observationDatabase(spec)))
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to trigger session observation: %w", err)
+	}
+	return nil
+}
+
+func getUserActivity(ctx context.Context, db *sql.DB, database, username string) (time.Time, error) {
+	var lastSeen sql.NullTime
+	query := fmt.Sprintf("SELECT MAX(last_seen) FROM %s.session_observation WHERE user = ?", quoteIdentifier(database))
+	if err := db.QueryRowContext(ctx, query, username).Scan(&lastSeen); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get user activity: %w", err)
+	}
+	if !lastSeen.Valid {
+		return time.Unix(0, 0), nil
+	}
+	return lastSeen.Time, nil
+}
+
+func validateUser(user *enterprise.MySQLUser) error {
+	if user.Username == "" {
+		return errors.New("user.username is required")
+	}
+	suffixSize := defaultSuffixSize
+	if user.SuffixSize != nil {
+		suffixSize = *user.SuffixSize
+	}
+	length := len(user.Username)
+	if suffixSize > 0 {
+		length += 1 + suffixSize
+	}
+	if length > maxUsernameLength {
+		return fmt.Errorf("user.username with its suffix must not be longer than %d characters, got %d", maxUsernameLength, length)
+	}
+	for _, grant := range user.Grants {
+		if len(grant.Privileges) == 0 {
+			return errors.New("grants must have at least one privilege")
+		}
+		for _, privilege := range grant.Privileges {
+			if !privilegeRegexp.MatchString(privilege) {
+				return fmt.Errorf("invalid privilege %q", privilege)
+			}
+		}
+		if grant.Table != "" && grant.Database == "" {
+			return fmt.Errorf("grant on table %q requires a database", grant.Table)
+		}
+	}
+	if user.TLS != nil && user.TLS.Require != "" {
+		// The requirement is written into the statement, as MySQL does not take it as a parameter.
+		switch strings.ToUpper(user.TLS.Require) {
+		case "SSL", "X509":
+		default:
+			return fmt.Errorf("invalid tls.require %q, must be SSL or X509", user.TLS.Require)
+		}
+	}
+	return nil
+}
+
+func createUser(ctx context.Context, db *sql.DB, spec *enterprise.MySQLSpec) (map[string][]byte, []string, error) {
+	username := spec.User.Username
+	suffixSize := defaultSuffixSize
+	if spec.User.SuffixSize != nil {
+		suffixSize = *spec.User.SuffixSize
+	}
+	suffix, err := utils.GenerateRandomString(suffixSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate random suffix: %w", err)
+	}
+	if suffix != "" {
+		username = fmt.Sprintf("%s_%s", username, suffix)
+	}
+	hosts := spec.User.Hosts
+	if len(hosts) == 0 {
+		hosts = []string{defaultHost}
+	}
+
+	pass, err := generatePassword(genv1alpha1.Password{
+		Spec: genv1alpha1.PasswordSpec{
+			SymbolCharacters: ptr.To("~!@#$%^&*()_+-={}|[]:<>?,./"),
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	for i, host := range hosts {
+		if err := createAccount(ctx, db, username, host, string(pass), &spec.User); err != nil {
+			if dropErr := dropUser(ctx, db, username, hosts[:i+1]); dropErr != nil {
+				err = errors.Join(err, dropErr)
+			}
+			return nil, nil, fmt.Errorf("failed to create user %s@%s: %w", username, host, err)
+		}
+	}
+
+	return map[string][]byte{
+		"username": []byte(username),
+		"password": pass,
+	}, hosts, nil
+}
+
+// createAccount creates the account of the user for a host pattern, or resets it if it already exists.
+func createAccount(ctx context.Context, db *sql.DB, username, host, pass string, user *enterprise.MySQLUser) error {
+	var exists int
+	err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM mysql.user WHERE User = ? AND Host = ?", username, host).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check user: %w", err)
+	}
+
+	statement := "CREATE USER"
+	if exists > 0 {
+		statement = "ALTER USER"
+	}
+	query := fmt.Sprintf("%s ?@? IDENTIFIED BY ? %s WITH MAX_USER_CONNECTIONS %d", statement, requireClause(user.TLS), ptr.Deref(user.MaxUserConnections, 0))
+	args := append([]any{username, host, pass}, requireArgs(user.TLS)...)
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+	if exists > 0 {
+		if _, err := db.ExecContext(ctx, "REVOKE ALL PRIVILEGES, GRANT OPTION FROM ?@?", username, host); err != nil {
+			return fmt.Errorf("failed to revoke privileges: %w", err)
+		}
+	}
+
+	for _, grant := range user.Grants {
+		if _, err := db.ExecContext(ctx, grantStatement(grant), username, host); err != nil {
+			return fmt.Errorf("failed to grant %s: %w", strings.Join(grant.Privileges, ", "), err)
+		}
+	}
+	return nil
+}
+
+// requireClause returns the REQUIRE clause of the TLS requirements, with placeholders for requireArgs.
+func requireClause(tls *enterprise.MySQLUserTLS) string {
+	if tls == nil {
+		return "REQUIRE NONE"
+	}
+	var options []string
+	if tls.Require != "" {
+		options = append(options, strings.ToUpper(tls.Require))
+	}
+	if tls.Issuer != "" {
+		options = append(options, "ISSUER ?")
+	}
+	if tls.Subject != "" {
+		options = append(options, "SUBJECT ?")
+	}
+	if tls.Cipher != "" {
+		options = append(options, "CIPHER ?")
+	}
+	if len(options) == 0 {
+		return "REQUIRE NONE"
+	}
+	return "REQUIRE " + strings.Join(options, " AND ")
+}
+
+func requireArgs(tls *enterprise.MySQLUserTLS) []any {
+	if tls == nil {
+		return nil
+	}
+	var args []any
+	for _, arg := range []string{tls.Issuer, tls.Subject, tls.Cipher} {
+		if arg != "" {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// grantStatement returns the GRANT statement of a grant, with placeholders for the user and host.
+func grantStatement(grant enterprise.MySQLGrant) string {
+	privileges := make([]string, len(grant.Privileges))
+	for i, privilege := range grant.Privileges {
+		privileges[i] = strings.ToUpper(privilege)
+	}
+	level := "*.*"
+	if grant.Database != "" {
+		table := "*"
+		if grant.Table != "" {
+			table = quoteIdentifier(grant.Table)
+		}
+		level = quoteIdentifier(grant.Database) + "." + table
+	}
+	query := fmt.Sprintf("GRANT %s ON %s TO ?@?", strings.Join(privileges, ", "), level)
+	if grant.WithGrantOption {
+		query += " WITH GRANT OPTION"
+	}
+	return query
+}
+
+// dropUser terminates the sessions of the user and drops its accounts.
+func dropUser(ctx context.Context, db *sql.DB, username string, hosts []string) error {
+	rows, err := db.QueryContext(ctx, "SELECT ID FROM information_schema.PROCESSLIST WHERE USER = ?", username)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions of %s: %w", username, err)
+	}
+	var ids []uint64
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan session: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+		return fmt.Errorf("error iterating sessions: %w", err)
+	}
+	for _, id := range ids {
+		// The session may have ended since it was listed.
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("KILL %d", id)); err != nil && !isUnknownThread(err) {
+			return fmt.Errorf("failed to terminate session %d of %s: %w", id, username, err)
+		}
+	}
+
+	if len(hosts) == 0 {
+		hosts = []string{defaultHost}
+	}
+	for _, host := range hosts {
+		if _, err := db.ExecContext(ctx, "DROP USER IF EXISTS ?@?", username, host); err != nil {
+			return fmt.Errorf("failed to drop user %s@%s: %w", username, host, err)
+		}
+	}
+	return nil
+}
+
+// isUnknownThread returns whether the error is ER_NO_SUCH_THREAD.
+func isUnknownThread(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1094
+}
+
+// quoteIdentifier quotes a database or table name.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func generatePassword(
+	passSpec genv1alpha1.Password,
+) ([]byte, error) {
+	gen := password.Generator{}
+	rawPassSpec, err := yaml.Marshal(passSpec)
+	if err != nil {
+		return nil, err
+	}
+	passMap, _, err := gen.Generate(context.TODO(), &apiextensions.JSON{Raw: rawPassSpec}, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	pass, ok := passMap["password"]
+	if !ok {
+		return nil, fmt.Errorf("password not found in generated map")
+	}
+	return pass, nil
+}
+
+func parseSpec(data []byte) (*enterprise.MySQL, error) {
+	var spec enterprise.MySQL
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func parseStatus(data []byte) (*enterprise.MySQLUserState, error) {
+	var state enterprise.MySQLUserState
+	err := json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+	return &state, err
+}
+
+func init() {
+	genv1alpha1.Register(enterprise.MySQLKind, &Generator{})
+	genv1alpha1.RegisterGeneric(enterprise.MySQLKind, &enterprise.MySQL{})
+}
@@ -0,0 +1,431 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// /*
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	tcmariadb "github.com/testcontainers/testcontainers-go/modules/mariadb"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/scheduler"
+)
+
+const (
+	testUser       = "generated_user"
+	testPass       = "strongpassword"
+	testNamespace  = "default"
+	testSecretName = "testpass"
+	testSecretKey  = "password"
+)
+
+type generatorMockClient struct {
+	client.Client
+}
+
+func (m generatorMockClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	if key.Name == testSecretName {
+		obj.(*corev1.Secret).Data = map[string][]byte{
+			testSecretKey: []byte(testPass),
+		}
+	}
+	return nil
+}
+
+func TestGrantStatement(t *testing.T) {
+	tests := []struct {
+		name  string
+		grant enterprise.MySQLGrant
+		want  string
+	}{
+		{
+			name:  "global",
+			grant: enterprise.MySQLGrant{Privileges: []string{"PROCESS"}},
+			want:  "GRANT PROCESS ON *.* TO ?@?",
+		},
+		{
+			name:  "database",
+			grant: enterprise.MySQLGrant{Privileges: []string{"select", "INSERT"}, Database: "app"},
+			want:  "GRANT SELECT, INSERT ON `app`.* TO ?@?",
+		},
+		{
+			name:  "table with grant option",
+			grant: enterprise.MySQLGrant{Privileges: []string{"ALL PRIVILEGES"}, Database: "app", Table: "or`ders", WithGrantOption: true},
+			want:  "GRANT ALL PRIVILEGES ON `app`.`or``ders` TO ?@? WITH GRANT OPTION",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grantStatement(tt.grant))
+		})
+	}
+}
+
+func TestRequireClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		tls      *enterprise.MySQLUserTLS
+		want     string
+		wantArgs []any
+	}{
+		{
+			name: "no tls",
+			want: "REQUIRE NONE",
+		},
+		{
+			name: "ssl",
+			tls:  &enterprise.MySQLUserTLS{Require: "SSL"},
+			want: "REQUIRE SSL",
+		},
+		{
+			name:     "issuer and subject",
+			tls:      &enterprise.MySQLUserTLS{Issuer: "/CN=ca", Subject: "/CN=app"},
+			want:     "REQUIRE ISSUER ? AND SUBJECT ?",
+			wantArgs: []any{"/CN=ca", "/CN=app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, requireClause(tt.tls))
+			assert.Equal(t, tt.wantArgs, requireArgs(tt.tls))
+		})
+	}
+}
+
+func TestValidateUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    enterprise.MySQLUser
+		wantErr string
+	}{
+		{
+			name: "valid",
+			user: enterprise.MySQLUser{Username: "app", Grants: []enterprise.MySQLGrant{{Privileges: []string{"SELECT"}, Database: "app"}}},
+		},
+		{
+			name:    "missing username",
+			user:    enterprise.MySQLUser{},
+			wantErr: "user.username is required",
+		},
+		{
+			name:    "injected privilege",
+			user:    enterprise.MySQLUser{Username: "app", Grants: []enterprise.MySQLGrant{{Privileges: []string{"SELECT ON *.* TO root; --"}}}},
+			wantErr: "invalid privilege",
+		},
+		{
+			name:    "username too long with suffix",
+			user:    enterprise.MySQLUser{Username: "a-very-long-application-name"},
+			wantErr: "must not be longer than 32 characters, got 37",
+		},
+		{
+			name: "long username without suffix",
+			user: enterprise.MySQLUser{Username: "a-very-long-application-name", SuffixSize: ptr.To(0)},
+		},
+		{
+			name:    "table without database",
+			user:    enterprise.MySQLUser{Username: "app", Grants: []enterprise.MySQLGrant{{Privileges: []string{"SELECT"}, Table: "orders"}}},
+			wantErr: "requires a database",
+		},
+		{
+			name: "tls requirement",
+			user: enterprise.MySQLUser{Username: "app", TLS: &enterprise.MySQLUserTLS{Require: "X509"}},
+		},
+		{
+			name:    "injected tls requirement",
+			user:    enterprise.MySQLUser{Username: "app", TLS: &enterprise.MySQLUserTLS{Require: "NONE; DROP USER root"}},
+			wantErr: "invalid tls.require",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUser(&tt.user)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+type MySQLTestSuite struct {
+	suite.Suite
+	ctx    context.Context
+	client generatorMockClient
+	db     *sql.DB
+	host   string
+	port   string
+}
+
+func TestMySQLGeneratorTestSuite(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	suite.Run(t, new(MySQLTestSuite))
+}
+
+func (s *MySQLTestSuite) SetupSuite() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.ctx = ctx
+
+	container, err := tcmariadb.Run(s.ctx,
+		"mariadb:11",
+		tcmariadb.WithDatabase("app"),
+		tcmariadb.WithUsername("root"),
+		tcmariadb.WithPassword(testPass),
+	)
+	require.NoError(s.T(), err)
+	s.host, err = container.Host(s.ctx)
+	require.NoError(s.T(), err)
+	port, err := container.MappedPort(s.ctx, "3306/tcp")
+	require.NoError(s.T(), err)
+	s.port = port.Port()
+
+	cfg := mysql.NewConfig()
+	cfg.User = "root"
+	cfg.Passwd = testPass
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(s.host, s.port)
+	cfg.InterpolateParams = true
+	connector, err := mysql.NewConnector(cfg)
+	require.NoError(s.T(), err)
+	s.db = sql.OpenDB(connector)
+	require.NoError(s.T(), s.db.PingContext(s.ctx))
+	_, err = s.db.ExecContext(s.ctx, "CREATE TABLE app.orders (id INT PRIMARY KEY)")
+	require.NoError(s.T(), err)
+
+	cl := fake.NewClientBuilder().Build()
+	log := testr.NewWithOptions(s.T(), testr.Options{Verbosity: 1})
+	sched := scheduler.New(cl, log)
+	scheduler.SetGlobal(sched)
+	go func() {
+		if err := sched.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			s.T().Errorf("scheduler.Start: %v", err)
+		}
+	}()
+
+	s.T().Cleanup(func() {
+		_ = s.db.Close()
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			s.T().Logf("failed to terminate container: %s", err)
+		}
+		cancel()
+	})
+}
+
+func (s *MySQLTestSuite) newGeneratorSpec(username string) *enterprise.MySQL {
+	return &enterprise.MySQL{
+		Spec: enterprise.MySQLSpec{
+			Host: s.host,
+			Port: s.port,
+			Auth: enterprise.MySQLAuth{
+				Username: "root",
+				Password: esmeta.SecretKeySelector{
+					Name: testSecretName,
+					Key:  testSecretKey,
+				},
+			},
+			User: enterprise.MySQLUser{
+				Username: username,
+				Hosts:    []string{"%", "localhost"},
+				Grants: []enterprise.MySQLGrant{
+					{Privileges: []string{"SELECT", "INSERT"}, Database: "app"},
+					{Privileges: []string{"DELETE"}, Database: "app", Table: "orders"},
+				},
+				MaxUserConnections: ptr.To(5),
+			},
+		},
+	}
+}
+
+func (s *MySQLTestSuite) accounts(username string) []string {
+	rows, err := s.db.QueryContext(s.ctx, "SELECT Host FROM mysql.user WHERE User = ? ORDER BY Host", username)
+	require.NoError(s.T(), err)
+	defer func() {
+		_ = rows.Close()
+	}()
+	var hosts []string
+	for rows.Next() {
+		var host string
+		require.NoError(s.T(), rows.Scan(&host))
+		hosts = append(hosts, host)
+	}
+	require.NoError(s.T(), rows.Err())
+	return hosts
+}
+
+func (s *MySQLTestSuite) grants(username, host string) []string {
+	rows, err := s.db.QueryContext(s.ctx, "SHOW GRANTS FOR ?@?", username, host)
+	require.NoError(s.T(), err)
+	defer func() {
+		_ = rows.Close()
+	}()
+	var grants []string
+	for rows.Next() {
+		var grant string
+		require.NoError(s.T(), rows.Scan(&grant))
+		grants = append(grants, grant)
+	}
+	require.NoError(s.T(), rows.Err())
+	return grants
+}
+
+func (s *MySQLTestSuite) TestGenerateAndCleanupUser() {
+	username := fmt.Sprintf("%s_generate", testUser)
+	specJSON, err := yaml.Marshal(s.newGeneratorSpec(username))
+	require.NoError(s.T(), err)
+
+	gen := &Generator{}
+	result, state, err := gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), result, "password")
+	assert.Regexp(s.T(), regexp.MustCompile(fmt.Sprintf(`^%s_[a-zA-Z0-9]{8}$`, username)), string(result["username"]))
+
+	generatedUsername := string(result["username"])
+	assert.Equal(s.T(), []string{"%", "localhost"}, s.accounts(generatedUsername))
+	grants := s.grants(generatedUsername, "%")
+	assert.Contains(s.T(), grants, fmt.Sprintf("GRANT SELECT, INSERT ON `app`.* TO `%s`@`%%`", generatedUsername))
+	assert.Contains(s.T(), grants, fmt.Sprintf("GRANT DELETE ON `app`.`orders` TO `%s`@`%%`", generatedUsername))
+
+	// The generated credentials can log in.
+	cfg := mysql.NewConfig()
+	cfg.User = generatedUsername
+	cfg.Passwd = string(result["password"])
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(s.host, s.port)
+	cfg.DBName = "app"
+	connector, err := mysql.NewConnector(cfg)
+	require.NoError(s.T(), err)
+	userDB := sql.OpenDB(connector)
+	defer func() {
+		_ = userDB.Close()
+	}()
+	require.NoError(s.T(), userDB.PingContext(s.ctx))
+
+	err = gen.Cleanup(s.ctx, &apiextensions.JSON{Raw: specJSON}, state, s.client, testNamespace)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), s.accounts(generatedUsername))
+}
+
+func (s *MySQLTestSuite) TestGenerateUserWithSameUsername() {
+	username := fmt.Sprintf("%s_same", testUser)
+	spec := s.newGeneratorSpec(username)
+	spec.Spec.User.SuffixSize = ptr.To(0)
+	specJSON, err := yaml.Marshal(spec)
+	require.NoError(s.T(), err)
+
+	gen := &Generator{}
+	first, _, err := gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), username, string(first["username"]))
+
+	// Generating again resets the password and the grants of the existing user.
+	spec.Spec.User.Grants = []enterprise.MySQLGrant{{Privileges: []string{"SELECT"}, Database: "app"}}
+	specJSON, err = yaml.Marshal(spec)
+	require.NoError(s.T(), err)
+	second, state, err := gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), username, string(second["username"]))
+	assert.NotEqual(s.T(), first["password"], second["password"])
+
+	grants := s.grants(username, "%")
+	assert.Contains(s.T(), grants, fmt.Sprintf("GRANT SELECT ON `app`.* TO `%s`@`%%`", username))
+	assert.NotContains(s.T(), grants, fmt.Sprintf("GRANT DELETE ON `app`.`orders` TO `%s`@`%%`", username))
+
+	require.NoError(s.T(), gen.Cleanup(s.ctx, &apiextensions.JSON{Raw: specJSON}, state, s.client, testNamespace))
+	assert.Empty(s.T(), s.accounts(username))
+}
+
+func (s *MySQLTestSuite) TestGenerateWithIdleCleanup() {
+	username := fmt.Sprintf("%s_idle", testUser)
+	spec := s.newGeneratorSpec(username)
+	spec.Spec.CleanupPolicy = &enterprise.MySQLCleanupPolicy{
+		ActivityTrackingInterval: metav1.Duration{Duration: time.Second},
+		CleanupPolicy: genv1alpha1.CleanupPolicy{
+			Type:        genv1alpha1.IdleCleanupPolicy,
+			IdleTimeout: metav1.Duration{Duration: time.Second * 10},
+			GracePeriod: metav1.Duration{Duration: time.Second * 10},
+		},
+	}
+	specJSON, err := yaml.Marshal(spec)
+	require.NoError(s.T(), err)
+
+	gen := &Generator{}
+	result, state, err := gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	require.NoError(s.T(), err)
+
+	lastActivity, ok, err := gen.LastActivityTime(s.ctx, &apiextensions.JSON{Raw: specJSON}, state, s.client, testNamespace)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), time.Unix(0, 0), lastActivity)
+
+	// Keep a session of the user open until it is observed.
+	cfg := mysql.NewConfig()
+	cfg.User = string(result["username"])
+	cfg.Passwd = string(result["password"])
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(s.host, s.port)
+	connector, err := mysql.NewConnector(cfg)
+	require.NoError(s.T(), err)
+	userDB := sql.OpenDB(connector)
+	defer func() {
+		_ = userDB.Close()
+	}()
+	require.NoError(s.T(), userDB.PingContext(s.ctx))
+
+	assert.Eventually(s.T(), func() bool {
+		lastActivity, _, err := gen.LastActivityTime(s.ctx, &apiextensions.JSON{Raw: specJSON}, state, s.client, testNamespace)
+		return err == nil && lastActivity.After(time.Unix(0, 0))
+	}, 30*time.Second, time.Second)
+
+	require.NoError(s.T(), gen.Cleanup(s.ctx, &apiextensions.JSON{Raw: specJSON}, state, s.client, testNamespace))
+	assert.Empty(s.T(), s.accounts(string(result["username"])))
+}
@@ -61,10 +61,41 @@ func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON,
 		return nil, nil, err
 	}
 
+	if res.Spec.RotationPolicy != nil && res.Spec.RotationPolicy.Strategy == genv1alpha1.AlternatingRotationStrategy {
+		return nil, nil, errors.New("the alternating rotation strategy requires the generator state to be enabled")
+	}
+	if strings.Contains(res.Spec.User.User, "-") {
+		return nil, nil, fmt.Errorf("invalid username %q: must not contain dashes (-)", res.Spec.User.User)
+	}
+
+	username, err := buildUsername(res.Spec.User)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g.generate(ctx, res, kube, namespace, username, nil)
+}
+
+// GenerateAlternating creates the user of the given slot or replaces it with a new password.
+func (g *Generator) GenerateAlternating(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace, slot string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Spec.User == nil || res.Spec.User.User == "" {
+		return nil, nil, errors.New("a user is required to alternate users")
+	}
 	if strings.Contains(res.Spec.User.User, "-") {
 		return nil, nil, fmt.Errorf("invalid username %q: must not contain dashes (-)", res.Spec.User.User)
 	}
 
+	usernames := []string{
+		alternatingUsername(res.Spec.User.User, genv1alpha1.AlternatingSlotA),
+		alternatingUsername(res.Spec.User.User, genv1alpha1.AlternatingSlotB),
+	}
+	return g.generate(ctx, res, kube, namespace, alternatingUsername(res.Spec.User.User, slot), usernames)
+}
+
+func (g *Generator) generate(ctx context.Context, res *enterprise.Neo4j, kube client.Client, namespace, username string, usernames []string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
 	driver, err := newDriver(ctx, &res.Spec.Auth, kube, namespace)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to create driver: %w", err)
@@ -89,15 +120,15 @@ func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON,
 		res.Spec.User.Provider = defaultProvider
 	}
 
-	user, err := createOrReplaceUser(ctx, driver, &res.Spec)
+	user, err := createOrReplaceUser(ctx, driver, &res.Spec, username)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to create or replace user: %w", err)
 	}
 
 	if res.Spec.Enterprise {
-		err = addRolesToUser(ctx, driver, &res.Spec)
+		err = addRolesToUser(ctx, driver, &res.Spec, username)
 		if err != nil {
-			dropErr := dropUser(ctx, driver, res.Spec.User.User)
+			dropErr := dropUser(ctx, driver, username)
 			if dropErr != nil {
 				return nil, nil, fmt.Errorf("unable to drop user: %w", dropErr)
 			}
@@ -105,13 +136,9 @@ func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON,
 		}
 	}
 
-	username, ok := user["user"]
-	if !ok {
-		return nil, nil, fmt.Errorf("user not found in response")
-	}
-
 	rawState, err := json.Marshal(&enterprise.Neo4jUserState{
-		User: string(username),
+		User:      username,
+		Usernames: usernames,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to marshal state: %w", err)
@@ -149,15 +176,21 @@ func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, p
 		return fmt.Errorf("unable to verify connectivity: %w", err)
 	}
 
-	if res.Spec.Enterprise {
-		err = suspendUser(ctx, driver, status.User)
-		if err != nil {
-			return fmt.Errorf("unable to suspend user: %w", err)
-		}
-	} else {
-		err = dropUser(ctx, driver, status.User)
-		if err != nil {
-			return fmt.Errorf("unable to drop user: %w", err)
+	users := status.Usernames
+	if len(users) == 0 {
+		users = []string{status.User}
+	}
+	for _, user := range users {
+		if res.Spec.Enterprise {
+			err = suspendUser(ctx, driver, user)
+			if err != nil {
+				return fmt.Errorf("unable to suspend user: %w", err)
+			}
+		} else {
+			err = dropUser(ctx, driver, user)
+			if err != nil {
+				return fmt.Errorf("unable to drop user: %w", err)
+			}
 		}
 	}
 
@@ -169,6 +202,15 @@ func (g *Generator) GetCleanupPolicy(_ *apiextensions.JSON) (*genv1alpha1.Cleanu
 	return nil, nil
 }
 
+// GetRotationPolicy returns the rotation policy of the generator.
+func (g *Generator) GetRotationPolicy(obj *apiextensions.JSON) (*genv1alpha1.RotationPolicy, error) {
+	res, err := parseSpec(obj.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return res.Spec.RotationPolicy, nil
+}
+
 // LastActivityTime returns the last activity time for generated resources.
 func (g *Generator) LastActivityTime(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) (time.Time, bool, error) {
 	return time.Time{}, false, nil
@@ -238,21 +280,29 @@ func newDriver(ctx context.Context, auth *enterprise.Neo4jAuth, kclient client.C
 	)
 }
 
-func createOrReplaceUser(ctx context.Context, driver neo4j.DriverWithContext, spec *enterprise.Neo4jSpec) (map[string][]byte, error) {
-	var query strings.Builder
-	username := spec.User.User
+func buildUsername(user *enterprise.Neo4jUser) (string, error) {
+	username := user.User
 	suffixSize := defaultSuffixSize
-	if spec.User.SuffixSize != nil {
-		suffixSize = *spec.User.SuffixSize
+	if user.SuffixSize != nil {
+		suffixSize = *user.SuffixSize
 	}
 	suffix, err := utils.GenerateRandomString(suffixSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate random suffix: %w", err)
+		return "", fmt.Errorf("failed to generate random suffix: %w", err)
 	}
 
 	if suffix != "" {
 		username = fmt.Sprintf("%s_%s", username, suffix)
 	}
+	return username, nil
+}
+
+func alternatingUsername(username, slot string) string {
+	return fmt.Sprintf("%s_%s", username, slot)
+}
+
+func createOrReplaceUser(ctx context.Context, driver neo4j.DriverWithContext, spec *enterprise.Neo4jSpec, username string) (map[string][]byte, error) {
+	var query strings.Builder
 	sanitizedUsername, err := EscapeNeo4jIdentifier(username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sanitize username %q: %w", username, err)
@@ -304,7 +354,7 @@ func createOrReplaceUser(ctx context.Context, driver neo4j.DriverWithContext, sp
 	return nil, fmt.Errorf("unsupported auth provider: %s", spec.User.Provider)
 }
 
-func addRolesToUser(ctx context.Context, driver neo4j.DriverWithContext, spec *enterprise.Neo4jSpec) error {
+func addRolesToUser(ctx context.Context, driver neo4j.DriverWithContext, spec *enterprise.Neo4jSpec, username string) error {
 	if len(spec.User.Roles) == 0 {
 		return nil
 	}
@@ -344,9 +394,9 @@ func addRolesToUser(ctx context.Context, driver neo4j.DriverWithContext, spec *e
 		sanitizedRoles = append(sanitizedRoles, sanitizedRole)
 	}
 
-	sanitizedUsername, err := EscapeNeo4jIdentifier(spec.User.User)
+	sanitizedUsername, err := EscapeNeo4jIdentifier(username)
 	if err != nil {
-		return fmt.Errorf("failed to sanitize username %q: %w", spec.User.User, err)
+		return fmt.Errorf("failed to sanitize username %q: %w", username, err)
 	}
 	query := fmt.Sprintf("GRANT ROLE %s TO %s", strings.Join(sanitizedRoles, ", "), sanitizedUsername)
 	_, err = neo4j.ExecuteQuery(ctx, driver,
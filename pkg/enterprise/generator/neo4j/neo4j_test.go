@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	neo4jSDK "github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/stretchr/testify/assert"
@@ -347,3 +348,58 @@ func (s *Neo4jTestSuite) TestNeo4jCleanupAfterUserDBManipulation() {
 	)
 	require.NoError(s.T(), err)
 }
+
+func (s *Neo4jTestSuite) verifyConnectivity(user string, password []byte) error {
+	customClient := generatorMockClient{t: s.T(), userPassword: password}
+	driver, err := newDriver(s.ctx, &enterprise.Neo4jAuth{
+		URI: s.uri,
+		Basic: &enterprise.Neo4jBasicAuth{
+			Username: user,
+			Password: esmeta.SecretKeySelector{
+				Name: testGeneratedSecretName,
+				Key:  testSecretKey,
+			},
+		},
+	}, customClient, testNamespace)
+	require.NoError(s.T(), err)
+	defer closeDriver(s.T(), s.ctx, driver)
+	return driver.VerifyConnectivity(s.ctx)
+}
+
+func (s *Neo4jTestSuite) TestNeo4jGenerateAlternating() {
+	user := fmt.Sprintf("%s_TestNeo4jGenerateAlternating", testUser)
+	spec := newGeneratorSpec(s.T(), s.uri, user)
+	spec.Spec.RotationPolicy = &genv1alpha1.RotationPolicy{Strategy: genv1alpha1.AlternatingRotationStrategy}
+	specJSON, _ := yaml.Marshal(spec)
+
+	gen := &Generator{}
+	_, _, err := gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	require.ErrorContains(s.T(), err, "requires the generator state")
+
+	first, _, err := gen.GenerateAlternating(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace, genv1alpha1.AlternatingSlotA)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), user+"_a", string(first["user"]))
+	require.NoError(s.T(), s.verifyConnectivity(user+"_a", first[testSecretKey]))
+
+	// Resetting the inactive user does not change the active one.
+	second, rawStatus, err := gen.GenerateAlternating(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace, genv1alpha1.AlternatingSlotB)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), user+"_b", string(second["user"]))
+	require.NoError(s.T(), s.verifyConnectivity(user+"_a", first[testSecretKey]))
+	require.NoError(s.T(), s.verifyConnectivity(user+"_b", second[testSecretKey]))
+
+	status, err := parseStatus(rawStatus.Raw)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), user+"_b", status.User)
+	assert.Equal(s.T(), []string{user + "_a", user + "_b"}, status.Usernames)
+
+	third, _, err := gen.GenerateAlternating(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace, genv1alpha1.AlternatingSlotA)
+	require.NoError(s.T(), err)
+	require.ErrorContains(s.T(), s.verifyConnectivity(user+"_a", first[testSecretKey]), "Neo.ClientError.Security.Unauthorized")
+	require.NoError(s.T(), s.verifyConnectivity(user+"_a", third[testSecretKey]))
+
+	err = gen.Cleanup(s.ctx, &apiextensions.JSON{Raw: specJSON}, rawStatus, s.client, testNamespace)
+	require.NoError(s.T(), err)
+	require.ErrorContains(s.T(), s.verifyConnectivity(user+"_a", third[testSecretKey]), "Neo.ClientError.Security.Unauthorized")
+	require.ErrorContains(s.T(), s.verifyConnectivity(user+"_b", second[testSecretKey]), "Neo.ClientError.Security.Unauthorized")
+}
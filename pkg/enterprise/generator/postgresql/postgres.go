@@ -41,11 +41,9 @@ import (
 	"sigs.k8s.io/yaml"
 
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
-	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	"github.com/external-secrets/external-secrets/generators/v1/password"
 	"github.com/external-secrets/external-secrets/pkg/enterprise/scheduler"
 	utils "github.com/external-secrets/external-secrets/runtime/esutils"
-	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
 )
 
 // Generator implements the PostgreSQL user generator.
@@ -77,7 +75,35 @@ func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON,
 	if err != nil {
 		return nil, nil, err
 	}
+	if res.Spec.RotationPolicy != nil && res.Spec.RotationPolicy.Strategy == genv1alpha1.AlternatingRotationStrategy {
+		return nil, nil, errors.New("the alternating rotation strategy requires the generator state to be enabled")
+	}
+
+	username, err := buildUsername(res.Spec.User)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g.generate(ctx, jsonSpec, res, kube, namespace, username, nil)
+}
+
+// GenerateAlternating creates the user of the given slot or resets its password.
+func (g *Generator) GenerateAlternating(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace, slot string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Spec.User == nil || res.Spec.User.Username == "" {
+		return nil, nil, errors.New("a username is required to alternate users")
+	}
 
+	usernames := []string{
+		alternatingUsername(res.Spec.User.Username, genv1alpha1.AlternatingSlotA),
+		alternatingUsername(res.Spec.User.Username, genv1alpha1.AlternatingSlotB),
+	}
+	return g.generate(ctx, jsonSpec, res, kube, namespace, alternatingUsername(res.Spec.User.Username, slot), usernames)
+}
+
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, res *enterprise.PostgreSQL, kube client.Client, namespace, username string, usernames []string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
 	db, err := newConnection(ctx, &res.Spec, kube, namespace)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to create db connection: %w", err)
@@ -113,18 +139,14 @@ func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON,
 		})
 	}
 
-	user, err := createUser(ctx, db, &res.Spec)
+	user, err := createUser(ctx, db, &res.Spec, username)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to create or update user: %w", err)
 	}
 
-	username, ok := user["username"]
-	if !ok {
-		return nil, nil, fmt.Errorf("user not found in response")
-	}
-
 	rawState, err := json.Marshal(&enterprise.PostgreSQLUserState{
-		Username: string(username),
+		Username:  username,
+		Usernames: usernames,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to marshal state: %w", err)
@@ -162,11 +184,28 @@ func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, p
 		return fmt.Errorf("unable to ping the database: %w", err)
 	}
 
-	err = dropUser(ctx, db, status.Username, res.Spec)
-	if err != nil {
-		return fmt.Errorf("unable to drop user: %w", err)
+	if len(status.Usernames) == 0 {
+		err = dropUser(ctx, db, status.Username, res.Spec)
+		if err != nil {
+			return fmt.Errorf("unable to drop user: %w", err)
+		}
+		return nil
 	}
 
+	// The second alternating user is only created on the second rotation.
+	currentRoles, err := getExistingRoles(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to get existing roles: %w", err)
+	}
+	for _, username := range status.Usernames {
+		if !slices.Contains(currentRoles, username) {
+			continue
+		}
+		err = dropUser(ctx, db, username, res.Spec)
+		if err != nil {
+			return fmt.Errorf("unable to drop user: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -188,6 +227,15 @@ func (g *Generator) GetCleanupPolicy(obj *apiextensions.JSON) (*genv1alpha1.Clea
 	return &policy, nil
 }
 
+// GetRotationPolicy returns the rotation policy of the generator.
+func (g *Generator) GetRotationPolicy(obj *apiextensions.JSON) (*genv1alpha1.RotationPolicy, error) {
+	res, err := parseSpec(obj.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return res.Spec.RotationPolicy, nil
+}
+
 // LastActivityTime returns the last activity time of the user.
 func (g *Generator) LastActivityTime(ctx context.Context, obj *apiextensions.JSON, state genv1alpha1.GeneratorProviderState, kube client.Client, namespace string) (time.Time, bool, error) {
 	status, err := parseStatus(state.Raw)
@@ -244,21 +292,33 @@ func newConnection(ctx context.Context, spec *enterprise.PostgreSQLSpec, kclient
 	if spec.Auth.Username != "" {
 		username = spec.Auth.Username
 	}
-	password, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
-		Namespace: &ns,
-		Name:      spec.Auth.Password.Name,
-		Key:       spec.Auth.Password.Key,
-	})
+	tlsConfig, err := newTLSConfig(ctx, spec.TLS, spec.Host, kclient, ns)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to configure tls: %w", err)
 	}
 
-	psqlInfo := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		spec.Host, port, username, password, dbName,
-	)
+	// TLS is configured below, as pgx only loads certificates from files.
+	cfg, err := pgx.ParseConfig(fmt.Sprintf("host=%s port=%s dbname=%s sslmode=disable", spec.Host, port, dbName))
+	if err != nil {
+		return nil, err
+	}
+	password, release, err := adminPassword(ctx, &spec.Auth, kclient, ns)
+	if err != nil {
+		return nil, err
+	}
+	cfg.User = username
+	cfg.Password = password
+	cfg.TLSConfig = tlsConfig
 
-	return pgx.Connect(ctx, psqlInfo)
+	// The token only authenticates the connection, so it is released right after connecting.
+	db, err := pgx.ConnectConfig(ctx, cfg)
+	if releaseErr := release(ctx); releaseErr != nil {
+		if db != nil {
+			_ = db.Close(ctx)
+		}
+		return nil, errors.Join(err, releaseErr)
+	}
+	return db, err
 }
 
 func createSessionObservationTable(ctx context.Context, db *pgx.Conn) error {
@@ -457,6 +517,9 @@ func resetRole(ctx context.Context, db *pgx.Conn, roleName string) error {
 		return fmt.Errorf("error iterating granted roles: %w", err)
 	}
 
+	if len(grantedRoles) == 0 {
+		return nil
+	}
 	rolesCSV := strings.Join(grantedRoles, ", ")
 
 	_, err = db.Exec(ctx, fmt.Sprintf("REVOKE %s FROM %s", rolesCSV, sanitizedRole))
@@ -467,20 +530,33 @@ func resetRole(ctx context.Context, db *pgx.Conn, roleName string) error {
 	return nil
 }
 
-func createUser(ctx context.Context, db *pgx.Conn, spec *enterprise.PostgreSQLSpec) (map[string][]byte, error) {
-	username := spec.User.Username
+func buildUsername(user *enterprise.PostgreSQLUser) (string, error) {
+	username := user.Username
 	suffixSize := defaultSuffixSize
-	if spec.User.SuffixSize != nil {
-		suffixSize = *spec.User.SuffixSize
+	if user.SuffixSize != nil {
+		suffixSize = *user.SuffixSize
 	}
 	suffix, err := utils.GenerateRandomString(suffixSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate random suffix: %w", err)
+		return "", fmt.Errorf("failed to generate random suffix: %w", err)
 	}
 
 	if suffix != "" {
 		username = fmt.Sprintf("%s_%s", username, suffix)
 	}
+	return username, nil
+}
+
+func alternatingUsername(username, slot string) string {
+	return fmt.Sprintf("%s_%s", username, slot)
+}
+
+func createUser(ctx context.Context, db *pgx.Conn, spec *enterprise.PostgreSQLSpec, username string) (map[string][]byte, error) {
+	// Privileges are validated before the role is created.
+	grants, err := grantStatements(username, spec.User.Privileges)
+	if err != nil {
+		return nil, fmt.Errorf("invalid privileges: %w", err)
+	}
 
 	currentRoles, err := getExistingRoles(ctx, db)
 	if err != nil {
@@ -505,7 +581,8 @@ func createUser(ctx context.Context, db *pgx.Conn, spec *enterprise.PostgreSQLSp
 		},
 	)
 
-	if !slices.Contains(currentRoles, username) {
+	created := !slices.Contains(currentRoles, username)
+	if created {
 		err = createRole(ctx, db, username, spec.User.Attributes)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create role %s: %w", username, err)
@@ -515,15 +592,25 @@ func createUser(ctx context.Context, db *pgx.Conn, spec *enterprise.PostgreSQLSp
 		if err != nil {
 			return nil, fmt.Errorf("failed to reset role %s: %w", username, err)
 		}
+		err = revokePrivileges(ctx, db, username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to revoke privileges of role %s: %w", username, err)
+		}
 		err = updateRole(ctx, db, username, spec.User.Attributes)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create role %s: %w", username, err)
 		}
 	}
 
-	err = grantRolesToUser(ctx, db, username, spec.User.Roles, currentRoles)
+	err = configureUser(ctx, db, spec, username, grants, currentRoles)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add roles to user %s: %w", username, err)
+		// A role created for this request is dropped, so that a failed grant does not leave it behind.
+		if created {
+			if dropErr := dropUser(ctx, db, username, *spec); dropErr != nil {
+				return nil, errors.Join(err, fmt.Errorf("failed to drop role %s: %w", username, dropErr))
+			}
+		}
+		return nil, err
 	}
 
 	return map[string][]byte{
@@ -532,6 +619,21 @@ func createUser(ctx context.Context, db *pgx.Conn, spec *enterprise.PostgreSQLSp
 	}, nil
 }
 
+// configureUser grants the roles, privileges and search_path of the spec to the user.
+func configureUser(ctx context.Context, db *pgx.Conn, spec *enterprise.PostgreSQLSpec, username string, grants, currentRoles []string) error {
+	err := grantRolesToUser(ctx, db, username, spec.User.Roles, currentRoles)
+	if err != nil {
+		return fmt.Errorf("failed to add roles to user %s: %w", username, err)
+	}
+
+	err = execStatements(ctx, db, grants)
+	if err != nil {
+		return fmt.Errorf("failed to grant privileges to user %s: %w", username, err)
+	}
+
+	return setSearchPath(ctx, db, username, spec.User.SearchPath)
+}
+
 func grantRolesToUser(ctx context.Context, db *pgx.Conn, username string, roles, currentRoles []string) error {
 	sanitizedUsername := pgx.Identifier{username}.Sanitize()
 
@@ -583,6 +685,11 @@ func dropUser(ctx context.Context, db *pgx.Conn, username string, spec enterpris
 			return fmt.Errorf("failed to reassign owned by %s to %s: %w", username, reassignToUser, err)
 		}
 	}
+	// Privileges on other databases are not revoked by DROP OWNED and prevent dropping the role.
+	err := revokePrivileges(ctx, db, username)
+	if err != nil {
+		return fmt.Errorf("failed to revoke privileges of %s: %w", username, err)
+	}
 	dropQueries := []string{
 		`DROP OWNED BY %s`,
 		`DROP ROLE %s`,
@@ -416,6 +416,57 @@ func (s *PostgresTestSuite) TestGenerateUserWithoutSuffix() {
 	require.NoError(s.T(), err)
 }
 
+func (s *PostgresTestSuite) TestGenerateAlternatingUsers() {
+	username := fmt.Sprintf("%s_Alternating", testUser)
+
+	spec := newGeneratorSpec(s.T(), "localhost", s.port.Port(), username, true, nil)
+	spec.Spec.RotationPolicy = &genv1alpha1.RotationPolicy{Strategy: genv1alpha1.AlternatingRotationStrategy}
+	specJSON, err := yaml.Marshal(spec)
+	require.NoError(s.T(), err)
+
+	gen := &Generator{}
+
+	policy, err := gen.GetRotationPolicy(&apiextensions.JSON{Raw: specJSON})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), genv1alpha1.AlternatingRotationStrategy, policy.Strategy)
+
+	_, _, err = gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	assert.ErrorContains(s.T(), err, "requires the generator state")
+
+	first, statusRaw, err := gen.GenerateAlternating(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace, genv1alpha1.AlternatingSlotA)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), username+"_a", string(first["username"]))
+	assert.JSONEq(s.T(), fmt.Sprintf(`{"username":"%[1]s_a","usernames":["%[1]s_a","%[1]s_b"]}`, username), string(statusRaw.Raw))
+
+	// Only the first user exists until the users are flipped.
+	err = gen.Cleanup(s.ctx, &apiextensions.JSON{Raw: specJSON}, statusRaw, s.client, testNamespace)
+	require.NoError(s.T(), err)
+
+	first, _, err = gen.GenerateAlternating(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace, genv1alpha1.AlternatingSlotA)
+	require.NoError(s.T(), err)
+	reset, _, err := gen.GenerateAlternating(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace, genv1alpha1.AlternatingSlotA)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), first["username"], reset["username"])
+	assert.NotEqual(s.T(), first["password"], reset["password"])
+
+	second, statusRaw, err := gen.GenerateAlternating(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace, genv1alpha1.AlternatingSlotB)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), username+"_b", string(second["username"]))
+	s.verifyAttributes(username+"_a", true, true, false, false, false, true, false, -1)
+	s.verifyAttributes(username+"_b", true, true, false, false, false, true, false, -1)
+	s.verifyGrantedRoles(username+"_b", []string{"pg_read_all_data", "customrole"})
+
+	err = gen.Cleanup(s.ctx, &apiextensions.JSON{Raw: specJSON}, statusRaw, s.client, testNamespace)
+	require.NoError(s.T(), err)
+
+	for _, user := range []string{username + "_a", username + "_b"} {
+		row := s.db.QueryRow(s.ctx, `SELECT 1 FROM pg_roles WHERE rolname = $1`, user)
+		var dummy int
+		err = row.Scan(&dummy)
+		assert.ErrorIs(s.T(), err, sql.ErrNoRows)
+	}
+}
+
 func (s *PostgresTestSuite) TestNonDestructiveCleanup() {
 	username := fmt.Sprintf("%s_NonDestructive", testUser)
 
@@ -641,3 +692,101 @@ func (s *PostgresTestSuite) TestDestructiveCleanup() {
 	err = row.Scan(&dummy)
 	assert.ErrorIs(s.T(), err, sql.ErrNoRows)
 }
+
+func (s *PostgresTestSuite) hasPrivilege(query, username, object, privilege string) bool {
+	var granted bool
+	require.NoError(s.T(), s.db.QueryRow(s.ctx, query, username, object, privilege).Scan(&granted))
+	return granted
+}
+
+func (s *PostgresTestSuite) TestGenerateWithPrivileges() {
+	_, err := s.db.Exec(s.ctx, `
+		CREATE SCHEMA IF NOT EXISTS sales;
+		CREATE TABLE IF NOT EXISTS sales.orders (id SERIAL PRIMARY KEY);
+	`)
+	require.NoError(s.T(), err)
+
+	username := fmt.Sprintf("%s_TestPrivileges", testUser)
+	spec := newGeneratorSpec(s.T(), "localhost", s.port.Port(), username, true, nil)
+	spec.Spec.User.SuffixSize = ptr.To(0)
+	spec.Spec.User.Roles = nil
+	spec.Spec.User.SearchPath = []string{"sales", "public"}
+	spec.Spec.User.Privileges = &enterprise.PostgreSQLPrivileges{
+		Databases: []enterprise.PostgreSQLDatabasePrivilege{{Name: "postgres", Privileges: []string{"CONNECT"}}},
+		Schemas:   []enterprise.PostgreSQLSchemaPrivilege{{Name: "sales", Privileges: []string{"USAGE"}}},
+		Tables:    []enterprise.PostgreSQLTablePrivilege{{Schema: "sales", Privileges: []string{"SELECT", "INSERT"}}},
+		Sequences: []enterprise.PostgreSQLSequencePrivilege{{Schema: "sales", Privileges: []string{"USAGE"}}},
+		DefaultPrivileges: []enterprise.PostgreSQLDefaultPrivilege{
+			{Schema: "sales", ObjectType: "TABLES", Privileges: []string{"SELECT"}},
+		},
+	}
+	specJSON, err := yaml.Marshal(spec)
+	require.NoError(s.T(), err)
+
+	gen := &Generator{}
+	_, _, err = gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	require.NoError(s.T(), err)
+
+	assert.True(s.T(), s.hasPrivilege(`SELECT has_database_privilege($1, $2, $3)`, username, "postgres", "CONNECT"))
+	assert.True(s.T(), s.hasPrivilege(`SELECT has_schema_privilege($1, $2, $3)`, username, "sales", "USAGE"))
+	assert.True(s.T(), s.hasPrivilege(`SELECT has_table_privilege($1, $2, $3)`, username, "sales.orders", "INSERT"))
+	assert.True(s.T(), s.hasPrivilege(`SELECT has_sequence_privilege($1, $2, $3)`, username, "sales.orders_id_seq", "USAGE"))
+
+	// Tables created later get the default privileges.
+	_, err = s.db.Exec(s.ctx, `CREATE TABLE sales.customers (id INT)`)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), s.hasPrivilege(`SELECT has_table_privilege($1, $2, $3)`, username, "sales.customers", "SELECT"))
+
+	var config []string
+	require.NoError(s.T(), s.db.QueryRow(s.ctx, `SELECT rolconfig FROM pg_roles WHERE rolname = $1`, username).Scan(&config))
+	assert.Equal(s.T(), []string{`search_path="sales", "public"`}, config)
+
+	// Regenerating the user revokes the privileges no longer listed.
+	spec.Spec.User.SearchPath = nil
+	spec.Spec.User.Privileges = &enterprise.PostgreSQLPrivileges{
+		Schemas: []enterprise.PostgreSQLSchemaPrivilege{{Name: "sales", Privileges: []string{"USAGE"}}},
+		Tables:  []enterprise.PostgreSQLTablePrivilege{{Schema: "sales", Tables: []string{"orders"}, Privileges: []string{"SELECT"}}},
+	}
+	specJSON, err = yaml.Marshal(spec)
+	require.NoError(s.T(), err)
+	_, state, err := gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	require.NoError(s.T(), err)
+
+	assert.True(s.T(), s.hasPrivilege(`SELECT has_table_privilege($1, $2, $3)`, username, "sales.orders", "SELECT"))
+	assert.False(s.T(), s.hasPrivilege(`SELECT has_table_privilege($1, $2, $3)`, username, "sales.orders", "INSERT"))
+	assert.False(s.T(), s.hasPrivilege(`SELECT has_table_privilege($1, $2, $3)`, username, "sales.customers", "SELECT"))
+	var defaultACLs int
+	require.NoError(s.T(), s.db.QueryRow(s.ctx, `
+		SELECT count(*) FROM pg_default_acl d CROSS JOIN LATERAL aclexplode(d.defaclacl) a
+		WHERE a.grantee = (SELECT oid FROM pg_roles WHERE rolname = $1)`, username).Scan(&defaultACLs))
+	assert.Zero(s.T(), defaultACLs)
+	require.NoError(s.T(), s.db.QueryRow(s.ctx, `SELECT rolconfig FROM pg_roles WHERE rolname = $1`, username).Scan(&config))
+	assert.Empty(s.T(), config)
+
+	err = gen.Cleanup(s.ctx, &apiextensions.JSON{Raw: specJSON}, state, s.client, testNamespace)
+	require.NoError(s.T(), err)
+	row := s.db.QueryRow(s.ctx, `SELECT 1 FROM pg_roles WHERE rolname = $1`, username)
+	var dummy int
+	assert.ErrorIs(s.T(), row.Scan(&dummy), sql.ErrNoRows)
+}
+
+func (s *PostgresTestSuite) TestGenerateDropsRoleWhenGrantFails() {
+	username := fmt.Sprintf("%s_TestGrantFails", testUser)
+	spec := newGeneratorSpec(s.T(), "localhost", s.port.Port(), username, true, nil)
+	spec.Spec.User.SuffixSize = ptr.To(0)
+	spec.Spec.User.Roles = nil
+	spec.Spec.User.Privileges = &enterprise.PostgreSQLPrivileges{
+		Schemas: []enterprise.PostgreSQLSchemaPrivilege{{Name: "missing", Privileges: []string{"USAGE"}}},
+	}
+	specJSON, err := yaml.Marshal(spec)
+	require.NoError(s.T(), err)
+
+	gen := &Generator{}
+	_, _, err = gen.Generate(s.ctx, &apiextensions.JSON{Raw: specJSON}, s.client, testNamespace)
+	require.ErrorContains(s.T(), err, "failed to grant privileges")
+
+	// The role created for the request is dropped again.
+	row := s.db.QueryRow(s.ctx, `SELECT 1 FROM pg_roles WHERE rolname = $1`, username)
+	var dummy int
+	assert.ErrorIs(s.T(), row.Scan(&dummy), sql.ErrNoRows)
+}
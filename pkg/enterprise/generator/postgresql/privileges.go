@@ -0,0 +1,265 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+)
+
+const defaultSchema = "public"
+
+var (
+	databasePrivileges = []string{"CONNECT", "CREATE", "TEMPORARY", "ALL"}
+	schemaPrivileges   = []string{"USAGE", "CREATE", "ALL"}
+	tablePrivileges    = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", "ALL"}
+	sequencePrivileges = []string{"USAGE", "SELECT", "UPDATE", "ALL"}
+
+	// defaultPrivileges maps the object types of default privileges to their privileges.
+	defaultPrivileges = map[string][]string{
+		"TABLES":    tablePrivileges,
+		"SEQUENCES": sequencePrivileges,
+		"FUNCTIONS": {"EXECUTE", "ALL"},
+		"TYPES":     {"USAGE", "ALL"},
+	}
+
+	// defaultACLObjectTypes maps pg_default_acl.defaclobjtype to object types.
+	defaultACLObjectTypes = map[string]string{
+		"r": "TABLES",
+		"S": "SEQUENCES",
+		"f": "FUNCTIONS",
+		"T": "TYPES",
+		"n": "SCHEMAS",
+	}
+)
+
+// privilegeList validates the privileges against the allowed ones and returns them comma separated.
+// Privileges are keywords that can not be quoted, so anything not allowed is rejected.
+func privilegeList(privileges, allowed []string) (string, error) {
+	if len(privileges) == 0 {
+		return "", fmt.Errorf("at least one privilege is required")
+	}
+	list := make([]string, len(privileges))
+	for i, privilege := range privileges {
+		privilege = strings.ToUpper(strings.TrimSpace(privilege))
+		if !slices.Contains(allowed, privilege) {
+			return "", fmt.Errorf("invalid privilege %q, must be one of %s", privileges[i], strings.Join(allowed, ", "))
+		}
+		list[i] = privilege
+	}
+	return strings.Join(list, ", "), nil
+}
+
+func schemaOrDefault(schema string) string {
+	if schema == "" {
+		return defaultSchema
+	}
+	return schema
+}
+
+// qualifiedNames returns the sanitized names of objects in a schema.
+func qualifiedNames(schema string, names []string) string {
+	qualified := make([]string, len(names))
+	for i, name := range names {
+		qualified[i] = pgx.Identifier{schema, name}.Sanitize()
+	}
+	return strings.Join(qualified, ", ")
+}
+
+// grantStatements returns the statements granting the privileges to the user.
+func grantStatements(username string, privileges *enterprise.PostgreSQLPrivileges) ([]string, error) {
+	if privileges == nil {
+		return nil, nil
+	}
+	user := pgx.Identifier{username}.Sanitize()
+	var statements []string
+
+	for _, p := range privileges.Databases {
+		list, err := privilegeList(p.Privileges, databasePrivileges)
+		if err != nil {
+			return nil, fmt.Errorf("database %s: %w", p.Name, err)
+		}
+		statements = append(statements, fmt.Sprintf("GRANT %s ON DATABASE %s TO %s", list, pgx.Identifier{p.Name}.Sanitize(), user))
+	}
+	for _, p := range privileges.Schemas {
+		list, err := privilegeList(p.Privileges, schemaPrivileges)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", p.Name, err)
+		}
+		statements = append(statements, fmt.Sprintf("GRANT %s ON SCHEMA %s TO %s", list, pgx.Identifier{p.Name}.Sanitize(), user))
+	}
+	for _, p := range privileges.Tables {
+		schema := schemaOrDefault(p.Schema)
+		list, err := privilegeList(p.Privileges, tablePrivileges)
+		if err != nil {
+			return nil, fmt.Errorf("tables in schema %s: %w", schema, err)
+		}
+		target := "ALL TABLES IN SCHEMA " + pgx.Identifier{schema}.Sanitize()
+		if len(p.Tables) > 0 {
+			target = "TABLE " + qualifiedNames(schema, p.Tables)
+		}
+		statements = append(statements, fmt.Sprintf("GRANT %s ON %s TO %s", list, target, user))
+	}
+	for _, p := range privileges.Sequences {
+		schema := schemaOrDefault(p.Schema)
+		list, err := privilegeList(p.Privileges, sequencePrivileges)
+		if err != nil {
+			return nil, fmt.Errorf("sequences in schema %s: %w", schema, err)
+		}
+		target := "ALL SEQUENCES IN SCHEMA " + pgx.Identifier{schema}.Sanitize()
+		if len(p.Sequences) > 0 {
+			target = "SEQUENCE " + qualifiedNames(schema, p.Sequences)
+		}
+		statements = append(statements, fmt.Sprintf("GRANT %s ON %s TO %s", list, target, user))
+	}
+	for _, p := range privileges.DefaultPrivileges {
+		objectType := strings.ToUpper(p.ObjectType)
+		allowed, ok := defaultPrivileges[objectType]
+		if !ok {
+			return nil, fmt.Errorf("invalid default privileges object type %q", p.ObjectType)
+		}
+		list, err := privilegeList(p.Privileges, allowed)
+		if err != nil {
+			return nil, fmt.Errorf("default privileges on %s: %w", objectType, err)
+		}
+		statements = append(statements, fmt.Sprintf("ALTER DEFAULT PRIVILEGES%s GRANT %s ON %s TO %s",
+			defaultPrivilegesScope(p.ForRole, p.Schema), list, objectType, user))
+	}
+	return statements, nil
+}
+
+// defaultPrivilegesScope returns the FOR ROLE and IN SCHEMA clauses of ALTER DEFAULT PRIVILEGES.
+func defaultPrivilegesScope(role, schema string) string {
+	var scope strings.Builder
+	if role != "" {
+		scope.WriteString(" FOR ROLE " + pgx.Identifier{role}.Sanitize())
+	}
+	if schema != "" {
+		scope.WriteString(" IN SCHEMA " + pgx.Identifier{schema}.Sanitize())
+	}
+	return scope.String()
+}
+
+func execStatements(ctx context.Context, db *pgx.Conn, statements []string) error {
+	for _, statement := range statements {
+		if _, err := db.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("failed to execute %q: %w", statement, err)
+		}
+	}
+	return nil
+}
+
+// revokePrivileges revokes all privileges granted directly to the user: on every database,
+// and on the schemas, tables, sequences and default privileges of the current database.
+func revokePrivileges(ctx context.Context, db *pgx.Conn, username string) error {
+	user := pgx.Identifier{username}.Sanitize()
+	var statements []string
+
+	queries := []struct {
+		query  string
+		revoke func(values []string) string
+	}{
+		{
+			query: `SELECT d.datname FROM pg_database d CROSS JOIN LATERAL aclexplode(d.datacl) a
+				WHERE a.grantee = (SELECT oid FROM pg_roles WHERE rolname = $1)`,
+			revoke: func(values []string) string {
+				return fmt.Sprintf("REVOKE ALL ON DATABASE %s FROM %s", pgx.Identifier{values[0]}.Sanitize(), user)
+			},
+		},
+		{
+			query: `SELECT n.nspname FROM pg_namespace n CROSS JOIN LATERAL aclexplode(n.nspacl) a
+				WHERE a.grantee = (SELECT oid FROM pg_roles WHERE rolname = $1)`,
+			revoke: func(values []string) string {
+				return fmt.Sprintf("REVOKE ALL ON SCHEMA %s FROM %s", pgx.Identifier{values[0]}.Sanitize(), user)
+			},
+		},
+		{
+			query: `SELECT n.nspname, c.relname, c.relkind::text FROM pg_class c
+				JOIN pg_namespace n ON n.oid = c.relnamespace
+				CROSS JOIN LATERAL aclexplode(c.relacl) a
+				WHERE a.grantee = (SELECT oid FROM pg_roles WHERE rolname = $1)`,
+			revoke: func(values []string) string {
+				objectType := "TABLE"
+				if values[2] == "S" {
+					objectType = "SEQUENCE"
+				}
+				return fmt.Sprintf("REVOKE ALL ON %s %s FROM %s", objectType, pgx.Identifier{values[0], values[1]}.Sanitize(), user)
+			},
+		},
+		{
+			query: `SELECT pg_get_userbyid(d.defaclrole), coalesce(n.nspname, ''), d.defaclobjtype::text FROM pg_default_acl d
+				LEFT JOIN pg_namespace n ON n.oid = d.defaclnamespace
+				CROSS JOIN LATERAL aclexplode(d.defaclacl) a
+				WHERE a.grantee = (SELECT oid FROM pg_roles WHERE rolname = $1)`,
+			revoke: func(values []string) string {
+				return fmt.Sprintf("ALTER DEFAULT PRIVILEGES%s REVOKE ALL ON %s FROM %s",
+					defaultPrivilegesScope(values[0], values[1]), defaultACLObjectTypes[values[2]], user)
+			},
+		},
+	}
+	for _, q := range queries {
+		rows, err := db.Query(ctx, q.query, username)
+		if err != nil {
+			return fmt.Errorf("failed to list privileges of %s: %w", username, err)
+		}
+		for rows.Next() {
+			raw, err := rows.Values()
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan privilege: %w", err)
+			}
+			values := make([]string, len(raw))
+			for i, v := range raw {
+				values[i] = fmt.Sprint(v)
+			}
+			statements = append(statements, q.revoke(values))
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating privileges: %w", err)
+		}
+	}
+
+	// A privilege can be listed more than once, e.g. when it was granted by several roles.
+	slices.Sort(statements)
+	return execStatements(ctx, db, slices.Compact(statements))
+}
+
+// setSearchPath sets the search_path of the user, or resets it to the server default if empty.
+func setSearchPath(ctx context.Context, db *pgx.Conn, username string, searchPath []string) error {
+	user := pgx.Identifier{username}.Sanitize()
+	query := fmt.Sprintf("ALTER ROLE %s RESET search_path", user)
+	if len(searchPath) > 0 {
+		schemas := make([]string, len(searchPath))
+		for i, schema := range searchPath {
+			schemas[i] = pgx.Identifier{schema}.Sanitize()
+		}
+		query = fmt.Sprintf("ALTER ROLE %s SET search_path TO %s", user, strings.Join(schemas, ", "))
+	}
+	if _, err := db.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to set search_path of %s: %w", username, err)
+	}
+	return nil
+}
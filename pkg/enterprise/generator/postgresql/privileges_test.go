@@ -0,0 +1,130 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// /*
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+)
+
+func TestGrantStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		privileges *enterprise.PostgreSQLPrivileges
+		want       []string
+		wantErr    string
+	}{
+		{
+			name: "no privileges",
+		},
+		{
+			name: "all object types",
+			privileges: &enterprise.PostgreSQLPrivileges{
+				Databases: []enterprise.PostgreSQLDatabasePrivilege{{Name: "app", Privileges: []string{"CONNECT", "temporary"}}},
+				Schemas:   []enterprise.PostgreSQLSchemaPrivilege{{Name: "sales", Privileges: []string{"USAGE"}}},
+				Tables: []enterprise.PostgreSQLTablePrivilege{
+					{Schema: "sales", Privileges: []string{"SELECT"}},
+					{Tables: []string{"orders", "Line Items"}, Privileges: []string{"INSERT", "UPDATE"}},
+				},
+				Sequences: []enterprise.PostgreSQLSequencePrivilege{
+					{Schema: "sales", Privileges: []string{"USAGE", "SELECT"}},
+					{Schema: "sales", Sequences: []string{"orders_id_seq"}, Privileges: []string{"UPDATE"}},
+				},
+				DefaultPrivileges: []enterprise.PostgreSQLDefaultPrivilege{
+					{Schema: "sales", ObjectType: "TABLES", Privileges: []string{"SELECT"}},
+					{ForRole: "migrator", ObjectType: "functions", Privileges: []string{"EXECUTE"}},
+				},
+			},
+			want: []string{
+				`GRANT CONNECT, TEMPORARY ON DATABASE "app" TO "app_user"`,
+				`GRANT USAGE ON SCHEMA "sales" TO "app_user"`,
+				`GRANT SELECT ON ALL TABLES IN SCHEMA "sales" TO "app_user"`,
+				`GRANT INSERT, UPDATE ON TABLE "public"."orders", "public"."Line Items" TO "app_user"`,
+				`GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA "sales" TO "app_user"`,
+				`GRANT UPDATE ON SEQUENCE "sales"."orders_id_seq" TO "app_user"`,
+				`ALTER DEFAULT PRIVILEGES IN SCHEMA "sales" GRANT SELECT ON TABLES TO "app_user"`,
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "migrator" GRANT EXECUTE ON FUNCTIONS TO "app_user"`,
+			},
+		},
+		{
+			name: "quoted identifiers",
+			privileges: &enterprise.PostgreSQLPrivileges{
+				Schemas: []enterprise.PostgreSQLSchemaPrivilege{{Name: `x"; DROP TABLE users; --`, Privileges: []string{"USAGE"}}},
+			},
+			want: []string{
+				`GRANT USAGE ON SCHEMA "x""; DROP TABLE users; --" TO "app_user"`,
+			},
+		},
+		{
+			name: "privilege not allowed for object type",
+			privileges: &enterprise.PostgreSQLPrivileges{
+				Schemas: []enterprise.PostgreSQLSchemaPrivilege{{Name: "sales", Privileges: []string{"SELECT"}}},
+			},
+			wantErr: `schema sales: invalid privilege "SELECT"`,
+		},
+		{
+			name: "injected privilege",
+			privileges: &enterprise.PostgreSQLPrivileges{
+				Tables: []enterprise.PostgreSQLTablePrivilege{{Privileges: []string{"SELECT ON ALL TABLES IN SCHEMA public TO PUBLIC; --"}}},
+			},
+			wantErr: "invalid privilege",
+		},
+		{
+			name: "missing privileges",
+			privileges: &enterprise.PostgreSQLPrivileges{
+				Databases: []enterprise.PostgreSQLDatabasePrivilege{{Name: "app"}},
+			},
+			wantErr: "at least one privilege is required",
+		},
+		{
+			name: "invalid default privileges object type",
+			privileges: &enterprise.PostgreSQLPrivileges{
+				DefaultPrivileges: []enterprise.PostgreSQLDefaultPrivilege{{ObjectType: "DATABASES", Privileges: []string{"ALL"}}},
+			},
+			wantErr: `invalid default privileges object type "DATABASES"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := grantStatements("app_user", tt.privileges)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
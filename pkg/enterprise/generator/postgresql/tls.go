@@ -0,0 +1,166 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+package postgresql
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/generator/tlsutil"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+const defaultTokenKey = "password"
+
+// releaseFunc cleans up the generator state of an admin token once it has been used.
+type releaseFunc func(ctx context.Context) error
+
+func noRelease(context.Context) error {
+	return nil
+}
+
+// adminPassword returns the password of the admin user. It is a token generated by the
+// token generator if one is referenced, and is empty for passwordless authentication.
+// The returned release func must be called once the password has been used.
+func adminPassword(ctx context.Context, auth *enterprise.PostgreSQLAuth, kclient client.Client, ns string) (string, releaseFunc, error) {
+	if auth.TokenGeneratorRef != nil {
+		return generateToken(ctx, auth.TokenGeneratorRef, kclient, ns)
+	}
+	if auth.Password.Name == "" {
+		return "", noRelease, nil
+	}
+	password, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      auth.Password.Name,
+		Key:       auth.Password.Key,
+	})
+	return password, noRelease, err
+}
+
+// generateToken generates a token with the referenced generator. The generator state is
+// cleaned up by the returned release func, so that no credential outlives the connection.
+func generateToken(ctx context.Context, ref *enterprise.PostgreSQLTokenGeneratorRef, kclient client.Client, ns string) (string, releaseFunc, error) {
+	apiVersion := ref.APIVersion
+	if apiVersion == "" {
+		apiVersion = genv1alpha1.SchemeGroupVersion.String()
+	}
+	gen, obj, err := resolvers.GeneratorRef(ctx, kclient, kclient.Scheme(), ns, &esv1.GeneratorRef{
+		APIVersion: apiVersion,
+		Kind:       ref.Kind,
+		Name:       ref.Name,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	data, state, err := gen.Generate(ctx, obj, kclient, ns)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to generate token: %w", err)
+	}
+	release := func(ctx context.Context) error {
+		if err := gen.Cleanup(ctx, obj, state, kclient, ns); err != nil {
+			return fmt.Errorf("unable to clean up token: %w", err)
+		}
+		return nil
+	}
+	key := defaultTokenKey
+	if ref.Key != "" {
+		key = ref.Key
+	}
+	token, ok := data[key]
+	if !ok {
+		return "", nil, errors.Join(fmt.Errorf("generator %s/%s did not generate key %q", ref.Kind, ref.Name, key), release(ctx))
+	}
+	return string(token), release, nil
+}
+
+// newTLSConfig returns the TLS configuration of the connection, or nil if TLS is disabled.
+func newTLSConfig(ctx context.Context, spec *enterprise.PostgreSQLTLS, host string, kclient client.Client, ns string) (*tls.Config, error) {
+	if spec == nil || spec.SSLMode == enterprise.PostgreSQLSSLModeDisable {
+		return nil, nil
+	}
+	roots, err := tlsutil.CAPool(ctx, spec.CABundle, spec.CAProvider, kclient, ns)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if spec.ClientCertificate != nil || spec.ClientKey != nil {
+		cert, err := tlsutil.ClientCertificate(ctx, spec.ClientCertificate, spec.ClientKey, kclient, ns)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{*cert}
+	}
+
+	switch spec.SSLMode {
+	case enterprise.PostgreSQLSSLModeRequire:
+		if roots == nil {
+			cfg.InsecureSkipVerify = true
+			break
+		}
+		// As libpq, require verifies the server certificate if a CA is configured.
+		fallthrough
+	case enterprise.PostgreSQLSSLModeVerifyCA:
+		// The chain is verified without the host name by verifyChain.
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyPeerCertificate = verifyChain(roots)
+	case enterprise.PostgreSQLSSLModeVerifyFull, "":
+		cfg.RootCAs = roots
+		cfg.ServerName = host
+	default:
+		return nil, fmt.Errorf("unsupported sslMode %q", spec.SSLMode)
+	}
+	return cfg, nil
+}
+
+// verifyChain verifies the server certificate is signed by a trusted CA, without checking the host name.
+func verifyChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("server did not present a certificate")
+		}
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("failed to parse server certificate: %w", err)
+			}
+			certs[i] = cert
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := certs[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+		})
+		return err
+	}
+}
@@ -0,0 +1,383 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// /*
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package postgresql
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/generators/v1/password"
+)
+
+type testCA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM encoded certificate and key signed by the CA.
+func (ca *testCA) issue(t *testing.T, cn string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// startTLSServer starts a TLS server for db.example.com, requiring client certificates of clientCA if set.
+func startTLSServer(t *testing.T, ca, clientCA *testCA) string {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t, "db.example.com", x509.ExtKeyUsageServerAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if clientCA != nil {
+		pool := x509.NewCertPool()
+		pool.AddCert(clientCA.cert)
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// handshake connects to the server and waits for it to close the connection, as TLS 1.3
+// servers reject client certificates after the client completed its handshake.
+func handshake(addr string, cfg *tls.Config) error {
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", addr, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+	clientCA := newTestCA(t)
+	clientCert, clientKey := clientCA.issue(t, "admin", x509.ExtKeyUsageClientAuth)
+	addr := startTLSServer(t, ca, nil)
+	mtlsAddr := startTLSServer(t, ca, clientCA)
+
+	kube := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-ca", Namespace: testNamespace},
+			Data:       map[string]string{"ca.crt": string(ca.certPEM)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-client", Namespace: testNamespace},
+			Data:       map[string][]byte{"tls.crt": clientCert, "tls.key": clientKey},
+		},
+	).Build()
+	clientCertRef := &esmeta.SecretKeySelector{Name: "db-client", Key: "tls.crt"}
+	clientKeyRef := &esmeta.SecretKeySelector{Name: "db-client", Key: "tls.key"}
+
+	tests := []struct {
+		name          string
+		spec          *enterprise.PostgreSQLTLS
+		host          string
+		addr          string
+		wantConfigErr string
+		wantErr       bool
+	}{
+		{
+			name: "verify-full",
+			spec: &enterprise.PostgreSQLTLS{SSLMode: enterprise.PostgreSQLSSLModeVerifyFull, CABundle: ca.certPEM},
+			host: "db.example.com",
+		},
+		{
+			name:    "verify-full is the default",
+			spec:    &enterprise.PostgreSQLTLS{CABundle: ca.certPEM},
+			host:    "other.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "verify-full rejects host mismatch",
+			spec:    &enterprise.PostgreSQLTLS{SSLMode: enterprise.PostgreSQLSSLModeVerifyFull, CABundle: ca.certPEM},
+			host:    "other.example.com",
+			wantErr: true,
+		},
+		{
+			name: "verify-ca ignores the host",
+			spec: &enterprise.PostgreSQLTLS{
+				SSLMode:    enterprise.PostgreSQLSSLModeVerifyCA,
+				CAProvider: &esv1.CAProvider{Type: esv1.CAProviderTypeConfigMap, Name: "db-ca", Key: "ca.crt"},
+			},
+			host: "other.example.com",
+		},
+		{
+			name:    "verify-ca rejects untrusted CA",
+			spec:    &enterprise.PostgreSQLTLS{SSLMode: enterprise.PostgreSQLSSLModeVerifyCA, CABundle: otherCA.certPEM},
+			host:    "db.example.com",
+			wantErr: true,
+		},
+		{
+			name: "require without CA",
+			spec: &enterprise.PostgreSQLTLS{SSLMode: enterprise.PostgreSQLSSLModeRequire},
+			host: "other.example.com",
+		},
+		{
+			name:    "require verifies configured CA",
+			spec:    &enterprise.PostgreSQLTLS{SSLMode: enterprise.PostgreSQLSSLModeRequire, CABundle: otherCA.certPEM},
+			host:    "db.example.com",
+			wantErr: true,
+		},
+		{
+			name: "client certificate",
+			spec: &enterprise.PostgreSQLTLS{CABundle: ca.certPEM, ClientCertificate: clientCertRef, ClientKey: clientKeyRef},
+			host: "db.example.com",
+			addr: mtlsAddr,
+		},
+		{
+			name:    "missing client certificate",
+			spec:    &enterprise.PostgreSQLTLS{CABundle: ca.certPEM},
+			host:    "db.example.com",
+			addr:    mtlsAddr,
+			wantErr: true,
+		},
+		{
+			name:          "client key without certificate",
+			spec:          &enterprise.PostgreSQLTLS{CABundle: ca.certPEM, ClientKey: clientKeyRef},
+			wantConfigErr: "clientCertificate and clientKey must be set together",
+		},
+		{
+			name:          "invalid CA bundle",
+			spec:          &enterprise.PostgreSQLTLS{CABundle: []byte("not a certificate")},
+			wantConfigErr: "failed to decode ca bundle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := newTLSConfig(context.Background(), tt.spec, tt.host, kube, testNamespace)
+			if tt.wantConfigErr != "" {
+				require.ErrorContains(t, err, tt.wantConfigErr)
+				return
+			}
+			require.NoError(t, err)
+			target := addr
+			if tt.addr != "" {
+				target = tt.addr
+			}
+			err = handshake(target, cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewTLSConfigDisabled(t *testing.T) {
+	cfg, err := newTLSConfig(context.Background(), nil, "db.example.com", nil, testNamespace)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = newTLSConfig(context.Background(), &enterprise.PostgreSQLTLS{SSLMode: enterprise.PostgreSQLSSLModeDisable}, "db.example.com", nil, testNamespace)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+}
+
+// cleanupCountingGenerator is a password generator counting the cleaned up states.
+type cleanupCountingGenerator struct {
+	password.Generator
+	cleanups atomic.Int32
+}
+
+func (g *cleanupCountingGenerator) Cleanup(_ context.Context, _ *apiextensions.JSON, _ genv1alpha1.GeneratorProviderState, _ client.Client, _ string) error {
+	g.cleanups.Add(1)
+	return nil
+}
+
+func TestAdminPassword(t *testing.T) {
+	gen := &cleanupCountingGenerator{}
+	genv1alpha1.ForceRegister(genv1alpha1.PasswordKind, gen)
+	t.Cleanup(func() {
+		genv1alpha1.ForceRegister(genv1alpha1.PasswordKind, &password.Generator{})
+	})
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, genv1alpha1.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: testSecretName, Namespace: testNamespace},
+			Data:       map[string][]byte{testSecretKey: []byte(testPass)},
+		},
+		&genv1alpha1.Password{
+			ObjectMeta: metav1.ObjectMeta{Name: "iam-token", Namespace: testNamespace},
+			Spec:       genv1alpha1.PasswordSpec{Length: 48, AllowRepeat: true},
+		},
+	).Build()
+
+	tests := []struct {
+		name         string
+		auth         enterprise.PostgreSQLAuth
+		check        func(t *testing.T, password string)
+		wantCleanups int32
+		wantErr      string
+	}{
+		{
+			name: "password",
+			auth: enterprise.PostgreSQLAuth{Password: esmeta.SecretKeySelector{Name: testSecretName, Key: testSecretKey}},
+			check: func(t *testing.T, password string) {
+				assert.Equal(t, testPass, password)
+			},
+		},
+		{
+			name: "passwordless",
+			auth: enterprise.PostgreSQLAuth{},
+			check: func(t *testing.T, password string) {
+				assert.Empty(t, password)
+			},
+		},
+		{
+			name: "token generator takes precedence",
+			auth: enterprise.PostgreSQLAuth{
+				Password:          esmeta.SecretKeySelector{Name: testSecretName, Key: testSecretKey},
+				TokenGeneratorRef: &enterprise.PostgreSQLTokenGeneratorRef{Kind: "Password", Name: "iam-token"},
+			},
+			check: func(t *testing.T, password string) {
+				assert.Len(t, password, 48)
+			},
+			wantCleanups: 1,
+		},
+		{
+			name:         "missing token key",
+			auth:         enterprise.PostgreSQLAuth{TokenGeneratorRef: &enterprise.PostgreSQLTokenGeneratorRef{Kind: "Password", Name: "iam-token", Key: "token"}},
+			wantCleanups: 1,
+			wantErr:      `did not generate key "token"`,
+		},
+		{
+			name:    "missing generator",
+			auth:    enterprise.PostgreSQLAuth{TokenGeneratorRef: &enterprise.PostgreSQLTokenGeneratorRef{Kind: "Password", Name: "missing"}},
+			wantErr: "unable to get generator",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen.cleanups.Store(0)
+			password, release, err := adminPassword(context.Background(), &tt.auth, kube, testNamespace)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, tt.wantCleanups, gen.cleanups.Load())
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, password)
+
+			// The generator state is only cleaned up once the token has been used.
+			assert.Zero(t, gen.cleanups.Load())
+			require.NoError(t, release(context.Background()))
+			assert.Equal(t, tt.wantCleanups, gen.cleanups.Load())
+		})
+	}
+}
@@ -0,0 +1,554 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+// Package redis implements Redis ACL user generator.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/redis/go-redis/v9"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/generators/v1/password"
+	"github.com/external-secrets/external-secrets/pkg/enterprise/scheduler"
+	utils "github.com/external-secrets/external-secrets/runtime/esutils"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+// Generator implements the Redis ACL user generator.
+type Generator struct{}
+
+const (
+	defaultPort           = "6379"
+	defaultSuffixSize     = 8
+	defaultObservationKey = "external-secrets:session-observation"
+	schedIDFmt            = "redis-session-observation-%s-%s:%s"
+)
+
+var (
+	// commandRegexp matches command rules, e.g. +get, -flushall, +config|get or -@dangerous.
+	commandRegexp = regexp.MustCompile(`^[+-]@?[a-zA-Z0-9_|-]+$`)
+	// categoryRegexp matches command categories, e.g. read or sortedset.
+	categoryRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+)
+
+// Generate creates a new ACL user on the server, or on every node of a cluster.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateUser(&res.Spec.User); err != nil {
+		return nil, nil, err
+	}
+
+	rdb, err := newConnection(ctx, &res.Spec, kube, namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create redis connection: %w", err)
+	}
+	defer closeConnection(rdb)
+
+	err = rdb.Ping(ctx).Err()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to ping the server: %w", err)
+	}
+
+	cleanupPolicy := res.Spec.CleanupPolicy
+	if cleanupPolicy != nil && cleanupPolicy.Type == genv1alpha1.IdleCleanupPolicy {
+		scheduleObservation(res, kube, namespace)
+	}
+
+	user, err := createUser(ctx, rdb, &res.Spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create user: %w", err)
+	}
+
+	rawState, err := json.Marshal(&enterprise.RedisUserState{
+		Username: string(user["username"]),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal state: %w", err)
+	}
+
+	return user, &apiextensions.JSON{Raw: rawState}, nil
+}
+
+// Cleanup deletes the user, which disconnects its clients.
+func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, previousStatus genv1alpha1.GeneratorProviderState, kclient client.Client, namespace string) error {
+	if previousStatus == nil {
+		return fmt.Errorf("missing previous status")
+	}
+	status, err := parseStatus(previousStatus.Raw)
+	if err != nil {
+		return err
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return err
+	}
+	rdb, err := newConnection(ctx, &res.Spec, kclient, namespace)
+	if err != nil {
+		return err
+	}
+	defer closeConnection(rdb)
+
+	err = forEachNode(ctx, rdb, func(ctx context.Context, node *redis.Client) error {
+		return node.ACLDelUser(ctx, status.Username).Err()
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete user: %w", err)
+	}
+	if res.Spec.CleanupPolicy != nil {
+		err = rdb.HDel(ctx, observationKey(&res.Spec), status.Username).Err()
+		if err != nil {
+			return fmt.Errorf("unable to delete user activity: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetCleanupPolicy returns the cleanup policy of the generator.
+func (g *Generator) GetCleanupPolicy(obj *apiextensions.JSON) (*genv1alpha1.CleanupPolicy, error) {
+	res, err := parseSpec(obj.Raw)
+	if err != nil {
+		return nil, err
+	}
+	if res.Spec.CleanupPolicy == nil {
+		return nil, nil
+	}
+
+	policy := genv1alpha1.CleanupPolicy{
+		Type:        res.Spec.CleanupPolicy.Type,
+		IdleTimeout: res.Spec.CleanupPolicy.IdleTimeout,
+		GracePeriod: res.Spec.CleanupPolicy.GracePeriod,
+	}
+	return &policy, nil
+}
+
+// LastActivityTime returns the last time the user was seen, from its connected clients,
+// the ACL log and the activity recorded by the observation.
+func (g *Generator) LastActivityTime(ctx context.Context, obj *apiextensions.JSON, state genv1alpha1.GeneratorProviderState, kube client.Client, namespace string) (time.Time, bool, error) {
+	status, err := parseStatus(state.Raw)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	res, err := parseSpec(obj.Raw)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	rdb, err := newConnection(ctx, &res.Spec, kube, namespace)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	defer closeConnection(rdb)
+
+	lastActivity, err := getUserActivity(ctx, rdb, observationKey(&res.Spec), status.Username)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return lastActivity, true, nil
+}
+
+// GetKeys returns the keys that are generated by the generator.
+func (g *Generator) GetKeys() map[string]string {
+	return map[string]string{
+		"username": "Redis ACL username",
+		"password": "Redis ACL user password",
+		"url":      "Redis connection URL of the user",
+	}
+}
+
+func newConnection(ctx context.Context, spec *enterprise.RedisSpec, kclient client.Client, ns string) (redis.UniversalClient, error) {
+	password, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      spec.Auth.Password.Name,
+		Key:       spec.Auth.Password.Key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := newTLSConfig(ctx, spec.TLS, spec.Host, kclient, ns)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure tls: %w", err)
+	}
+
+	addr := address(spec)
+	if spec.Cluster {
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     []string{addr},
+			Username:  spec.Auth.Username,
+			Password:  password,
+			TLSConfig: tlsConfig,
+		}), nil
+	}
+	return redis.NewClient(&redis.Options{
+		Addr:      addr,
+		Username:  spec.Auth.Username,
+		Password:  password,
+		TLSConfig: tlsConfig,
+	}), nil
+}
+
+func closeConnection(rdb redis.UniversalClient) {
+	if err := rdb.Close(); err != nil {
+		fmt.Printf("failed to close redis client: %v", err)
+	}
+}
+
+func address(spec *enterprise.RedisSpec) string {
+	port := defaultPort
+	if spec.Port != "" {
+		port = spec.Port
+	}
+	return net.JoinHostPort(spec.Host, port)
+}
+
+// forEachNode runs fn on the server, or on every node of a cluster. ACL users are local
+// to each node, so they have to be set on replicas as well to survive a failover.
+// The nodes of a cluster are run concurrently, so fn has to synchronize the state it shares.
+func forEachNode(ctx context.Context, rdb redis.UniversalClient, fn func(ctx context.Context, node *redis.Client) error) error {
+	switch c := rdb.(type) {
+	case *redis.ClusterClient:
+		return c.ForEachShard(ctx, fn)
+	case *redis.Client:
+		return fn(ctx, c)
+	default:
+		return fmt.Errorf("unsupported redis client %T", rdb)
+	}
+}
+
+func validateUser(user *enterprise.RedisUser) error {
+	if user.Username == "" {
+		return errors.New("user.username is required")
+	}
+	for _, patterns := range [][]string{user.Keys, user.ReadKeys, user.WriteKeys, user.Channels} {
+		for _, pattern := range patterns {
+			if pattern == "" || strings.ContainsAny(pattern, " \t\r\n") {
+				return fmt.Errorf("invalid pattern %q", pattern)
+			}
+		}
+	}
+	for _, category := range user.Categories {
+		if !categoryRegexp.MatchString(strings.TrimPrefix(category, "@")) {
+			return fmt.Errorf("invalid category %q", category)
+		}
+	}
+	for _, command := range user.Commands {
+		if !commandRegexp.MatchString(command) {
+			return fmt.Errorf("invalid command rule %q, must start with + or -", command)
+		}
+	}
+	return nil
+}
+
+// aclRules returns the ACL SETUSER rules of the user. The user is reset first, so
+// regenerating it replaces all its previous rules and passwords.
+func aclRules(user *enterprise.RedisUser, pass string) []string {
+	rules := []string{"reset", "on", ">" + pass}
+	for _, pattern := range user.Keys {
+		rules = append(rules, "~"+pattern)
+	}
+	for _, pattern := range user.ReadKeys {
+		rules = append(rules, "%R~"+pattern)
+	}
+	for _, pattern := range user.WriteKeys {
+		rules = append(rules, "%W~"+pattern)
+	}
+	for _, pattern := range user.Channels {
+		rules = append(rules, "&"+pattern)
+	}
+	for _, category := range user.Categories {
+		rules = append(rules, "+@"+strings.TrimPrefix(category, "@"))
+	}
+	return append(rules, user.Commands...)
+}
+
+func createUser(ctx context.Context, rdb redis.UniversalClient, spec *enterprise.RedisSpec) (map[string][]byte, error) {
+	username := spec.User.Username
+	suffixSize := defaultSuffixSize
+	if spec.User.SuffixSize != nil {
+		suffixSize = *spec.User.SuffixSize
+	}
+	suffix, err := utils.GenerateRandomString(suffixSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random suffix: %w", err)
+	}
+	if suffix != "" {
+		username = fmt.Sprintf("%s_%s", username, suffix)
+	}
+
+	pass, err := generatePassword(genv1alpha1.Password{
+		Spec: genv1alpha1.PasswordSpec{
+			Length:      32,
+			AllowRepeat: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	rules := aclRules(&spec.User, string(pass))
+	err = forEachNode(ctx, rdb, func(ctx context.Context, node *redis.Client) error {
+		if err := node.ACLSetUser(ctx, username, rules...).Err(); err != nil {
+			return fmt.Errorf("failed to set user on %s: %w", node.Options().Addr, err)
+		}
+		return nil
+	})
+	if err != nil {
+		// Do not leave the user on part of the nodes of a cluster.
+		if delErr := forEachNode(ctx, rdb, func(ctx context.Context, node *redis.Client) error {
+			return node.ACLDelUser(ctx, username).Err()
+		}); delErr != nil {
+			err = errors.Join(err, delErr)
+		}
+		return nil, err
+	}
+
+	return map[string][]byte{
+		"username": []byte(username),
+		"password": pass,
+		"url":      []byte(connectionURL(spec, username, string(pass))),
+	}, nil
+}
+
+func connectionURL(spec *enterprise.RedisSpec, username, pass string) string {
+	u := url.URL{
+		Scheme: "redis",
+		User:   url.UserPassword(username, pass),
+		Host:   address(spec),
+	}
+	if spec.TLS != nil {
+		u.Scheme = "rediss"
+	}
+	return u.String()
+}
+
+func observationKey(spec *enterprise.RedisSpec) string {
+	if spec.CleanupPolicy != nil && spec.CleanupPolicy.ObservationKey != "" {
+		return spec.CleanupPolicy.ObservationKey
+	}
+	return defaultObservationKey
+}
+
+func scheduleObservation(res *enterprise.Redis, kube client.Client, namespace string) {
+	schedID := fmt.Sprintf(schedIDFmt, res.UID, res.Spec.Host, res.Spec.Port)
+	scheduler.Global().ScheduleInterval(schedID, res.Spec.CleanupPolicy.ActivityTrackingInterval.Duration, time.Minute, func(ctx context.Context, log logr.Logger) {
+		err := triggerSessionSnapshot(ctx, &res.Spec, kube, namespace)
+		if err != nil {
+			log.Error(err, "failed to trigger session observation")
+			return
+		}
+	})
+}
+
+// triggerSessionSnapshot records the last activity of the users with connected clients.
+func triggerSessionSnapshot(ctx context.Context, spec *enterprise.RedisSpec, kube client.Client, namespace string) error {
+	rdb, err := newConnection(ctx, spec, kube, namespace)
+	if err != nil {
+		return err
+	}
+	defer closeConnection(rdb)
+
+	activity, err := clientActivity(ctx, rdb)
+	if err != nil {
+		return err
+	}
+	if len(activity) == 0 {
+		return nil
+	}
+	values := make(map[string]any, len(activity))
+	for user, lastSeen := range activity {
+		values[user] = lastSeen.Unix()
+	}
+	if err := rdb.HSet(ctx, observationKey(spec), values).Err(); err != nil {
+		return fmt.Errorf("failed to record session observation: %w", err)
+	}
+	return nil
+}
+
+// clientActivity returns the last activity of the users with clients connected to any node.
+func clientActivity(ctx context.Context, rdb redis.UniversalClient) (map[string]time.Time, error) {
+	var (
+		mu    sync.Mutex
+		lists []string
+	)
+	err := forEachNode(ctx, rdb, func(ctx context.Context, node *redis.Client) error {
+		list, err := node.ClientList(ctx).Result()
+		if err != nil {
+			return fmt.Errorf("failed to list clients of %s: %w", node.Options().Addr, err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		lists = append(lists, list)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	activity := map[string]time.Time{}
+	for _, list := range lists {
+		for user, idle := range parseClientList(list) {
+			lastSeen := now.Add(-idle)
+			if lastSeen.After(activity[user]) {
+				activity[user] = lastSeen
+			}
+		}
+	}
+	return activity, nil
+}
+
+// parseClientList returns the shortest idle time of the clients of each user in a CLIENT LIST reply.
+func parseClientList(list string) map[string]time.Duration {
+	idle := map[string]time.Duration{}
+	for _, line := range strings.Split(list, "\n") {
+		var user string
+		var seconds int64 = -1
+		for _, field := range strings.Fields(line) {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "user":
+				user = value
+			case "idle":
+				if _, err := fmt.Sscan(value, &seconds); err != nil {
+					seconds = -1
+				}
+			}
+		}
+		if user == "" || seconds < 0 {
+			continue
+		}
+		d := time.Duration(seconds) * time.Second
+		if current, ok := idle[user]; !ok || d < current {
+			idle[user] = d
+		}
+	}
+	return idle
+}
+
+// getUserActivity returns the last activity of the user, or the Unix epoch if it was never seen.
+func getUserActivity(ctx context.Context, rdb redis.UniversalClient, key, username string) (time.Time, error) {
+	lastActivity := time.Unix(0, 0)
+
+	activity, err := clientActivity(ctx, rdb)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if lastSeen, ok := activity[username]; ok {
+		lastActivity = lastSeen
+	}
+
+	// Denied commands and failed authentications are logged with the user, and show it is still in use.
+	var (
+		mu      sync.Mutex
+		entries []*redis.ACLLogEntry
+	)
+	err = forEachNode(ctx, rdb, func(ctx context.Context, node *redis.Client) error {
+		log, err := node.ACLLog(ctx, 0).Result()
+		if err != nil {
+			return fmt.Errorf("failed to get ACL log of %s: %w", node.Options().Addr, err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		entries = append(entries, log...)
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, entry := range entries {
+		if entry.Username != username {
+			continue
+		}
+		lastSeen := time.Now().Add(-time.Duration(entry.AgeSeconds * float64(time.Second)))
+		if entry.TimestampLastUpdated > 0 {
+			lastSeen = time.UnixMilli(entry.TimestampLastUpdated)
+		}
+		if lastSeen.After(lastActivity) {
+			lastActivity = lastSeen
+		}
+	}
+
+	observed, err := rdb.HGet(ctx, key, username).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return time.Time{}, fmt.Errorf("failed to get user activity: %w", err)
+	}
+	if err == nil && time.Unix(observed, 0).After(lastActivity) {
+		lastActivity = time.Unix(observed, 0)
+	}
+	return lastActivity, nil
+}
+
+func generatePassword(
+	passSpec genv1alpha1.Password,
+) ([]byte, error) {
+	gen := password.Generator{}
+	rawPassSpec, err := yaml.Marshal(passSpec)
+	if err != nil {
+		return nil, err
+	}
+	passMap, _, err := gen.Generate(context.TODO(), &apiextensions.JSON{Raw: rawPassSpec}, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	pass, ok := passMap["password"]
+	if !ok {
+		return nil, fmt.Errorf("password not found in generated map")
+	}
+	return pass, nil
+}
+
+func parseSpec(data []byte) (*enterprise.Redis, error) {
+	var spec enterprise.Redis
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func parseStatus(data []byte) (*enterprise.RedisUserState, error) {
+	var state enterprise.RedisUserState
+	err := json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+	return &state, err
+}
+
+func init() {
+	genv1alpha1.Register(enterprise.RedisKind, &Generator{})
+	genv1alpha1.RegisterGeneric(enterprise.RedisKind, &enterprise.Redis{})
+}
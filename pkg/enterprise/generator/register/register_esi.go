@@ -37,12 +37,18 @@ import (
 	// Register enterprise generators.
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/aws_iam"
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/basic_auth"
+	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/certificate"
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/federation"
+	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/jwt"
+	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/kafka"
+	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/ldap"
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/mongodb"
+	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/mysql"
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/neo4j"
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/openai"
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/postgresql"
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/rabbitmq"
+	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/redis"
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/sendgrid"
 	_ "github.com/external-secrets/external-secrets/pkg/enterprise/generator/ssh"
 )
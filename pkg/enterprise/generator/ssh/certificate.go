@@ -0,0 +1,131 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+package ssh
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+const (
+	defaultCertificateTTL = time.Hour
+
+	// backdate tolerates clock skew between the controller and the servers validating the certificate.
+	backdate = 5 * time.Minute
+)
+
+// defaultExtensions are the extensions ssh-keygen grants to user certificates.
+var defaultExtensions = map[string]string{
+	"permit-X11-forwarding":   "",
+	"permit-agent-forwarding": "",
+	"permit-port-forwarding":  "",
+	"permit-pty":              "",
+	"permit-user-rc":          "",
+}
+
+// signCertificate signs the public key with the CA, and returns the certificate in authorized_keys format.
+func signCertificate(ctx context.Context, spec *enterprise.SSHCertificate, pub ssh.PublicKey, kube client.Client, namespace string, now time.Time) ([]byte, error) {
+	cert, err := certificateTemplate(spec, pub, now)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := loadCA(ctx, &spec.CA, kube, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load CA: %w", err)
+	}
+	if err := cert.SignCert(rand.Reader, signer); err != nil {
+		return nil, fmt.Errorf("unable to sign certificate: %w", err)
+	}
+	return ssh.MarshalAuthorizedKey(cert), nil
+}
+
+func certificateTemplate(spec *enterprise.SSHCertificate, pub ssh.PublicKey, now time.Time) (*ssh.Certificate, error) {
+	if len(spec.Principals) == 0 {
+		return nil, errors.New("at least one principal is required")
+	}
+	var serial [8]byte
+	if _, err := rand.Read(serial[:]); err != nil {
+		return nil, fmt.Errorf("unable to generate serial number: %w", err)
+	}
+	ttl := defaultCertificateTTL
+	if spec.TTL.Duration > 0 {
+		ttl = spec.TTL.Duration
+	}
+
+	cert := &ssh.Certificate{
+		Key:             pub,
+		Serial:          binary.BigEndian.Uint64(serial[:]),
+		KeyId:           spec.KeyID,
+		ValidPrincipals: spec.Principals,
+		ValidAfter:      uint64(now.Add(-backdate).Unix()),
+		ValidBefore:     uint64(now.Add(ttl).Unix()),
+	}
+	switch spec.Type {
+	case enterprise.SSHCertificateTypeUser, "":
+		cert.CertType = ssh.UserCert
+		cert.CriticalOptions = spec.CriticalOptions
+		cert.Extensions = spec.Extensions
+		if cert.Extensions == nil {
+			cert.Extensions = defaultExtensions
+		}
+	case enterprise.SSHCertificateTypeHost:
+		// OpenSSH defines no critical options nor extensions for host certificates.
+		if len(spec.CriticalOptions) > 0 || len(spec.Extensions) > 0 {
+			return nil, errors.New("critical options and extensions are only supported for user certificates")
+		}
+		cert.CertType = ssh.HostCert
+	default:
+		return nil, fmt.Errorf("unsupported certificate type: %s", spec.Type)
+	}
+	return cert, nil
+}
+
+func loadCA(ctx context.Context, spec *enterprise.SSHCertificateCA, kube client.Client, namespace string) (ssh.Signer, error) {
+	key, err := resolvers.SecretKeyRef(ctx, kube, resolvers.EmptyStoreKind, namespace, &esmeta.SecretKeySelector{
+		Namespace: &namespace,
+		Name:      spec.PrivateKey.Name,
+		Key:       spec.PrivateKey.Key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CA private key: %w", err)
+	}
+	if spec.Passphrase == nil {
+		return ssh.ParsePrivateKey([]byte(key))
+	}
+	passphrase, err := resolvers.SecretKeyRef(ctx, kube, resolvers.EmptyStoreKind, namespace, &esmeta.SecretKeySelector{
+		Namespace: &namespace,
+		Name:      spec.Passphrase.Name,
+		Key:       spec.Passphrase.Key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CA passphrase: %w", err)
+	}
+	return ssh.ParsePrivateKeyWithPassphrase([]byte(key), []byte(passphrase))
+}
@@ -16,12 +16,16 @@
 
 // Copyright External Secrets Inc. All Rights Reserved
 
-// Package ssh implements SSH key generator.
+// Package ssh implements SSH key and certificate generator.
 package ssh
 
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -43,8 +47,9 @@ import (
 type Generator struct{}
 
 const (
-	defaultKeyType = enterprise.SSHKeyTypeRSA
-	defaultBits    = 4096
+	defaultKeyType   = enterprise.SSHKeyTypeRSA
+	defaultBits      = 4096
+	defaultCurveBits = 256
 
 	errNoSpec    = "no config spec provided"
 	errParseSpec = "unable to parse spec: %w"
@@ -56,10 +61,13 @@ type RSAGenerateFunc func(
 	bits int,
 ) (string, string, error)
 
-// Generate generates SSH key pairs.
-func (g *Generator) Generate(_ context.Context, jsonSpec *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+// Generate generates SSH key pairs, and signs them with an SSH CA if a certificate is configured.
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
 	return g.generate(
+		ctx,
 		jsonSpec,
+		kube,
+		namespace,
 		generateRSA,
 	)
 }
@@ -80,14 +88,22 @@ func (g *Generator) LastActivityTime(_ context.Context, _ *apiextensions.JSON, _
 }
 
 // GetKeys returns the keys generated by this generator.
+// The names of the keys depend on the key type.
 func (g *Generator) GetKeys() map[string]string {
 	return map[string]string{
-		"id_rsa":     "Private SSH key in PEM format",
-		"id_rsa.pub": "Public SSH key in authorized_keys format",
+		"id_rsa":              "Private RSA SSH key in PEM format",
+		"id_rsa.pub":          "Public RSA SSH key in authorized_keys format",
+		"id_rsa-cert.pub":     "OpenSSH certificate of the RSA key, if a certificate is configured",
+		"id_ecdsa":            "Private ECDSA SSH key in OpenSSH format",
+		"id_ecdsa.pub":        "Public ECDSA SSH key in authorized_keys format",
+		"id_ecdsa-cert.pub":   "OpenSSH certificate of the ECDSA key, if a certificate is configured",
+		"id_ed25519":          "Private Ed25519 SSH key in OpenSSH format",
+		"id_ed25519.pub":      "Public Ed25519 SSH key in authorized_keys format",
+		"id_ed25519-cert.pub": "OpenSSH certificate of the Ed25519 key, if a certificate is configured",
 	}
 }
 
-func (g *Generator) generate(jsonSpec *apiextensions.JSON, rsaGen RSAGenerateFunc) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string, rsaGen RSAGenerateFunc) (map[string][]byte, genv1alpha1.GeneratorProviderState, error) {
 	if jsonSpec == nil {
 		return nil, nil, errors.New(errNoSpec)
 	}
@@ -96,27 +112,115 @@ func (g *Generator) generate(jsonSpec *apiextensions.JSON, rsaGen RSAGenerateFun
 		return nil, nil, fmt.Errorf(errParseSpec, err)
 	}
 
+	certSpec := res.Spec.Certificate
+	var out map[string][]byte
+	var name string
+	if certSpec != nil && certSpec.PublicKey != "" {
+		pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certSpec.PublicKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse public key: %w", err)
+		}
+		name = keyName(pub.Type())
+		out = map[string][]byte{
+			name + ".pub": ssh.MarshalAuthorizedKey(pub),
+		}
+	} else {
+		out, name, err = generateKeyPair(&res.Spec, rsaGen)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if certSpec != nil {
+		pub, _, _, _, err := ssh.ParseAuthorizedKey(out[name+".pub"])
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse public key: %w", err)
+		}
+		cert, err := signCertificate(ctx, certSpec, pub, kube, namespace, time.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+		out[name+"-cert.pub"] = cert
+	}
+	return out, nil, nil
+}
+
+// generateKeyPair generates a key pair, and returns it with the name of its private key.
+func generateKeyPair(spec *enterprise.SSHSpec, rsaGen RSAGenerateFunc) (map[string][]byte, string, error) {
 	keyType := defaultKeyType
-	if res.Spec.KeyType != "" {
-		keyType = res.Spec.KeyType
+	if spec.KeyType != "" {
+		keyType = spec.KeyType
 	}
 
-	if keyType == enterprise.SSHKeyTypeRSA {
+	switch keyType {
+	case enterprise.SSHKeyTypeRSA:
 		bits := defaultBits
-		if res.Spec.RSAConfig.Bits > 0 {
-			bits = res.Spec.RSAConfig.Bits
+		if spec.RSAConfig.Bits > 0 {
+			bits = spec.RSAConfig.Bits
 		}
 
 		privPEM, pubKey, err := rsaGen(bits)
 		if err != nil {
-			return nil, nil, err
+			return nil, "", err
 		}
 		return map[string][]byte{
 			"id_rsa":     []byte(privPEM),
 			"id_rsa.pub": []byte(pubKey),
-		}, nil, nil
+		}, "id_rsa", nil
+	case enterprise.SSHKeyTypeECDSA:
+		var curve elliptic.Curve
+		switch spec.ECDSAConfig.Bits {
+		case defaultCurveBits, 0:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, "", fmt.Errorf("unsupported ECDSA key size: %d", spec.ECDSAConfig.Bits)
+		}
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate ECDSA key: %w", err)
+		}
+		return encodeKeyPair("id_ecdsa", key)
+	case enterprise.SSHKeyTypeEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate Ed25519 key: %w", err)
+		}
+		return encodeKeyPair("id_ed25519", key)
+	default:
+		return nil, "", fmt.Errorf("unsupported key type: %s", keyType)
+	}
+}
+
+// encodeKeyPair encodes the private key in OpenSSH format, as ssh-keygen does.
+func encodeKeyPair(name string, key crypto.Signer) (map[string][]byte, string, error) {
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode private key: %w", err)
+	}
+	pub, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate public key: %w", err)
+	}
+	return map[string][]byte{
+		name:          pem.EncodeToMemory(block),
+		name + ".pub": ssh.MarshalAuthorizedKey(pub),
+	}, name, nil
+}
+
+// keyName returns the conventional file name of a key of the given type.
+func keyName(keyType string) string {
+	switch keyType {
+	case ssh.KeyAlgoED25519:
+		return "id_ed25519"
+	case ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521:
+		return "id_ecdsa"
+	default:
+		return "id_rsa"
 	}
-	return nil, nil, fmt.Errorf("unsupported key type: %s", res.Spec.KeyType)
 }
 
 func generateRSA(
@@ -19,12 +19,29 @@
 package ssh
 
 import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
 	"errors"
+	"net"
 	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	enterprise "github.com/external-secrets/external-secrets/apis/enterprise/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 func TestGenerate(t *testing.T) {
@@ -117,7 +134,7 @@ func TestGenerate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := &Generator{}
-			got, _, err := g.generate(tt.args.jsonSpec, tt.args.rsaGen)
+			got, _, err := g.generate(context.Background(), tt.args.jsonSpec, nil, "", tt.args.rsaGen)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Generator.Generate() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -128,3 +145,182 @@ func TestGenerate(t *testing.T) {
 		})
 	}
 }
+
+const (
+	testNamespace = "default"
+	testCASecret  = "ssh-ca"
+)
+
+func newCASecret(t *testing.T, passphrase string) (*corev1.Secret, ssh.PublicKey) {
+	t.Helper()
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if passphrase != "" {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte(passphrase))
+	}
+	require.NoError(t, err)
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testCASecret},
+		Data: map[string][]byte{
+			"ca":         pem.EncodeToMemory(block),
+			"passphrase": []byte(passphrase),
+		},
+	}, sshPub
+}
+
+func parseCertificate(t *testing.T, data []byte) *ssh.Certificate {
+	t.Helper()
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(data)
+	require.NoError(t, err)
+	cert, ok := pub.(*ssh.Certificate)
+	require.True(t, ok)
+	return cert
+}
+
+func TestGenerateKeyTypes(t *testing.T) {
+	tests := []struct {
+		spec    string
+		name    string
+		keyType string
+	}{
+		{spec: `{"spec":{"keyType":"ECDSA"}}`, name: "id_ecdsa", keyType: ssh.KeyAlgoECDSA256},
+		{spec: `{"spec":{"keyType":"ECDSA","ecdsaConfig":{"bits":521}}}`, name: "id_ecdsa", keyType: ssh.KeyAlgoECDSA521},
+		{spec: `{"spec":{"keyType":"Ed25519"}}`, name: "id_ed25519", keyType: ssh.KeyAlgoED25519},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			g := &Generator{}
+			got, _, err := g.Generate(context.Background(), &apiextensions.JSON{Raw: []byte(tt.spec)}, nil, "")
+			require.NoError(t, err)
+			assert.Len(t, got, 2)
+
+			signer, err := ssh.ParsePrivateKey(got[tt.name])
+			require.NoError(t, err)
+			assert.Equal(t, tt.keyType, signer.PublicKey().Type())
+			assert.Equal(t, string(ssh.MarshalAuthorizedKey(signer.PublicKey())), string(got[tt.name+".pub"]))
+		})
+	}
+
+	g := &Generator{}
+	_, _, err := g.Generate(context.Background(), &apiextensions.JSON{Raw: []byte(`{"spec":{"keyType":"ECDSA","ecdsaConfig":{"bits":224}}}`)}, nil, "")
+	assert.EqualError(t, err, "unsupported ECDSA key size: 224")
+}
+
+func TestGenerateCertificate(t *testing.T) {
+	secret, caPub := newCASecret(t, "")
+	kube := fake.NewClientBuilder().WithObjects(secret).Build()
+	spec := &enterprise.SSH{
+		Spec: enterprise.SSHSpec{
+			KeyType: enterprise.SSHKeyTypeEd25519,
+			Certificate: &enterprise.SSHCertificate{
+				CA:              enterprise.SSHCertificateCA{PrivateKey: esmeta.SecretKeySelector{Name: testCASecret, Key: "ca"}},
+				KeyID:           "deploy",
+				Principals:      []string{"deploy"},
+				TTL:             metav1.Duration{Duration: 10 * time.Minute},
+				CriticalOptions: map[string]string{"source-address": "10.0.0.0/8"},
+			},
+		},
+	}
+	raw, err := yaml.Marshal(spec)
+	require.NoError(t, err)
+
+	g := &Generator{}
+	got, state, err := g.Generate(context.Background(), &apiextensions.JSON{Raw: raw}, kube, testNamespace)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+	assert.Len(t, got, 3)
+
+	cert := parseCertificate(t, got["id_ed25519-cert.pub"])
+	signer, err := ssh.ParsePrivateKey(got["id_ed25519"])
+	require.NoError(t, err)
+	assert.Equal(t, signer.PublicKey().Marshal(), cert.Key.Marshal())
+	assert.Equal(t, uint32(ssh.UserCert), cert.CertType)
+	assert.Equal(t, "deploy", cert.KeyId)
+	assert.Equal(t, []string{"deploy"}, cert.ValidPrincipals)
+	assert.Equal(t, map[string]string{"source-address": "10.0.0.0/8"}, cert.CriticalOptions)
+	assert.Equal(t, defaultExtensions, cert.Extensions)
+	assert.InDelta(t, time.Now().Add(10*time.Minute).Unix(), int64(cert.ValidBefore), 5)
+
+	checker := &ssh.CertChecker{
+		IsUserAuthority: func(auth ssh.PublicKey) bool {
+			return bytes.Equal(auth.Marshal(), caPub.Marshal())
+		},
+	}
+	assert.NoError(t, checker.CheckCert("deploy", cert))
+	assert.Error(t, checker.CheckCert("root", cert))
+}
+
+func TestGenerateHostCertificateForPublicKey(t *testing.T) {
+	secret, caPub := newCASecret(t, "secret")
+	kube := fake.NewClientBuilder().WithObjects(secret).Build()
+	hostPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshHostPub, err := ssh.NewPublicKey(hostPub)
+	require.NoError(t, err)
+
+	spec := &enterprise.SSH{
+		Spec: enterprise.SSHSpec{
+			Certificate: &enterprise.SSHCertificate{
+				CA: enterprise.SSHCertificateCA{
+					PrivateKey: esmeta.SecretKeySelector{Name: testCASecret, Key: "ca"},
+					Passphrase: &esmeta.SecretKeySelector{Name: testCASecret, Key: "passphrase"},
+				},
+				PublicKey:  string(ssh.MarshalAuthorizedKey(sshHostPub)),
+				Type:       enterprise.SSHCertificateTypeHost,
+				Principals: []string{"bastion.example.com"},
+			},
+		},
+	}
+	raw, err := yaml.Marshal(spec)
+	require.NoError(t, err)
+
+	g := &Generator{}
+	got, _, err := g.Generate(context.Background(), &apiextensions.JSON{Raw: raw}, kube, testNamespace)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id_ed25519-cert.pub", "id_ed25519.pub"}, sortedKeys(got))
+
+	cert := parseCertificate(t, got["id_ed25519-cert.pub"])
+	assert.Equal(t, uint32(ssh.HostCert), cert.CertType)
+	assert.Empty(t, cert.Extensions)
+	checker := &ssh.CertChecker{
+		IsHostAuthority: func(auth ssh.PublicKey, _ string) bool {
+			return bytes.Equal(auth.Marshal(), caPub.Marshal())
+		},
+	}
+	assert.NoError(t, checker.CheckHostKey("bastion.example.com:22", &net.TCPAddr{}, cert))
+}
+
+func TestCertificateTemplate(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	_, err = certificateTemplate(&enterprise.SSHCertificate{}, sshPub, time.Now())
+	assert.EqualError(t, err, "at least one principal is required")
+	_, err = certificateTemplate(&enterprise.SSHCertificate{
+		Principals: []string{"host"},
+		Type:       enterprise.SSHCertificateTypeHost,
+		Extensions: map[string]string{"permit-pty": ""},
+	}, sshPub, time.Now())
+	assert.EqualError(t, err, "critical options and extensions are only supported for user certificates")
+
+	cert, err := certificateTemplate(&enterprise.SSHCertificate{
+		Principals: []string{"deploy"},
+		Extensions: map[string]string{},
+	}, sshPub, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, cert.Extensions, "explicitly empty extensions are not defaulted")
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,91 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+// Package tlsutil resolves the CA bundles and client certificates of the TLS connections of generators.
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/runtime/esutils"
+	"github.com/external-secrets/external-secrets/runtime/esutils/resolvers"
+)
+
+// CAPool returns the CA bundle, or the bundle of the CA provider if the bundle is empty.
+// It returns nil to use the system root certificates if neither is set.
+// The CA provider is read from the namespace of the generator.
+func CAPool(ctx context.Context, bundle []byte, provider *esv1.CAProvider, kclient client.Client, ns string) (*x509.CertPool, error) {
+	if len(bundle) == 0 && provider != nil && provider.Namespace != nil && *provider.Namespace != ns {
+		return nil, errors.New("caProvider.namespace must not be set to another namespace than the one of the generator")
+	}
+	pem, err := esutils.FetchCACertFromSource(ctx, esutils.CreateCertOpts{
+		CABundle:   bundle,
+		CAProvider: provider,
+		StoreKind:  resolvers.EmptyStoreKind,
+		Namespace:  ns,
+		Client:     kclient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CA bundle: %w", err)
+	}
+	if len(pem) == 0 {
+		return nil, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("failed to parse CA bundle")
+	}
+	return pool, nil
+}
+
+// ClientCertificate returns the client certificate of the PEM encoded certificate and key
+// referenced in the namespace of the generator.
+func ClientCertificate(ctx context.Context, certRef, keyRef *esmeta.SecretKeySelector, kclient client.Client, ns string) (*tls.Certificate, error) {
+	if certRef == nil || keyRef == nil {
+		return nil, errors.New("clientCertificate and clientKey must be set together")
+	}
+	certPEM, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      certRef.Name,
+		Key:       certRef.Key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client certificate: %w", err)
+	}
+	keyPEM, err := resolvers.SecretKeyRef(ctx, kclient, resolvers.EmptyStoreKind, ns, &esmeta.SecretKeySelector{
+		Namespace: &ns,
+		Name:      keyRef.Name,
+		Key:       keyRef.Key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client key: %w", err)
+	}
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+	return &cert, nil
+}
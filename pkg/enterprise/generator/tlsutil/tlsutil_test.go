@@ -0,0 +1,148 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+// Copyright External Secrets Inc. All Rights Reserved
+
+package tlsutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const testNamespace = "apps"
+
+// selfSigned returns a PEM encoded self-signed certificate and its PEM encoded key.
+func selfSigned(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestCAPool(t *testing.T) {
+	certPEM, _ := selfSigned(t)
+	kube := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-ca", Namespace: testNamespace},
+			Data:       map[string]string{"ca.crt": string(certPEM)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-ca", Namespace: testNamespace},
+			Data:       map[string][]byte{"ca.crt": certPEM},
+		},
+	).Build()
+
+	tests := []struct {
+		name     string
+		bundle   []byte
+		provider *esv1.CAProvider
+		wantPool bool
+		wantErr  string
+	}{
+		{
+			name: "system roots",
+		},
+		{
+			name:     "bundle",
+			bundle:   certPEM,
+			wantPool: true,
+		},
+		{
+			name:     "config map",
+			provider: &esv1.CAProvider{Type: esv1.CAProviderTypeConfigMap, Name: "db-ca", Key: "ca.crt"},
+			wantPool: true,
+		},
+		{
+			name:     "secret",
+			provider: &esv1.CAProvider{Type: esv1.CAProviderTypeSecret, Name: "db-ca", Key: "ca.crt"},
+			wantPool: true,
+		},
+		{
+			name:     "provider in another namespace",
+			provider: &esv1.CAProvider{Type: esv1.CAProviderTypeConfigMap, Name: "db-ca", Key: "ca.crt", Namespace: ptr.To("other")},
+			wantErr:  "caProvider.namespace must not be set",
+		},
+		{
+			name:     "missing provider",
+			provider: &esv1.CAProvider{Type: esv1.CAProviderTypeSecret, Name: "missing", Key: "ca.crt"},
+			wantErr:  "failed to get CA bundle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := CAPool(context.Background(), tt.bundle, tt.provider, kube, testNamespace)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPool, pool != nil)
+		})
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	certPEM, keyPEM := selfSigned(t)
+	kube := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: testNamespace},
+		Data:       map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM},
+	}).Build()
+	certRef := &esmeta.SecretKeySelector{Name: "client", Key: "tls.crt"}
+	keyRef := &esmeta.SecretKeySelector{Name: "client", Key: "tls.key"}
+
+	cert, err := ClientCertificate(context.Background(), certRef, keyRef, kube, testNamespace)
+	require.NoError(t, err)
+	assert.Len(t, cert.Certificate, 1)
+
+	_, err = ClientCertificate(context.Background(), certRef, nil, kube, testNamespace)
+	assert.ErrorContains(t, err, "clientCertificate and clientKey must be set together")
+
+	// References are resolved in the namespace of the generator only.
+	_, err = ClientCertificate(context.Background(), certRef, keyRef, kube, "other")
+	assert.ErrorContains(t, err, "failed to get client certificate")
+}
@@ -35,20 +35,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 )
 
 const (
-	errNotImplemented = "not implemented"
+	validateTimeout = 10 * time.Second
 )
 
 // Client is the ExternalSecrets provider client.
@@ -61,52 +63,166 @@ type Client struct {
 	secretStoreName string
 }
 
-// DeleteSecret deletes a secret (not implemented).
-func (g *Client) DeleteSecret(_ context.Context, _ esv1.PushSecretRemoteRef) error {
-	return errors.New(errNotImplemented)
+// pushSecretRequest is the payload of a PushSecret sent to the federation server.
+type pushSecretRequest struct {
+	Data      map[string][]byte     `json:"data"`
+	SecretKey string                `json:"secretKey,omitempty"`
+	Metadata  *apiextensionsv1.JSON `json:"metadata,omitempty"`
 }
 
-// SecretExists checks if a secret exists (not implemented).
-func (g *Client) SecretExists(_ context.Context, _ esv1.PushSecretRemoteRef) (bool, error) {
-	return false, errors.New(errNotImplemented)
+type secretExistsResponse struct {
+	Exists bool `json:"exists"`
 }
 
-// PushSecret pushes a secret (not implemented).
-func (g *Client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1.PushSecretData) error {
-	return errors.New(errNotImplemented)
+// statusError is returned for unsuccessful responses of the federation server.
+type statusError struct {
+	status string
+	body   []byte
 }
 
-// GetAllSecrets retrieves all secrets (not implemented).
-func (g *Client) GetAllSecrets(_ context.Context, _ esv1.ExternalSecretFind) (map[string][]byte, error) {
-	return nil, errors.New(errNotImplemented)
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status %s, body: %s", e.status, e.body)
 }
 
-// GetSecretMap retrieves a secret map (not implemented).
-func (g *Client) GetSecretMap(_ context.Context, _ esv1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
-	return nil, errors.New(errNotImplemented)
+// DeleteSecret deletes a pushed secret from the store of the ExternalSecrets server.
+func (g *Client) DeleteSecret(ctx context.Context, ref esv1.PushSecretRemoteRef) error {
+	if _, err := g.do(ctx, http.MethodDelete, g.secretPath(ref.GetRemoteKey()), propertyQuery(ref.GetProperty()), nil); err != nil {
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+	return nil
+}
+
+// SecretExists checks if a secret exists in the store of the ExternalSecrets server.
+func (g *Client) SecretExists(ctx context.Context, ref esv1.PushSecretRemoteRef) (bool, error) {
+	resBody, err := g.do(ctx, http.MethodGet, g.secretPath(ref.GetRemoteKey())+"/exists", propertyQuery(ref.GetProperty()), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to check secret: %w", err)
+	}
+	var res secretExistsResponse
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return res.Exists, nil
+}
+
+// PushSecret pushes a secret to the store of the ExternalSecrets server.
+func (g *Client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1.PushSecretData) error {
+	req := pushSecretRequest{
+		Data:      secret.Data,
+		SecretKey: data.GetSecretKey(),
+		Metadata:  data.GetMetadata(),
+	}
+	// Only the pushed key leaves the cluster.
+	if key := data.GetSecretKey(); key != "" {
+		value, ok := secret.Data[key]
+		if !ok {
+			return fmt.Errorf("secret key %q not found", key)
+		}
+		req.Data = map[string][]byte{key: value}
+	}
+	if _, err := g.do(ctx, http.MethodPut, g.secretPath(data.GetRemoteKey()), propertyQuery(data.GetProperty()), req); err != nil {
+		return fmt.Errorf("failed to push secret: %w", err)
+	}
+	return nil
+}
+
+// GetAllSecrets finds secrets in the store of the ExternalSecrets server.
+// Secrets the authorization of the client does not allow reading are left out by the server.
+func (g *Client) GetAllSecrets(ctx context.Context, ref esv1.ExternalSecretFind) (map[string][]byte, error) {
+	resBody, err := g.do(ctx, http.MethodPost, fmt.Sprintf("/secretstore/%s/find", g.secretStoreName), url.Values{}, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secrets: %w", err)
+	}
+	var secrets map[string][]byte
+	if err := json.Unmarshal(resBody, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return secrets, nil
+}
+
+// GetSecretMap retrieves a secret from the ExternalSecrets server as a map of its properties.
+func (g *Client) GetSecretMap(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	query := propertyQuery(ref.Property)
+	if ref.Version != "" {
+		query.Set("version", ref.Version)
+	}
+	resBody, err := g.do(ctx, http.MethodPost, g.secretPath(ref.Key)+"/map", query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret map: %w", err)
+	}
+	var secretMap map[string][]byte
+	if err := json.Unmarshal(resBody, &secretMap); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return secretMap, nil
 }
 
 // GetSecret retrieves a secret from the ExternalSecrets server.
-func (g *Client) GetSecret(_ context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	realURL := fmt.Sprintf("%s/secretstore/%s/secrets/%s", g.serverURL, g.secretStoreName, ref.Key)
-	serverURL, err := url.Parse(realURL)
+func (g *Client) GetSecret(ctx context.Context, ref esv1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	query := propertyQuery(ref.Property)
+	if ref.Version != "" {
+		query.Set("version", ref.Version)
+	}
+	resBody, err := g.do(ctx, http.MethodPost, g.secretPath(ref.Key), query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+	return resBody, nil
+}
+
+// secretPath is the path of a secret of the store on the ExternalSecrets server.
+func (g *Client) secretPath(key string) string {
+	return fmt.Sprintf("/secretstore/%s/secrets/%s", g.secretStoreName, url.PathEscape(key))
+}
+
+func propertyQuery(property string) url.Values {
+	query := url.Values{}
+	if property != "" {
+		query.Set("property", property)
+	}
+	return query
+}
+
+// requestBody encodes the payload of a request as a JSON object holding the CA of the local cluster
+// as ca.crt, which the server verifies the token of the client with.
+func (g *Client) requestBody(payload any) ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, fmt.Errorf("payload is not a JSON object: %w", err)
+		}
+	}
+	caCrt, err := json.Marshal(base64.StdEncoding.EncodeToString(g.localCaRef))
 	if err != nil {
 		return nil, err
 	}
-	headers := http.Header{}
-	headers.Add("Authorization", fmt.Sprintf("Bearer %s", g.token))
-	headers.Add("Content-Type", "application/json")
-	b64cert := base64.StdEncoding.EncodeToString(g.localCaRef)
-	preview := `{"ca.crt": "%s"}`
-	body := fmt.Sprintf(preview, b64cert)
-	req := http.Request{
-		Method: "POST",
-		URL:    serverURL,
-		Header: headers,
-		Body:   io.NopCloser(bytes.NewReader([]byte(body))),
+	fields["ca.crt"] = caCrt
+	return json.Marshal(fields)
+}
+
+// do sends an authenticated request with the payload to the ExternalSecrets server and returns the body of the response.
+func (g *Client) do(ctx context.Context, method, path string, query url.Values, payload any) ([]byte, error) {
+	serverURL, err := url.Parse(g.serverURL + path)
+	if err != nil {
+		return nil, err
 	}
+	body, err := g.requestBody(payload)
+	if err != nil {
+		return nil, err
+	}
+	serverURL.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, serverURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", g.token))
+	req.Header.Add("Content-Type", "application/json")
 
-	res, err := g.httpClient.Do(&req)
+	res, err := g.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -117,8 +233,8 @@ func (g *Client) GetSecret(_ context.Context, ref esv1.ExternalSecretDataRemoteR
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to get secret: status %s, body: %s", res.Status, resBody)
+	if res.StatusCode != http.StatusOK {
+		return nil, &statusError{status: res.Status, body: resBody}
 	}
 	return resBody, nil
 }
@@ -128,7 +244,13 @@ func (g *Client) Close(_ context.Context) error {
 	return nil
 }
 
-// Validate validates the client configuration.
+// Validate checks that the client can authenticate to the ExternalSecrets server
+// and is allowed to use the store.
 func (g *Client) Validate() (esv1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if _, err := g.do(ctx, http.MethodGet, fmt.Sprintf("/secretstore/%s/ping", g.secretStoreName), url.Values{}, nil); err != nil {
+		return esv1.ValidationResultError, fmt.Errorf("failed to ping server: %w", err)
+	}
 	return esv1.ValidationResultReady, nil
 }
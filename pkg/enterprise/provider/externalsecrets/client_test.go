@@ -0,0 +1,193 @@
+// /*
+// Copyright © 2025 ESO Maintainer Team
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// */
+
+package externalsecrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	esv1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	testingfake "github.com/external-secrets/external-secrets/runtime/testing/fake"
+)
+
+const testLocalCA = "-----BEGIN CERTIFICATE-----\nlocal\n-----END CERTIFICATE-----\n"
+
+// federationServer fakes the routes of the federation server and records the bodies it receives.
+type federationServer struct {
+	mu     sync.Mutex
+	bodies map[string]map[string]any
+}
+
+func (f *federationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body := map[string]any{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	route := r.Method + " " + r.URL.EscapedPath()
+	f.mu.Lock()
+	f.bodies[route] = body
+	f.mu.Unlock()
+
+	switch route {
+	case "GET /secretstore/shared/ping", "DELETE /secretstore/shared/secrets/db%2Fapp", "PUT /secretstore/shared/secrets/db%2Fapp":
+		w.WriteHeader(http.StatusOK)
+	case "POST /secretstore/shared/secrets/db%2Fapp":
+		_, _ = w.Write([]byte("s3cr3t"))
+	case "POST /secretstore/shared/secrets/db%2Fapp/map":
+		_ = json.NewEncoder(w).Encode(map[string][]byte{"password": []byte("s3cr3t")})
+	case "GET /secretstore/shared/secrets/db%2Fapp/exists":
+		_ = json.NewEncoder(w).Encode(secretExistsResponse{Exists: true})
+	case "POST /secretstore/shared/find":
+		_ = json.NewEncoder(w).Encode(map[string][]byte{"db/app": []byte("s3cr3t")})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *federationServer) body(t *testing.T, route string) map[string]any {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, ok := f.bodies[route]
+	require.True(t, ok, "no request to %s", route)
+	return body
+}
+
+func newTestClient(t *testing.T) (*Client, *federationServer) {
+	t.Helper()
+	fed := &federationServer{bodies: map[string]map[string]any{}}
+	srv := httptest.NewServer(fed)
+	t.Cleanup(srv.Close)
+	return &Client{
+		httpClient:      srv.Client(),
+		serverURL:       srv.URL,
+		localCaRef:      []byte(testLocalCA),
+		token:           "token",
+		secretStoreName: "shared",
+	}, fed
+}
+
+func TestClientSendsCA(t *testing.T) {
+	ctx := context.Background()
+	ref := esv1.ExternalSecretDataRemoteRef{Key: "db/app"}
+	pushRef := testingfake.PushSecretData{RemoteKey: "db/app"}
+
+	tests := []struct {
+		route string
+		call  func(c *Client) error
+	}{
+		{
+			route: "GET /secretstore/shared/ping",
+			call: func(c *Client) error {
+				_, err := c.Validate()
+				return err
+			},
+		},
+		{
+			route: "POST /secretstore/shared/secrets/db%2Fapp",
+			call: func(c *Client) error {
+				secret, err := c.GetSecret(ctx, ref)
+				assert.Equal(t, "s3cr3t", string(secret))
+				return err
+			},
+		},
+		{
+			route: "POST /secretstore/shared/secrets/db%2Fapp/map",
+			call: func(c *Client) error {
+				secretMap, err := c.GetSecretMap(ctx, ref)
+				assert.Equal(t, map[string][]byte{"password": []byte("s3cr3t")}, secretMap)
+				return err
+			},
+		},
+		{
+			route: "GET /secretstore/shared/secrets/db%2Fapp/exists",
+			call: func(c *Client) error {
+				exists, err := c.SecretExists(ctx, pushRef)
+				assert.True(t, exists)
+				return err
+			},
+		},
+		{
+			route: "DELETE /secretstore/shared/secrets/db%2Fapp",
+			call: func(c *Client) error {
+				return c.DeleteSecret(ctx, pushRef)
+			},
+		},
+		{
+			route: "PUT /secretstore/shared/secrets/db%2Fapp",
+			call: func(c *Client) error {
+				return c.PushSecret(ctx, &corev1.Secret{Data: map[string][]byte{"password": []byte("s3cr3t")}}, pushRef)
+			},
+		},
+		{
+			route: "POST /secretstore/shared/find",
+			call: func(c *Client) error {
+				secrets, err := c.GetAllSecrets(ctx, esv1.ExternalSecretFind{Path: ptr.To("db")})
+				assert.Equal(t, map[string][]byte{"db/app": []byte("s3cr3t")}, secrets)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			c, fed := newTestClient(t)
+			require.NoError(t, tt.call(c))
+			body := fed.body(t, tt.route)
+			assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(testLocalCA)), body["ca.crt"])
+		})
+	}
+}
+
+func TestClientGetAllSecretsSendsFind(t *testing.T) {
+	c, fed := newTestClient(t)
+	_, err := c.GetAllSecrets(context.Background(), esv1.ExternalSecretFind{Path: ptr.To("db")})
+	require.NoError(t, err)
+	assert.Equal(t, "db", fed.body(t, "POST /secretstore/shared/find")["path"])
+}
+
+func TestClientPushSecretSendsPushedKeyOnly(t *testing.T) {
+	c, fed := newTestClient(t)
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("s3cr3t"), "username": []byte("admin")}}
+	data := testingfake.PushSecretData{RemoteKey: "db/app", SecretKey: "password"}
+	require.NoError(t, c.PushSecret(context.Background(), secret, data))
+
+	body := fed.body(t, "PUT /secretstore/shared/secrets/db%2Fapp")
+	assert.Equal(t, "password", body["secretKey"])
+	assert.Equal(t, map[string]any{"password": base64.StdEncoding.EncodeToString([]byte("s3cr3t"))}, body["data"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(testLocalCA)), body["ca.crt"])
+}
@@ -0,0 +1,355 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statemanager
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	reloaderv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/reloader/v1alpha1"
+	scanv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/scan/v1alpha1"
+	genapi "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+// AlternatingRotation returns the generator and its rotation policy if it rotates
+// its users with the alternating strategy, or nil otherwise.
+func AlternatingRotation(gen genapi.Generator, obj *apiextensions.JSON) (genapi.AlternatingGenerator, *genapi.RotationPolicy, error) {
+	alternating, ok := gen.(genapi.AlternatingGenerator)
+	if !ok {
+		return nil, nil, nil
+	}
+	policy, err := alternating.GetRotationPolicy(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	if policy == nil || policy.Strategy != genapi.AlternatingRotationStrategy {
+		return nil, nil, nil
+	}
+	return alternating, policy, nil
+}
+
+// GenerateAlternating generates the credentials of the users rotated by the alternating strategy.
+// The user the consumer is not using is reset, and the users are only flipped once the
+// consumer has rolled to the last issued user. Until then, the issued credentials are
+// returned again, so that the user the consumer is rolling to is not reset once more.
+// A single state is maintained for both users, it is created or updated on Commit.
+func (m *Manager) GenerateAlternating(stateKey, namespace string, resource *apiextensions.JSON, gen genapi.AlternatingGenerator, policy *genapi.RotationPolicy) (map[string][]byte, error) {
+	current, err := m.getAlternatingState(stateKey)
+	if err != nil {
+		return nil, err
+	}
+	active, err := m.activeSlot(current, policy)
+	if err != nil {
+		return nil, err
+	}
+	// Until the consumer has rolled for the first time, it may still use the credentials of
+	// the recreate strategy: the first user is issued until then.
+	slot := genapi.AlternatingSlotA
+	if active != "" {
+		slot = otherSlot(active)
+	}
+
+	issuedAt := time.Now()
+	if current != nil && current.Annotations[genapi.GeneratorStateAnnotationIssuedSlot] == slot {
+		issued, err := m.getIssuedCredentials(current)
+		if err != nil {
+			return nil, err
+		}
+		if issued != nil {
+			return issued, nil
+		}
+		// The credentials are issued again, so the time the consumer has to roll since is kept.
+		issuedAt, err = time.Parse(time.RFC3339, current.Annotations[genapi.GeneratorStateAnnotationIssuedAt])
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue time of generator state %s: %w", current.Name, err)
+		}
+	}
+
+	secretMap, state, err := gen.GenerateAlternating(m.ctx, resource, m.client, namespace, slot)
+	if err != nil {
+		return nil, err
+	}
+	// The user that was reset is not used by the consumer, so there is nothing to roll back.
+	m.queue = append(m.queue, QueueItem{
+		Commit: func() error {
+			return m.commitAlternatingState(current, stateKey, namespace, resource, state, active, slot, issuedAt, secretMap)
+		},
+	})
+	return secretMap, nil
+}
+
+func (m *Manager) getAlternatingState(key string) (*genapi.GeneratorState, error) {
+	allStates, err := m.GetAllStates(key)
+	if err != nil {
+		return nil, err
+	}
+	for i := range allStates {
+		state := &allStates[i]
+		if state.Spec.GarbageCollectionDeadline != nil {
+			continue
+		}
+		if _, ok := state.Annotations[genapi.GeneratorStateAnnotationIssuedSlot]; ok {
+			return state, nil
+		}
+	}
+	return nil, nil
+}
+
+// activeSlot returns the slot of the user the consumer uses, if it is known.
+func (m *Manager) activeSlot(state *genapi.GeneratorState, policy *genapi.RotationPolicy) (string, error) {
+	if state == nil {
+		return "", nil
+	}
+	issued := state.Annotations[genapi.GeneratorStateAnnotationIssuedSlot]
+	active := state.Annotations[genapi.GeneratorStateAnnotationActiveSlot]
+	if issued == active {
+		return active, nil
+	}
+	issuedAt, err := time.Parse(time.RFC3339, state.Annotations[genapi.GeneratorStateAnnotationIssuedAt])
+	if err != nil {
+		return "", fmt.Errorf("invalid issue time of generator state %s: %w", state.Name, err)
+	}
+	rolled, err := m.consumerRolled(policy, issuedAt)
+	if err != nil {
+		return "", err
+	}
+	if rolled {
+		return issued, nil
+	}
+	return active, nil
+}
+
+func otherSlot(slot string) string {
+	if slot == genapi.AlternatingSlotA {
+		return genapi.AlternatingSlotB
+	}
+	return genapi.AlternatingSlotA
+}
+
+func (m *Manager) commitAlternatingState(current *genapi.GeneratorState, key, namespace string, resource *apiextensions.JSON, state genapi.GeneratorProviderState, active, slot string, issuedAt time.Time, secretMap map[string][]byte) error {
+	genState := current
+	if genState == nil {
+		var err error
+		genState, err = m.createGeneratorState(resource, state, namespace, key)
+		if err != nil {
+			return err
+		}
+	}
+	genState.Spec.Resource = resource
+	genState.Spec.State = state
+	if genState.Annotations == nil {
+		genState.Annotations = make(map[string]string)
+	}
+	genState.Annotations[genapi.GeneratorStateAnnotationIssuedSlot] = slot
+	genState.Annotations[genapi.GeneratorStateAnnotationIssuedAt] = issuedAt.UTC().Format(time.RFC3339)
+	if active != "" {
+		genState.Annotations[genapi.GeneratorStateAnnotationActiveSlot] = active
+	}
+
+	var err error
+	if current == nil {
+		err = m.client.Create(m.ctx, genState)
+	} else {
+		err = m.client.Update(m.ctx, genState)
+	}
+	if err != nil {
+		return err
+	}
+	if err := m.storeIssuedCredentials(genState, secretMap); err != nil {
+		return err
+	}
+	// States of users created with the recreate strategy are cleaned up as usual.
+	return m.disposeStateExcept(key, genState.Name, m.getGCGracePeriod())
+}
+
+// issuedSecretName returns the name of the Secret with the credentials issued for the state.
+func issuedSecretName(state *genapi.GeneratorState) string {
+	return state.Name + "-issued"
+}
+
+// getIssuedCredentials returns the credentials last issued for the state, or nil if they are not stored.
+func (m *Manager) getIssuedCredentials(state *genapi.GeneratorState) (map[string][]byte, error) {
+	var secret corev1.Secret
+	err := m.client.Get(m.ctx, client.ObjectKey{Namespace: state.Namespace, Name: issuedSecretName(state)}, &secret)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get issued credentials of generator state %s: %w", state.Name, err)
+	}
+	return secret.Data, nil
+}
+
+// storeIssuedCredentials stores the issued credentials in a Secret owned by the state,
+// so that they are removed together.
+func (m *Manager) storeIssuedCredentials(state *genapi.GeneratorState, secretMap map[string][]byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: state.Namespace,
+			Name:      issuedSecretName(state),
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(m.ctx, m.client, secret, func() error {
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = secretMap
+		return controllerutil.SetControllerReference(state, secret, m.scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to store issued credentials of generator state %s: %w", state.Name, err)
+	}
+	return nil
+}
+
+// consumerRolled returns whether the consumer of the credentials has rolled since they were issued.
+func (m *Manager) consumerRolled(policy *genapi.RotationPolicy, issuedAt time.Time) (bool, error) {
+	if policy.Consumer != "" {
+		rolled, err := m.scanConsumerRolled(policy.Consumer, issuedAt)
+		if err != nil || !rolled {
+			return false, err
+		}
+	}
+	if policy.WaitFor != nil {
+		return m.waitForRolled(policy.WaitFor, issuedAt)
+	}
+	return true, nil
+}
+
+func (m *Manager) scanConsumerRolled(name string, issuedAt time.Time) (bool, error) {
+	var consumer scanv1alpha1.Consumer
+	err := m.client.Get(m.ctx, client.ObjectKey{Namespace: m.namespace, Name: name}, &consumer)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("unable to get consumer %s: %w", name, err)
+	}
+	condition := findCondition(consumer.Status.Conditions, string(scanv1alpha1.ConsumerLatestVersion))
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		return false, nil
+	}
+	if len(consumer.Status.ObservedIndex) == 0 {
+		return false, nil
+	}
+	for _, record := range consumer.Status.ObservedIndex {
+		if record.Timestamp.Time.Before(issuedAt) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func findCondition(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func (m *Manager) waitForRolled(waitFor *genapi.RotationWaitFor, issuedAt time.Time) (bool, error) {
+	if waitFor.Time != nil && time.Now().Before(issuedAt.Add(waitFor.Time.Duration)) {
+		return false, nil
+	}
+	if waitFor.Condition == nil {
+		return true, nil
+	}
+	if waitFor.Target == nil {
+		return false, errors.New("a target is required to wait for a condition")
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(waitFor.Target.APIVersion, waitFor.Target.Kind))
+	err := m.client.Get(m.ctx, client.ObjectKey{Namespace: m.namespace, Name: waitFor.Target.Name}, obj)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("unable to get %s %s: %w", waitFor.Target.Kind, waitFor.Target.Name, err)
+	}
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, fmt.Errorf("invalid conditions of %s %s: %w", waitFor.Target.Kind, waitFor.Target.Name, err)
+	}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if conditionMet(waitFor.Condition, condition, issuedAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// conditionMet returns whether the condition matches, and was updated after the credentials were issued.
+// Workload conditions such as the ones of Deployments have a lastUpdateTime, others only a lastTransitionTime.
+func conditionMet(want *reloaderv1alpha1.WaitForCondition, condition map[string]any, issuedAt time.Time) bool {
+	if fieldString(condition, "type") != want.Type {
+		return false
+	}
+	if want.Status != "" && fieldString(condition, "status") != want.Status {
+		return false
+	}
+	if want.Reason != "" && fieldString(condition, "reason") != want.Reason {
+		return false
+	}
+	if want.Message != "" && fieldString(condition, "message") != want.Message {
+		return false
+	}
+
+	transitioned := fieldTime(condition, "lastTransitionTime")
+	updated := fieldTime(condition, "lastUpdateTime")
+	if updated.IsZero() {
+		updated = transitioned
+	}
+	if updated.Before(issuedAt) {
+		return false
+	}
+	now := time.Now()
+	if want.TransitionedAfter != nil && now.Before(transitioned.Add(want.TransitionedAfter.Duration)) {
+		return false
+	}
+	if want.UpdatedAfter != nil && now.Before(updated.Add(want.UpdatedAfter.Duration)) {
+		return false
+	}
+	return true
+}
+
+func fieldString(obj map[string]any, field string) string {
+	value, _ := obj[field].(string)
+	return value
+}
+
+func fieldTime(obj map[string]any, field string) time.Time {
+	value, err := time.Parse(time.RFC3339, fieldString(obj, field))
+	if err != nil {
+		return time.Time{}
+	}
+	return value
+}
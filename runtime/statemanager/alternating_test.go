@@ -0,0 +1,407 @@
+/*
+Copyright © 2025 ESO Maintainer Team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package statemanager
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	reloaderv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/reloader/v1alpha1"
+	scanv1alpha1 "github.com/external-secrets/external-secrets/apis/enterprise/scan/v1alpha1"
+	genapi "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+const (
+	testNamespace = "default"
+	testKey       = "0"
+)
+
+type fakeAlternatingGenerator struct {
+	policy *genapi.RotationPolicy
+	slots  []string
+}
+
+func (g *fakeAlternatingGenerator) Generate(_ context.Context, _ *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, genapi.GeneratorProviderState, error) {
+	return map[string][]byte{"username": []byte("app_random")}, &apiextensions.JSON{Raw: []byte(`{}`)}, nil
+}
+
+func (g *fakeAlternatingGenerator) GenerateAlternating(_ context.Context, _ *apiextensions.JSON, _ client.Client, _, slot string) (map[string][]byte, genapi.GeneratorProviderState, error) {
+	g.slots = append(g.slots, slot)
+	state, err := json.Marshal(map[string]string{"username": "app_" + slot})
+	if err != nil {
+		return nil, nil, err
+	}
+	// The password changes on each reset of the user.
+	return map[string][]byte{
+		"username": []byte("app_" + slot),
+		"password": []byte(strconv.Itoa(len(g.slots))),
+	}, &apiextensions.JSON{Raw: state}, nil
+}
+
+func (g *fakeAlternatingGenerator) Cleanup(_ context.Context, _ *apiextensions.JSON, _ genapi.GeneratorProviderState, _ client.Client, _ string) error {
+	return nil
+}
+
+func (g *fakeAlternatingGenerator) LastActivityTime(_ context.Context, _ *apiextensions.JSON, _ genapi.GeneratorProviderState, _ client.Client, _ string) (time.Time, bool, error) {
+	return time.Time{}, false, nil
+}
+
+func (g *fakeAlternatingGenerator) GetCleanupPolicy(_ *apiextensions.JSON) (*genapi.CleanupPolicy, error) {
+	return nil, nil
+}
+
+func (g *fakeAlternatingGenerator) GetRotationPolicy(_ *apiextensions.JSON) (*genapi.RotationPolicy, error) {
+	return g.policy, nil
+}
+
+func (g *fakeAlternatingGenerator) GetKeys() map[string]string {
+	return map[string]string{"username": "username"}
+}
+
+func newTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, genapi.AddToScheme(scheme))
+	require.NoError(t, scanv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newTestOwner() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "owner",
+			Namespace: testNamespace,
+			UID:       "owner-uid",
+		},
+	}
+}
+
+// rotate runs a rotation and returns the slot of the issued user.
+func rotate(t *testing.T, kube client.Client, gen *fakeAlternatingGenerator) string {
+	t.Helper()
+	_, slot := rotateCredentials(t, kube, gen)
+	return slot
+}
+
+// rotateCredentials runs a rotation and returns the issued credentials and the slot of their user.
+func rotateCredentials(t *testing.T, kube client.Client, gen *fakeAlternatingGenerator) (map[string][]byte, string) {
+	t.Helper()
+	m := New(context.Background(), kube, kube.Scheme(), testNamespace, newTestOwner())
+	secretMap, err := m.GenerateAlternating(testKey, testNamespace, &apiextensions.JSON{Raw: []byte(`{}`)}, gen, gen.policy)
+	require.NoError(t, err)
+	require.NoError(t, m.Commit())
+	slot := gen.slots[len(gen.slots)-1]
+	assert.Equal(t, "app_"+slot, string(secretMap["username"]))
+	return secretMap, slot
+}
+
+func getAlternatingState(t *testing.T, kube client.Client) *genapi.GeneratorState {
+	t.Helper()
+	m := New(context.Background(), kube, kube.Scheme(), testNamespace, newTestOwner())
+	states, err := m.GetAllStates(testKey)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	return &states[0]
+}
+
+func TestAlternatingRotation(t *testing.T) {
+	obj := &apiextensions.JSON{Raw: []byte(`{}`)}
+
+	alternating, policy, err := AlternatingRotation(&fakeAlternatingGenerator{}, obj)
+	require.NoError(t, err)
+	assert.Nil(t, alternating)
+	assert.Nil(t, policy)
+
+	alternating, _, err = AlternatingRotation(&fakeAlternatingGenerator{policy: &genapi.RotationPolicy{Strategy: genapi.RecreateRotationStrategy}}, obj)
+	require.NoError(t, err)
+	assert.Nil(t, alternating)
+
+	gen := &fakeAlternatingGenerator{policy: &genapi.RotationPolicy{Strategy: genapi.AlternatingRotationStrategy}}
+	alternating, policy, err = AlternatingRotation(gen, obj)
+	require.NoError(t, err)
+	assert.Equal(t, gen, alternating)
+	assert.Equal(t, gen.policy, policy)
+}
+
+func TestGenerateAlternatingWithoutConsumer(t *testing.T) {
+	kube := newTestClient(t)
+	gen := &fakeAlternatingGenerator{policy: &genapi.RotationPolicy{Strategy: genapi.AlternatingRotationStrategy}}
+
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+	state := getAlternatingState(t, kube)
+	assert.Equal(t, genapi.AlternatingSlotA, state.Annotations[genapi.GeneratorStateAnnotationIssuedSlot])
+	assert.NotContains(t, state.Annotations, genapi.GeneratorStateAnnotationActiveSlot)
+	assert.JSONEq(t, `{"username":"app_a"}`, string(state.Spec.State.Raw))
+
+	// Without a consumer to wait for, the users are flipped on each rotation.
+	assert.Equal(t, genapi.AlternatingSlotB, rotate(t, kube, gen))
+	state = getAlternatingState(t, kube)
+	assert.Equal(t, genapi.AlternatingSlotB, state.Annotations[genapi.GeneratorStateAnnotationIssuedSlot])
+	assert.Equal(t, genapi.AlternatingSlotA, state.Annotations[genapi.GeneratorStateAnnotationActiveSlot])
+	assert.JSONEq(t, `{"username":"app_b"}`, string(state.Spec.State.Raw))
+	assert.Nil(t, state.Spec.GarbageCollectionDeadline)
+
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+	assert.Equal(t, genapi.AlternatingSlotB, rotate(t, kube, gen))
+}
+
+func TestGenerateAlternatingWithScanConsumer(t *testing.T) {
+	consumer := &scanv1alpha1.Consumer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: testNamespace,
+		},
+	}
+	kube := newTestClient(t, consumer)
+	gen := &fakeAlternatingGenerator{policy: &genapi.RotationPolicy{
+		Strategy: genapi.AlternatingRotationStrategy,
+		Consumer: "app",
+	}}
+	setObserved := func(observedAt time.Time, status metav1.ConditionStatus) {
+		t.Helper()
+		require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(consumer), consumer))
+		consumer.Status.ObservedIndex = map[string]scanv1alpha1.SecretUpdateRecord{
+			"default/app": {Timestamp: metav1.NewTime(observedAt), SecretHash: "hash"},
+		}
+		consumer.Status.Conditions = []metav1.Condition{{
+			Type:               string(scanv1alpha1.ConsumerLatestVersion),
+			Status:             status,
+			Reason:             string(scanv1alpha1.ConsumerLocationsUpToDate),
+			LastTransitionTime: metav1.Now(),
+		}}
+		require.NoError(t, kube.Update(context.Background(), consumer))
+	}
+
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+
+	// The consumer has not rolled yet: the user it may still use is not changed.
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+	setObserved(time.Now().Add(-time.Hour), metav1.ConditionTrue)
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+	setObserved(time.Now().Add(time.Hour), metav1.ConditionFalse)
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+
+	setObserved(time.Now().Add(time.Hour), metav1.ConditionTrue)
+	assert.Equal(t, genapi.AlternatingSlotB, rotate(t, kube, gen))
+	state := getAlternatingState(t, kube)
+	assert.Equal(t, genapi.AlternatingSlotA, state.Annotations[genapi.GeneratorStateAnnotationActiveSlot])
+
+	// The consumer was observed before the user b was issued.
+	setObserved(time.Now().Add(-time.Hour), metav1.ConditionTrue)
+	assert.Equal(t, genapi.AlternatingSlotB, rotate(t, kube, gen))
+	state = getAlternatingState(t, kube)
+	assert.Equal(t, genapi.AlternatingSlotA, state.Annotations[genapi.GeneratorStateAnnotationActiveSlot])
+
+	setObserved(time.Now().Add(time.Hour), metav1.ConditionTrue)
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+	state = getAlternatingState(t, kube)
+	assert.Equal(t, genapi.AlternatingSlotB, state.Annotations[genapi.GeneratorStateAnnotationActiveSlot])
+}
+
+func TestGenerateAlternatingWithWaitFor(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: testNamespace,
+		},
+	}
+	kube := newTestClient(t, deployment)
+	gen := &fakeAlternatingGenerator{policy: &genapi.RotationPolicy{
+		Strategy: genapi.AlternatingRotationStrategy,
+		WaitFor: &genapi.RotationWaitFor{
+			WaitStrategy: reloaderv1alpha1.WaitStrategy{
+				Condition: &reloaderv1alpha1.WaitForCondition{
+					Type:   string(appsv1.DeploymentProgressing),
+					Status: string(corev1.ConditionTrue),
+					Reason: "NewReplicaSetAvailable",
+				},
+			},
+			Target: &genapi.RotationTarget{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "app",
+			},
+		},
+	}}
+	setCondition := func(reason string, updatedAt time.Time) {
+		t.Helper()
+		require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(deployment), deployment))
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{{
+			Type:               appsv1.DeploymentProgressing,
+			Status:             corev1.ConditionTrue,
+			Reason:             reason,
+			LastUpdateTime:     metav1.NewTime(updatedAt),
+			LastTransitionTime: metav1.NewTime(updatedAt.Add(-time.Hour)),
+		}}
+		require.NoError(t, kube.Status().Update(context.Background(), deployment))
+	}
+
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+
+	setCondition("ReplicaSetUpdated", time.Now().Add(time.Hour))
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+	setCondition("NewReplicaSetAvailable", time.Now().Add(-time.Hour))
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+
+	setCondition("NewReplicaSetAvailable", time.Now().Add(time.Hour))
+	assert.Equal(t, genapi.AlternatingSlotB, rotate(t, kube, gen))
+
+	// The rollout must also have been updated for long enough.
+	gen.policy.WaitFor.Condition.UpdatedAfter = &metav1.Duration{Duration: 2 * time.Hour}
+	assert.Equal(t, genapi.AlternatingSlotB, rotate(t, kube, gen))
+	gen.policy.WaitFor.Condition.UpdatedAfter = nil
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+}
+
+func TestGenerateAlternatingWithWaitForTime(t *testing.T) {
+	kube := newTestClient(t)
+	gen := &fakeAlternatingGenerator{policy: &genapi.RotationPolicy{
+		Strategy: genapi.AlternatingRotationStrategy,
+		WaitFor: &genapi.RotationWaitFor{
+			WaitStrategy: reloaderv1alpha1.WaitStrategy{
+				Time: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}}
+
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+
+	gen.policy.WaitFor.Time.Duration = 0
+	assert.Equal(t, genapi.AlternatingSlotB, rotate(t, kube, gen))
+}
+
+func TestGenerateAlternatingReissuesUntilConsumerRolled(t *testing.T) {
+	kube := newTestClient(t)
+	gen := &fakeAlternatingGenerator{policy: &genapi.RotationPolicy{
+		Strategy: genapi.AlternatingRotationStrategy,
+		WaitFor: &genapi.RotationWaitFor{
+			WaitStrategy: reloaderv1alpha1.WaitStrategy{
+				Time: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}}
+	// passTime moves the issue time of the state back, as if the time had passed.
+	passTime := func(d time.Duration) {
+		t.Helper()
+		state := getAlternatingState(t, kube)
+		issuedAt, err := time.Parse(time.RFC3339, state.Annotations[genapi.GeneratorStateAnnotationIssuedAt])
+		require.NoError(t, err)
+		state.Annotations[genapi.GeneratorStateAnnotationIssuedAt] = issuedAt.Add(-d).Format(time.RFC3339)
+		require.NoError(t, kube.Update(context.Background(), state))
+	}
+
+	issued, slot := rotateCredentials(t, kube, gen)
+	assert.Equal(t, genapi.AlternatingSlotA, slot)
+
+	// Refreshes before the consumer rolls issue the same credentials, without resetting the user
+	// or moving the time the consumer has to roll since.
+	for range 3 {
+		passTime(15 * time.Minute)
+		issuedAt := getAlternatingState(t, kube).Annotations[genapi.GeneratorStateAnnotationIssuedAt]
+		secretMap, slot := rotateCredentials(t, kube, gen)
+		assert.Equal(t, genapi.AlternatingSlotA, slot)
+		assert.Equal(t, issued, secretMap)
+		assert.Equal(t, issuedAt, getAlternatingState(t, kube).Annotations[genapi.GeneratorStateAnnotationIssuedAt])
+	}
+	assert.Equal(t, []string{genapi.AlternatingSlotA}, gen.slots)
+
+	// Once the wait time has passed since the first issue, the users are flipped.
+	passTime(15 * time.Minute)
+	secretMap, slot := rotateCredentials(t, kube, gen)
+	assert.Equal(t, genapi.AlternatingSlotB, slot)
+	assert.NotEqual(t, issued, secretMap)
+	assert.Equal(t, []string{genapi.AlternatingSlotA, genapi.AlternatingSlotB}, gen.slots)
+	assert.Equal(t, genapi.AlternatingSlotA, getAlternatingState(t, kube).Annotations[genapi.GeneratorStateAnnotationActiveSlot])
+
+	// The issued credentials are stored in a Secret owned by the state.
+	state := getAlternatingState(t, kube)
+	var secret corev1.Secret
+	require.NoError(t, kube.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: issuedSecretName(state)}, &secret))
+	assert.Equal(t, secretMap, secret.Data)
+	require.Len(t, secret.OwnerReferences, 1)
+	assert.Equal(t, state.Name, secret.OwnerReferences[0].Name)
+
+	// Without stored credentials, the user is reset, but the issue time is kept.
+	require.NoError(t, kube.Delete(context.Background(), &secret))
+	issuedAt := state.Annotations[genapi.GeneratorStateAnnotationIssuedAt]
+	secretMap, slot = rotateCredentials(t, kube, gen)
+	assert.Equal(t, genapi.AlternatingSlotB, slot)
+	assert.Equal(t, "3", string(secretMap["password"]))
+	assert.Equal(t, issuedAt, getAlternatingState(t, kube).Annotations[genapi.GeneratorStateAnnotationIssuedAt])
+}
+
+func TestGenerateAlternatingWaitForWithoutTarget(t *testing.T) {
+	kube := newTestClient(t)
+	gen := &fakeAlternatingGenerator{policy: &genapi.RotationPolicy{
+		Strategy: genapi.AlternatingRotationStrategy,
+		WaitFor: &genapi.RotationWaitFor{
+			WaitStrategy: reloaderv1alpha1.WaitStrategy{
+				Condition: &reloaderv1alpha1.WaitForCondition{Type: "Ready"},
+			},
+		},
+	}}
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+
+	m := New(context.Background(), kube, kube.Scheme(), testNamespace, newTestOwner())
+	_, err := m.GenerateAlternating(testKey, testNamespace, &apiextensions.JSON{Raw: []byte(`{}`)}, gen, gen.policy)
+	assert.ErrorContains(t, err, "a target is required")
+}
+
+func TestGenerateAlternatingDisposesRecreatedStates(t *testing.T) {
+	kube := newTestClient(t)
+	gen := &fakeAlternatingGenerator{policy: &genapi.RotationPolicy{Strategy: genapi.AlternatingRotationStrategy}}
+
+	m := New(context.Background(), kube, kube.Scheme(), testNamespace, newTestOwner())
+	secretMap, state, err := gen.Generate(context.Background(), &apiextensions.JSON{Raw: []byte(`{}`)}, kube, testNamespace)
+	require.NoError(t, err)
+	assert.Equal(t, "app_random", string(secretMap["username"]))
+	m.EnqueueCreateState(testKey, testNamespace, &apiextensions.JSON{Raw: []byte(`{}`)}, gen, state)
+	require.NoError(t, m.Commit())
+
+	assert.Equal(t, genapi.AlternatingSlotA, rotate(t, kube, gen))
+
+	states, err := m.GetAllStates(testKey)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	for _, s := range states {
+		if _, ok := s.Annotations[genapi.GeneratorStateAnnotationIssuedSlot]; ok {
+			assert.Nil(t, s.Spec.GarbageCollectionDeadline)
+			continue
+		}
+		assert.NotNil(t, s.Spec.GarbageCollectionDeadline)
+	}
+}
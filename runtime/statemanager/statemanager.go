@@ -218,6 +218,11 @@ func ownerKey(resource genapi.StatefulResource, key string) string {
 }
 
 func (m *Manager) disposeState(key string, gcGracePeriod time.Duration) error {
+	return m.disposeStateExcept(key, "", gcGracePeriod)
+}
+
+// disposeStateExcept flags all the states for the given key for garbage collection, except the named one.
+func (m *Manager) disposeStateExcept(key, name string, gcGracePeriod time.Duration) error {
 	allStates, err := m.GetAllStates(key)
 	if err != nil {
 		return err
@@ -225,7 +230,7 @@ func (m *Manager) disposeState(key string, gcGracePeriod time.Duration) error {
 
 	var errs []error
 	for _, state := range allStates {
-		if state.Spec.GarbageCollectionDeadline != nil {
+		if state.Spec.GarbageCollectionDeadline != nil || state.Name == name {
 			continue
 		}
 		state.Spec.GarbageCollectionDeadline = &metav1.Time{